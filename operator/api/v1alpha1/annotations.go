@@ -0,0 +1,173 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotations glooscap reads and writes on TranslationJobs. The operator, the
+// API server and the translation runner must agree on these keys, so always
+// refer to them through these constants.
+const (
+	// AnnotationPublishedPageID records the Outline page a TranslationJob produced.
+	AnnotationPublishedPageID = "glooscap.dasmlab.org/published-page-id"
+	// AnnotationPublishedPageSlug records the slug (urlId) of the produced page.
+	AnnotationPublishedPageSlug = "glooscap.dasmlab.org/published-page-slug"
+	// AnnotationPublishedPageURL records the browsable URL of the produced page.
+	AnnotationPublishedPageURL = "glooscap.dasmlab.org/published-page-url"
+	// AnnotationPublishedPageTitle records the title the produced page was created with.
+	AnnotationPublishedPageTitle = "glooscap.dasmlab.org/published-page-title"
+	// AnnotationIsDraft is "true" while the produced page is an unpublished draft.
+	AnnotationIsDraft = "glooscap.dasmlab.org/is-draft"
+
+	// AnnotationDuplicateApproved is set to "true" when a user approves
+	// overwriting an existing translation.
+	AnnotationDuplicateApproved = "glooscap.dasmlab.org/duplicate-approved"
+	// AnnotationPublishJob names the job publishing this job's draft. On the
+	// publish job itself it is "true".
+	AnnotationPublishJob = "glooscap.dasmlab.org/publish-job"
+	// AnnotationOriginalJob names the job whose draft a publish job publishes.
+	AnnotationOriginalJob = "glooscap.dasmlab.org/original-job"
+	// AnnotationApprovedAt records when a draft was approved for publishing.
+	AnnotationApprovedAt = "glooscap.dasmlab.org/approved-at"
+
+	// AnnotationSupersededBy is set on an older job when a newer job for the
+	// same source page and language supersedes it. The reconciler cancels such jobs.
+	AnnotationSupersededBy = "glooscap.dasmlab.org/superseded-by"
+	// AnnotationAllowTranslatedSource lets a job deliberately translate a page
+	// that glooscap itself produced.
+	AnnotationAllowTranslatedSource = "glooscap.dasmlab.org/allow-translated-source"
+	// AnnotationSourceContentHash records the hash of the source markdown a job translated.
+	AnnotationSourceContentHash = "glooscap.dasmlab.org/source-content-hash"
+	// AnnotationTranslatedSourceTitle records the source title the translated
+	// page's title was last derived from, so unchanged titles aren't retranslated.
+	AnnotationTranslatedSourceTitle = "glooscap.dasmlab.org/translated-source-title"
+	// AnnotationTranslationEngine and AnnotationTranslationEngineVersion
+	// record the engine and version that produced the page a job published.
+	AnnotationTranslationEngine        = "glooscap.dasmlab.org/translation-engine"
+	AnnotationTranslationEngineVersion = "glooscap.dasmlab.org/translation-engine-version"
+	// AnnotationRetryOf names the job a language retry was created from.
+	AnnotationRetryOf = "glooscap.dasmlab.org/retry-of"
+	// AnnotationNotifiedState records the last job state a webhook was sent for.
+	AnnotationNotifiedState = "glooscap.dasmlab.org/notified-state"
+	// AnnotationParentJob names the job whose page linked to a FollowLinks
+	// child job's page, and AnnotationRootJob the job the links were first
+	// followed from.
+	AnnotationParentJob = "glooscap.dasmlab.org/parent-job"
+	AnnotationRootJob   = "glooscap.dasmlab.org/root-job"
+	// AnnotationArchivedAt records when a finished job was written to the job archive.
+	AnnotationArchivedAt = "glooscap.dasmlab.org/archived-at"
+)
+
+// Annotations on other glooscap resources.
+const (
+	// AnnotationForceRefresh on a WikiTarget requests an immediate discovery run.
+	// The controller removes it once processed.
+	AnnotationForceRefresh = "glooscap.dasmlab.org/force-refresh"
+	// AnnotationTLSDefaulted marks a WikiTarget whose InsecureSkipTLSVerify
+	// default the controller has already applied, so later changes to the
+	// field, including turning it off, are left alone.
+	AnnotationTLSDefaulted = "glooscap.dasmlab.org/tls-defaulted"
+	// AnnotationDiagnosticMasterKey and AnnotationDiagnosticLastPageID track the
+	// page a WikiTarget's diagnostic run writes to.
+	AnnotationDiagnosticMasterKey  = "glooscap.dasmlab.org/diagnostic-master-key"
+	AnnotationDiagnosticLastPageID = "glooscap.dasmlab.org/diagnostic-last-page-id"
+	// AnnotationLastAppliedSpec records the TranslationService spec the current
+	// client was created from.
+	AnnotationLastAppliedSpec = "glooscap.dasmlab.org/last-applied-spec"
+	// AnnotationReconcileRequested on the TranslationService makes the
+	// controller recreate its client even though the spec is unchanged, e.g.
+	// after the service restarted. The controller removes it once done.
+	AnnotationReconcileRequested = "glooscap.dasmlab.org/reconcile-requested"
+)
+
+// Labels glooscap sets on TranslationJobs and the Jobs running them.
+const (
+	// LabelDiagnostic marks diagnostic TranslationJobs.
+	LabelDiagnostic = "glooscap.dasmlab.org/diagnostic"
+	// LabelAutoTranslate marks TranslationJobs created by auto-translation.
+	LabelAutoTranslate = "glooscap.dasmlab.org/auto-translate"
+	// LabelSourceTarget names the WikiTarget an auto-translation job came from.
+	LabelSourceTarget = "glooscap.dasmlab.org/source-target"
+	// LabelJob names the TranslationJob a runner Job, Pod or content ConfigMap belongs to.
+	LabelJob = "glooscap.dasmlab.org/job"
+)
+
+// LabelDefaultTarget set to "true" on a WikiTarget makes it the one job
+// submissions in its namespace use when they name no target. Only one
+// WikiTarget per namespace may carry it.
+const LabelDefaultTarget = "glooscap.dasmlab.org/default-target"
+
+// PublishedPage is the page a TranslationJob produced, as recorded in its
+// AnnotationPublishedPage* and AnnotationIsDraft annotations.
+// +kubebuilder:object:generate=false
+type PublishedPage struct {
+	ID      string
+	Slug    string
+	URL     string
+	Title   string
+	IsDraft bool
+}
+
+// GetPublishedPage reads the published page annotations of obj. ID is empty
+// when obj hasn't produced a page.
+func GetPublishedPage(obj metav1.Object) PublishedPage {
+	annotations := obj.GetAnnotations()
+	return PublishedPage{
+		ID:      annotations[AnnotationPublishedPageID],
+		Slug:    annotations[AnnotationPublishedPageSlug],
+		URL:     annotations[AnnotationPublishedPageURL],
+		Title:   annotations[AnnotationPublishedPageTitle],
+		IsDraft: annotations[AnnotationIsDraft] == "true",
+	}
+}
+
+// SetPublishedPage records page in obj's annotations.
+func SetPublishedPage(obj metav1.Object, page PublishedPage) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AnnotationPublishedPageID] = page.ID
+	annotations[AnnotationPublishedPageSlug] = page.Slug
+	annotations[AnnotationPublishedPageURL] = page.URL
+	annotations[AnnotationPublishedPageTitle] = page.Title
+	annotations[AnnotationIsDraft] = boolString(page.IsDraft)
+	obj.SetAnnotations(annotations)
+}
+
+// SetTranslationEngine records engine in obj's AnnotationTranslationEngine*
+// annotations, removing those engine leaves empty.
+func SetTranslationEngine(obj metav1.Object, engine TranslationEngine) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	for key, value := range map[string]string{
+		AnnotationTranslationEngine:        engine.Name,
+		AnnotationTranslationEngineVersion: engine.Version,
+	} {
+		if value == "" {
+			delete(annotations, key)
+		} else {
+			annotations[key] = value
+		}
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// SetDraft updates obj's AnnotationIsDraft annotation.
+func SetDraft(obj metav1.Object, isDraft bool) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AnnotationIsDraft] = boolString(isDraft)
+	obj.SetAnnotations(annotations)
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -41,12 +43,77 @@ type TranslationJobSpec struct {
 	// Parameters includes optional overrides for translation prompts or throttling.
 	// +optional
 	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// SupersedeOlderJobs, when true, cancels older non-terminal jobs for the same
+	// source page and language so only this (newest) job runs.
+	// +optional
+	SupersedeOlderJobs bool `json:"supersedeOlderJobs,omitempty"`
+
+	// MaxTokens caps the tokens the translation may consume. The translation
+	// service protocol has no cap, so a job whose translation reports more tokens
+	// fails before publishing. Zero means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxTokens int64 `json:"maxTokens,omitempty"`
+
+	// NotifyWebhook is a URL the operator POSTs a JSON notification to when the
+	// job completes, fails or waits for approval. Defaults to the operator's
+	// GLOOSCAP_NOTIFY_WEBHOOK, which is also used instead when the host isn't
+	// in the operator's --notify-webhook-hosts. Delivery failures never affect
+	// the job.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	NotifyWebhook string `json:"notifyWebhook,omitempty"`
+
+	// Context is a note passed to the translation model alongside the page,
+	// such as the intended audience or how to render product names.
+	// +kubebuilder:validation:MaxLength=2000
+	// +optional
+	Context string `json:"context,omitempty"`
+
+	// PublishStrategy says whether the translation is published as a new
+	// page (create, the default), rewrites the page an earlier job published
+	// for the same source page, destination and language (update), or does
+	// the latter when there is such a page and the former otherwise
+	// (createOrUpdate). Diagnostic jobs ignore it.
+	// +kubebuilder:validation:Enum=create;update;createOrUpdate
+	// +optional
+	PublishStrategy PublishStrategy `json:"publishStrategy,omitempty"`
+
+	// FollowLinks also translates the pages of the source WikiTarget that the
+	// page links to (/doc/ links), each in a child job with this job's
+	// destination and settings, and so on up to FollowLinks.Depth levels.
+	// Diagnostic jobs ignore it.
+	// +optional
+	FollowLinks *FollowLinksSpec `json:"followLinks,omitempty"`
+}
+
+// FollowLinksSpec limits how far TranslationJobSpec.FollowLinks goes.
+type FollowLinksSpec struct {
+	// Depth is how many levels of links are followed: 1 translates the pages
+	// this page links to, 2 also the pages those link to.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +kubebuilder:default=1
+	// +optional
+	Depth int32 `json:"depth,omitempty"`
+}
+
+// LinkDepth returns the levels of links the job follows, 0 when it follows none.
+func (s *TranslationJobSpec) LinkDepth() int32 {
+	if s.FollowLinks == nil {
+		return 0
+	}
+	if s.FollowLinks.Depth <= 0 {
+		return 1
+	}
+	return s.FollowLinks.Depth
 }
 
 // TranslationJobStatus defines the observed state of TranslationJob.
 type TranslationJobStatus struct {
 	// State reflects the high-level lifecycle phase.
-	// +kubebuilder:validation:Enum=Queued;Validating;AwaitingApproval;Dispatching;Running;Publishing;Completed;Failed
+	// +kubebuilder:validation:Enum=Queued;Validating;AwaitingApproval;Dispatching;Running;Publishing;Completed;PartiallyCompleted;Failed;Cancelled
 	// +optional
 	State TranslationJobState `json:"state,omitempty"`
 
@@ -73,6 +140,97 @@ type TranslationJobStatus struct {
 	// DuplicateInfo contains information about a duplicate page found at destination.
 	// +optional
 	DuplicateInfo *DuplicateInfo `json:"duplicateInfo,omitempty"`
+
+	// TokensUsed is the number of tokens the translation service reported.
+	// +optional
+	TokensUsed int64 `json:"tokensUsed,omitempty"`
+
+	// InferenceTimeMilliseconds is the time the translation service reported
+	// spending on the translation.
+	// +optional
+	InferenceTimeMilliseconds int64 `json:"inferenceTimeMilliseconds,omitempty"`
+
+	// TranslationCompletedAt is when the translation service finished the
+	// translation, as it reported. FinishedAt is only set once the job is done,
+	// after publishing.
+	// +optional
+	TranslationCompletedAt *metav1.Time `json:"translationCompletedAt,omitempty"`
+
+	// Engine is the translation engine, and the version it reported, that
+	// produced the translation, so pages can be found and retranslated once
+	// a better model is deployed.
+	// +optional
+	Engine *TranslationEngine `json:"engine,omitempty"`
+
+	// LanguageResults records the outcome for each target language, so a job
+	// whose languages finished differently shows which ones need a retry.
+	// +optional
+	// +listType=map
+	// +listMapKey=lang
+	LanguageResults []LanguageResult `json:"languageResults,omitempty"`
+
+	// TranslatedContent records the latest translation output so it can be
+	// previewed or republished without translating again.
+	// +optional
+	TranslatedContent *TranslatedContent `json:"translatedContent,omitempty"`
+
+	// ChildJobs names the jobs created to translate the pages this job's
+	// page links to, when FollowLinks is set.
+	// +optional
+	ChildJobs []string `json:"childJobs,omitempty"`
+}
+
+// TranslationEngine identifies what produced a translation.
+type TranslationEngine struct {
+	// Name is the engine the service reported, or the TranslationService
+	// type (iskoces, nanabush) when it reported none.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Version is the engine or model version the service reported.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// LanguageResult is the outcome of translating a job into one language.
+type LanguageResult struct {
+	// Lang is the BCP 47 language tag.
+	Lang string `json:"lang"`
+
+	// State is Completed or Failed.
+	State TranslationJobState `json:"state"`
+
+	// PageURL links to the published translation.
+	// +optional
+	PageURL string `json:"pageURL,omitempty"`
+
+	// Error explains why the language failed.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// TranslatedContent is the translated page. Small content is kept inline;
+// larger content is stored in a ConfigMap owned by the job.
+type TranslatedContent struct {
+	// Title is the translated page title.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Markdown is the translated body when it fits inline.
+	// +optional
+	Markdown string `json:"markdown,omitempty"`
+
+	// ConfigMapName names the ConfigMap holding the body when it is too large
+	// to keep inline.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Size is the length of the body in bytes.
+	Size int64 `json:"size"`
+
+	// SHA256 is the hex digest of the body.
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
 }
 
 // DuplicateInfo describes a duplicate page found at the destination.
@@ -97,11 +255,27 @@ type TranslationSourceSpec struct {
 	// +kubebuilder:validation:Required
 	PageID string `json:"pageId"`
 
-	// Revision allows locking translation to a specific revision.
+	// RevisionID pins the translation to an Outline revision of the page
+	// (from revisions.list), e.g. the one that was reviewed and approved,
+	// instead of its current content. The job fails if the revision is gone.
+	// +optional
+	RevisionID string `json:"revisionId,omitempty"`
+
+	// Revision is the older name of RevisionID, used when RevisionID is empty.
+	// Deprecated: set RevisionID.
 	// +optional
 	Revision string `json:"revision,omitempty"`
 }
 
+// PinnedRevision returns the Outline revision the source is pinned to, or ""
+// to translate the page's current content.
+func (s TranslationSourceSpec) PinnedRevision() string {
+	if s.RevisionID != "" {
+		return s.RevisionID
+	}
+	return s.Revision
+}
+
 // TranslationDestinationSpec configures where to publish translated content.
 type TranslationDestinationSpec struct {
 	// TargetRef overrides the target wiki; defaults to source target.
@@ -115,6 +289,17 @@ type TranslationDestinationSpec struct {
 	// LanguageTag sets the desired language annotation.
 	// +optional
 	LanguageTag string `json:"languageTag,omitempty"`
+
+	// ParentDocument nests translated pages under an existing document on the
+	// destination, given by its ID, URL, slug or title. Publishing fails when
+	// it matches no document or a title shared by several.
+	// +optional
+	ParentDocument string `json:"parentDocument,omitempty"`
+
+	// Footer overrides the destination WikiTarget's translationFooter for
+	// this job; set enabled: false to publish without one.
+	// +optional
+	Footer *TranslationFooterSpec `json:"footer,omitempty"`
 }
 
 // TranslationPipelineMode sets the execution backend.
@@ -125,6 +310,15 @@ const (
 	TranslationPipelineModeTektonJob TranslationPipelineMode = "TektonJob"
 )
 
+// PublishStrategy selects how a translation reaches the destination wiki.
+type PublishStrategy string
+
+const (
+	PublishStrategyCreate         PublishStrategy = "create"
+	PublishStrategyUpdate         PublishStrategy = "update"
+	PublishStrategyCreateOrUpdate PublishStrategy = "createOrUpdate"
+)
+
 // TranslationJobState enumerates job lifecycle states.
 type TranslationJobState string
 
@@ -137,8 +331,68 @@ const (
 	TranslationJobStatePublishing       TranslationJobState = "Publishing"
 	TranslationJobStateCompleted        TranslationJobState = "Completed"
 	TranslationJobStateFailed           TranslationJobState = "Failed"
+	TranslationJobStateCancelled        TranslationJobState = "Cancelled"
+	// TranslationJobStatePartiallyCompleted means some languages completed and others failed.
+	TranslationJobStatePartiallyCompleted TranslationJobState = "PartiallyCompleted"
 )
 
+// IsTerminal reports whether the state is final and the job will not progress further.
+func (s TranslationJobState) IsTerminal() bool {
+	switch s {
+	case TranslationJobStateCompleted, TranslationJobStateFailed, TranslationJobStateCancelled,
+		TranslationJobStatePartiallyCompleted:
+		return true
+	}
+	return false
+}
+
+// SetLanguageResult adds result to LanguageResults, replacing any earlier
+// result for the same language.
+func (s *TranslationJobStatus) SetLanguageResult(result LanguageResult) {
+	for i := range s.LanguageResults {
+		if s.LanguageResults[i].Lang == result.Lang {
+			s.LanguageResults[i] = result
+			return
+		}
+	}
+	s.LanguageResults = append(s.LanguageResults, result)
+}
+
+// FailedLanguages returns the languages whose result is Failed.
+func (s *TranslationJobStatus) FailedLanguages() []string {
+	var failed []string
+	for _, r := range s.LanguageResults {
+		if r.State == TranslationJobStateFailed {
+			failed = append(failed, r.Lang)
+		}
+	}
+	return failed
+}
+
+// LanguageResultsState summarises LanguageResults: Completed or Failed when
+// every language agrees, PartiallyCompleted when they differ, and empty when
+// there are no results.
+func (s *TranslationJobStatus) LanguageResultsState() TranslationJobState {
+	var completed, failed int
+	for _, r := range s.LanguageResults {
+		switch r.State {
+		case TranslationJobStateCompleted:
+			completed++
+		case TranslationJobStateFailed:
+			failed++
+		}
+	}
+	switch {
+	case completed > 0 && failed > 0:
+		return TranslationJobStatePartiallyCompleted
+	case failed > 0:
+		return TranslationJobStateFailed
+	case completed > 0:
+		return TranslationJobStateCompleted
+	}
+	return ""
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -159,6 +413,71 @@ type TranslationJob struct {
 	Status TranslationJobStatus `json:"status,omitempty"`
 }
 
+// IsDiagnostic reports whether the job is a diagnostic job, which translates
+// embedded test content instead of a wiki page.
+func (j *TranslationJob) IsDiagnostic() bool {
+	return j.Labels[LabelDiagnostic] == "true" || j.Spec.Parameters["diagnostic"] == "true"
+}
+
+// IsPublishJob reports whether the job publishes the draft another job
+// created, as approving a draft does, rather than translating a page.
+func (j *TranslationJob) IsPublishJob() bool {
+	return j.Spec.Parameters["publish"] == "true"
+}
+
+// TranslationFooter returns the footer to append when publishing the job to
+// dest - the job's own setting, else dest's - or nil when there is none.
+func (j *TranslationJob) TranslationFooter(dest *WikiTarget) *TranslationFooterSpec {
+	footer := dest.Spec.TranslationFooter
+	if j.Spec.Destination != nil && j.Spec.Destination.Footer != nil {
+		footer = j.Spec.Destination.Footer
+	}
+	if footer == nil || !footer.Enabled {
+		return nil
+	}
+	return footer
+}
+
+// DestinationTargetRef returns the WikiTarget the job publishes to: the
+// destination's, else the source's.
+func (j *TranslationJob) DestinationTargetRef() string {
+	if j.Spec.Destination != nil && j.Spec.Destination.TargetRef != "" {
+		return j.Spec.Destination.TargetRef
+	}
+	return j.Spec.Source.TargetRef
+}
+
+// languageTag returns the target language the job names, if any.
+func (j *TranslationJob) languageTag() string {
+	if j.Spec.Destination != nil && j.Spec.Destination.LanguageTag != "" {
+		return j.Spec.Destination.LanguageTag
+	}
+	return j.Spec.Parameters["languageTag"]
+}
+
+// PreviousTranslation returns the newest job among jobs, other than j, that
+// published a page for j's source page, destination and language, or nil.
+// Its published page is the one PublishStrategyUpdate rewrites; when no such
+// job is left, the controller looks for the page by its translated title.
+func (j *TranslationJob) PreviousTranslation(jobs []TranslationJob) *TranslationJob {
+	var newest *TranslationJob
+	for i := range jobs {
+		other := &jobs[i]
+		if other.Name == j.Name || other.Namespace != j.Namespace || other.IsDiagnostic() ||
+			GetPublishedPage(other).ID == "" ||
+			other.Spec.Source.TargetRef != j.Spec.Source.TargetRef ||
+			other.Spec.Source.PageID != j.Spec.Source.PageID ||
+			other.DestinationTargetRef() != j.DestinationTargetRef() ||
+			!strings.EqualFold(other.languageTag(), j.languageTag()) {
+			continue
+		}
+		if newest == nil || newest.CreationTimestamp.Before(&other.CreationTimestamp) {
+			newest = other
+		}
+	}
+	return newest
+}
+
 // +kubebuilder:object:root=true
 
 // TranslationJobList contains a list of TranslationJob
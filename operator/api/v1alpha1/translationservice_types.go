@@ -36,6 +36,31 @@ type TranslationServiceSpec struct {
 	// +optional
 	// +kubebuilder:default=false
 	Secure bool `json:"secure,omitempty"`
+
+	// SupportedLanguages lists the target language tags the service can translate to
+	// (e.g., "fr-CA", "es"). A bare language such as "fr" accepts all of its regional variants.
+	// When empty, any language is accepted and jobs fail only if the service rejects them.
+	// +optional
+	SupportedLanguages []string `json:"supportedLanguages,omitempty"`
+
+	// Fallbacks are standby translation service endpoints, tried in order when the
+	// primary address is unreachable or in an error state
+	// +optional
+	// +kubebuilder:validation:MaxItems=5
+	Fallbacks []TranslationServiceEndpoint `json:"fallbacks,omitempty"`
+}
+
+// TranslationServiceEndpoint is an alternative address for the translation service.
+type TranslationServiceEndpoint struct {
+	// Address is the gRPC address of the fallback translation service
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=512
+	Address string `json:"address"`
+
+	// Secure enables TLS/mTLS for the connection
+	// +optional
+	// +kubebuilder:default=false
+	Secure bool `json:"secure,omitempty"`
 }
 
 // TranslationServiceStatus defines the observed state of TranslationService.
@@ -70,24 +95,36 @@ type TranslationServiceStatus struct {
 	// +optional
 	HeartbeatIntervalSeconds int `json:"heartbeatIntervalSeconds,omitempty"`
 
+	// ActiveEndpoint is the address translation requests are currently routed to
+	// (the primary address, or a fallback while the primary is unhealthy)
+	// +optional
+	ActiveEndpoint string `json:"activeEndpoint,omitempty"`
+
 	// Conditions represent the latest available observations of the service's state
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// TranslationServiceName is the name of the TranslationService the operator
+// acts on. Only this single cluster-scoped instance is supported.
+const TranslationServiceName = "glooscap-translation-service"
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'glooscap-translation-service'",message="only a single TranslationService named glooscap-translation-service is supported"
 // +kubebuilder:printcolumn:name="Address",type="string",JSONPath=".spec.address",description="Translation service address"
 // +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type",description="Service type"
 // +kubebuilder:printcolumn:name="Connected",type="boolean",JSONPath=".status.connected",description="Connection status"
 // +kubebuilder:printcolumn:name="Registered",type="boolean",JSONPath=".status.registered",description="Registration status"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="Overall status"
+// +kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.activeEndpoint",description="Endpoint currently in use",priority=1
 // +kubebuilder:printcolumn:name="ClientID",type="string",JSONPath=".status.clientId",description="Client ID"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Ready condition"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // TranslationService is the Schema for the translationservices API.
+// It is a cluster-wide singleton that must be named glooscap-translation-service.
 type TranslationService struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
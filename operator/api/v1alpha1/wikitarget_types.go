@@ -25,9 +25,10 @@ import (
 
 // WikiTargetSpec defines the desired state of WikiTarget
 type WikiTargetSpec struct {
-	// URI is the base URL of the Outline wiki to synchronise.
+	// URI is the base URL of the Outline wiki to synchronise. Only http and https are accepted.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Format=uri
+	// +kubebuilder:validation:Pattern=`^https?://`
 	// +kubebuilder:validation:MaxLength=512
 	URI string `json:"uri"`
 
@@ -48,6 +49,11 @@ type WikiTargetSpec struct {
 	// +optional
 	TranslationDefaults *TranslationDefaults `json:"translationDefaults,omitempty"`
 
+	// TranslationFooter appends an attribution footer to translations
+	// published to this target. A job's spec.destination.footer overrides it.
+	// +optional
+	TranslationFooter *TranslationFooterSpec `json:"translationFooter,omitempty"`
+
 	// IsPaused when true, stops reconciliation of this WikiTarget.
 	// +optional
 	// +kubebuilder:default=false
@@ -58,6 +64,102 @@ type WikiTargetSpec struct {
 	// +optional
 	// +kubebuilder:default=true
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// CABundleRef points at a PEM-encoded CA bundle used to verify the wiki's
+	// certificate, e.g. for an Outline instance behind a private CA. When set,
+	// verification is always performed and InsecureSkipTLSVerify is ignored.
+	// +optional
+	CABundleRef *CABundleRef `json:"caBundleRef,omitempty"`
+
+	// CollectionFilter restricts discovery to one collection: the first whose
+	// name matches an entry, with entries tried in order. Names match ignoring
+	// case, parentheses and a trailing " Collection"; entries containing *, ?
+	// or [ are glob patterns. Empty discovers pages in every collection.
+	// Discovery fails, with reason CollectionNotFound when nothing matches,
+	// rather than falling back to every collection.
+	// +optional
+	CollectionFilter []string `json:"collectionFilter,omitempty"`
+
+	// IncludeDrafts keeps Outline draft pages in the discovered catalogue.
+	// Off by default so catalogues only list published pages.
+	// +optional
+	// +kubebuilder:default=false
+	IncludeDrafts bool `json:"includeDrafts,omitempty"`
+
+	// DefaultSourceLanguage is the language of this wiki's pages when it can't be
+	// inferred from the page title, e.g. "fr" for a French-primary wiki.
+	// Defaults to "en".
+	// +optional
+	// +kubebuilder:validation:MaxLength=35
+	DefaultSourceLanguage string `json:"defaultSourceLanguage,omitempty"`
+
+	// AutoTranslate, when enabled, creates TranslationJobs automatically whenever
+	// discovery detects a content change on a source page.
+	// +optional
+	AutoTranslate *AutoTranslatePolicy `json:"autoTranslate,omitempty"`
+
+	// MaxConcurrentTranslations caps the translation jobs publishing to this
+	// target that are dispatched, running or publishing at once, so a small
+	// wiki isn't hit by every queued job together. Further jobs wait in
+	// Queued. Zero or unset leaves the target unlimited.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentTranslations int32 `json:"maxConcurrentTranslations,omitempty"`
+}
+
+// FallbackSourceLanguage is the source language used when neither the page nor
+// its WikiTarget specifies one.
+const FallbackSourceLanguage = "en"
+
+// SourceLanguage returns inferred, the language detected for a page, falling
+// back to the target's DefaultSourceLanguage and then FallbackSourceLanguage.
+func (s *WikiTargetSpec) SourceLanguage(inferred string) string {
+	if inferred != "" {
+		return inferred
+	}
+	if s.DefaultSourceLanguage != "" {
+		return s.DefaultSourceLanguage
+	}
+	return FallbackSourceLanguage
+}
+
+// AutoTranslatePolicy configures automatic translation on content change.
+type AutoTranslatePolicy struct {
+	// Enabled turns automatic translation on or off.
+	// +optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Languages lists the BCP 47 language tags to translate changed pages into.
+	// +optional
+	Languages []string `json:"languages,omitempty"`
+
+	// MaxConcurrentJobs caps the number of in-flight auto-translation jobs for this target.
+	// Changes beyond the cap are deferred to a later refresh. Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentJobs int32 `json:"maxConcurrentJobs,omitempty"`
+
+	// MaxJobsPerMinute caps how fast auto-translation jobs are created for this
+	// target, so a bulk edit is spread out. Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxJobsPerMinute int32 `json:"maxJobsPerMinute,omitempty"`
+
+	// SettleSeconds is how long a page must go unedited before it is
+	// translated, so a burst of edits produces one job. Zero translates on the
+	// first refresh that sees the change. Defaults to 60.
+	// +optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	SettleSeconds *int32 `json:"settleSeconds,omitempty"`
+
+	// MaxPendingPages bounds the queue of changed pages waiting for capacity.
+	// Changes arriving while it is full are dropped and picked up again the
+	// next time the page changes. Defaults to 500.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPendingPages int32 `json:"maxPendingPages,omitempty"`
 }
 
 // WikiTargetStatus defines the observed state of WikiTarget.
@@ -84,14 +186,51 @@ type WikiTargetStatus struct {
 	// +kubebuilder:default=false
 	Paused bool `json:"paused,omitempty"`
 
-	// CollectionID stores the ID of the target collection (e.g., "Maurice (PGD") once found.
-	// This avoids re-searching for the collection on every refresh.
+	// CollectionID stores the ID of the collection spec.collectionFilter
+	// matched, so it isn't searched for on every refresh.
 	// +optional
 	CollectionID string `json:"collectionID,omitempty"`
 
 	// CollectionName stores the name of the target collection for reference.
 	// +optional
 	CollectionName string `json:"collectionName,omitempty"`
+
+	// OutlineVersion is the Outline release the wiki reported, empty when it
+	// doesn't say. Older or unknown versions get heuristic template detection
+	// and a separate publish call instead of the newer API features.
+	// +optional
+	OutlineVersion string `json:"outlineVersion,omitempty"`
+
+	// ConsecutiveFailures counts discovery runs that have failed since the last success.
+	// The controller backs off retries exponentially while it is non-zero.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// LastFailureTime records the most recent failed discovery run.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// LastSyncAdded is the number of pages that appeared in the most recent discovery run.
+	// +optional
+	LastSyncAdded int32 `json:"lastSyncAdded,omitempty"`
+
+	// LastSyncUpdated is the number of existing pages that changed in the most recent discovery run.
+	// +optional
+	LastSyncUpdated int32 `json:"lastSyncUpdated,omitempty"`
+
+	// LastSyncDeleted is the number of pages that disappeared in the most recent discovery run.
+	// +optional
+	LastSyncDeleted int32 `json:"lastSyncDeleted,omitempty"`
+}
+
+// CollectionIDFor returns CollectionID when name, a page's collection name, is
+// the cached collection's, and "" otherwise. Translations are created in their
+// source page's collection, and the operator and the runner both find it here.
+func (s *WikiTargetStatus) CollectionIDFor(name string) string {
+	if name == "" || s.CollectionID == "" || name != s.CollectionName {
+		return ""
+	}
+	return s.CollectionID
 }
 
 // WikiTargetMode enumerates supported publication modes.
@@ -129,17 +268,86 @@ type TranslationDefaults struct {
 	LanguageTag string `json:"languageTag,omitempty"`
 }
 
-// SecretKeyRef identifies a secret and optional key.
+// TranslationFooterSpec configures the footer appended to published
+// translations, telling readers the page was machine translated and linking
+// its source.
+type TranslationFooterSpec struct {
+	// Enabled appends the footer.
+	Enabled bool `json:"enabled"`
+
+	// Template is a Go text/template for the footer's markdown. It can use
+	// .SourceTitle, .SourceURL, .SourceLanguage, .TargetLanguage, .Date
+	// (YYYY-MM-DD), .Disclaimer, a machine translation notice in the target
+	// language, and .Engine and .EngineVersion, the translation engine as
+	// recorded in the job's status.engine. Empty uses a built-in footer
+	// showing all but the engine.
+	// +optional
+	// +kubebuilder:validation:MaxLength=2000
+	Template string `json:"template,omitempty"`
+}
+
+// SecretKeyRef identifies where a token is read from: a secret and optional
+// key by default, or a mounted file or environment variable.
+// +kubebuilder:validation:XValidation:rule="(has(self.tokenProviderType) && self.tokenProviderType != 'secret') || (has(self.name) && size(self.name) > 0)",message="name is required for the secret token provider"
+// +kubebuilder:validation:XValidation:rule="!has(self.tokenProviderType) || self.tokenProviderType != 'file' || (has(self.path) && size(self.path) > 0)",message="path is required for the file token provider"
+// +kubebuilder:validation:XValidation:rule="!has(self.tokenProviderType) || self.tokenProviderType != 'env' || (has(self.key) && size(self.key) > 0)",message="key is required for the env token provider"
+// +kubebuilder:validation:XValidation:rule="!has(self.tokenProviderType) || self.tokenProviderType != 'env' || !has(self.key) || (self.key.startsWith('GLOOSCAP_TOKEN_') && size(self.key) > 15)",message="the env token provider only reads variables starting with GLOOSCAP_TOKEN_"
+// +kubebuilder:validation:XValidation:rule="!has(self.tokenProviderType) || self.tokenProviderType != 'file' || !has(self.path) || !self.path.split('/').exists(p, p == '..')",message="the token file path may not contain '..'"
 type SecretKeyRef struct {
 	// Name of the secret.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Key within the secret data map. Defaults to "token".
+	// For the env provider, the name of the environment variable.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// TokenProviderType selects the token source. "secret" (default) reads Key
+	// from the named Secret; "file" reads Path, e.g. a token projected by
+	// External Secrets or a Vault agent; "env" reads the operator's environment
+	// variable named by Key. The operator hands file and env tokens to the
+	// translation runner pods in a Secret. Files must be inside the operator's
+	// token directory (GLOOSCAP_TOKEN_DIR, default /var/run/glooscap/tokens)
+	// and variables must start with GLOOSCAP_TOKEN_.
+	// +kubebuilder:validation:Enum=secret;file;env
+	// +optional
+	TokenProviderType TokenProviderType `json:"tokenProviderType,omitempty"`
+
+	// Path is the token file read by the file provider, absolute or relative
+	// to the token directory.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// CABundleRef references a key holding PEM certificates in a Secret or ConfigMap
+// in the WikiTarget's namespace.
+type CABundleRef struct {
+	// Kind of the referenced object.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +kubebuilder:default=ConfigMap
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the Secret or ConfigMap.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
 
-	// Key within the secret data map. Defaults to "token".
+	// Key holding the PEM bundle. Defaults to "ca.crt".
 	// +optional
 	Key string `json:"key,omitempty"`
 }
 
+// TokenProviderType enumerates the sources a WikiTarget token can be read from.
+type TokenProviderType string
+
+const (
+	TokenProviderSecret TokenProviderType = "secret"
+	TokenProviderFile   TokenProviderType = "file"
+	TokenProviderEnv    TokenProviderType = "env"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -25,6 +25,46 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoTranslatePolicy) DeepCopyInto(out *AutoTranslatePolicy) {
+	*out = *in
+	if in.Languages != nil {
+		in, out := &in.Languages, &out.Languages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SettleSeconds != nil {
+		in, out := &in.SettleSeconds, &out.SettleSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoTranslatePolicy.
+func (in *AutoTranslatePolicy) DeepCopy() *AutoTranslatePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoTranslatePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleRef) DeepCopyInto(out *CABundleRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleRef.
+func (in *CABundleRef) DeepCopy() *CABundleRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DuplicateInfo) DeepCopyInto(out *DuplicateInfo) {
 	*out = *in
@@ -40,6 +80,36 @@ func (in *DuplicateInfo) DeepCopy() *DuplicateInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FollowLinksSpec) DeepCopyInto(out *FollowLinksSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FollowLinksSpec.
+func (in *FollowLinksSpec) DeepCopy() *FollowLinksSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FollowLinksSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LanguageResult) DeepCopyInto(out *LanguageResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LanguageResult.
+func (in *LanguageResult) DeepCopy() *LanguageResult {
+	if in == nil {
+		return nil
+	}
+	out := new(LanguageResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
 	*out = *in
@@ -55,6 +125,21 @@ func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TranslatedContent) DeepCopyInto(out *TranslatedContent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranslatedContent.
+func (in *TranslatedContent) DeepCopy() *TranslatedContent {
+	if in == nil {
+		return nil
+	}
+	out := new(TranslatedContent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TranslationDefaults) DeepCopyInto(out *TranslationDefaults) {
 	*out = *in
@@ -73,6 +158,11 @@ func (in *TranslationDefaults) DeepCopy() *TranslationDefaults {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TranslationDestinationSpec) DeepCopyInto(out *TranslationDestinationSpec) {
 	*out = *in
+	if in.Footer != nil {
+		in, out := &in.Footer, &out.Footer
+		*out = new(TranslationFooterSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranslationDestinationSpec.
@@ -85,6 +175,36 @@ func (in *TranslationDestinationSpec) DeepCopy() *TranslationDestinationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TranslationEngine) DeepCopyInto(out *TranslationEngine) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranslationEngine.
+func (in *TranslationEngine) DeepCopy() *TranslationEngine {
+	if in == nil {
+		return nil
+	}
+	out := new(TranslationEngine)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TranslationFooterSpec) DeepCopyInto(out *TranslationFooterSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranslationFooterSpec.
+func (in *TranslationFooterSpec) DeepCopy() *TranslationFooterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TranslationFooterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TranslationJob) DeepCopyInto(out *TranslationJob) {
 	*out = *in
@@ -151,7 +271,7 @@ func (in *TranslationJobSpec) DeepCopyInto(out *TranslationJobSpec) {
 	if in.Destination != nil {
 		in, out := &in.Destination, &out.Destination
 		*out = new(TranslationDestinationSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
@@ -160,6 +280,11 @@ func (in *TranslationJobSpec) DeepCopyInto(out *TranslationJobSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.FollowLinks != nil {
+		in, out := &in.FollowLinks, &out.FollowLinks
+		*out = new(FollowLinksSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranslationJobSpec.
@@ -195,6 +320,30 @@ func (in *TranslationJobStatus) DeepCopyInto(out *TranslationJobStatus) {
 		*out = new(DuplicateInfo)
 		**out = **in
 	}
+	if in.TranslationCompletedAt != nil {
+		in, out := &in.TranslationCompletedAt, &out.TranslationCompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Engine != nil {
+		in, out := &in.Engine, &out.Engine
+		*out = new(TranslationEngine)
+		**out = **in
+	}
+	if in.LanguageResults != nil {
+		in, out := &in.LanguageResults, &out.LanguageResults
+		*out = make([]LanguageResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.TranslatedContent != nil {
+		in, out := &in.TranslatedContent, &out.TranslatedContent
+		*out = new(TranslatedContent)
+		**out = **in
+	}
+	if in.ChildJobs != nil {
+		in, out := &in.ChildJobs, &out.ChildJobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranslationJobStatus.
@@ -212,7 +361,7 @@ func (in *TranslationService) DeepCopyInto(out *TranslationService) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -234,6 +383,21 @@ func (in *TranslationService) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TranslationServiceEndpoint) DeepCopyInto(out *TranslationServiceEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranslationServiceEndpoint.
+func (in *TranslationServiceEndpoint) DeepCopy() *TranslationServiceEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(TranslationServiceEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TranslationServiceList) DeepCopyInto(out *TranslationServiceList) {
 	*out = *in
@@ -269,6 +433,16 @@ func (in *TranslationServiceList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TranslationServiceSpec) DeepCopyInto(out *TranslationServiceSpec) {
 	*out = *in
+	if in.SupportedLanguages != nil {
+		in, out := &in.SupportedLanguages, &out.SupportedLanguages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]TranslationServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TranslationServiceSpec.
@@ -395,6 +569,26 @@ func (in *WikiTargetSpec) DeepCopyInto(out *WikiTargetSpec) {
 		*out = new(TranslationDefaults)
 		**out = **in
 	}
+	if in.TranslationFooter != nil {
+		in, out := &in.TranslationFooter, &out.TranslationFooter
+		*out = new(TranslationFooterSpec)
+		**out = **in
+	}
+	if in.CABundleRef != nil {
+		in, out := &in.CABundleRef, &out.CABundleRef
+		*out = new(CABundleRef)
+		**out = **in
+	}
+	if in.CollectionFilter != nil {
+		in, out := &in.CollectionFilter, &out.CollectionFilter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoTranslate != nil {
+		in, out := &in.AutoTranslate, &out.AutoTranslate
+		*out = new(AutoTranslatePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WikiTargetSpec.
@@ -421,6 +615,10 @@ func (in *WikiTargetStatus) DeepCopyInto(out *WikiTargetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WikiTargetStatus.
@@ -22,12 +22,14 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -46,7 +48,11 @@ import (
 	"github.com/dasmlab/glooscap-operator/internal/controller"
 	"github.com/dasmlab/glooscap-operator/internal/server"
 	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+	"github.com/dasmlab/glooscap-operator/pkg/flags"
+	"github.com/dasmlab/glooscap-operator/pkg/jobarchive"
 	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
 	"github.com/dasmlab/glooscap-operator/pkg/vllm"
 	// +kubebuilder:scaffold:imports
 )
@@ -77,6 +83,25 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var outlineSlowCallThreshold time.Duration
+	var stdoutLogLevel string
+	var translationCacheSize int
+	var translationCacheTTL time.Duration
+	var translationCachePath string
+	var apiMaxBodyBytes int64
+	var stateMaxPages int
+	var stateMaxJobs int
+	var apiAllowedNamespaces string
+	var notifyWebhookHosts string
+	var diagnosticMaxConcurrent int
+	var diagnosticMaxPerMinute int
+	var pageFetchWorkers int
+	var pauseOnTokenBudget bool
+	var jobArchivePath string
+	var jobArchiveInterval time.Duration
+	var jobRetention time.Duration
+	var sseMaxSubscribers int
+	var sseStallTimeout time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -95,6 +120,48 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&outlineSlowCallThreshold, "outline-slow-call-threshold", 5*time.Second,
+		"Log a warning for Outline API calls slower than this. Use a negative value to disable.")
+	flag.Int64Var(&apiMaxBodyBytes, "api-max-body-bytes", 1<<20,
+		"Maximum request body size accepted by the REST API; larger requests are rejected with 413.")
+	flag.StringVar(&apiAllowedNamespaces, "api-allowed-namespaces", "",
+		"Comma-separated namespaces the REST API may read and write; requests for other namespaces get 403. "+
+			"Empty allows every namespace.")
+	flag.StringVar(&notifyWebhookHosts, "notify-webhook-hosts", "",
+		"Comma-separated hosts a TranslationJob's notifyWebhook may point at; other webhooks are refused by the API "+
+			"and replaced by GLOOSCAP_NOTIFY_WEBHOOK. That webhook's host is always allowed.")
+	flag.IntVar(&stateMaxPages, "state-max-pages", 0,
+		"Targets with more pages than this are sent to the UI as a page count only; the UI loads their pages "+
+			"from /api/v1/catalogue. 0 uses the default (5000), a negative value sends every page.")
+	flag.IntVar(&stateMaxJobs, "state-max-jobs", 0,
+		"Maximum number of most recent TranslationJobs sent to the UI. 0 uses the default (500), a negative value sends all jobs.")
+	flag.IntVar(&diagnosticMaxConcurrent, "diagnostic-max-concurrent", controller.DefaultDiagnosticMaxConcurrent,
+		"Maximum number of diagnostic TranslationJobs running at once; more wait in Queued.")
+	flag.IntVar(&diagnosticMaxPerMinute, "diagnostic-max-per-minute", controller.DefaultDiagnosticMaxPerMinute,
+		"Maximum number of diagnostic TranslationJobs started per minute.")
+	flag.IntVar(&pageFetchWorkers, "page-fetch-workers", outline.DefaultFetchWorkers,
+		"Maximum number of Outline page exports in flight for one batch page content request.")
+	flag.BoolVar(&pauseOnTokenBudget, "pause-on-token-budget", false,
+		"Hold queued TranslationJobs of a namespace that has spent its token budget until it has budget again, instead of failing them.")
+	flag.StringVar(&jobArchivePath, "job-archive-path", "",
+		"JSONL file finished TranslationJobs are appended to, e.g. on a PVC, and served from /api/v1/jobs/archive. Empty disables the archive.")
+	flag.DurationVar(&jobArchiveInterval, "job-archive-interval", controller.DefaultJobArchiveInterval,
+		"How often finished TranslationJobs are archived and checked against --job-retention.")
+	flag.DurationVar(&jobRetention, "job-retention", 0,
+		"Delete finished TranslationJobs this long after they finish (after archiving them when --job-archive-path is set). 0 keeps them.")
+	flag.IntVar(&sseMaxSubscribers, "sse-max-subscribers", 0,
+		"Maximum number of open SSE event streams; more are refused with 503. 0 uses the default (1000), a negative value removes the cap.")
+	flag.DurationVar(&sseStallTimeout, "sse-stall-timeout", 0,
+		"Close an SSE event stream whose client hasn't read its queued events for this long. 0 uses the default (1m), a negative value never does.")
+	flag.IntVar(&translationCacheSize, "translation-cache-size", 0,
+		"Maximum number of translations cached by source content hash and language. 0 disables the cache.")
+	flag.DurationVar(&translationCacheTTL, "translation-cache-ttl", 24*time.Hour,
+		"How long cached translations are reused. 0 keeps them until evicted.")
+	flag.StringVar(&translationCachePath, "translation-cache-path", "",
+		"File the translation cache is persisted to. Empty keeps the cache in memory only.")
+	flag.StringVar(&stdoutLogLevel, "stdout-log-level", verbosity.FromEnv().String(),
+		"Verbosity of the Outline, translation service and API diagnostics printed to stdout: "+
+			"quiet, info or debug. Defaults to GLOOSCAP_LOG_LEVEL (or quiet if GLOOSCAP_QUIET is set).")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -103,6 +170,13 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	stdoutLevel, err := verbosity.ParseLevel(stdoutLogLevel)
+	if err != nil {
+		setupLog.Error(err, "invalid --stdout-log-level")
+		os.Exit(1)
+	}
+	verbosity.SetLevel(stdoutLevel)
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -220,7 +294,46 @@ func main() {
 
 	catalogStore := catalog.NewStore()
 	jobStore := catalog.NewJobStore()
-	outlineFactory := controller.DefaultOutlineClientFactory{}
+	if spec := os.Getenv("GLOOSCAP_NAMESPACE_TOKEN_BUDGET"); spec != "" {
+		budgets, err := catalog.ParseTokenBudgets(spec)
+		if err != nil {
+			setupLog.Error(err, "invalid GLOOSCAP_NAMESPACE_TOKEN_BUDGET")
+			os.Exit(1)
+		}
+		jobStore.SetTokenBudgets(budgets)
+		setupLog.Info("namespace token budgets configured", "budgets", budgets)
+	}
+	translationCheck := nanabush.TranslationCheckConfigFromEnv()
+	var translationCache *nanabush.TranslationCache
+	if translationCacheSize > 0 {
+		translationCache, err = nanabush.NewTranslationCache(nanabush.CacheConfig{
+			MaxEntries: translationCacheSize,
+			TTL:        translationCacheTTL,
+			Path:       translationCachePath,
+			Check:      translationCheck,
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to load translation cache", "path", translationCachePath)
+			os.Exit(1)
+		}
+		setupLog.Info("translation cache enabled", "maxEntries", translationCacheSize, "ttl", translationCacheTTL, "path", translationCachePath, "loaded", translationCache.Len())
+	}
+	var jobArchive jobarchive.Sink
+	if jobArchivePath != "" {
+		fileSink, err := jobarchive.NewFileSink(jobArchivePath)
+		if err != nil {
+			setupLog.Error(err, "unable to open job archive", "path", jobArchivePath)
+			os.Exit(1)
+		}
+		jobArchive = fileSink
+		setupLog.Info("job archive enabled", "path", jobArchivePath, "interval", jobArchiveInterval)
+	}
+	outlineFactory := controller.NewCachingOutlineClientFactory(controller.DefaultOutlineClientFactory{
+		SlowCallThreshold: outlineSlowCallThreshold,
+	})
+	// Feature flags live in the glooscap-config ConfigMap; read them uncached to
+	// avoid needing a cluster-wide ConfigMap watch
+	flagStore := flags.NewStore(mgr.GetAPIReader(), mgr.GetClient(), "")
 
 	tektonNamespace := os.Getenv("VLLM_JOB_NAMESPACE")
 	if tektonNamespace == "" {
@@ -236,7 +349,9 @@ func main() {
 	}
 
 	var dispatcher vllm.Dispatcher
+	dispatcherMode := vllm.ModeTektonJob
 	if os.Getenv("VLLM_MODE") == string(vllm.ModeInline) {
+		dispatcherMode = vllm.ModeInline
 		dispatcher = &vllm.InlineDispatcher{}
 	} else {
 		dispatcher = &vllm.TektonJobDispatcher{
@@ -244,6 +359,7 @@ func main() {
 			Namespace:    tektonNamespace,
 			Image:        vllmImage,
 			APIServerURL: vllmAPI,
+			Env:          runnerEnv(),
 		}
 	}
 
@@ -252,7 +368,9 @@ func main() {
 		Scheme:        mgr.GetScheme(),
 		Recorder:      eventRecorder,
 		Catalogue:     catalogStore,
+		Jobs:          jobStore,
 		OutlineClient: outlineFactory,
+		Pipeline:      wikiv1alpha1.TranslationPipelineMode(dispatcherMode),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "WikiTarget")
 		os.Exit(1)
@@ -303,10 +421,13 @@ func main() {
 			Address:       addr,
 			Secure:        secure,
 			Timeout:       30 * time.Second,
+			ServiceType:   svcType,
 			ClientName:    "glooscap",
 			ClientVersion: os.Getenv("OPERATOR_VERSION"), // Could be set in deployment
 			Namespace:     namespace,
 			Metadata:      metadata,
+			Heartbeat:     nanabush.HeartbeatConfigFromEnv(),
+			Circuit:       nanabush.CircuitConfigFromEnv(),
 			// Set callback to trigger SSE broadcast on status changes
 			// Use a closure that captures the client reference
 			OnStatusChange: func(status nanabush.Status) {
@@ -458,6 +579,11 @@ func main() {
 	// Create channel for TranslationJob events
 	translationJobEventCh := make(chan controller.TranslationJobEvent, 100)
 
+	var allowedWebhookHosts []string
+	if notifyWebhookHosts != "" {
+		allowedWebhookHosts = strings.Split(notifyWebhookHosts, ",")
+	}
+	jobNotifier := controller.NewJobNotifier(os.Getenv("GLOOSCAP_NOTIFY_WEBHOOK"), allowedWebhookHosts)
 	if err := (&controller.TranslationJobReconciler{
 		Client:                mgr.GetClient(),
 		Scheme:                mgr.GetScheme(),
@@ -468,7 +594,16 @@ func main() {
 		OutlineClient:         outlineFactory,
 		Nanabush:              nanabushClient,    // Initial client (for backward compatibility)
 		GetNanabushClient:     getNanabushClient, // Getter function for runtime updates
+		TranslationCache:      translationCache,
+		TranslationCheck:      translationCheck,
+		Notifier:              jobNotifier,
 		TranslationJobEventCh: translationJobEventCh,
+		Diagnostics: &controller.DiagnosticLimits{
+			MaxConcurrent: diagnosticMaxConcurrent,
+			MaxPerMinute:  diagnosticMaxPerMinute,
+		},
+		PauseOnTokenBudget: pauseOnTokenBudget,
+		APIReader:          mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "TranslationJob")
 		os.Exit(1)
@@ -496,17 +631,35 @@ func main() {
 	setupLog.Info("diagnostic runnable registered (creates test jobs every 30 seconds)")
 
 	// Register WikiTarget diagnostic runnable (tests write access to readWrite WikiTargets every 5 minutes)
-	if err := controller.SetupWikiTargetDiagnosticRunnable(mgr, outlineFactory); err != nil {
+	if err := controller.SetupWikiTargetDiagnosticRunnable(mgr, outlineFactory, flagStore); err != nil {
 		setupLog.Error(err, "unable to setup WikiTarget diagnostic runnable")
 		os.Exit(1)
 	}
 	setupLog.Info("WikiTarget diagnostic runnable registered (tests write access every 30 seconds)")
 
+	if jobArchive != nil || jobRetention > 0 {
+		if err := mgr.Add(&controller.JobArchiver{
+			Client:    mgr.GetClient(),
+			Sink:      jobArchive,
+			Interval:  jobArchiveInterval,
+			Retention: jobRetention,
+		}); err != nil {
+			setupLog.Error(err, "unable to add job archiver")
+			os.Exit(1)
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
 		addr := os.Getenv("GLOOSCAP_API_ADDR")
 
+		var allowedNamespaces []string
+		if apiAllowedNamespaces != "" {
+			allowedNamespaces = strings.Split(apiAllowedNamespaces, ",")
+			setupLog.Info("REST API restricted to namespaces", "namespaces", allowedNamespaces)
+		}
+
 		// Create a wrapper function that uses the current nanabushClient
 		// This allows runtime reconfiguration
 		reconfigureFn := func(cfg server.TranslationServiceConfig) error {
@@ -519,13 +672,50 @@ func main() {
 			Jobs:                          jobStore,
 			Client:                        mgr.GetClient(),
 			APIReader:                     mgr.GetAPIReader(), // Use uncached client for ConfigMap reads
-			Nanabush:                      nanabushClient,    // Keep for backward compatibility
-			GetNanabushClient:             getNanabushClient, // Use getter for runtime updates
+			Flags:                         flagStore,
+			Nanabush:                      nanabushClient,     // Keep for backward compatibility
+			GetNanabushClient:             getNanabushClient,  // Use getter for runtime updates
 			NanabushStatusCh:              nanabushStatusCh,
 			TranslationJobEventCh:         translationJobEventCh,
 			ConfigStore:                   configStore,
 			ReconfigureTranslationService: reconfigureFn,
 			OutlineClientFactory:          outlineFactory,
+			MaxRequestBodyBytes:           apiMaxBodyBytes,
+			StateMaxPages:                 stateMaxPages,
+			StateMaxJobs:                  stateMaxJobs,
+			Dispatcher:                    dispatcher,
+			AllowedNamespaces:             allowedNamespaces,
+			Notifier:                      jobNotifier,
+			PageFetchWorkers:              pageFetchWorkers,
+			PauseOnTokenBudget:            pauseOnTokenBudget,
+			JobArchive:                    jobArchive,
+			MaxEventSubscribers:           sseMaxSubscribers,
+			EventStallTimeout:             sseStallTimeout,
+			RuntimeConfig: &server.RuntimeConfig{
+				DispatcherMode:           string(dispatcherMode),
+				RunnerNamespace:          tektonNamespace,
+				RunnerImage:              vllmImage,
+				RunnerAPIServerURL:       vllmAPI,
+				OutlineSlowCallThreshold: outlineSlowCallThreshold.String(),
+				StdoutLogLevel:           verbosity.CurrentLevel().String(),
+				TranslationCacheSize:     translationCacheSize,
+				TranslationCacheTTL:      translationCacheTTL.String(),
+				APIMaxBodyBytes:          apiMaxBodyBytes,
+				StateMaxPages:            stateMaxPages,
+				StateMaxJobs:             stateMaxJobs,
+				AllowedNamespaces:        allowedNamespaces,
+				DiagnosticMaxConcurrent:  diagnosticMaxConcurrent,
+				DiagnosticMaxPerMinute:   diagnosticMaxPerMinute,
+				PageFetchWorkers:         pageFetchWorkers,
+				PauseOnTokenBudget:       pauseOnTokenBudget,
+				JobArchivePath:           jobArchivePath,
+				JobRetention:             jobRetention.String(),
+				SSEMaxSubscribers:        sseMaxSubscribers,
+				SSEStallTimeout:          sseStallTimeout.String(),
+				LeaderElection:           enableLeaderElection,
+				SecureMetrics:            secureMetrics,
+				EnableHTTP2:              enableHTTP2,
+			},
 		})
 	})); err != nil {
 		setupLog.Error(err, "unable to add API server runnable")
@@ -556,6 +746,25 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if raw := os.Getenv("GLOOSCAP_STARTUP_GATE_TIMEOUT"); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil {
+			setupLog.Error(err, "invalid GLOOSCAP_STARTUP_GATE_TIMEOUT")
+			os.Exit(1)
+		}
+		if timeout > 0 {
+			gate := &controller.TranslationServiceGate{
+				Reader:            mgr.GetAPIReader(),
+				GetNanabushClient: getNanabushClient,
+				Timeout:           timeout,
+			}
+			if err := mgr.AddReadyzCheck("translation-service", gate.Check); err != nil {
+				setupLog.Error(err, "unable to set up translation service ready check")
+				os.Exit(1)
+			}
+			setupLog.Info("readiness waits for the translation service client", "timeout", timeout)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -563,3 +772,23 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// runnerForwardedEnv lists the operator's environment variables that runner
+// Jobs read too; see runnerEnv.
+var runnerForwardedEnv = []string{
+	"NANABUSH_MIN_TRANSLATION_RATIO",
+	"TRANSLATION_SERVICE_TYPE",
+}
+
+// runnerEnv copies the runnerForwardedEnv variables set for the operator into
+// the environment of the runner Jobs it dispatches, so a translation is checked
+// and its engine recorded the same way whichever of them runs it.
+func runnerEnv() []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, name := range runnerForwardedEnv {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, corev1.EnvVar{Name: name, Value: value})
+		}
+	}
+	return env
+}
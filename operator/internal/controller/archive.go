@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/jobarchive"
+)
+
+// DefaultJobArchiveInterval is how often JobArchiver looks for finished jobs.
+const DefaultJobArchiveInterval = time.Minute
+
+// JobArchiver periodically writes finished TranslationJobs to an archive and,
+// with a retention set, deletes finished jobs once they are old enough. A job
+// is only deleted after it was archived, unless there is no archive.
+type JobArchiver struct {
+	Client client.Client
+	// Sink receives finished jobs. Nil only applies Retention.
+	Sink jobarchive.Sink
+	// Interval between sweeps; 0 uses DefaultJobArchiveInterval.
+	Interval time.Duration
+	// Retention deletes jobs that finished longer ago than this. 0 keeps them.
+	Retention time.Duration
+
+	// Clock is used for archive times and retention. Nil uses the real clock.
+	Clock clock.PassiveClock
+}
+
+// Start implements manager.Runnable.
+func (a *JobArchiver) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("job-archive")
+	interval := a.Interval
+	if interval <= 0 {
+		interval = DefaultJobArchiveInterval
+	}
+	logger.Info("starting job archiver", "interval", interval, "retention", a.Retention, "archive", a.Sink != nil)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := a.sweep(ctx); err != nil {
+			logger.Error(err, "job archive sweep failed")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep archives every finished job not archived yet, marks it with
+// AnnotationArchivedAt, then deletes the finished jobs past Retention.
+func (a *JobArchiver) sweep(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("job-archive")
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := a.Client.List(ctx, &jobs); err != nil {
+		return err
+	}
+	now := nowFrom(a.Clock)
+
+	if a.Sink != nil {
+		var pending []*wikiv1alpha1.TranslationJob
+		var records []jobarchive.Record
+		for i := range jobs.Items {
+			job := &jobs.Items[i]
+			if !job.Status.State.IsTerminal() || job.Annotations[wikiv1alpha1.AnnotationArchivedAt] != "" {
+				continue
+			}
+			pending = append(pending, job)
+			records = append(records, jobarchive.FromJob(job, now.Time))
+		}
+		// Marking after the append may archive a job twice after a crash,
+		// but never loses one
+		if err := a.Sink.Append(ctx, records); err != nil {
+			return err
+		}
+		for _, job := range pending {
+			patch := client.MergeFrom(job.DeepCopy())
+			if job.Annotations == nil {
+				job.Annotations = map[string]string{}
+			}
+			job.Annotations[wikiv1alpha1.AnnotationArchivedAt] = now.UTC().Format(time.RFC3339)
+			if err := a.Client.Patch(ctx, job, patch); err != nil && !errors.IsNotFound(err) {
+				logger.Error(err, "failed to mark job archived", "job", job.Name, "namespace", job.Namespace)
+			}
+		}
+		if len(pending) > 0 {
+			logger.V(1).Info("archived finished jobs", "count", len(pending))
+		}
+	}
+
+	if a.Retention <= 0 {
+		return nil
+	}
+	deleted := 0
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if !job.Status.State.IsTerminal() || job.Status.FinishedAt == nil ||
+			now.Sub(job.Status.FinishedAt.Time) < a.Retention {
+			continue
+		}
+		if a.Sink != nil && job.Annotations[wikiv1alpha1.AnnotationArchivedAt] == "" {
+			continue
+		}
+		if err := a.Client.Delete(ctx, job); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "failed to delete expired job", "job", job.Name, "namespace", job.Namespace)
+			continue
+		}
+		deleted++
+	}
+	if deleted > 0 {
+		logger.Info("deleted finished jobs past retention", "count", deleted, "retention", a.Retention)
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/markdown"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+)
+
+const (
+	// DefaultAutoTranslateMaxConcurrentJobs caps in-flight auto-translation jobs per WikiTarget.
+	DefaultAutoTranslateMaxConcurrentJobs = 5
+	// DefaultAutoTranslateMaxJobsPerMinute caps the auto-translation job creation rate per WikiTarget.
+	DefaultAutoTranslateMaxJobsPerMinute = 10
+	// DefaultAutoTranslateMaxPendingPages bounds the changed pages queued per WikiTarget.
+	DefaultAutoTranslateMaxPendingPages = 500
+	// DefaultAutoTranslateSettleSeconds is how long a page must go unedited before it is auto-translated.
+	DefaultAutoTranslateSettleSeconds = 60
+
+	// translatedTitlePrefix marks pages produced by glooscap; they are never auto-translated.
+	translatedTitlePrefix = "AUTOTRANSLATED"
+)
+
+// autoTranslate creates TranslationJobs for pages whose content changed during
+// discovery, one per configured language. Jobs are named after the source page,
+// language and content hash, so identical content never triggers a second run.
+// Pages that can't be scheduled yet, because the concurrency or rate cap is
+// reached or the page was edited within the settle window, are kept pending
+// and retried on the next refresh. Pending pages are keyed by ID, so repeated
+// edits to one page collapse into a single job for its latest content.
+func (r *WikiTargetReconciler) autoTranslate(ctx context.Context, target *wikiv1alpha1.WikiTarget, outlineClient *outline.Client, changed []outline.PageSummary) {
+	policy := target.Spec.AutoTranslate
+	if policy == nil || !policy.Enabled || len(policy.Languages) == 0 {
+		return
+	}
+	logger := log.FromContext(ctx).WithValues("wikitarget", fmt.Sprintf("%s/%s", target.Namespace, target.Name))
+	targetID := fmt.Sprintf("%s/%s", target.Namespace, target.Name)
+
+	// Merge previously deferred pages with this refresh's changes
+	candidates := r.takePendingAutoTranslate(targetID)
+	for _, page := range changed {
+		candidates[page.ID] = page
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	maxJobs := int(policy.MaxConcurrentJobs)
+	if maxJobs <= 0 {
+		maxJobs = DefaultAutoTranslateMaxConcurrentJobs
+	}
+	maxPending := int(policy.MaxPendingPages)
+	if maxPending <= 0 {
+		maxPending = DefaultAutoTranslateMaxPendingPages
+	}
+	inFlight, err := r.countActiveAutoTranslateJobs(ctx, target)
+	if err != nil {
+		logger.Error(err, "failed to count active auto-translation jobs, deferring")
+		r.deferAutoTranslate(ctx, target, candidates, maxPending)
+		return
+	}
+
+	limiter := r.autoTranslateLimiter(targetID, policy)
+	settle := DefaultAutoTranslateSettleSeconds * time.Second
+	if policy.SettleSeconds != nil {
+		settle = time.Duration(*policy.SettleSeconds) * time.Second
+	}
+	now := nowFrom(r.Clock).Time
+
+	deferred := make(map[string]outline.PageSummary)
+	created := 0
+	// Oldest changes first, so a steady stream of edits can't starve them
+	pages := slices.SortedFunc(maps.Values(candidates), func(a, b outline.PageSummary) int {
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	})
+	for _, page := range pages {
+		id := page.ID
+		if page.IsTemplate || strings.HasPrefix(page.Title, translatedTitlePrefix) {
+			continue
+		}
+		if inFlight >= maxJobs || now.Sub(page.UpdatedAt) < settle {
+			deferred[id] = page
+			continue
+		}
+
+		content, err := outlineClient.GetPageContent(ctx, page.ID)
+		if stderrors.Is(err, outline.ErrEmptyContent) {
+			logger.V(1).Info("skipping empty page for auto-translation", "pageID", page.ID)
+			continue
+		}
+		if err != nil {
+			logger.Info("failed to fetch page content for auto-translation, deferring", "pageID", page.ID, "error", err.Error())
+			deferred[id] = page
+			continue
+		}
+		hash := ContentHash(content.Markdown)
+
+		for _, lang := range policy.Languages {
+			if inFlight >= maxJobs || !limiter.Allow() {
+				deferred[id] = page
+				break
+			}
+			job := newAutoTranslateJob(target, page, lang, hash, r.Pipeline)
+			err := r.Create(ctx, job)
+			if apierrors.IsAlreadyExists(err) {
+				// Same content already translated (or in flight) for this language,
+				// unless that job didn't finish the translation
+				var replaced bool
+				if replaced, err = r.replaceUnfinishedAutoTranslateJob(ctx, job); err == nil && !replaced {
+					continue
+				}
+			}
+			if err != nil {
+				logger.Error(err, "failed to create auto-translation job", "pageID", page.ID, "language", lang)
+				deferred[id] = page
+				continue
+			}
+			inFlight++
+			created++
+			logger.Info("created auto-translation job", "job", job.Name, "pageID", page.ID, "title", page.Title, "language", lang)
+		}
+	}
+
+	if len(deferred) > 0 {
+		logger.Info("auto-translation deferred", "pages", len(deferred), "maxConcurrentJobs", maxJobs, "settle", settle)
+		r.deferAutoTranslate(ctx, target, deferred, maxPending)
+	}
+	if created > 0 && r.Recorder != nil {
+		r.Recorder.Eventf(target, "Normal", "AutoTranslate", "Created %d auto-translation job(s)", created)
+	}
+}
+
+// replaceUnfinishedAutoTranslateJob recreates job when the existing job of the
+// same name, for the same page, language and content, ended without
+// completing, e.g. because the translation service was briefly down. It
+// reports false, leaving the job alone, when that job completed or is still
+// running. A replaced job that is still being deleted makes the create fail,
+// so the page is tried again later.
+func (r *WikiTargetReconciler) replaceUnfinishedAutoTranslateJob(ctx context.Context, job *wikiv1alpha1.TranslationJob) (bool, error) {
+	var existing wikiv1alpha1.TranslationJob
+	if err := r.Get(ctx, client.ObjectKeyFromObject(job), &existing); err != nil {
+		return false, err
+	}
+	state := existing.Status.State
+	if !state.IsTerminal() || state == wikiv1alpha1.TranslationJobStateCompleted {
+		return false, nil
+	}
+	if err := r.Delete(ctx, &existing, client.Preconditions{UID: &existing.UID}); err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	log.FromContext(ctx).Info("replacing unfinished auto-translation job", "job", job.Name, "state", state)
+	return true, r.Create(ctx, job)
+}
+
+// ContentHash returns the hash recorded in the source-content-hash annotation
+// of jobs created for md. Translation footers are left out, since their date
+// changes on every publish.
+func ContentHash(md string) string {
+	sum := sha256.Sum256([]byte(markdown.StripFooter(md)))
+	return hex.EncodeToString(sum[:])
+}
+
+// newAutoTranslateJob builds the TranslationJob for one page/language/content
+// combination, run in pipeline.
+func newAutoTranslateJob(target *wikiv1alpha1.WikiTarget, page outline.PageSummary, lang, contentHash string, pipeline wikiv1alpha1.TranslationPipelineMode) *wikiv1alpha1.TranslationJob {
+	destTarget := target.Name
+	pathPrefix := ""
+	if defaults := target.Spec.TranslationDefaults; defaults != nil {
+		if defaults.DestinationTarget != "" {
+			destTarget = defaults.DestinationTarget
+		}
+		pathPrefix = defaults.DestinationPathPrefix
+	}
+
+	return &wikiv1alpha1.TranslationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      autoTranslateJobName(page.ID, lang, contentHash),
+			Namespace: target.Namespace,
+			Labels: map[string]string{
+				wikiv1alpha1.LabelAutoTranslate: "true",
+				wikiv1alpha1.LabelSourceTarget:  target.Name,
+			},
+			Annotations: map[string]string{
+				wikiv1alpha1.AnnotationSourceContentHash: contentHash,
+			},
+		},
+		Spec: wikiv1alpha1.TranslationJobSpec{
+			Source: wikiv1alpha1.TranslationSourceSpec{
+				TargetRef: target.Name,
+				PageID:    page.ID,
+			},
+			Destination: &wikiv1alpha1.TranslationDestinationSpec{
+				TargetRef:   destTarget,
+				PathPrefix:  pathPrefix,
+				LanguageTag: lang,
+			},
+			Pipeline: pipeline,
+			Parameters: map[string]string{
+				"pageTitle":     page.Title,
+				"autoTranslate": "true",
+			},
+		},
+	}
+}
+
+// autoTranslateJobName derives a deterministic, RFC 1123 compliant job name.
+func autoTranslateJobName(pageID, lang, contentHash string) string {
+	id := strings.ToLower(pageID)
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	langPart := strings.Trim(strings.ToLower(strings.ReplaceAll(lang, "_", "-")), "-")
+	return fmt.Sprintf("auto-%s-%s-%s", strings.Trim(id, "-"), langPart, contentHash[:12])
+}
+
+// countActiveAutoTranslateJobs returns the number of non-terminal auto-translation jobs for target.
+func (r *WikiTargetReconciler) countActiveAutoTranslateJobs(ctx context.Context, target *wikiv1alpha1.WikiTarget) (int, error) {
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := r.List(ctx, &jobs,
+		client.InNamespace(target.Namespace),
+		client.MatchingLabels{wikiv1alpha1.LabelAutoTranslate: "true", wikiv1alpha1.LabelSourceTarget: target.Name},
+	); err != nil {
+		return 0, err
+	}
+	active := 0
+	for _, job := range jobs.Items {
+		// Terminal jobs, or jobs parked waiting for a human, don't consume capacity
+		if job.Status.State.IsTerminal() || job.Status.State == wikiv1alpha1.TranslationJobStateAwaitingApproval {
+			continue
+		}
+		active++
+	}
+	return active, nil
+}
+
+func (r *WikiTargetReconciler) takePendingAutoTranslate(targetID string) map[string]outline.PageSummary {
+	r.autoTranslateMu.Lock()
+	defer r.autoTranslateMu.Unlock()
+	pending := r.pendingAutoTranslate[targetID]
+	delete(r.pendingAutoTranslate, targetID)
+	if pending == nil {
+		pending = make(map[string]outline.PageSummary)
+	}
+	return pending
+}
+
+// forgetAutoTranslate drops the queued pages and rate limiter of a deleted target.
+func (r *WikiTargetReconciler) forgetAutoTranslate(targetID string) {
+	r.autoTranslateMu.Lock()
+	defer r.autoTranslateMu.Unlock()
+	delete(r.pendingAutoTranslate, targetID)
+	delete(r.autoTranslateLimiters, targetID)
+}
+
+// deferAutoTranslate queues pages for the next refresh. Pages already queued
+// are updated in place; new pages are dropped once maxPending are queued.
+func (r *WikiTargetReconciler) deferAutoTranslate(ctx context.Context, target *wikiv1alpha1.WikiTarget, pages map[string]outline.PageSummary, maxPending int) {
+	targetID := fmt.Sprintf("%s/%s", target.Namespace, target.Name)
+	r.autoTranslateMu.Lock()
+	if r.pendingAutoTranslate == nil {
+		r.pendingAutoTranslate = make(map[string]map[string]outline.PageSummary)
+	}
+	existing := r.pendingAutoTranslate[targetID]
+	if existing == nil {
+		existing = make(map[string]outline.PageSummary)
+		r.pendingAutoTranslate[targetID] = existing
+	}
+	dropped := 0
+	for id, page := range pages {
+		if _, queued := existing[id]; !queued && len(existing) >= maxPending {
+			dropped++
+			continue
+		}
+		existing[id] = page
+	}
+	r.autoTranslateMu.Unlock()
+
+	if dropped > 0 {
+		log.FromContext(ctx).Info("auto-translation queue full, dropping changes", "wikitarget", targetID, "dropped", dropped, "maxPendingPages", maxPending)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(target, "Warning", "AutoTranslateQueueFull", "Dropped %d changed page(s); the auto-translation queue holds at most %d", dropped, maxPending)
+		}
+	}
+}
+
+// autoTranslateLimiter returns the job creation rate limiter for targetID,
+// replacing it when the policy's rate has changed.
+func (r *WikiTargetReconciler) autoTranslateLimiter(targetID string, policy *wikiv1alpha1.AutoTranslatePolicy) *rate.Limiter {
+	perMinute := int(policy.MaxJobsPerMinute)
+	if perMinute <= 0 {
+		perMinute = DefaultAutoTranslateMaxJobsPerMinute
+	}
+	limit := rate.Every(time.Minute / time.Duration(perMinute))
+
+	r.autoTranslateMu.Lock()
+	defer r.autoTranslateMu.Unlock()
+	if r.autoTranslateLimiters == nil {
+		r.autoTranslateLimiters = make(map[string]*rate.Limiter)
+	}
+	limiter := r.autoTranslateLimiters[targetID]
+	if limiter == nil || limiter.Limit() != limit {
+		limiter = rate.NewLimiter(limit, perMinute)
+		r.autoTranslateLimiters[targetID] = limiter
+	}
+	return limiter
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+)
+
+var _ = Describe("WikiTarget auto-translation", func() {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var (
+		outlineClient *outline.Client
+		fakeClock     *clocktesting.FakePassiveClock
+		c             client.Client
+		r             *WikiTargetReconciler
+		target        *wikiv1alpha1.WikiTarget
+	)
+
+	BeforeEach(func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":"# Guide"}`))
+		}))
+		DeferCleanup(srv.Close)
+		var err error
+		outlineClient, err = outline.NewClient(outline.Config{BaseURL: srv.URL, Token: "token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		target = &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "auto"},
+			Spec: wikiv1alpha1.WikiTargetSpec{
+				AutoTranslate: &wikiv1alpha1.AutoTranslatePolicy{Enabled: true, Languages: []string{"fr-CA", "de"}},
+			},
+		}
+		fakeClock = clocktesting.NewFakePassiveClock(start)
+		c = fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).Build()
+		r = &WikiTargetReconciler{Client: c, Clock: fakeClock}
+	})
+
+	page := func(id, title string, updated time.Time) outline.PageSummary {
+		return outline.PageSummary{ID: id, Title: title, UpdatedAt: updated}
+	}
+	jobNames := func() []string {
+		var jobs wikiv1alpha1.TranslationJobList
+		Expect(c.List(ctx, &jobs)).To(Succeed())
+		var names []string
+		for _, job := range jobs.Items {
+			names = append(names, job.Name)
+		}
+		return names
+	}
+	pending := func() []string {
+		var ids []string
+		for id := range r.takePendingAutoTranslate("auto/wiki") {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	It("should wait for a page to settle, unless the policy settles at once", func() {
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{page("p1", "Guide", start.Add(-30*time.Second))})
+		Expect(jobNames()).To(BeEmpty())
+
+		// The deferred page is picked up once it has gone unedited for the default settle time
+		fakeClock.SetTime(start.Add(DefaultAutoTranslateSettleSeconds * time.Second))
+		r.autoTranslate(ctx, target, outlineClient, nil)
+		Expect(jobNames()).To(HaveLen(2))
+
+		target.Spec.AutoTranslate.SettleSeconds = ptr.To[int32](0)
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{page("p2", "Setup", fakeClock.Now())})
+		Expect(jobNames()).To(HaveLen(4))
+	})
+
+	It("should create one job per language for the same content", func() {
+		target.Spec.AutoTranslate.SettleSeconds = ptr.To[int32](0)
+		r.Pipeline = wikiv1alpha1.TranslationPipelineModeInlineLLM
+		edited := page("p1", "Guide", start)
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{edited})
+		first := jobNames()
+		Expect(first).To(ConsistOf(
+			autoTranslateJobName("p1", "fr-CA", ContentHash("# Guide")),
+			autoTranslateJobName("p1", "de", ContentHash("# Guide")),
+		))
+		// They run in the pipeline the operator dispatches to
+		var jobs wikiv1alpha1.TranslationJobList
+		Expect(c.List(ctx, &jobs)).To(Succeed())
+		for _, job := range jobs.Items {
+			Expect(job.Spec.Pipeline).To(Equal(wikiv1alpha1.TranslationPipelineModeInlineLLM))
+		}
+
+		// A refresh reporting the page again, with the same content, adds nothing
+		fakeClock.SetTime(start.Add(time.Minute))
+		edited.UpdatedAt = fakeClock.Now()
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{edited})
+		Expect(jobNames()).To(ConsistOf(first))
+		Expect(pending()).To(BeEmpty())
+	})
+
+	It("should replace a job that ended without translating the same content", func() {
+		target.Spec.AutoTranslate.SettleSeconds = ptr.To[int32](0)
+		target.Spec.AutoTranslate.Languages = []string{"fr-CA"}
+		edited := page("p1", "Guide", start)
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{edited})
+		name := autoTranslateJobName("p1", "fr-CA", ContentHash("# Guide"))
+		Expect(jobNames()).To(ConsistOf(name))
+
+		setState := func(state wikiv1alpha1.TranslationJobState) {
+			var job wikiv1alpha1.TranslationJob
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: "auto", Name: name}, &job)).To(Succeed())
+			job.Status.State = state
+			Expect(c.Update(ctx, &job)).To(Succeed())
+		}
+		state := func() wikiv1alpha1.TranslationJobState {
+			var job wikiv1alpha1.TranslationJob
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: "auto", Name: name}, &job)).To(Succeed())
+			return job.Status.State
+		}
+
+		// A failed job is recreated the next time the page is reported
+		setState(wikiv1alpha1.TranslationJobStateFailed)
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{edited})
+		Expect(jobNames()).To(ConsistOf(name))
+		Expect(state()).To(BeEmpty())
+
+		// Completed or running jobs are kept
+		for _, kept := range []wikiv1alpha1.TranslationJobState{wikiv1alpha1.TranslationJobStateCompleted, wikiv1alpha1.TranslationJobStateRunning} {
+			setState(kept)
+			r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{edited})
+			Expect(state()).To(Equal(kept))
+		}
+		Expect(pending()).To(BeEmpty())
+	})
+
+	It("should collapse repeated edits to a deferred page", func() {
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{page("p1", "Guide", start)})
+		fakeClock.SetTime(start.Add(10 * time.Second))
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{page("p1", "Guide v2", fakeClock.Now())})
+
+		queued := r.takePendingAutoTranslate("auto/wiki")
+		Expect(queued).To(HaveLen(1))
+		Expect(queued["p1"].Title).To(Equal("Guide v2"))
+	})
+
+	It("should follow the policy's switches, limits and exclusions", func() {
+		target.Spec.AutoTranslate.SettleSeconds = ptr.To[int32](0)
+		changed := []outline.PageSummary{
+			{ID: "tpl", Title: "Template", UpdatedAt: start, IsTemplate: true},
+			page("fr", "AUTOTRANSLATED--> Guide", start),
+			page("p1", "Guide", start),
+			page("p2", "Setup", start.Add(time.Second)),
+		}
+
+		// Disabled, or without languages, nothing is created or queued
+		target.Spec.AutoTranslate.Enabled = false
+		r.autoTranslate(ctx, target, outlineClient, changed)
+		target.Spec.AutoTranslate.Enabled = true
+		target.Spec.AutoTranslate.Languages = nil
+		r.autoTranslate(ctx, target, outlineClient, changed)
+		Expect(jobNames()).To(BeEmpty())
+		Expect(pending()).To(BeEmpty())
+
+		// Only the oldest change fits under the concurrency cap; the newer waits
+		target.Spec.AutoTranslate.Languages = []string{"fr-CA"}
+		target.Spec.AutoTranslate.MaxConcurrentJobs = 1
+		r.autoTranslate(ctx, target, outlineClient, changed)
+		Expect(jobNames()).To(ConsistOf(autoTranslateJobName("p1", "fr-CA", ContentHash("# Guide"))))
+		Expect(pending()).To(ConsistOf("p2"))
+
+		// Changes that don't fit in a full queue are dropped
+		target.Spec.AutoTranslate.MaxPendingPages = 1
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{page("p2", "Setup", start), page("p3", "FAQ", start)})
+		r.autoTranslate(ctx, target, outlineClient, []outline.PageSummary{page("p4", "Intro", start)})
+		Expect(pending()).To(HaveLen(1))
+	})
+})
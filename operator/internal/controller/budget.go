@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+const (
+	// ConditionBudgetExceeded is true while a job is stopped or held because
+	// it, or its namespace, ran out of tokens.
+	ConditionBudgetExceeded = "BudgetExceeded"
+	// BudgetExceededReason is the Ready condition reason of such a job.
+	BudgetExceededReason = "BudgetExceeded"
+
+	// Values of the scope label of glooscap_token_budget_exceeded_total, and
+	// the BudgetExceeded condition's reasons.
+	budgetScopeJob       = "job"
+	budgetScopeNamespace = "namespace"
+
+	budgetRequeueInterval = time.Minute
+)
+
+var budgetExceeded = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "glooscap_token_budget_exceeded_total",
+	Help: "Translation jobs failed or held because their own token limit or their namespace's token budget was spent.",
+}, []string{"namespace", "scope"})
+
+func init() {
+	metrics.Registry.MustRegister(budgetExceeded)
+}
+
+// markBudgetExceeded sets the BudgetExceeded condition on status. The job is
+// counted in glooscap_token_budget_exceeded_total when the condition turns on,
+// not on every reconcile that finds it still over budget.
+func markBudgetExceeded(status *wikiv1alpha1.TranslationJobStatus, namespace, scope, message string, now metav1.Time) {
+	if !meta.IsStatusConditionTrue(status.Conditions, ConditionBudgetExceeded) {
+		budgetExceeded.WithLabelValues(namespace, scope).Inc()
+	}
+	reason := "JobTokenLimit"
+	if scope == budgetScopeNamespace {
+		reason = "NamespaceTokenBudget"
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ConditionBudgetExceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+	})
+}
+
+// clearBudgetExceeded turns off a BudgetExceeded condition left by a pause
+// once the namespace has budget again.
+func clearBudgetExceeded(status *wikiv1alpha1.TranslationJobStatus, now metav1.Time) {
+	if !meta.IsStatusConditionTrue(status.Conditions, ConditionBudgetExceeded) {
+		return
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ConditionBudgetExceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             "WithinBudget",
+		Message:            "Namespace token budget available again",
+		LastTransitionTime: now,
+	})
+}
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+)
+
+// nowFrom returns the current time of c, a reconciler's injected clock, or of
+// the real clock when none was injected.
+func nowFrom(c clock.PassiveClock) metav1.Time {
+	if c == nil {
+		return metav1.Now()
+	}
+	return metav1.NewTime(c.Now())
+}
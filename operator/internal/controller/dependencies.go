@@ -2,14 +2,17 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
-	"strings"
+	"sync"
+	"time"
 
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/credentials"
 	"github.com/dasmlab/glooscap-operator/pkg/outline"
 )
 
@@ -19,48 +22,148 @@ type OutlineClientFactory interface {
 }
 
 // DefaultOutlineClientFactory reads secrets from Kubernetes and instantiates clients.
-type DefaultOutlineClientFactory struct{}
-
-// New creates an Outline client using the service account secret referenced by the target.
-func (DefaultOutlineClientFactory) New(ctx context.Context, c client.Client, target *wikiv1alpha1.WikiTarget) (*outline.Client, error) {
-	if target.Spec.ServiceAccountSecretRef.Name == "" {
-		return nil, fmt.Errorf("outline factory: service account secret ref is empty")
-	}
+type DefaultOutlineClientFactory struct {
+	// SlowCallThreshold is passed through to outline.Config.SlowCallThreshold.
+	SlowCallThreshold time.Duration
+}
 
-	var secret corev1.Secret
-	key := types.NamespacedName{
-		Namespace: target.Namespace,
-		Name:      target.Spec.ServiceAccountSecretRef.Name,
+// New creates an Outline client using the token provider configured on the target.
+func (f DefaultOutlineClientFactory) New(ctx context.Context, c client.Client, target *wikiv1alpha1.WikiTarget) (*outline.Client, error) {
+	token, err := credentials.OutlineToken(ctx, c, target)
+	if err != nil {
+		return nil, fmt.Errorf("outline factory: %w", err)
 	}
-	if err := c.Get(ctx, key, &secret); err != nil {
-		return nil, fmt.Errorf("outline factory: get secret %s: %w", key, err)
+	caBundle, err := credentials.CABundle(ctx, c, target)
+	if err != nil {
+		return nil, fmt.Errorf("outline factory: %w", err)
 	}
-
-	keyName := target.Spec.ServiceAccountSecretRef.Key
-	if keyName == "" {
-		keyName = "token"
+	outlineClient, err := f.build(target, token, caBundle)
+	if err != nil {
+		return nil, err
 	}
+	detectOutlineServer(ctx, outlineClient, target)
+	return outlineClient, nil
+}
 
-	tokenBytes, ok := secret.Data[keyName]
-	if !ok {
-		return nil, fmt.Errorf("outline factory: key %q not found in secret %s", keyName, key)
+// detectOutlineServer probes a new client's Outline version so it can use the
+// features that version has, and reports whether the probe succeeded. A failed
+// probe leaves the client on the calls every version supports; the error
+// surfaces again on the client's first call.
+func detectOutlineServer(ctx context.Context, outlineClient *outline.Client, target *wikiv1alpha1.WikiTarget) bool {
+	if _, err := outlineClient.DetectServer(ctx); err != nil {
+		log.FromContext(ctx).V(1).Info("could not detect Outline version", "wikitarget", target.Name, "error", err.Error())
+		return false
 	}
+	return true
+}
 
-	// Kubernetes secrets store data as base64-encoded bytes, but the client library
-	// automatically decodes them. When using stringData, the value is stored as-is
-	// and read back as decoded bytes. Convert to string and trim whitespace.
-	token := string(tokenBytes)
-
-	// Trim any leading/trailing whitespace or newlines
-	token = strings.TrimSpace(token)
-
+// build instantiates a client for the target authenticating with token and
+// trusting caBundle, if any. Clients for ReadOnly targets refuse every write.
+func (f DefaultOutlineClientFactory) build(target *wikiv1alpha1.WikiTarget, token string, caBundle []byte) (*outline.Client, error) {
 	client, err := outline.NewClient(outline.Config{
 		BaseURL:              target.Spec.URI,
 		Token:                token,
 		InsecureSkipTLSVerify: target.Spec.InsecureSkipTLSVerify,
+		CABundle:              caBundle,
+		SlowCallThreshold:     f.SlowCallThreshold,
+		ReadOnly:              target.Spec.Mode == wikiv1alpha1.WikiTargetModeReadOnly,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("outline factory: %w", err)
 	}
 	return client, nil
 }
+
+// CachingOutlineClientFactory reuses Outline clients (and their transports) across
+// reconciles and API calls. An entry is rebuilt whenever the WikiTarget's generation
+// or its token or CA bundle changes, whichever provider the token comes from.
+type CachingOutlineClientFactory struct {
+	DefaultOutlineClientFactory
+
+	mu      sync.Mutex
+	clients map[types.NamespacedName]cachedOutlineClient
+}
+
+type cachedOutlineClient struct {
+	generation int64
+	tokenHash  [sha256.Size]byte
+	caHash     [sha256.Size]byte
+	client     *outline.Client
+}
+
+// NewCachingOutlineClientFactory wraps base with a per-WikiTarget client cache.
+func NewCachingOutlineClientFactory(base DefaultOutlineClientFactory) *CachingOutlineClientFactory {
+	return &CachingOutlineClientFactory{
+		DefaultOutlineClientFactory: base,
+		clients:                     make(map[types.NamespacedName]cachedOutlineClient),
+	}
+}
+
+// New returns the cached client for target if it is still current, otherwise
+// builds a new one. The new client is probed without holding the cache lock,
+// so a slow Outline doesn't hold up clients for other targets, and is only
+// cached once the probe succeeds; until then each call probes again.
+func (f *CachingOutlineClientFactory) New(ctx context.Context, c client.Client, target *wikiv1alpha1.WikiTarget) (*outline.Client, error) {
+	token, err := credentials.OutlineToken(ctx, c, target)
+	if err != nil {
+		return nil, fmt.Errorf("outline factory: %w", err)
+	}
+	tokenHash := sha256.Sum256([]byte(token))
+	caBundle, err := credentials.CABundle(ctx, c, target)
+	if err != nil {
+		return nil, fmt.Errorf("outline factory: %w", err)
+	}
+	caHash := sha256.Sum256(caBundle)
+
+	key := types.NamespacedName{Namespace: target.Namespace, Name: target.Name}
+	// Targets built in-memory (e.g. diagnostics) have no generation and are never cached
+	cacheable := target.Generation != 0
+	entry := cachedOutlineClient{generation: target.Generation, tokenHash: tokenHash, caHash: caHash}
+	if cacheable {
+		if cached, ok := f.cached(key, entry); ok {
+			return cached, nil
+		}
+	}
+
+	outlineClient, err := f.build(target, token, caBundle)
+	if err != nil {
+		return nil, err
+	}
+	if !detectOutlineServer(ctx, outlineClient, target) || !cacheable {
+		return outlineClient, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// Another call may have cached a client for the same settings meanwhile
+	if existing, ok := f.clients[key]; ok && existing.current(entry) {
+		return existing.client, nil
+	}
+	entry.client = outlineClient
+	f.clients[key] = entry
+	return outlineClient, nil
+}
+
+// cached returns the client cached under key when it was built for want's
+// generation, token and CA bundle.
+func (f *CachingOutlineClientFactory) cached(key types.NamespacedName, want cachedOutlineClient) (*outline.Client, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if entry, ok := f.clients[key]; ok && entry.current(want) {
+		return entry.client, true
+	}
+	return nil, false
+}
+
+// current reports whether e was built for the same generation, token and CA
+// bundle as want.
+func (e cachedOutlineClient) current(want cachedOutlineClient) bool {
+	return e.generation == want.generation && e.tokenHash == want.tokenHash && e.caHash == want.caHash
+}
+
+// Evict drops the cached client for a WikiTarget, e.g. after it has been deleted.
+func (f *CachingOutlineClientFactory) Evict(namespace, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.clients, types.NamespacedName{Namespace: namespace, Name: name})
+}
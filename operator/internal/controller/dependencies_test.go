@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+var _ = Describe("CachingOutlineClientFactory", func() {
+	It("should only cache clients whose server detection succeeded", func() {
+		var reachable atomic.Bool
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if !reachable.Load() {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"ok":false}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"version":"0.80.0"}}`))
+		}))
+		defer srv.Close()
+
+		target := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "factory", Generation: 1},
+			Spec: wikiv1alpha1.WikiTargetSpec{
+				URI:                     srv.URL,
+				ServiceAccountSecretRef: wikiv1alpha1.SecretKeyRef{Name: "outline"},
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "outline", Namespace: "factory"},
+			Data:       map[string][]byte{"token": []byte("token")},
+		}
+		c := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(target, secret).Build()
+		factory := NewCachingOutlineClientFactory(DefaultOutlineClientFactory{})
+
+		first, err := factory.New(ctx, c, target)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := factory.New(ctx, c, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).NotTo(BeIdenticalTo(first))
+
+		reachable.Store(true)
+		detected, err := factory.New(ctx, c, target)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(detected.Server().Version).To(Equal("0.80.0"))
+		Expect(factory.New(ctx, c, target)).To(BeIdenticalTo(detected))
+	})
+})
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"sync"
@@ -38,6 +39,15 @@ import (
 // to test the translation pipeline end-to-end.
 type DiagnosticRunnable struct {
 	Client client.Client
+	// Mode is passed to the runner as the diagnosticMode parameter
+	// (update, append or new). Empty keeps the runner default (update).
+	Mode string
+	// Target names a dedicated WikiTarget that diagnostic jobs publish to.
+	// When empty, diagnostic jobs only exercise the translation service and
+	// never write to a wiki.
+	Target string
+	// Collection is the Outline collection used on Target (default GLOOSCAP-DIAG).
+	Collection string
 	// Track last failure time per job type to implement cooldown
 	lastFailureTime map[string]time.Time
 	lastFailureMu   sync.Mutex
@@ -103,6 +113,10 @@ func (r *DiagnosticRunnable) createTestJob(ctx context.Context, logger logr.Logg
 	} else {
 		logger.Info("no WikiTargets found, using dummy targets for translation service test only")
 	}
+	// A configured diagnostic target replaces the production destination
+	if r.Target != "" {
+		destTargetName = r.Target
+	}
 
 	// Use a fixed test page ID for StarWars test
 	pageID := "998e669e-a2fe-496a-92d3-a265cb27a362"
@@ -123,7 +137,7 @@ Pursued by the Empire's sinister agents, Princess Leia races home aboard her sta
 	var existingJobs wikiv1alpha1.TranslationJobList
 	if err := r.Client.List(ctx, &existingJobs,
 		client.InNamespace("glooscap-system"),
-		client.MatchingLabels{"glooscap.dasmlab.org/diagnostic": "true"}); err == nil {
+		client.MatchingLabels{wikiv1alpha1.LabelDiagnostic: "true"}); err == nil {
 		// Find the most recent test-starwars job
 		var mostRecentJob *wikiv1alpha1.TranslationJob
 		var mostRecentTime time.Time
@@ -213,7 +227,7 @@ Pursued by the Empire's sinister agents, Princess Leia races home aboard her sta
 				Namespace: "glooscap-system",
 				Labels: map[string]string{
 					"app.kubernetes.io/managed-by":    "diagnostic-controller",
-					"glooscap.dasmlab.org/diagnostic": "true",
+					wikiv1alpha1.LabelDiagnostic: "true",
 				},
 			},
 			Spec: wikiv1alpha1.TranslationJobSpec{
@@ -234,6 +248,15 @@ Pursued by the Empire's sinister agents, Princess Leia races home aboard her sta
 				},
 			},
 		}
+		if r.Mode != "" {
+			job.Spec.Parameters["diagnosticMode"] = r.Mode
+		}
+		if r.Target != "" {
+			job.Spec.Parameters["diagnosticTarget"] = r.Target
+			if r.Collection != "" {
+				job.Spec.Parameters["diagnosticCollection"] = r.Collection
+			}
+		}
 
 		if err := r.Client.Create(ctx, job); err != nil {
 		// Failures are ok - just log and continue
@@ -241,13 +264,17 @@ Pursued by the Empire's sinister agents, Princess Leia races home aboard her sta
 		return
 		}
 
+	note := "This job tests translation service connectivity - results will be logged but not posted to wiki"
+	if r.Target != "" {
+		note = "This job publishes its result to the diagnostic target only"
+	}
 	logger.Info("created test TranslationJob",
 		"name", jobName,
 		"source", sourceTargetName,
 		"destination", destTargetName,
 		"language", "fr-CA",
 		"pageID", pageID,
-		"note", "This job tests translation service connectivity - results will be logged but not posted to wiki")
+		"note", note)
 }
 
 // createDiagnosticJobs creates multiple diagnostic jobs (kept for backward compatibility, but not used by default)
@@ -260,7 +287,10 @@ func (r *DiagnosticRunnable) createDiagnosticJobs(ctx context.Context, logger lo
 // SetupDiagnosticRunnable sets up the diagnostic runnable with the Manager.
 func SetupDiagnosticRunnable(mgr manager.Manager) error {
 	runnable := &DiagnosticRunnable{
-		Client: mgr.GetClient(),
+		Client:     mgr.GetClient(),
+		Mode:       os.Getenv("GLOOSCAP_DIAGNOSTIC_MODE"),
+		Target:     os.Getenv("GLOOSCAP_DIAGNOSTIC_TARGET"),
+		Collection: os.Getenv("GLOOSCAP_DIAGNOSTIC_COLLECTION"),
 	}
 	return mgr.Add(runnable)
 }
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+const (
+	// DefaultDiagnosticMaxConcurrent caps diagnostic jobs running at once.
+	DefaultDiagnosticMaxConcurrent = 1
+	// DefaultDiagnosticMaxPerMinute caps diagnostic jobs started per minute.
+	DefaultDiagnosticMaxPerMinute = 4
+
+	// DiagnosticThrottledReason is the Ready condition reason of a diagnostic
+	// job waiting in Queued for diagnostic capacity.
+	DiagnosticThrottledReason = "DiagnosticThrottled"
+	// diagnosticDuplicateReason cancels a diagnostic job that repeats one
+	// already queued or running.
+	diagnosticDuplicateReason = "DiagnosticDuplicate"
+
+	diagnosticRequeueInterval = 15 * time.Second
+)
+
+// DiagnosticLimits throttles diagnostic jobs separately from translations, so
+// a burst of connection tests can't take runner capacity from real work.
+type DiagnosticLimits struct {
+	// MaxConcurrent caps diagnostic jobs dispatched and not yet finished.
+	// 0 uses DefaultDiagnosticMaxConcurrent.
+	MaxConcurrent int
+	// MaxPerMinute caps diagnostic jobs started per minute.
+	// 0 uses DefaultDiagnosticMaxPerMinute.
+	MaxPerMinute int
+
+	once    sync.Once
+	limiter *rate.Limiter
+}
+
+func (l *DiagnosticLimits) maxConcurrent() int {
+	if l.MaxConcurrent > 0 {
+		return l.MaxConcurrent
+	}
+	return DefaultDiagnosticMaxConcurrent
+}
+
+func (l *DiagnosticLimits) allow() bool {
+	l.once.Do(func() {
+		perMinute := l.MaxPerMinute
+		if perMinute <= 0 {
+			perMinute = DefaultDiagnosticMaxPerMinute
+		}
+		l.limiter = rate.NewLimiter(rate.Limit(float64(perMinute)/60), perMinute)
+	})
+	return l.limiter.Allow()
+}
+
+// diagnosticAdmission is the outcome of admitDiagnosticJob.
+type diagnosticAdmission struct {
+	// Admit is true when the job may dispatch now.
+	Admit bool
+	// DuplicateOf names an earlier job running the same diagnostic.
+	DuplicateOf string
+	// Queued and Active count the other diagnostic jobs waiting and running.
+	Queued, Active int
+}
+
+// admitDiagnosticJob decides whether the queued diagnostic job may dispatch.
+// A job repeating an earlier, unfinished diagnostic - same test content,
+// destination and language - is folded into it instead.
+func (r *TranslationJobReconciler) admitDiagnosticJob(ctx context.Context, job *wikiv1alpha1.TranslationJob) (diagnosticAdmission, error) {
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := r.List(ctx, &jobs, client.InNamespace(job.Namespace)); err != nil {
+		return diagnosticAdmission{}, fmt.Errorf("list diagnostic jobs: %w", err)
+	}
+
+	var admission diagnosticAdmission
+	key := diagnosticKey(job)
+	for i := range jobs.Items {
+		other := &jobs.Items[i]
+		if other.Name == job.Name || !other.IsDiagnostic() || other.Status.State.IsTerminal() {
+			continue
+		}
+		if admission.DuplicateOf == "" && createdBefore(other, job) && diagnosticKey(other) == key {
+			admission.DuplicateOf = other.Name
+		}
+		if isActiveTranslationState(other.Status.State) {
+			admission.Active++
+		} else {
+			admission.Queued++
+		}
+	}
+	if admission.DuplicateOf != "" {
+		return admission, nil
+	}
+	admission.Admit = admission.Active < r.Diagnostics.maxConcurrent() && r.Diagnostics.allow()
+	return admission, nil
+}
+
+// diagnosticKey identifies what a diagnostic job tests.
+func diagnosticKey(job *wikiv1alpha1.TranslationJob) string {
+	destination := job.Spec.Source.TargetRef
+	if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
+		destination = job.Spec.Destination.TargetRef
+	}
+	sum := sha256.Sum256([]byte(job.Spec.Parameters["testContent"]))
+	return destination + "/" + job.Spec.Parameters["diagnosticTarget"] + "/" + languageTagForJob(job) + "/" + hex.EncodeToString(sum[:])
+}
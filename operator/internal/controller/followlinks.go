@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+)
+
+// maxLinkedPagesPerJob caps the child jobs one page's links create, so a
+// table-of-contents page can't fan out into hundreds of translations.
+const maxLinkedPagesPerJob = 50
+
+// spawnLinkedJobs creates a child job for each catalogue page of the source
+// target that job's page links to, when job follows links, and returns the
+// names of the jobs it created.
+//
+// Children are named after the root job and their page, so a page reached
+// twice in one tree, through a cycle or from two pages, gets a single job, and
+// calling this again for the same job creates nothing new.
+func (r *TranslationJobReconciler) spawnLinkedJobs(ctx context.Context, job *wikiv1alpha1.TranslationJob, sourceTarget *wikiv1alpha1.WikiTarget) ([]string, error) {
+	if job.Spec.LinkDepth() == 0 || job.IsDiagnostic() || r.OutlineClient == nil || r.Catalogue == nil {
+		return nil, nil
+	}
+	logger := log.FromContext(ctx).WithValues("translationjob", client.ObjectKeyFromObject(job))
+
+	root := rootJobName(job)
+	skip := map[string]bool{job.Spec.Source.PageID: true}
+	if root != job.Name {
+		var rootJob wikiv1alpha1.TranslationJob
+		err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: root}, &rootJob)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		skip[rootJob.Spec.Source.PageID] = true
+	}
+
+	sourceClient, err := r.OutlineClient.New(ctx, r.Client, sourceTarget)
+	if err != nil {
+		return nil, fmt.Errorf("create outline client: %w", err)
+	}
+	content, err := sourceClient.GetPageRevisionContent(ctx, job.Spec.Source.PageID, job.Spec.Source.PinnedRevision())
+	if stderrors.Is(err, outline.ErrEmptyContent) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetch source page: %w", err)
+	}
+
+	targetID := fmt.Sprintf("%s/%s", sourceTarget.Namespace, sourceTarget.Name)
+	pages, truncated := linkedPages(outline.LinkedDocuments(content.Markdown), r.Catalogue.List(targetID), skip)
+	if truncated && r.Recorder != nil {
+		r.Recorder.Eventf(job, "Warning", "TooManyLinks", "Page links to more than %d pages; only the first %d are translated", maxLinkedPagesPerJob, maxLinkedPagesPerJob)
+	}
+
+	var created []string
+	for _, page := range pages {
+		child := newLinkedJob(job, root, page)
+		if err := r.Create(ctx, child); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Already translated elsewhere in this tree
+				continue
+			}
+			return created, fmt.Errorf("create child job for page %s: %w", page.ID, err)
+		}
+		logger.Info("created child job for linked page", "job", child.Name, "pageID", page.ID, "title", page.Title)
+		created = append(created, child.Name)
+	}
+	return created, nil
+}
+
+// linkedPages resolves the linked urlIds (or IDs) against the catalogue pages
+// of the source target. Pages in skip, templates and glooscap's own
+// translations are left out, as are links beyond maxLinkedPagesPerJob, which
+// truncated reports.
+func linkedPages(urlIDs []string, catalogue []*catalog.Page, skip map[string]bool) (pages []catalog.Page, truncated bool) {
+	bySlug := make(map[string]*catalog.Page, len(catalogue))
+	for _, page := range catalogue {
+		bySlug[page.ID] = page
+		if page.Slug != "" {
+			bySlug[page.Slug] = page
+		}
+	}
+	seen := maps.Clone(skip)
+	for _, urlID := range urlIDs {
+		page := bySlug[urlID]
+		if page == nil || seen[page.ID] || page.IsTemplate || strings.HasPrefix(page.Title, translatedTitlePrefix) {
+			continue
+		}
+		if len(pages) == maxLinkedPagesPerJob {
+			return pages, true
+		}
+		seen[page.ID] = true
+		pages = append(pages, *page)
+	}
+	return pages, false
+}
+
+// rootJobName returns the job links were first followed from in job's tree.
+func rootJobName(job *wikiv1alpha1.TranslationJob) string {
+	if root := job.Annotations[wikiv1alpha1.AnnotationRootJob]; root != "" {
+		return root
+	}
+	return job.Name
+}
+
+// newLinkedJob builds the child of parent that translates page, with the
+// parent's destination and settings and one level of links less to follow.
+func newLinkedJob(parent *wikiv1alpha1.TranslationJob, root string, page catalog.Page) *wikiv1alpha1.TranslationJob {
+	spec := parent.Spec.DeepCopy()
+	spec.Source.PageID = page.ID
+	spec.Source.RevisionID = ""
+	spec.Source.Revision = ""
+	spec.Parameters = maps.Clone(spec.Parameters)
+	if spec.Parameters == nil {
+		spec.Parameters = map[string]string{}
+	}
+	spec.Parameters["pageTitle"] = page.Title
+	spec.FollowLinks = nil
+	if depth := parent.Spec.LinkDepth() - 1; depth > 0 {
+		spec.FollowLinks = &wikiv1alpha1.FollowLinksSpec{Depth: depth}
+	}
+
+	return &wikiv1alpha1.TranslationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      linkedJobName(root, page.ID),
+			Namespace: parent.Namespace,
+			Annotations: map[string]string{
+				wikiv1alpha1.AnnotationParentJob: parent.Name,
+				wikiv1alpha1.AnnotationRootJob:   root,
+			},
+		},
+		Spec: *spec,
+	}
+}
+
+// linkedJobName derives the child job name for pageID in root's tree.
+func linkedJobName(root, pageID string) string {
+	sum := sha256.Sum256([]byte(pageID))
+	// Leave room for the suffix within the 253 characters of a name
+	if len(root) > 230 {
+		root = strings.TrimRight(root[:230], "-.")
+	}
+	return fmt.Sprintf("%s-link-%s", root, hex.EncodeToString(sum[:])[:10])
+}
+
+// appendChildJobs adds names to children, skipping those already listed.
+func appendChildJobs(children []string, names ...string) []string {
+	for _, name := range names {
+		if !slices.Contains(children, name) {
+			children = append(children, name)
+		}
+	}
+	return children
+}
@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// notifyTimeout bounds a single webhook delivery.
+const notifyTimeout = 10 * time.Second
+
+// JobNotifier POSTs a JSON notification to a webhook when a TranslationJob
+// completes, fails or waits for approval. Delivery is best effort: it runs in
+// the background and its failures are only logged.
+//
+// A job's own spec.notifyWebhook is only used when its host is allowed (see
+// CheckWebhook); otherwise the job falls back to DefaultURL. Connections to
+// loopback and link-local addresses are refused whatever the host resolves
+// to, so a webhook can't reach the operator's pod or the cloud metadata
+// service.
+type JobNotifier struct {
+	// DefaultURL is used for jobs without spec.notifyWebhook. Diagnostic jobs
+	// only notify when they set their own webhook.
+	DefaultURL string
+	// AllowedHosts lists the hosts a job's spec.notifyWebhook may point at,
+	// compared without the port. DefaultURL's host is always allowed.
+	AllowedHosts []string
+	HTTPClient   *http.Client
+}
+
+// NewJobNotifier creates a notifier with defaultURL as the operator-wide
+// webhook and allowedHosts as the hosts jobs may send their own webhooks to.
+func NewJobNotifier(defaultURL string, allowedHosts []string) *JobNotifier {
+	dialer := &net.Dialer{Timeout: notifyTimeout, Control: refuseInternalAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	return &JobNotifier{
+		DefaultURL:   defaultURL,
+		AllowedHosts: allowedHosts,
+		HTTPClient:   &http.Client{Timeout: notifyTimeout, Transport: transport},
+	}
+}
+
+// CheckWebhook returns an error unless rawURL is an http(s) URL whose host
+// the notifier allows.
+func (n *JobNotifier) CheckWebhook(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("notifyWebhook must be an http(s) URL")
+	}
+	host := strings.ToLower(u.Hostname())
+	if def, err := url.Parse(n.DefaultURL); err == nil && n.DefaultURL != "" && strings.EqualFold(def.Hostname(), host) {
+		return nil
+	}
+	for _, allowed := range n.AllowedHosts {
+		if strings.EqualFold(strings.TrimSpace(allowed), host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("notifyWebhook host %q is not in the operator's webhook allowlist", u.Hostname())
+}
+
+// refuseInternalAddress is a net.Dialer Control function refusing loopback,
+// link-local and unspecified addresses. It runs on the resolved address, so
+// a host name can't be pointed at one of them later.
+func refuseInternalAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("webhook address %s is not allowed", address)
+	}
+	return nil
+}
+
+// JobNotification is the webhook payload. It extends TranslationJobEvent with
+// the job's identity and a Slack-compatible text summary.
+type JobNotification struct {
+	TranslationJobEvent
+	LanguageTag     string `json:"languageTag"`
+	SourceTargetRef string `json:"sourceTargetRef"`
+	SourcePageID    string `json:"sourcePageId"`
+	TokensUsed      int64  `json:"tokensUsed,omitempty"`
+	Text            string `json:"text"`
+}
+
+// notifyEventTypes maps the states that trigger a notification to the event type sent.
+var notifyEventTypes = map[wikiv1alpha1.TranslationJobState]string{
+	wikiv1alpha1.TranslationJobStateCompleted:          "translation_complete",
+	wikiv1alpha1.TranslationJobStatePartiallyCompleted: "translation_partially_complete",
+	wikiv1alpha1.TranslationJobStateFailed:             "translation_failed",
+	wikiv1alpha1.TranslationJobStateAwaitingApproval:   "awaiting_approval",
+}
+
+// webhookURL returns where job's notifications go, or "" when it has none. A
+// webhook the job sets itself is only used when CheckWebhook allows it.
+func (n *JobNotifier) webhookURL(ctx context.Context, job *wikiv1alpha1.TranslationJob) string {
+	if job.Spec.NotifyWebhook != "" {
+		err := n.CheckWebhook(job.Spec.NotifyWebhook)
+		if err == nil {
+			return job.Spec.NotifyWebhook
+		}
+		log.FromContext(ctx).Info("ignoring the job's notifyWebhook", "job", job.Name, "reason", err.Error())
+	}
+	if isDiagnosticJob(job) {
+		return ""
+	}
+	return n.DefaultURL
+}
+
+// notifyJob sends a notification for job's current state unless one was
+// already sent for it. The state is recorded on the job before delivery so a
+// slow or failing webhook never causes duplicates or blocks the reconcile.
+func (r *TranslationJobReconciler) notifyJob(ctx context.Context, job *wikiv1alpha1.TranslationJob) {
+	if r.Notifier == nil {
+		return
+	}
+	eventType, ok := notifyEventTypes[job.Status.State]
+	if !ok || job.Annotations[wikiv1alpha1.AnnotationNotifiedState] == string(job.Status.State) {
+		return
+	}
+	url := r.Notifier.webhookURL(ctx, job)
+	if url == "" {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	patch := client.MergeFrom(job.DeepCopy())
+	if job.Annotations == nil {
+		job.Annotations = make(map[string]string)
+	}
+	job.Annotations[wikiv1alpha1.AnnotationNotifiedState] = string(job.Status.State)
+	if err := r.Patch(ctx, job, patch); err != nil {
+		logger.Error(err, "failed to record notification state, will retry", "job", job.Name)
+		return
+	}
+
+	payload := newJobNotification(job, eventType)
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+		if err := r.Notifier.send(sendCtx, url, payload); err != nil {
+			logger.Error(err, "translation job webhook failed", "job", payload.JobName, "state", payload.State)
+			return
+		}
+		logger.Info("translation job webhook delivered", "job", payload.JobName, "state", payload.State)
+	}()
+}
+
+func newJobNotification(job *wikiv1alpha1.TranslationJob, eventType string) JobNotification {
+	pageURL := job.Annotations[wikiv1alpha1.AnnotationPublishedPageURL]
+	lang := languageTagForJob(job)
+	for _, res := range job.Status.LanguageResults {
+		if pageURL == "" && res.Lang == lang {
+			pageURL = res.PageURL
+		}
+	}
+
+	text := fmt.Sprintf("Translation job %s/%s (%s): %s", job.Namespace, job.Name, lang, job.Status.State)
+	if pageURL != "" {
+		text += " - " + pageURL
+	} else if job.Status.Message != "" {
+		text += " - " + job.Status.Message
+	}
+
+	return JobNotification{
+		TranslationJobEvent: TranslationJobEvent{
+			Type:      eventType,
+			JobName:   job.Name,
+			Namespace: job.Namespace,
+			PageURL:   pageURL,
+			PageID:    job.Annotations[wikiv1alpha1.AnnotationPublishedPageID],
+			PageTitle: job.Annotations[wikiv1alpha1.AnnotationPublishedPageTitle],
+			State:     string(job.Status.State),
+			Message:   job.Status.Message,
+		},
+		LanguageTag:     lang,
+		SourceTargetRef: job.Spec.Source.TargetRef,
+		SourcePageID:    job.Spec.Source.PageID,
+		TokensUsed:      job.Status.TokensUsed,
+		Text:            text,
+	}
+}
+
+func (n *JobNotifier) send(ctx context.Context, url string, payload JobNotification) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := n.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
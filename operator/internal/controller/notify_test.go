@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+var _ = Describe("JobNotifier webhooks", func() {
+	notifier := NewJobNotifier("https://hooks.example.com/glooscap", []string{"chat.example.org"})
+	jobWithWebhook := func(webhook string) *wikiv1alpha1.TranslationJob {
+		return &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default"},
+			Spec:       wikiv1alpha1.TranslationJobSpec{NotifyWebhook: webhook},
+		}
+	}
+
+	It("only allows the default webhook's host and the allowlist", func() {
+		Expect(notifier.CheckWebhook("https://hooks.example.com/other")).To(Succeed())
+		Expect(notifier.CheckWebhook("https://CHAT.example.org:8443/hook")).To(Succeed())
+		Expect(notifier.CheckWebhook("http://169.254.169.254/latest/meta-data")).NotTo(Succeed())
+		Expect(notifier.CheckWebhook("https://attacker.example.net/")).NotTo(Succeed())
+		Expect(notifier.CheckWebhook("ftp://chat.example.org/")).NotTo(Succeed())
+	})
+
+	It("falls back to the default webhook for a host that isn't allowed", func() {
+		ctx := context.Background()
+		Expect(notifier.webhookURL(ctx, jobWithWebhook("https://chat.example.org/hook"))).
+			To(Equal("https://chat.example.org/hook"))
+		Expect(notifier.webhookURL(ctx, jobWithWebhook("http://kubernetes.default.svc/api"))).
+			To(Equal(notifier.DefaultURL))
+	})
+
+	It("refuses loopback and link-local addresses at dial time", func() {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+		}))
+		defer srv.Close()
+
+		// The host is allowed, but it resolves to loopback
+		local := NewJobNotifier(srv.URL, nil)
+		err := local.send(context.Background(), srv.URL, newJobNotification(jobWithWebhook(""), "translation_complete"))
+		Expect(err).To(MatchError(ContainSubstring("is not allowed")))
+		Expect(calls.Load()).To(BeZero())
+
+		for _, address := range []string{"127.0.0.1:80", "[::1]:80", "169.254.169.254:80", "[fe80::1]:80", "0.0.0.0:80"} {
+			Expect(refuseInternalAddress("tcp", address, nil)).NotTo(Succeed(), address)
+		}
+		Expect(refuseInternalAddress("tcp", "10.0.0.12:443", nil)).To(Succeed())
+	})
+})
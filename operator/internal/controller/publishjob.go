@@ -0,0 +1,282 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+	"github.com/dasmlab/glooscap-operator/pkg/jobcontent"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+)
+
+// reconcilePublishJob runs a publish job in the operator, without a runner.
+// Nothing is fetched from the source wiki or sent to the translation service:
+// the draft the original job created is published as it is.
+func (r *TranslationJobReconciler) reconcilePublishJob(ctx context.Context, job *wikiv1alpha1.TranslationJob, updated *wikiv1alpha1.TranslationJobStatus, now metav1.Time) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("translationjob", client.ObjectKeyFromObject(job))
+	if updated.StartedAt == nil {
+		updated.StartedAt = &now
+	}
+
+	admission, err := r.admitTargetJob(ctx, job, nil)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !admission.Admit {
+		message := admission.waitingMessage()
+		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			Reason:             TargetThrottledReason,
+			Message:            message,
+			LastTransitionTime: now,
+		})
+		updated.State = wikiv1alpha1.TranslationJobStateQueued
+		updated.Message = message
+		if err := updateTranslationJobStatus(ctx, r.Client, job, updated); err != nil {
+			return ctrl.Result{}, err
+		}
+		if r.Jobs != nil {
+			r.Jobs.Update(job)
+		}
+		return ctrl.Result{RequeueAfter: targetRequeueInterval}, nil
+	}
+	defer admission.Release()
+
+	page, err := r.publishApprovedDraft(ctx, job)
+	if err != nil {
+		logger.Error(err, "failed to publish approved draft")
+		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			Reason:             "PublishFailed",
+			Message:            err.Error(),
+			LastTransitionTime: now,
+		})
+		updated.State = wikiv1alpha1.TranslationJobStateFailed
+		updated.Message = fmt.Sprintf("Failed to publish page: %v", err)
+		updated.FinishedAt = &now
+	} else {
+		logger.Info("approved draft published", "page_id", page.ID, "title", page.Title)
+		provenance := client.MergeFrom(job.DeepCopy())
+		wikiv1alpha1.SetPublishedPage(job, page)
+		if err := r.Patch(ctx, job, provenance); err != nil {
+			logger.Error(err, "failed to record the published page on the job")
+		}
+		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionTrue,
+			Reason:             "Published",
+			Message:            fmt.Sprintf("Translation published as: %s", page.Title),
+			LastTransitionTime: now,
+		})
+		updated.State = wikiv1alpha1.TranslationJobStateCompleted
+		updated.Message = fmt.Sprintf("Page published successfully (page: %s)", page.Slug)
+		updated.FinishedAt = &now
+	}
+
+	if err := updateTranslationJobStatus(ctx, r.Client, job, updated); err != nil {
+		return ctrl.Result{}, err
+	}
+	if r.Recorder != nil {
+		r.Recorder.Event(job, "Normal", string(job.Status.State), job.Status.Message)
+	}
+	if r.Jobs != nil {
+		r.Jobs.Update(job)
+	}
+	return ctrl.Result{}, nil
+}
+
+// publishApprovedDraft publishes the draft publish job names. A draft deleted
+// since it was created is replaced by a published page made from the
+// translation its original job stored, which then points at the new page.
+func (r *TranslationJobReconciler) publishApprovedDraft(ctx context.Context, job *wikiv1alpha1.TranslationJob) (wikiv1alpha1.PublishedPage, error) {
+	var page wikiv1alpha1.PublishedPage
+	pageID := job.Spec.Parameters["pageId"]
+	if pageID == "" {
+		pageID = job.Spec.Source.PageID
+	}
+	if pageID == "" {
+		return page, fmt.Errorf("page ID not found in publish job parameters")
+	}
+	destTargetRef := job.Spec.Parameters["targetRef"]
+	if destTargetRef == "" {
+		destTargetRef = job.Spec.Source.TargetRef
+	}
+
+	var destTarget wikiv1alpha1.WikiTarget
+	if err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: destTargetRef}, &destTarget); err != nil {
+		return page, fmt.Errorf("get destination WikiTarget %s: %w", destTargetRef, err)
+	}
+	destClient, err := r.OutlineClient.New(ctx, r.Client, &destTarget)
+	if err != nil {
+		return page, fmt.Errorf("create destination client: %w", err)
+	}
+
+	published, err := destClient.PublishPage(ctx, outline.PublishPageRequest{ID: pageID})
+	if err == nil {
+		return publishedPage(&destTarget, published.Data.ID, published.Data.Title, published.Data.Slug), nil
+	}
+	if !outline.IsNotFound(err) {
+		return page, fmt.Errorf("publish draft %s: %w", pageID, err)
+	}
+
+	var original wikiv1alpha1.TranslationJob
+	originalName := job.Spec.Parameters["originalJob"]
+	if err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: originalName}, &original); err != nil {
+		return page, fmt.Errorf("draft %s no longer exists and its TranslationJob %q can't be read: %w", pageID, originalName, err)
+	}
+	title, markdown, ok, err := jobcontent.Load(ctx, r.apiReader(), &original)
+	if err != nil {
+		return page, err
+	}
+	if !ok {
+		return page, fmt.Errorf("draft %s no longer exists and TranslationJob %s has no stored translation to recreate it from", pageID, original.Name)
+	}
+	if draftTitle := wikiv1alpha1.GetPublishedPage(&original).Title; draftTitle != "" {
+		title = draftTitle
+	}
+
+	// Place and sign the page as the original job would have: in its source
+	// page's collection, under its parent document, with the footer
+	var sourceTarget wikiv1alpha1.WikiTarget
+	if err := r.Get(ctx, client.ObjectKey{Namespace: original.Namespace, Name: original.Spec.Source.TargetRef}, &sourceTarget); err != nil {
+		return page, fmt.Errorf("draft %s no longer exists and its source WikiTarget can't be read: %w", pageID, err)
+	}
+	sourcePage := r.cataloguePage(&sourceTarget, original.Spec.Source.PageID)
+	sourceClient, err := r.OutlineClient.New(ctx, r.Client, &sourceTarget)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to create Outline client for source")
+		sourceClient = nil
+	}
+	sourceTitle := strings.TrimPrefix(title, "AUTOTRANSLATED--> ")
+	if sourcePage != nil {
+		sourceTitle = sourcePage.Title
+	}
+	created, err := createTranslatedPage(ctx, destClient, &original, outline.CreatePageRequest{
+		Title:        title,
+		Text:         r.withTranslationFooter(ctx, &original, &destTarget, &sourceTarget, sourcePage, original.Status.Engine, sourceTitle, markdown, metav1.Now()),
+		CollectionID: sourceCollectionID(ctx, &original, &sourceTarget, sourcePage, sourceClient),
+		Publish:      true,
+	})
+	if err != nil {
+		return page, fmt.Errorf("draft %s no longer exists, and recreating it failed: %w", pageID, err)
+	}
+	page = publishedPage(&destTarget, created.Data.ID, created.Data.Title, created.Data.Slug)
+	log.FromContext(ctx).Info("draft was deleted, published the stored translation as a new page",
+		"draft", pageID, "page_id", page.ID, "originalJob", original.Name)
+
+	provenance := client.MergeFrom(original.DeepCopy())
+	wikiv1alpha1.SetPublishedPage(&original, page)
+	if err := r.Patch(ctx, &original, provenance); err != nil {
+		log.FromContext(ctx).Error(err, "failed to record the new page on the original job", "originalJob", original.Name)
+	}
+	return page, nil
+}
+
+// resumePublishing publishes the translation an earlier reconcile of job
+// stored but stopped before publishing. The job is read again from the API
+// server first: the cached copy may predate the published-page annotation an
+// earlier reconcile recorded, and publishing again would duplicate the page.
+func (r *TranslationJobReconciler) resumePublishing(ctx context.Context, job *wikiv1alpha1.TranslationJob, updated *wikiv1alpha1.TranslationJobStatus,
+	sourceTarget *wikiv1alpha1.WikiTarget, now metav1.Time) error {
+	if r.OutlineClient == nil {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	var current wikiv1alpha1.TranslationJob
+	if err := r.apiReader().Get(ctx, client.ObjectKeyFromObject(job), &current); err != nil {
+		return fmt.Errorf("reread job before resuming publishing: %w", err)
+	}
+	if page := wikiv1alpha1.GetPublishedPage(&current); page.ID != "" {
+		logger.Info("translation was already published, not publishing it again", "job", job.Name, "page_id", page.ID)
+		job.Annotations = current.Annotations
+		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionTrue,
+			Reason:             "Completed",
+			Message:            fmt.Sprintf("Translation published as: %s", page.Title),
+			LastTransitionTime: now,
+		})
+		updated.State = wikiv1alpha1.TranslationJobStateCompleted
+		updated.Message = fmt.Sprintf("Translation completed and published (page: %s)", page.Slug)
+		updated.FinishedAt = &now
+		updated.SetLanguageResult(wikiv1alpha1.LanguageResult{
+			Lang:    languageTagForJob(job),
+			State:   wikiv1alpha1.TranslationJobStateCompleted,
+			PageURL: page.URL,
+		})
+		return nil
+	}
+
+	_, markdown, ok, err := jobcontent.Load(ctx, r.apiReader(), job)
+	if err == nil && !ok {
+		err = fmt.Errorf("stored translation is missing")
+	}
+	if err != nil {
+		message := fmt.Sprintf("Failed to load the stored translation: %v", err)
+		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			Reason:             "PublishFailed",
+			Message:            message,
+			LastTransitionTime: now,
+		})
+		updated.State = wikiv1alpha1.TranslationJobStateFailed
+		updated.Message = message
+		updated.FinishedAt = &now
+		return nil
+	}
+	logger.Info("publishing the stored translation", "job", job.Name)
+
+	sourcePage := r.cataloguePage(sourceTarget, job.Spec.Source.PageID)
+	// Only used to look up the source page's collection
+	sourceClient, err := r.OutlineClient.New(ctx, r.Client, sourceTarget)
+	if err != nil {
+		logger.Error(err, "failed to create Outline client for source")
+		sourceClient = nil
+	}
+	r.publishTranslation(ctx, job, updated, sourceTarget, sourcePage, sourceClient, markdown, now)
+	return nil
+}
+
+// cataloguePage returns the page with pageID in target's catalogue, nil when
+// the catalogue doesn't hold it.
+func (r *TranslationJobReconciler) cataloguePage(target *wikiv1alpha1.WikiTarget, pageID string) *catalog.Page {
+	if r.Catalogue == nil {
+		return nil
+	}
+	for _, page := range r.Catalogue.List(fmt.Sprintf("%s/%s", target.Namespace, target.Name)) {
+		if page.ID == pageID {
+			return page
+		}
+	}
+	return nil
+}
+
+// publishedPage describes a page on target.
+func publishedPage(target *wikiv1alpha1.WikiTarget, id, title, slug string) wikiv1alpha1.PublishedPage {
+	return wikiv1alpha1.PublishedPage{
+		ID:    id,
+		Slug:  slug,
+		URL:   outline.DocumentURL(target.Spec.URI, title, slug),
+		Title: title,
+	}
+}
+
+// apiReader returns the reader for objects the manager's cache doesn't hold.
+func (r *TranslationJobReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/credentials"
+	"github.com/dasmlab/glooscap-operator/pkg/vllm"
+)
+
+// runnerTokens resolves the file and env provider tokens of the WikiTargets a
+// runner Job for job connects to. Only the operator's pod has those files and
+// variables, so the dispatcher hands the tokens to the runner; Secret provider
+// tokens the runner reads itself. Targets that don't exist are left to the
+// runner to report.
+func (r *TranslationJobReconciler) runnerTokens(ctx context.Context, job *wikiv1alpha1.TranslationJob) ([]vllm.RunnerToken, error) {
+	refs := []string{job.Spec.Source.TargetRef, job.Spec.Parameters["targetRef"]}
+	if job.Spec.Destination != nil {
+		refs = append(refs, job.Spec.Destination.TargetRef)
+	}
+
+	var tokens []vllm.RunnerToken
+	seen := map[string]bool{}
+	for _, name := range refs {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		var target wikiv1alpha1.WikiTarget
+		if err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: name}, &target); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		ref := target.Spec.ServiceAccountSecretRef
+		token := vllm.RunnerToken{}
+		switch ref.TokenProviderType {
+		case wikiv1alpha1.TokenProviderFile:
+			path, err := credentials.TokenFile(ref)
+			if err != nil {
+				return nil, fmt.Errorf("token for WikiTarget %s: %w", name, err)
+			}
+			token.Path = path
+		case wikiv1alpha1.TokenProviderEnv:
+			token.Env = ref.Key
+		default:
+			continue
+		}
+		value, err := credentials.OutlineToken(ctx, r.Client, &target)
+		if err != nil {
+			return nil, fmt.Errorf("token for WikiTarget %s: %w", name, err)
+		}
+		token.Value = value
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+)
+
+// TranslationServiceGate is a readiness check that holds the operator unready
+// after startup until the translation service client has registered, so jobs
+// don't reach a replica whose client is still connecting. It passes at once
+// when no TranslationService exists, and after Timeout regardless, leaving the
+// operator ready but degraded. Once passed it stays passed: later connection
+// trouble is reported by the status endpoints rather than by taking the
+// pod out of service.
+type TranslationServiceGate struct {
+	// Reader lists TranslationServices; use an uncached reader, since the
+	// check may run before the manager's cache has synced.
+	Reader client.Reader
+	// GetNanabushClient returns the current translation service client.
+	GetNanabushClient func() *nanabush.Client
+	// Timeout bounds how long the gate holds readiness back, counted from
+	// the first check.
+	Timeout time.Duration
+	// Clock measures the timeout. Nil uses the real clock.
+	Clock clock.PassiveClock
+
+	once    sync.Once
+	started time.Time
+	mu      sync.Mutex
+	passed  bool
+}
+
+// Check implements healthz.Checker.
+func (g *TranslationServiceGate) Check(req *http.Request) error {
+	now := nowFrom(g.Clock).Time
+	g.once.Do(func() { g.started = now })
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.passed {
+		return nil
+	}
+	logger := log.FromContext(req.Context()).WithName("startup-gate")
+
+	if c := g.GetNanabushClient(); c != nil && c.IsRegistered() {
+		logger.Info("translation service client registered, marking ready", "waited", now.Sub(g.started))
+		g.passed = true
+		return nil
+	}
+	var services wikiv1alpha1.TranslationServiceList
+	err := g.Reader.List(req.Context(), &services, client.Limit(1))
+	if err == nil && len(services.Items) == 0 {
+		g.passed = true
+		return nil
+	}
+	if now.Sub(g.started) >= g.Timeout {
+		logger.Info("translation service client not registered before the startup timeout, marking ready (degraded)",
+			"timeout", g.Timeout)
+		g.passed = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("list translation services: %w", err)
+	}
+	return fmt.Errorf("waiting for the translation service client to register")
+}
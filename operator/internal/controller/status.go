@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// StatusUpdateBackoff controls how status writes are retried when they hit a
+// resourceVersion conflict (e.g. a heartbeat callback racing the reconciler).
+var StatusUpdateBackoff = retry.DefaultRetry
+
+// errStatusOverlap is returned by mergeStatus when another writer changed a
+// field this writer is changing too.
+var errStatusOverlap = stderrors.New("status field changed by another writer")
+
+// updateStatusWithRetry writes obj's status. When the write conflicts, obj is
+// re-read and apply carries the writer's changes over to the fresh object; it
+// returns false when there is nothing left to write. If apply can't merge the
+// changes, the conflict is returned so the caller recomputes the status from
+// the latest object. On success obj holds the object as persisted by the API
+// server.
+func updateStatusWithRetry[T client.Object](ctx context.Context, c client.Client, obj T, apply func(fresh T) (bool, error)) error {
+	key := client.ObjectKeyFromObject(obj)
+	var conflict error
+	err := retry.RetryOnConflict(StatusUpdateBackoff, func() error {
+		if conflict != nil {
+			if err := c.Get(ctx, key, obj); err != nil {
+				return err
+			}
+			if write, err := apply(obj); err != nil || !write {
+				return err
+			}
+		}
+		err := c.Status().Update(ctx, obj)
+		if errors.IsConflict(err) {
+			conflict = err
+		}
+		return err
+	})
+	if stderrors.Is(err, errStatusOverlap) {
+		return conflict
+	}
+	return err
+}
+
+// mergeStatus carries the changes a writer made from base to desired over to
+// fresh, the status as another writer left it. Fields the writer didn't change
+// keep their fresh value, and conditions are merged by type. A field both
+// writers changed, to different values, is an errStatusOverlap.
+func mergeStatus[S any](base, desired, fresh *S) error {
+	b, d, f := reflect.ValueOf(base).Elem(), reflect.ValueOf(desired).Elem(), reflect.ValueOf(fresh).Elem()
+	for i := range b.NumField() {
+		bv, dv, fv := b.Field(i), d.Field(i), f.Field(i)
+		if conditions, ok := fv.Addr().Interface().(*[]metav1.Condition); ok {
+			if err := mergeConditions(bv.Interface().([]metav1.Condition), dv.Interface().([]metav1.Condition), conditions); err != nil {
+				return err
+			}
+			continue
+		}
+		switch {
+		case equality.Semantic.DeepEqual(dv.Interface(), bv.Interface()), equality.Semantic.DeepEqual(dv.Interface(), fv.Interface()):
+			// Left alone by this writer, or already as it wants
+		case !equality.Semantic.DeepEqual(fv.Interface(), bv.Interface()):
+			return fmt.Errorf("%w: %s", errStatusOverlap, b.Type().Field(i).Name)
+		default:
+			fv.Set(dv)
+		}
+	}
+	return nil
+}
+
+// mergeConditions is mergeStatus for a list of conditions, compared by type.
+func mergeConditions(base, desired []metav1.Condition, fresh *[]metav1.Condition) error {
+	merge := func(conditionType string, want *metav1.Condition) error {
+		had := meta.FindStatusCondition(base, conditionType)
+		has := meta.FindStatusCondition(*fresh, conditionType)
+		switch {
+		case equality.Semantic.DeepEqual(want, had), equality.Semantic.DeepEqual(want, has):
+		case !equality.Semantic.DeepEqual(has, had):
+			return fmt.Errorf("%w: condition %s", errStatusOverlap, conditionType)
+		case want == nil:
+			meta.RemoveStatusCondition(fresh, conditionType)
+		case has == nil:
+			*fresh = append(*fresh, *want)
+		default:
+			*has = *want
+		}
+		return nil
+	}
+	for i := range desired {
+		if err := merge(desired[i].Type, &desired[i]); err != nil {
+			return err
+		}
+	}
+	for _, condition := range base {
+		if meta.FindStatusCondition(desired, condition.Type) == nil {
+			if err := merge(condition.Type, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func updateWikiTargetStatus(ctx context.Context, c client.Client, target *wikiv1alpha1.WikiTarget, status wikiv1alpha1.WikiTargetStatus) error {
+	base := target.Status.DeepCopy()
+	target.Status = *status.DeepCopy()
+	return updateStatusWithRetry(ctx, c, target, func(fresh *wikiv1alpha1.WikiTarget) (bool, error) {
+		return true, mergeStatus(base, status.DeepCopy(), &fresh.Status)
+	})
+}
+
+// updateTranslationJobStatus writes status as job's status. A finished job is
+// never moved back to an unfinished state, and when another writer, such as the
+// runner, finishes the job in the meantime nothing is written; job is left
+// holding the finished job.
+func updateTranslationJobStatus(ctx context.Context, c client.Client, job *wikiv1alpha1.TranslationJob, status *wikiv1alpha1.TranslationJobStatus) error {
+	base := job.Status.DeepCopy()
+	if base.State.IsTerminal() && !status.State.IsTerminal() {
+		return nil
+	}
+	desired := status.DeepCopy()
+	job.Status = *desired.DeepCopy()
+	return updateStatusWithRetry(ctx, c, job, func(fresh *wikiv1alpha1.TranslationJob) (bool, error) {
+		if fresh.Status.State.IsTerminal() && !base.State.IsTerminal() {
+			return false, nil
+		}
+		return true, mergeStatus(base, desired.DeepCopy(), &fresh.Status)
+	})
+}
+
+func updateTranslationServiceStatus(ctx context.Context, c client.Client, ts *wikiv1alpha1.TranslationService, status wikiv1alpha1.TranslationServiceStatus) error {
+	base := ts.Status.DeepCopy()
+	ts.Status = *status.DeepCopy()
+	return updateStatusWithRetry(ctx, c, ts, func(fresh *wikiv1alpha1.TranslationService) (bool, error) {
+		return true, mergeStatus(base, status.DeepCopy(), &fresh.Status)
+	})
+}
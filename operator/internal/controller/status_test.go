@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+var _ = Describe("Status updates on conflict", func() {
+	now := metav1.NewTime(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	// racingClient lets another writer change the object's status, through
+	// race, just before the first status write, which then conflicts
+	racingClient := func(obj client.Object, race func(client.Client)) client.Client {
+		raced := false
+		return fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+			WithObjects(obj).WithStatusSubresource(obj).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					if !raced {
+						raced = true
+						race(c)
+					}
+					return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+				},
+			}).Build()
+	}
+
+	It("should not overwrite a job another writer finished", func() {
+		job := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "status"},
+			Status:     wikiv1alpha1.TranslationJobStatus{State: wikiv1alpha1.TranslationJobStateDispatching},
+		}
+		c := racingClient(job, func(c client.Client) {
+			var runner wikiv1alpha1.TranslationJob
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(job), &runner)).To(Succeed())
+			runner.Status.State = wikiv1alpha1.TranslationJobStateCompleted
+			runner.Status.Message = "Page published"
+			Expect(c.Status().Update(ctx, &runner)).To(Succeed())
+		})
+
+		var held wikiv1alpha1.TranslationJob
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(job), &held)).To(Succeed())
+		updated := held.Status.DeepCopy()
+		updated.State = wikiv1alpha1.TranslationJobStateRunning
+		updated.Message = "Runner dispatched"
+		Expect(updateTranslationJobStatus(ctx, c, &held, updated)).To(Succeed())
+		Expect(held.Status.State).To(Equal(wikiv1alpha1.TranslationJobStateCompleted))
+
+		var stored wikiv1alpha1.TranslationJob
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(job), &stored)).To(Succeed())
+		Expect(stored.Status.State).To(Equal(wikiv1alpha1.TranslationJobStateCompleted))
+		Expect(stored.Status.Message).To(Equal("Page published"))
+
+		// Nor move it back itself
+		updated = stored.Status.DeepCopy()
+		updated.State = wikiv1alpha1.TranslationJobStateQueued
+		Expect(updateTranslationJobStatus(ctx, c, &stored, updated)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(job), &stored)).To(Succeed())
+		Expect(stored.Status.State).To(Equal(wikiv1alpha1.TranslationJobStateCompleted))
+	})
+
+	It("should keep another writer's fields and give up on the ones both changed", func() {
+		ts := &wikiv1alpha1.TranslationService{
+			ObjectMeta: metav1.ObjectMeta{Name: wikiv1alpha1.TranslationServiceName},
+			Status:     wikiv1alpha1.TranslationServiceStatus{Status: "connecting"},
+		}
+		heartbeat := metav1.NewTime(now.Add(time.Minute))
+		c := racingClient(ts, func(c client.Client) {
+			var callback wikiv1alpha1.TranslationService
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(ts), &callback)).To(Succeed())
+			callback.Status.Connected = true
+			callback.Status.LastHeartbeat = &heartbeat
+			Expect(c.Status().Update(ctx, &callback)).To(Succeed())
+		})
+
+		var held wikiv1alpha1.TranslationService
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(ts), &held)).To(Succeed())
+		status := held.Status.DeepCopy()
+		status.Status = "healthy"
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type: "Ready", Status: metav1.ConditionTrue, Reason: "Connected", LastTransitionTime: now,
+		})
+		Expect(updateTranslationServiceStatus(ctx, c, &held, *status)).To(Succeed())
+
+		var stored wikiv1alpha1.TranslationService
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(ts), &stored)).To(Succeed())
+		Expect(stored.Status.Status).To(Equal("healthy"))
+		Expect(stored.Status.Connected).To(BeTrue())
+		Expect(stored.Status.LastHeartbeat.Equal(&heartbeat)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(stored.Status.Conditions, "Ready")).To(BeTrue())
+
+		// A field both writers changed is left for the caller to recompute
+		ts.Status = stored.Status
+		c = racingClient(ts, func(c client.Client) {
+			var callback wikiv1alpha1.TranslationService
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(ts), &callback)).To(Succeed())
+			callback.Status.Connected = false
+			callback.Status.Status = "error"
+			Expect(c.Status().Update(ctx, &callback)).To(Succeed())
+		})
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(ts), &held)).To(Succeed())
+		status = held.Status.DeepCopy()
+		status.Status = "degraded"
+		err := updateTranslationServiceStatus(ctx, c, &held, *status)
+		Expect(errors.IsConflict(err)).To(BeTrue(), "got %v", err)
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(ts), &stored)).To(Succeed())
+		Expect(stored.Status.Status).To(Equal("error"))
+	})
+})
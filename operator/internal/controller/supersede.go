@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// duplicateInProgressReason is the Ready condition reason of a job waiting for
+// an older job translating the same page into the same language.
+const duplicateInProgressReason = "DuplicateInProgress"
+
+// supersedeOlderJobs annotates older, non-terminal jobs for the same source page
+// and language as superseded by job. Failures are logged but never block job.
+func (r *TranslationJobReconciler) supersedeOlderJobs(ctx context.Context, job *wikiv1alpha1.TranslationJob) {
+	logger := log.FromContext(ctx)
+
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := r.List(ctx, &jobs, client.InNamespace(job.Namespace)); err != nil {
+		logger.Error(err, "failed to list jobs for supersession", "job", job.Name)
+		return
+	}
+
+	for i := range jobs.Items {
+		older := &jobs.Items[i]
+		if older.Name == job.Name ||
+			older.Status.State.IsTerminal() ||
+			!sameTranslation(older, job) ||
+			!createdBefore(older, job) {
+			continue
+		}
+		if _, already := older.Annotations[wikiv1alpha1.AnnotationSupersededBy]; already {
+			continue
+		}
+
+		patch := client.MergeFrom(older.DeepCopy())
+		if older.Annotations == nil {
+			older.Annotations = make(map[string]string)
+		}
+		older.Annotations[wikiv1alpha1.AnnotationSupersededBy] = job.Name
+		if err := r.Patch(ctx, older, patch); err != nil {
+			logger.Error(err, "failed to mark job as superseded", "job", older.Name, "supersededBy", job.Name)
+			continue
+		}
+		logger.Info("superseded older translation job", "job", older.Name, "supersededBy", job.Name)
+	}
+}
+
+// cancelSupersededJob moves a superseded job to Cancelled and removes any
+// runner Job it has already dispatched.
+func (r *TranslationJobReconciler) cancelSupersededJob(ctx context.Context, job *wikiv1alpha1.TranslationJob, supersededBy string) error {
+	logger := log.FromContext(ctx)
+
+	// Runner Jobs are named translation-{TranslationJob.Name} in the job's namespace
+	runner := &batchv1.Job{}
+	runner.Name = fmt.Sprintf("translation-%s", job.Name)
+	runner.Namespace = job.Namespace
+	propagation := metav1.DeletePropagationBackground
+	if err := r.Delete(ctx, runner, &client.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "failed to delete runner job for superseded translation", "job", job.Name)
+	}
+
+	now := nowFrom(r.Clock)
+	updated := job.Status.DeepCopy()
+	updated.State = wikiv1alpha1.TranslationJobStateCancelled
+	updated.Message = fmt.Sprintf("Superseded by newer job %s", supersededBy)
+	updated.FinishedAt = &now
+	meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionFalse,
+		Reason:             "Superseded",
+		Message:            updated.Message,
+		LastTransitionTime: now,
+	})
+	if err := updateTranslationJobStatus(ctx, r.Client, job, updated); err != nil {
+		return err
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Event(job, "Normal", "Superseded", job.Status.Message)
+	}
+	if r.Jobs != nil {
+		r.Jobs.Update(job)
+	}
+	if r.TranslationJobEventCh != nil {
+		select {
+		case r.TranslationJobEventCh <- TranslationJobEvent{
+			Type:      "translation_cancelled",
+			JobName:   job.Name,
+			Namespace: job.Namespace,
+			State:     string(job.Status.State),
+			Message:   job.Status.Message,
+		}:
+		default:
+		}
+	}
+	return nil
+}
+
+// activeDuplicateJob returns an older, non-terminal job translating the same
+// source page into the same language as job, or nil when there is none. Jobs
+// already marked as superseded, publish jobs and diagnostic jobs don't count.
+func (v *JobValidator) activeDuplicateJob(ctx context.Context, job *wikiv1alpha1.TranslationJob) (*wikiv1alpha1.TranslationJob, error) {
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := v.Client.List(ctx, &jobs, client.InNamespace(job.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var oldest *wikiv1alpha1.TranslationJob
+	for i := range jobs.Items {
+		other := &jobs.Items[i]
+		if other.Name == job.Name ||
+			other.Status.State.IsTerminal() ||
+			!sameTranslation(other, job) ||
+			!createdBefore(other, job) ||
+			isDiagnosticJob(other) ||
+			other.Annotations[wikiv1alpha1.AnnotationPublishJob] == "true" {
+			continue
+		}
+		if _, superseded := other.Annotations[wikiv1alpha1.AnnotationSupersededBy]; superseded {
+			continue
+		}
+		if oldest == nil || createdBefore(other, oldest) {
+			oldest = other
+		}
+	}
+	return oldest, nil
+}
+
+// sameTranslation reports whether a and b translate the same source page into
+// the same language.
+func sameTranslation(a, b *wikiv1alpha1.TranslationJob) bool {
+	return a.Spec.Source.TargetRef == b.Spec.Source.TargetRef &&
+		a.Spec.Source.PageID == b.Spec.Source.PageID &&
+		languageTagForJob(a) == languageTagForJob(b)
+}
+
+// createdBefore orders jobs by creation time. Creation timestamps only have
+// second precision, so jobs created together are ordered by name instead.
+func createdBefore(a, b *wikiv1alpha1.TranslationJob) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+const (
+	// TargetThrottledReason is the Ready condition reason of a job waiting in
+	// Queued because its destination WikiTarget is at its
+	// maxConcurrentTranslations.
+	TargetThrottledReason = "TargetThrottled"
+
+	targetRequeueInterval = 15 * time.Second
+)
+
+// targetSlots tracks jobs admitted against a limited WikiTarget whose
+// reconcile hasn't finished. A job translated in the operator only records
+// an active state once it is done, so the job list alone would let
+// concurrent reconciles all start against the same target.
+type targetSlots struct {
+	mu   sync.Mutex
+	held map[types.NamespacedName]struct{}
+}
+
+// targetAdmission is the outcome of admitTargetJob.
+type targetAdmission struct {
+	// Admit is true when the job may start now.
+	Admit bool
+	// Target is the destination WikiTarget and Limit its
+	// maxConcurrentTranslations; 0 means unlimited.
+	Target string
+	Limit  int
+	// Active counts the other jobs already working against the target.
+	Active int
+
+	release func()
+}
+
+// Release frees the slot an admitted job holds. The job's own status
+// counts it from then on, so call it once that status is saved.
+func (a targetAdmission) Release() {
+	if a.release != nil {
+		a.release()
+	}
+}
+
+// waitingMessage explains why a job that wasn't admitted is waiting.
+func (a targetAdmission) waitingMessage() string {
+	return fmt.Sprintf("Waiting for WikiTarget %s capacity (%d of %d translations in progress)", a.Target, a.Active, a.Limit)
+}
+
+// admitTargetJob decides whether job may start work against its destination
+// WikiTarget, holding a slot for it when it may. source is the job's source
+// target, reused when the job publishes back to it.
+func (r *TranslationJobReconciler) admitTargetJob(ctx context.Context, job *wikiv1alpha1.TranslationJob, source *wikiv1alpha1.WikiTarget) (targetAdmission, error) {
+	admission := targetAdmission{Admit: true, Target: job.DestinationTargetRef()}
+
+	dest := source
+	if source == nil || source.Name != admission.Target {
+		dest = &wikiv1alpha1.WikiTarget{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: admission.Target}, dest); err != nil {
+			// A missing destination fails the job later, with a clearer message
+			return admission, client.IgnoreNotFound(err)
+		}
+	}
+	admission.Limit = int(dest.Spec.MaxConcurrentTranslations)
+	if admission.Limit <= 0 {
+		return admission, nil
+	}
+
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := r.List(ctx, &jobs, client.InNamespace(job.Namespace)); err != nil {
+		return admission, fmt.Errorf("list jobs for WikiTarget %s: %w", admission.Target, err)
+	}
+
+	key := client.ObjectKeyFromObject(job)
+	r.targets.mu.Lock()
+	defer r.targets.mu.Unlock()
+	for i := range jobs.Items {
+		other := &jobs.Items[i]
+		if other.Name == job.Name || other.IsDiagnostic() || other.DestinationTargetRef() != admission.Target {
+			continue
+		}
+		_, held := r.targets.held[client.ObjectKeyFromObject(other)]
+		if held || isActiveTranslationState(other.Status.State) {
+			admission.Active++
+		}
+	}
+	admission.Admit = admission.Active < admission.Limit
+	if admission.Admit {
+		if r.targets.held == nil {
+			r.targets.held = map[types.NamespacedName]struct{}{}
+		}
+		r.targets.held[key] = struct{}{}
+		admission.release = func() {
+			r.targets.mu.Lock()
+			defer r.targets.mu.Unlock()
+			delete(r.targets.held, key)
+		}
+	}
+	return admission, nil
+}
+
+// isActiveTranslationState reports whether a job in state is working against
+// its wikis or the translation service.
+func isActiveTranslationState(state wikiv1alpha1.TranslationJobState) bool {
+	switch state {
+	case wikiv1alpha1.TranslationJobStateDispatching, wikiv1alpha1.TranslationJobStateRunning,
+		wikiv1alpha1.TranslationJobStatePublishing:
+		return true
+	}
+	return false
+}
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"strings"
 	"time"
@@ -31,23 +32,55 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
 	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+	"github.com/dasmlab/glooscap-operator/pkg/jobcontent"
+	"github.com/dasmlab/glooscap-operator/pkg/langtag"
+	"github.com/dasmlab/glooscap-operator/pkg/markdown"
 	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
 	"github.com/dasmlab/glooscap-operator/pkg/outline"
 	"github.com/dasmlab/glooscap-operator/pkg/vllm"
 )
 
+// SuspiciousTranslationReason is the Ready condition reason of a job failed
+// because the service reported success but returned empty or truncated
+// markdown.
+const SuspiciousTranslationReason = "SuspiciousTranslation"
+
+// TranslationCircuitOpenReason is the Ready condition reason of a job kept
+// Queued because the translation service's circuit breaker is open.
+const TranslationCircuitOpenReason = "TranslationCircuitOpen"
+
+// minCircuitRequeue is the shortest wait before a job refused by an open
+// circuit breaker is tried again.
+const minCircuitRequeue = 5 * time.Second
+
+const (
+	// translationServiceConditionType is the condition a job waiting for a
+	// translation service carries; its transition time is when the wait began.
+	translationServiceConditionType = "TranslationServiceAvailable"
+	// translationServiceWait is how long a job is kept Queued while no
+	// translation service is connected before it fails.
+	translationServiceWait = 10 * time.Minute
+	// translationServiceRequeueInterval is how often a job waiting for a
+	// translation service checks for one again.
+	translationServiceRequeueInterval = 30 * time.Second
+)
+
 // TranslationJobEvent represents a translation job event for SSE broadcasting
 // This type is also defined in internal/server/http.go - they must match
 type TranslationJobEvent struct {
 	Type      string `json:"type"`                // "processing_translation" or "translation_complete"
 	JobName   string `json:"jobName"`             // TranslationJob name (e.g., "translation-xxxx")
+	Namespace string `json:"namespace,omitempty"` // TranslationJob namespace
 	PageURL   string `json:"pageUrl,omitempty"`   // URL to the translated page (for completion events)
 	PageID    string `json:"pageId,omitempty"`    // Page ID of the translated page
 	PageTitle string `json:"pageTitle,omitempty"` // Title of the translated page
@@ -69,8 +102,28 @@ type TranslationJobReconciler struct {
 	Nanabush      *nanabush.Client // Direct reference (for backward compatibility)
 	// GetNanabushClient is a function that returns the current nanabush client (for runtime updates)
 	GetNanabushClient func() *nanabush.Client
+	// Clock stamps job status transitions. Nil uses the real clock.
+	Clock clock.PassiveClock
+	// Notifier, when set, sends webhook notifications for finished jobs.
+	Notifier *JobNotifier
+	// TranslationCache, when set, serves repeated content/language pairs without
+	// calling the translation service.
+	TranslationCache *nanabush.TranslationCache
+	// TranslationCheck decides when a successful translation is too short to
+	// publish. The zero value uses the defaults.
+	TranslationCheck nanabush.TranslationCheckConfig
 	// TranslationJobEventCh is a channel to send TranslationJob events for SSE broadcasting
 	TranslationJobEventCh chan<- TranslationJobEvent
+	// Diagnostics, when set, throttles and deduplicates diagnostic jobs.
+	Diagnostics *DiagnosticLimits
+	// PauseOnTokenBudget holds queued jobs of a namespace that has spent its
+	// token budget, instead of failing them, until it has budget again.
+	PauseOnTokenBudget bool
+	// APIReader reads stored translations spilled to ConfigMaps, which the
+	// manager's cache doesn't watch. Nil uses Client.
+	APIReader client.Reader
+
+	targets targetSlots
 }
 
 // +kubebuilder:rbac:groups=wiki.glooscap.dasmlab.org,resources=translationjobs,verbs=get;list;watch;create;update;patch;delete
@@ -79,6 +132,7 @@ type TranslationJobReconciler struct {
 // +kubebuilder:rbac:groups=wiki.glooscap.dasmlab.org,resources=wikitargets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=create;get;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -100,10 +154,38 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
-	now := metav1.Now()
+	// A newer job for the same page and language has superseded this one
+	if supersededBy, ok := job.Annotations[wikiv1alpha1.AnnotationSupersededBy]; ok && !job.Status.State.IsTerminal() {
+		logger.Info("translation job superseded, cancelling", "supersededBy", supersededBy)
+		if err := r.cancelSupersededJob(ctx, &job, supersededBy); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Token usage may have been written by a translation runner
+	if r.Jobs != nil {
+		r.Jobs.RecordTokens(&job)
+	}
+
+	// Terminal and approval states may have been written by a runner or by the
+	// previous reconcile; either way notify the job's webhook once per state
+	r.notifyJob(ctx, &job)
+
+	now := nowFrom(r.Clock)
 	updated := job.Status.DeepCopy()
 
+	// Approving a draft creates a publish job, which only publishes what the
+	// original job translated. Ones already handed to a runner are left to it.
+	if job.IsPublishJob() && r.OutlineClient != nil &&
+		!updated.State.IsTerminal() && updated.State != wikiv1alpha1.TranslationJobStateDispatching {
+		return r.reconcilePublishJob(ctx, &job, updated, now)
+	}
+
 	if updated.State == "" {
+		if job.Spec.SupersedeOlderJobs {
+			r.supersedeOlderJobs(ctx, &job)
+		}
 		updated.State = wikiv1alpha1.TranslationJobStateQueued
 		updated.StartedAt = &now
 		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
@@ -118,10 +200,11 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		if r.TranslationJobEventCh != nil {
 			select {
 			case r.TranslationJobEventCh <- TranslationJobEvent{
-				Type:    "processing_translation",
-				JobName: job.Name,
-				State:   string(updated.State),
-				Message: updated.Message,
+				Type:      "processing_translation",
+				JobName:   job.Name,
+				Namespace: job.Namespace,
+				State:     string(updated.State),
+				Message:   updated.Message,
 			}:
 			default:
 				// Channel full, skip (non-blocking)
@@ -144,15 +227,14 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		if !jobStatusChanged(&job.Status, updated) {
 			return ctrl.Result{}, nil
 		}
-		job.Status = *updated
-		if err := r.Status().Update(ctx, &job); err != nil {
+		if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, nil
 	}
 
 	// Check if this is a diagnostic job - diagnostic jobs skip WikiTarget validation
-	isDiagnostic := job.Labels["glooscap.dasmlab.org/diagnostic"] == "true" ||
+	isDiagnostic := job.Labels[wikiv1alpha1.LabelDiagnostic] == "true" ||
 		job.Spec.Parameters["diagnostic"] == "true"
 
 	// Get source target for use in validation and dispatch (skip for diagnostic jobs)
@@ -172,8 +254,7 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				updated.State = wikiv1alpha1.TranslationJobStateFailed
 				updated.Message = "WikiTarget not found"
 				updated.FinishedAt = &now
-				job.Status = *updated
-				if err := r.Status().Update(ctx, &job); err != nil {
+				if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
 					return ctrl.Result{}, err
 				}
 				return ctrl.Result{}, nil
@@ -184,8 +265,7 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		updated.State = wikiv1alpha1.TranslationJobStateFailed
 		updated.Message = "Source TargetRef is required"
 		updated.FinishedAt = &now
-		job.Status = *updated
-		if err := r.Status().Update(ctx, &job); err != nil {
+		if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, nil
@@ -197,127 +277,90 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// Run validation only if we're in Validating state
 	if updated.State == wikiv1alpha1.TranslationJobStateValidating {
 		logger.Info("validating translation job", "job", job.Name)
-		// Perform validation checks
-
-		// Check if page is a template (should not be translated)
-		if r.Catalogue != nil {
-			targetID := fmt.Sprintf("%s/%s", sourceTarget.Namespace, sourceTarget.Name)
-			pages := r.Catalogue.List(targetID)
-			for _, page := range pages {
-				if page.ID == job.Spec.Source.PageID {
-					if page.IsTemplate {
-						logger.Info("validation failed: page is a template", "pageID", job.Spec.Source.PageID, "title", page.Title)
-						meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
-							Type:               "Ready",
-							Status:             metav1.ConditionFalse,
-							Reason:             "TemplateRejected",
-							Message:            "Templates cannot be translated",
-							LastTransitionTime: now,
-						})
-						updated.State = wikiv1alpha1.TranslationJobStateFailed
-						updated.Message = "Page is a template and cannot be translated"
-						updated.FinishedAt = &now
-						job.Status = *updated
-						if err := r.Status().Update(ctx, &job); err != nil {
-							return ctrl.Result{}, err
-						}
-						return ctrl.Result{}, nil
-					}
-					break
-				}
+		// Keep the canonical form, which the runner, the translation service
+		// and the duplicate checks then all see
+		if canonicalizeLanguageTag(&job) {
+			logger.Info("normalized target language", "languageTag", languageTagForJob(&job))
+			if err := r.Update(ctx, &job); err != nil {
+				return ctrl.Result{}, err
 			}
 		}
-
-		// Validate destination (skip for diagnostic jobs)
-		if !isDiagnostic {
-		destTargetRef := job.Spec.Source.TargetRef
-		if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
-			destTargetRef = job.Spec.Destination.TargetRef
-		}
-
-		var destTarget wikiv1alpha1.WikiTarget
-		if err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: destTargetRef}, &destTarget); err != nil {
-			if errors.IsNotFound(err) {
-				// For diagnostic jobs, skip destination WikiTarget validation
-				if isDiagnostic {
-					logger.Info("diagnostic job: skipping destination WikiTarget validation, using dummy target", "targetRef", destTargetRef)
-					// Create a dummy destTarget for diagnostic jobs
-					destTarget = wikiv1alpha1.WikiTarget{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:      destTargetRef,
-							Namespace: job.Namespace,
-						},
-						Spec: wikiv1alpha1.WikiTargetSpec{
-							URI:  "diagnostic://test",
-							Mode: wikiv1alpha1.WikiTargetModeReadWrite,
-						},
-					}
-				} else {
-				meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
-					Type:               "Ready",
-					Status:             metav1.ConditionFalse,
-					Reason:             "DestinationMissing",
-					Message:            "Destination WikiTarget does not exist",
-					LastTransitionTime: now,
-				})
-				updated.State = wikiv1alpha1.TranslationJobStateFailed
-				updated.Message = "Destination WikiTarget not found"
-				updated.FinishedAt = &now
-				job.Status = *updated
-				if err := r.Status().Update(ctx, &job); err != nil {
-					return ctrl.Result{}, err
-				}
-				return ctrl.Result{}, nil
-			}
-			} else {
+		issues, err := r.validator().Validate(ctx, &job)
+		if err != nil {
 			return ctrl.Result{}, err
+		}
+		for _, issue := range issues {
+			if issue.Severity == ValidationWarning {
+				logger.Info("validation warning", "reason", issue.Reason, "message", issue.Message)
 			}
 		}
-
-		// Check if destination allows writes (skip for diagnostic jobs)
-		if !isDiagnostic && destTarget.Spec.Mode == wikiv1alpha1.WikiTargetModeReadOnly {
+		if issue := FirstIssue(issues, ValidationError); issue != nil {
+			logger.Info("validation failed", "reason", issue.Reason, "message", issue.Message)
+			if issue.Reason == BudgetExceededReason {
+				markBudgetExceeded(updated, job.Namespace, budgetScopeNamespace, issue.Message, now)
+			}
 			meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
 				Type:               "Ready",
 				Status:             metav1.ConditionFalse,
-				Reason:             "DestinationReadOnly",
-				Message:            "Destination WikiTarget is read-only",
+				Reason:             issue.Reason,
+				Message:            issue.Message,
 				LastTransitionTime: now,
 			})
 			updated.State = wikiv1alpha1.TranslationJobStateFailed
-			updated.Message = "Destination WikiTarget is read-only and cannot accept translations"
+			updated.Message = issue.Message
 			updated.FinishedAt = &now
-			job.Status = *updated
-			if err := r.Status().Update(ctx, &job); err != nil {
+			if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
 				return ctrl.Result{}, err
 			}
 			return ctrl.Result{}, nil
 		}
+		if issue := FirstIssue(issues, ValidationBlocked); issue != nil {
+			logger.Info("validation blocked", "reason", issue.Reason, "message", issue.Message)
+			meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+				Type:               "Ready",
+				Status:             metav1.ConditionFalse,
+				Reason:             issue.Reason,
+				Message:            issue.Message,
+				LastTransitionTime: now,
+			})
+			updated.State = wikiv1alpha1.TranslationJobStateAwaitingApproval
+			updated.Message = issue.Message
+			if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+
+		destTargetRef := job.Spec.Source.TargetRef
+		if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
+			destTargetRef = job.Spec.Destination.TargetRef
+		}
 
 		// Check for duplicate page at destination (skip for diagnostic jobs)
-		if !isDiagnostic && r.OutlineClient != nil && r.Catalogue != nil {
+		var destTarget wikiv1alpha1.WikiTarget
+		if !isDiagnostic && r.OutlineClient != nil && r.Catalogue != nil &&
+			r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: destTargetRef}, &destTarget) == nil {
 			destClient, err := r.OutlineClient.New(ctx, r.Client, &destTarget)
 			if err == nil {
-					// Use collection constraint from destination WikiTarget if available
-					var destPages []outline.PageSummary
-					if destTarget.Status.CollectionID != "" {
-						destPages, err = destClient.ListPages(ctx, destTarget.Status.CollectionID)
-						logger.V(1).Info("checking duplicates in destination collection", "collectionID", destTarget.Status.CollectionID, "collectionName", destTarget.Status.CollectionName)
-					} else {
-						destPages, err = destClient.ListPages(ctx)
-						logger.V(1).Info("checking duplicates in all destination pages (no collection constraint)")
-					}
-				if err == nil {
-					// Get source page title from catalog
-					sourcePageTitle := ""
-					targetID := fmt.Sprintf("%s/%s", sourceTarget.Namespace, sourceTarget.Name)
-					sourcePages := r.Catalogue.List(targetID)
-					for _, page := range sourcePages {
-						if page.ID == job.Spec.Source.PageID {
-							sourcePageTitle = page.Title
-							break
-						}
+				// Get source page title from catalog
+				sourcePageTitle := ""
+				targetID := fmt.Sprintf("%s/%s", sourceTarget.Namespace, sourceTarget.Name)
+				sourcePages := r.Catalogue.List(targetID)
+				for _, page := range sourcePages {
+					if page.ID == job.Spec.Source.PageID {
+						sourcePageTitle = page.Title
+						break
 					}
+				}
 
+				// Use collection constraint from destination WikiTarget if available
+				if destTarget.Status.CollectionID != "" {
+					logger.V(1).Info("checking duplicates in destination collection", "collectionID", destTarget.Status.CollectionID, "collectionName", destTarget.Status.CollectionName)
+				} else {
+					logger.V(1).Info("checking duplicates in all destination pages (no collection constraint)")
+				}
+				destPages, err := translatedPagesFor(ctx, destClient, destTarget.Status.CollectionID, sourcePageTitle)
+				if err == nil {
 					// Check for existing page with AUTOTRANSLATED prefix
 					// We NEVER overwrite existing pages - if one exists, we'll create a unique one
 					existingTranslatedPage := ""
@@ -346,13 +389,21 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 					}
 				}
 			}
-			}
-		} else {
-			logger.Info("diagnostic job: skipping destination WikiTarget validation", "job", job.Name)
 		}
 
 		// If we reach here, validation passed - transition to Queued
 		logger.Info("validation passed, transitioning to Queued", "job", job.Name)
+		if !isDiagnostic && job.Spec.LinkDepth() > 0 {
+			children, err := r.spawnLinkedJobs(ctx, &job, &sourceTarget)
+			if err != nil {
+				// The page itself still translates; its links can be followed by a new job
+				logger.Error(err, "failed to create jobs for linked pages")
+				if r.Recorder != nil {
+					r.Recorder.Eventf(&job, "Warning", "FollowLinksFailed", "Linked pages are not translated: %v", err)
+				}
+			}
+			updated.ChildJobs = appendChildJobs(updated.ChildJobs, children...)
+		}
 		updated.State = wikiv1alpha1.TranslationJobStateQueued
 		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
 			Type:               "Ready",
@@ -367,7 +418,7 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// Handle approval for duplicates or draft publishing (check if user approved via annotation or publish job)
 	if updated.State == wikiv1alpha1.TranslationJobStateAwaitingApproval {
 		// Check if this is a duplicate approval
-		if approved, ok := job.Annotations["glooscap.dasmlab.org/duplicate-approved"]; ok && approved == "true" {
+		if approved, ok := job.Annotations[wikiv1alpha1.AnnotationDuplicateApproved]; ok && approved == "true" {
 			// User approved, clear duplicate info and proceed
 			updated.DuplicateInfo = nil
 			updated.State = wikiv1alpha1.TranslationJobStateQueued
@@ -378,7 +429,7 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				Message:            "Duplicate overwrite approved by user",
 				LastTransitionTime: now,
 			})
-		} else if publishJobName, ok := job.Annotations["glooscap.dasmlab.org/publish-job"]; ok && publishJobName != "" {
+		} else if publishJobName, ok := job.Annotations[wikiv1alpha1.AnnotationPublishJob]; ok && publishJobName != "" {
 			// Check if publish job has completed successfully
 			var publishJob wikiv1alpha1.TranslationJob
 			if err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: publishJobName}, &publishJob); err == nil {
@@ -387,9 +438,7 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 					updated.State = wikiv1alpha1.TranslationJobStateCompleted
 					updated.FinishedAt = &now
 					updated.Message = "Translation published successfully"
-					if job.Annotations != nil {
-						job.Annotations["glooscap.dasmlab.org/is-draft"] = "false"
-					}
+					wikiv1alpha1.SetDraft(&job, false)
 					meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
 						Type:               "Ready",
 						Status:             metav1.ConditionTrue,
@@ -401,15 +450,16 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 					if r.TranslationJobEventCh != nil {
 						pageURL := ""
 						if job.Annotations != nil {
-							pageURL = job.Annotations["glooscap.dasmlab.org/published-page-url"]
+							pageURL = job.Annotations[wikiv1alpha1.AnnotationPublishedPageURL]
 						}
 						select {
 						case r.TranslationJobEventCh <- TranslationJobEvent{
 							Type:      "translation_complete",
 							JobName:   job.Name,
+							Namespace: job.Namespace,
 							PageURL:   pageURL,
-							PageID:    job.Annotations["glooscap.dasmlab.org/published-page-id"],
-							PageTitle: job.Annotations["glooscap.dasmlab.org/published-page-title"],
+							PageID:    job.Annotations[wikiv1alpha1.AnnotationPublishedPageID],
+							PageTitle: job.Annotations[wikiv1alpha1.AnnotationPublishedPageTitle],
 							State:     string(updated.State),
 							Message:   updated.Message,
 						}:
@@ -434,13 +484,35 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 					return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 				}
 			}
+		} else if cond := meta.FindStatusCondition(updated.Conditions, "Ready"); cond != nil && cond.Reason == duplicateInProgressReason {
+			// Blocked behind another job for the same page and language
+			duplicate, err := r.validator().activeDuplicateJob(ctx, &job)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if duplicate != nil {
+				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+			logger.Info("duplicate translation finished, revalidating", "job", job.Name)
+			updated.State = wikiv1alpha1.TranslationJobStateValidating
+			updated.Message = ""
+			meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+				Type:               "Ready",
+				Status:             metav1.ConditionFalse,
+				Reason:             "Validating",
+				Message:            "Duplicate translation finished, revalidating",
+				LastTransitionTime: now,
+			})
+			if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
 		} else {
 			// Still awaiting approval (draft or duplicate), requeue
 			if !jobStatusChanged(&job.Status, updated) {
 				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 			}
-			job.Status = *updated
-			if err := r.Status().Update(ctx, &job); err != nil {
+			if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
 				return ctrl.Result{}, err
 			}
 			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
@@ -580,38 +652,164 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		currentState = updated.State
 	}
 	
+	// An earlier reconcile translated the page but stopped before publishing
+	// it; publish the stored translation rather than translating again
+	if currentState == wikiv1alpha1.TranslationJobStatePublishing && updated.TranslatedContent != nil {
+		if err := r.resumePublishing(ctx, &job, updated, &sourceTarget, now); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Set when an open circuit breaker refused the translation; the job stays
+	// Queued and is retried once the breaker lets a call through
+	var circuitRetry time.Duration
 	if currentState == wikiv1alpha1.TranslationJobStateQueued {
 		// Check if this is a diagnostic job - diagnostic jobs always use dispatcher (runner)
-		isDiagnostic := job.Labels["glooscap.dasmlab.org/diagnostic"] == "true" ||
+		isDiagnostic := job.Labels[wikiv1alpha1.LabelDiagnostic] == "true" ||
 			job.Spec.Parameters["diagnostic"] == "true"
 
 		// Check if job explicitly requests TektonJob pipeline
 		useDispatcher := job.Spec.Pipeline == wikiv1alpha1.TranslationPipelineModeTektonJob || isDiagnostic
 
 		// Get current nanabush client (supports runtime reconfiguration)
-		var currentNanabush *nanabush.Client
-		if r.GetNanabushClient != nil {
-			currentNanabush = r.GetNanabushClient()
+		currentNanabush := r.currentNanabushClient()
+
+		// Hold the job while no translation service is connected, e.g. while one
+		// is being set up or reconnected; it fails once the wait runs out
+		serviceMissing := currentNanabush == nil && r.translationServiceWired()
+		if serviceMissing {
+			if waitingSince := translationServiceWaitStart(updated, now); now.Sub(waitingSince.Time) < translationServiceWait {
+				updated.Message = translationServiceWaitingMessage
+				logger.V(1).Info("job waiting for a translation service", "job", job.Name, "since", waitingSince)
+				if jobStatusChanged(&job.Status, updated) {
+					if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
+						return ctrl.Result{}, err
+					}
+					if r.Jobs != nil {
+						r.Jobs.Update(&job)
+					}
+				}
+				return ctrl.Result{RequeueAfter: translationServiceRequeueInterval}, nil
+			}
 		} else {
-			currentNanabush = r.Nanabush // Fallback to direct reference
+			meta.RemoveStatusCondition(&updated.Conditions, translationServiceConditionType)
+		}
+
+		// Hold the job while its namespace is over its token budget
+		if r.PauseOnTokenBudget && r.Jobs != nil {
+			if usage := r.Jobs.NamespaceTokens(job.Namespace); usage.Exceeded {
+				message := fmt.Sprintf("Namespace token budget exhausted (%d/%d tokens); dispatch is paused", usage.Used, usage.Budget)
+				markBudgetExceeded(updated, job.Namespace, budgetScopeNamespace, message, now)
+				meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+					Type:               "Ready",
+					Status:             metav1.ConditionFalse,
+					Reason:             BudgetExceededReason,
+					Message:            message,
+					LastTransitionTime: now,
+				})
+				updated.Message = message
+				logger.V(1).Info("job held by namespace token budget", "job", job.Name, "used", usage.Used, "budget", usage.Budget)
+				if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
+					return ctrl.Result{}, err
+				}
+				r.Jobs.Update(&job)
+				return ctrl.Result{RequeueAfter: budgetRequeueInterval}, nil
+			}
+			clearBudgetExceeded(updated, now)
+		}
+
+		// Diagnostic jobs have their own, smaller share of runner capacity
+		if isDiagnostic && r.Diagnostics != nil {
+			admission, err := r.admitDiagnosticJob(ctx, &job)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !admission.Admit {
+				result := ctrl.Result{}
+				if admission.DuplicateOf != "" {
+					message := fmt.Sprintf("Same diagnostic as TranslationJob %s, which has not finished yet", admission.DuplicateOf)
+					meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+						Type:               "Ready",
+						Status:             metav1.ConditionFalse,
+						Reason:             diagnosticDuplicateReason,
+						Message:            message,
+						LastTransitionTime: now,
+					})
+					updated.State = wikiv1alpha1.TranslationJobStateCancelled
+					updated.Message = message
+					updated.FinishedAt = &now
+					logger.Info("diagnostic job folded into an earlier one", "job", job.Name, "duplicateOf", admission.DuplicateOf)
+				} else {
+					message := fmt.Sprintf("Waiting for diagnostic capacity (%d running, %d other diagnostic jobs waiting)", admission.Active, admission.Queued)
+					meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+						Type:               "Ready",
+						Status:             metav1.ConditionFalse,
+						Reason:             DiagnosticThrottledReason,
+						Message:            message,
+						LastTransitionTime: now,
+					})
+					updated.Message = message
+					logger.V(1).Info("diagnostic job throttled", "job", job.Name, "active", admission.Active, "queued", admission.Queued)
+					result.RequeueAfter = diagnosticRequeueInterval
+				}
+				if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
+					return ctrl.Result{}, err
+				}
+				if r.Jobs != nil {
+					r.Jobs.Update(&job)
+				}
+				return result, nil
+			}
+		}
+
+		// Respect the destination wiki's maxConcurrentTranslations
+		if !isDiagnostic {
+			admission, err := r.admitTargetJob(ctx, &job, &sourceTarget)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !admission.Admit {
+				message := admission.waitingMessage()
+				meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+					Type:               "Ready",
+					Status:             metav1.ConditionFalse,
+					Reason:             TargetThrottledReason,
+					Message:            message,
+					LastTransitionTime: now,
+				})
+				updated.Message = message
+				logger.V(1).Info("job throttled by destination target", "job", job.Name, "target", admission.Target, "active", admission.Active, "limit", admission.Limit)
+				if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
+					return ctrl.Result{}, err
+				}
+				if r.Jobs != nil {
+					r.Jobs.Update(&job)
+				}
+				return ctrl.Result{RequeueAfter: targetRequeueInterval}, nil
+			}
+			defer admission.Release()
 		}
 
 		// Use dispatcher if requested, otherwise use gRPC to Nanabush if available
-		if useDispatcher && r.Dispatcher != nil {
+		if useDispatcher && r.Dispatcher != nil && !serviceMissing {
 			logger.Info("dispatching translation job to runner", "job", job.Name, "mode", job.Spec.Pipeline)
 			// Use dispatcher (runner) for TektonJob pipeline or diagnostic jobs
 			mode := vllm.ModeFromString(string(job.Spec.Pipeline))
 			if mode == "" {
 				mode = vllm.ModeTektonJob
 			}
-			dispatchErr := r.Dispatcher.Dispatch(ctx, vllm.Request{
-				JobName:      job.Name,
-				Namespace:    job.Namespace,
-				PageID:       job.Spec.Source.PageID,
-				LanguageTag:  languageTagForJob(&job),
-				SourceTarget: job.Spec.Source.TargetRef,
-				Mode:         mode,
-			})
+			tokens, dispatchErr := r.runnerTokens(ctx, &job)
+			if dispatchErr == nil {
+				dispatchErr = r.Dispatcher.Dispatch(ctx, vllm.Request{
+					JobName:      job.Name,
+					Namespace:    job.Namespace,
+					PageID:       job.Spec.Source.PageID,
+					LanguageTag:  languageTagForJob(&job),
+					SourceTarget: job.Spec.Source.TargetRef,
+					Mode:         mode,
+					Tokens:       tokens,
+				})
+			}
 			if dispatchErr != nil {
 				logger.Error(dispatchErr, "failed to dispatch translation job", "job", job.Name)
 				meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
@@ -664,7 +862,7 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				checkResp, err := currentNanabush.CheckTitle(ctx, nanabush.CheckTitleRequest{
 					Title:          sourcePage.Title,
 					LanguageTag:    languageTagForJob(&job),
-					SourceLanguage: sourcePage.Language,
+					SourceLanguage: sourceTarget.Spec.SourceLanguage(sourcePage.DetectedLanguage),
 				})
 				if err != nil {
 					logger.Error(err, "title check failed", "title", sourcePage.Title)
@@ -697,8 +895,34 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 					var pageContent *outline.PageContent
 					var templateContent *outline.PageContent
 					if sourceClient != nil {
-						content, err := sourceClient.GetPageContent(ctx, job.Spec.Source.PageID)
-						if err != nil {
+						revisionID := job.Spec.Source.PinnedRevision()
+						content, err := sourceClient.GetPageRevisionContent(ctx, job.Spec.Source.PageID, revisionID)
+						if stderrors.Is(err, outline.ErrRevisionNotFound) {
+							// A pinned revision must not silently turn into the current content
+							message := fmt.Sprintf("Pinned source revision %s no longer exists: %v", revisionID, err)
+							meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+								Type:               "Ready",
+								Status:             metav1.ConditionFalse,
+								Reason:             "RevisionNotFound",
+								Message:            message,
+								LastTransitionTime: now,
+							})
+							updated.State = wikiv1alpha1.TranslationJobStateFailed
+							updated.Message = message
+							updated.FinishedAt = &now
+						} else if stderrors.Is(err, outline.ErrEmptyContent) {
+							// Translating nothing would publish a blank "successful" translation
+							meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+								Type:               "Ready",
+								Status:             metav1.ConditionFalse,
+								Reason:             "SourcePageEmpty",
+								Message:            "Source page has no content to translate",
+								LastTransitionTime: now,
+							})
+							updated.State = wikiv1alpha1.TranslationJobStateFailed
+							updated.Message = "Source page has no content to translate"
+							updated.FinishedAt = &now
+						} else if err != nil {
 							logger.Error(err, "failed to fetch page content")
 							meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
 								Type:               "Ready",
@@ -724,6 +948,9 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 					}
 
 					if pageContent != nil {
+						// Keep links, mentions, embeds and code away from the model
+						protected := markdown.Protect(pageContent.Markdown)
+
 						// Build gRPC request
 						grpcReq := nanabush.TranslateRequest{
 							JobID:     job.Name,
@@ -731,18 +958,19 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 							Primitive: "doc-translate",
 							Document: &nanabush.DocumentContent{
 								Title:    pageContent.Title,
-								Markdown: pageContent.Markdown,
+								Markdown: protected.Text,
 								Slug:     pageContent.Slug,
 								Metadata: map[string]string{
 									"collection": sourcePage.Collection,
 									"template":   sourcePage.Template,
 								},
 							},
-							SourceLanguage: sourcePage.Language,
+							SourceLanguage: sourceTarget.Spec.SourceLanguage(sourcePage.DetectedLanguage),
 							TargetLanguage: languageTagForJob(&job),
 							SourceWikiURI:  sourceTarget.Spec.URI,
 							PageID:         job.Spec.Source.PageID,
 							PageSlug:       sourcePage.Slug,
+							Context:        job.Spec.Context,
 						}
 
 						if templateContent != nil {
@@ -768,8 +996,32 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 						// Use a longer timeout for translation (5 minutes) to handle large documents
 						translateCtx, translateCancel := context.WithTimeout(ctx, 5*time.Minute)
 						defer translateCancel()
-						translateResp, err := currentNanabush.Translate(translateCtx, grpcReq)
-						if err != nil {
+						translateResp, cacheHit, err := r.TranslationCache.Translate(translateCtx, currentNanabush, grpcReq)
+						if cacheHit {
+							logger.Info("translation served from cache", "language", grpcReq.TargetLanguage)
+						}
+						if translateResp != nil {
+							updated.TokensUsed = int64(translateResp.TokensUsed)
+							updated.InferenceTimeMilliseconds = translateResp.InferenceTime().Milliseconds()
+							if !translateResp.CompletedAt.IsZero() {
+								completedAt := metav1.NewTime(translateResp.CompletedAt)
+								updated.TranslationCompletedAt = &completedAt
+							}
+							updated.Engine = translationEngine(translateResp)
+						}
+						if stderrors.Is(err, nanabush.ErrCircuitOpen) {
+							circuitRetry = circuitRetryAfter(currentNanabush)
+							logger.Info("translation service circuit open, keeping job queued", "retryAfter", circuitRetry)
+							meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+								Type:               "Ready",
+								Status:             metav1.ConditionFalse,
+								Reason:             TranslationCircuitOpenReason,
+								Message:            fmt.Sprintf("Translation service is failing, retrying in %s", circuitRetry),
+								LastTransitionTime: now,
+							})
+							updated.State = wikiv1alpha1.TranslationJobStateQueued
+							updated.Message = fmt.Sprintf("Waiting for the translation service to recover (retrying in %s)", circuitRetry)
+						} else if err != nil {
 							logger.Error(err, "translation failed")
 							meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
 								Type:               "Ready",
@@ -792,6 +1044,33 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 							updated.State = wikiv1alpha1.TranslationJobStateFailed
 							updated.Message = translateResp.ErrorMessage
 							updated.FinishedAt = &now
+						} else if job.Spec.MaxTokens > 0 && updated.TokensUsed > job.Spec.MaxTokens {
+							// The tokens are spent either way; don't publish output that broke the cap
+							message := fmt.Sprintf("Translation used %d tokens, over the job's limit of %d", updated.TokensUsed, job.Spec.MaxTokens)
+							markBudgetExceeded(updated, job.Namespace, budgetScopeJob, message, now)
+							meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+								Type:               "Ready",
+								Status:             metav1.ConditionFalse,
+								Reason:             BudgetExceededReason,
+								Message:            message,
+								LastTransitionTime: now,
+							})
+							updated.State = wikiv1alpha1.TranslationJobStateFailed
+							updated.Message = fmt.Sprintf("Token limit exceeded (%d/%d tokens)", updated.TokensUsed, job.Spec.MaxTokens)
+							updated.FinishedAt = &now
+						} else if err := nanabush.CheckTranslation(protected.Text, translateResp.TranslatedMarkdown, r.TranslationCheck); err != nil {
+							// Success with nothing worth publishing; keep the destination as it is
+							logger.Info("translation rejected", "reason", err.Error())
+							meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+								Type:               "Ready",
+								Status:             metav1.ConditionFalse,
+								Reason:             SuspiciousTranslationReason,
+								Message:            err.Error(),
+								LastTransitionTime: now,
+							})
+							updated.State = wikiv1alpha1.TranslationJobStateFailed
+							updated.Message = fmt.Sprintf("Suspicious translation, not published: %v", err)
+							updated.FinishedAt = &now
 						} else {
 							// Translation succeeded - update status
 							updated.State = wikiv1alpha1.TranslationJobStatePublishing
@@ -805,210 +1084,60 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 							updated.Message = fmt.Sprintf("Translation completed (tokens: %d, time: %.2fs)", translateResp.TokensUsed, translateResp.InferenceTimeSeconds)
 							logger.Info("translation completed", "tokens", translateResp.TokensUsed, "time", translateResp.InferenceTimeSeconds)
 
+							restored, missing := protected.Restore(translateResp.TranslatedMarkdown)
+							translateResp.TranslatedMarkdown = restored
+							if len(missing) > 0 {
+								logger.Info("translation dropped protected markdown", "protected", protected.Len(), "missing", len(missing))
+							}
+
+							// Keep the output for preview and republishing; losing it doesn't fail the job
+							if content, err := jobcontent.Store(ctx, r.Client, &job, translateResp.TranslatedTitle, translateResp.TranslatedMarkdown); err != nil {
+								logger.Error(err, "failed to store translated content")
+							} else {
+								updated.TranslatedContent = content
+								// Save it, with the tokens it cost, before publishing: a reconcile
+								// interrupted while publishing then publishes it instead of
+								// translating again
+								if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
+									logger.Error(err, "failed to save the translation before publishing")
+								}
+							}
+
 							// Publish translated content to destination wiki
 							// SAFETY CHECKS:
 							// 1. NEVER overwrite existing pages - create unique pages if needed
 							// 2. Always prefix with "AUTOTRANSLATED--> <SOURCE TITLE>"
 							// 3. Create at same level as source (same collection/parent)
 							// 4. NEVER modify source pages
-
-							// Get destination target (re-fetch to ensure we have it)
-							destTargetRef := job.Spec.Source.TargetRef
-							if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
-								destTargetRef = job.Spec.Destination.TargetRef
-							}
-							var destTarget wikiv1alpha1.WikiTarget
-							if err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: destTargetRef}, &destTarget); err != nil {
-								logger.Error(err, "failed to get destination target")
-								meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
-									Type:               "Ready",
-									Status:             metav1.ConditionFalse,
-									Reason:             "PublishFailed",
-									Message:            fmt.Sprintf("Failed to get destination target: %v", err),
-									LastTransitionTime: now,
-								})
-								updated.State = wikiv1alpha1.TranslationJobStateFailed
-								updated.Message = fmt.Sprintf("Failed to get destination target: %v", err)
-								updated.FinishedAt = &now
-							} else {
-								// Get destination client
-								destClient, err := r.OutlineClient.New(ctx, r.Client, &destTarget)
-								if err != nil {
-									logger.Error(err, "failed to create destination client")
-									meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
-										Type:               "Ready",
-										Status:             metav1.ConditionFalse,
-										Reason:             "PublishFailed",
-										Message:            fmt.Sprintf("Failed to create destination client: %v", err),
-										LastTransitionTime: now,
-									})
-									updated.State = wikiv1alpha1.TranslationJobStateFailed
-									updated.Message = fmt.Sprintf("Failed to create destination client: %v", err)
-									updated.FinishedAt = &now
-								} else {
-									// Get source page info to determine collection/parent
-									var sourceCollectionID string
-									sourcePageTitle := ""
-									if sourcePage != nil {
-										sourcePageTitle = sourcePage.Title
-										// Use collection ID from source target status if available
-										// The sourcePage.Collection is the collection name, we need the ID
-										if sourceTarget.Status.CollectionID != "" && sourceTarget.Status.CollectionName != "" {
-											// If the source page's collection name matches the cached collection name, use the cached ID
-											if sourcePage.Collection == sourceTarget.Status.CollectionName {
-												sourceCollectionID = sourceTarget.Status.CollectionID
-												logger.V(1).Info("using cached collection ID for source page", "collectionID", sourceCollectionID, "collectionName", sourceTarget.Status.CollectionName)
-											}
-										}
-										// If we still don't have a collection ID, try to find it from Outline
-										// This should be rare - only if the page is in a different collection than the cached one
-										if sourceCollectionID == "" && sourceClient != nil {
-											// Use collection constraint from source WikiTarget if available to limit search
-											var sourcePages []outline.PageSummary
-											if sourceTarget.Status.CollectionID != "" {
-												sourcePages, err = sourceClient.ListPages(ctx, sourceTarget.Status.CollectionID)
-											} else {
-												sourcePages, err = sourceClient.ListPages(ctx)
-											}
-											if err == nil {
-												for _, sp := range sourcePages {
-													if sp.ID == job.Spec.Source.PageID {
-														// The page's Collection field is the name, not the ID
-														// We'd need to look up the ID, but for now, if it matches our cached collection, use that
-														if sp.Collection == sourceTarget.Status.CollectionName && sourceTarget.Status.CollectionID != "" {
-															sourceCollectionID = sourceTarget.Status.CollectionID
-														}
-														break
-													}
-												}
-											}
-										}
-									}
-
-									// Build page title with AUTOTRANSLATED prefix
-									baseTitle := sourcePageTitle
-									if baseTitle == "" {
-										baseTitle = "Untitled Page"
-									}
-									translatedTitle := fmt.Sprintf("AUTOTRANSLATED--> %s", baseTitle)
-
-									// Check if a page with this exact title already exists
-									// Use collection constraint from destination WikiTarget if available
-									var destPages []outline.PageSummary
-									if destTarget.Status.CollectionID != "" {
-										destPages, err = destClient.ListPages(ctx, destTarget.Status.CollectionID)
-										logger.V(1).Info("checking title uniqueness in destination collection", "collectionID", destTarget.Status.CollectionID, "collectionName", destTarget.Status.CollectionName)
-									} else {
-										destPages, err = destClient.ListPages(ctx)
-										logger.V(1).Info("checking title uniqueness in all destination pages (no collection constraint)")
-									}
-									uniqueTitle := translatedTitle
-									counter := 1
-									if err == nil {
-										for {
-											titleExists := false
-											for _, dp := range destPages {
-												if dp.Title == uniqueTitle {
-													titleExists = true
-													break
-												}
-											}
-											if !titleExists {
-												break
-											}
-											// Title exists - make it unique
-											uniqueTitle = fmt.Sprintf("AUTOTRANSLATED--> %s (%d)", baseTitle, counter)
-											counter++
-											if counter > 100 {
-												// Safety limit
-												logger.Error(nil, "unable to generate unique title after 100 attempts")
-												break
-											}
-										}
-									}
-
-									if uniqueTitle != translatedTitle {
-										logger.Info("using unique title to avoid overwrite",
-											"original", translatedTitle,
-											"unique", uniqueTitle)
-									}
-
-									// Create the page - NEVER overwrite, always create new
-									createReq := outline.CreatePageRequest{
-										Title:        uniqueTitle,
-										Text:         translateResp.TranslatedMarkdown,
-										CollectionID: sourceCollectionID, // Same collection as source
-									}
-
-									createResp, err := destClient.CreatePage(ctx, createReq)
-									if err != nil {
-										logger.Error(err, "failed to create translated page",
-											"title", uniqueTitle)
-										meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
-											Type:               "Ready",
-											Status:             metav1.ConditionFalse,
-											Reason:             "PublishFailed",
-											Message:            fmt.Sprintf("Failed to create page: %v", err),
-											LastTransitionTime: now,
-										})
-										updated.State = wikiv1alpha1.TranslationJobStateFailed
-										updated.Message = fmt.Sprintf("Failed to create translated page: %v", err)
-										updated.FinishedAt = &now
-									} else {
-										logger.Info("translated page created successfully",
-											"page_id", createResp.Data.ID,
-											"title", uniqueTitle,
-											"slug", createResp.Data.Slug)
-										updated.State = wikiv1alpha1.TranslationJobStateCompleted
-										updated.FinishedAt = &now
-										updated.Message = fmt.Sprintf("Translation completed and published (page: %s)", createResp.Data.Slug)
-										meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
-											Type:               "Ready",
-											Status:             metav1.ConditionTrue,
-											Reason:             "Completed",
-											Message:            fmt.Sprintf("Translation published as: %s", uniqueTitle),
-											LastTransitionTime: now,
-										})
-
-										// Build page URL from destination target
-										pageURL := ""
-										if destTarget.Spec.URI != "" {
-											// Construct URL: baseURI/doc/slug
-											pageURL = fmt.Sprintf("%s/doc/%s", strings.TrimSuffix(destTarget.Spec.URI, "/"), createResp.Data.Slug)
-										}
-
-										// Send translation_complete SSE event
-										if r.TranslationJobEventCh != nil {
-											select {
-											case r.TranslationJobEventCh <- TranslationJobEvent{
-												Type:      "translation_complete",
-												JobName:   job.Name,
-												PageURL:   pageURL,
-												PageID:    createResp.Data.ID,
-												PageTitle: uniqueTitle,
-												State:     string(updated.State),
-												Message:   updated.Message,
-											}:
-											default:
-												// Channel full, skip (non-blocking)
-											}
-										}
-									}
-								}
-							}
+							r.publishTranslation(ctx, &job, updated, &sourceTarget, sourcePage, sourceClient, translateResp.TranslatedMarkdown, now)
 						}
 					}
 				}
 			}
+		} else {
+			// No translation service turned up while the job waited
+			meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+				Type:               "Ready",
+				Status:             metav1.ConditionFalse,
+				Reason:             translationServiceUnavailableReason,
+				Message:            translationServiceUnavailableMessage,
+				LastTransitionTime: now,
+			})
+			updated.State = wikiv1alpha1.TranslationJobStateFailed
+			updated.Message = translationServiceUnavailableMessage
+			updated.FinishedAt = &now
 		}
 	}
 
+	if updated.State == wikiv1alpha1.TranslationJobStateCompleted || updated.State == wikiv1alpha1.TranslationJobStateFailed {
+		recordLanguageResult(&job, updated)
+	}
+
 	if !jobStatusChanged(&job.Status, updated) {
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: circuitRetry}, nil
 	}
 
-	job.Status = *updated
-	if err := r.Status().Update(ctx, &job); err != nil {
+	if err := updateTranslationJobStatus(ctx, r.Client, &job, updated); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -1017,11 +1146,12 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	if r.Jobs != nil {
 		r.Jobs.Update(&job)
+		r.Jobs.RecordTokens(&job)
 	}
 
 	// Do NOT requeue failed jobs - they will just create more pods and fail again
 	// Only requeue dispatching jobs to check Kubernetes Job status
-	requeue := ctrl.Result{}
+	requeue := ctrl.Result{RequeueAfter: circuitRetry}
 	if updated.State == wikiv1alpha1.TranslationJobStateDispatching {
 		// Dispatching jobs should be requeued to check Kubernetes Job status
 		logger.V(1).Info("job dispatching, requeuing to check status", "job", job.Name)
@@ -1031,8 +1161,8 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		// Return empty result to stop reconciliation
 		logger.Info("job failed, not requeuing to prevent pod accumulation", "state", job.Status.State, "message", job.Status.Message)
 		return ctrl.Result{}, nil
-	} else if updated.State == wikiv1alpha1.TranslationJobStateCompleted {
-		// Completed jobs should NOT be requeued
+	} else if updated.State == wikiv1alpha1.TranslationJobStateCompleted || updated.State == wikiv1alpha1.TranslationJobStateCancelled {
+		// Completed and cancelled jobs should NOT be requeued
 		logger.Info("job completed, not requeuing", "job", job.Name)
 		return ctrl.Result{}, nil
 	}
@@ -1040,6 +1170,447 @@ func (r *TranslationJobReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	return requeue, nil
 }
 
+// circuitRetryAfter is how long a job refused by an open circuit breaker
+// waits before trying again: the breaker's remaining cooldown, but at least
+// minCircuitRequeue.
+func circuitRetryAfter(c *nanabush.Client) time.Duration {
+	remaining := time.Duration(c.Circuit().CooldownRemainingSeconds * float64(time.Second))
+	return max(remaining, minCircuitRequeue).Round(time.Second)
+}
+
+// publishTranslation publishes translatedMarkdown, the translation of
+// sourcePage, to the job's destination and records the outcome in updated.
+// sourceClient, when set, is used to find the source page's collection.
+func (r *TranslationJobReconciler) publishTranslation(ctx context.Context, job *wikiv1alpha1.TranslationJob, updated *wikiv1alpha1.TranslationJobStatus,
+	sourceTarget *wikiv1alpha1.WikiTarget, sourcePage *catalog.Page, sourceClient *outline.Client, translatedMarkdown string, now metav1.Time) {
+	logger := log.FromContext(ctx)
+
+	// Get destination target (re-fetch to ensure we have it)
+	destTargetRef := job.Spec.Source.TargetRef
+	if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
+		destTargetRef = job.Spec.Destination.TargetRef
+	}
+	var destTarget wikiv1alpha1.WikiTarget
+	if err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: destTargetRef}, &destTarget); err != nil {
+		logger.Error(err, "failed to get destination target")
+		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			Reason:             "PublishFailed",
+			Message:            fmt.Sprintf("Failed to get destination target: %v", err),
+			LastTransitionTime: now,
+		})
+		updated.State = wikiv1alpha1.TranslationJobStateFailed
+		updated.Message = fmt.Sprintf("Failed to get destination target: %v", err)
+		updated.FinishedAt = &now
+	} else {
+		// Get destination client
+		destClient, err := r.OutlineClient.New(ctx, r.Client, &destTarget)
+		if err != nil {
+			logger.Error(err, "failed to create destination client")
+			meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+				Type:               "Ready",
+				Status:             metav1.ConditionFalse,
+				Reason:             "PublishFailed",
+				Message:            fmt.Sprintf("Failed to create destination client: %v", err),
+				LastTransitionTime: now,
+			})
+			updated.State = wikiv1alpha1.TranslationJobStateFailed
+			updated.Message = fmt.Sprintf("Failed to create destination client: %v", err)
+			updated.FinishedAt = &now
+		} else {
+			sourceCollectionID := sourceCollectionID(ctx, job, sourceTarget, sourcePage, sourceClient)
+			sourcePageTitle := ""
+			if sourcePage != nil {
+				sourcePageTitle = sourcePage.Title
+			}
+
+			// Build page title with AUTOTRANSLATED prefix
+			baseTitle := sourcePageTitle
+			if baseTitle == "" {
+				baseTitle = "Untitled Page"
+			}
+			translatedTitle := fmt.Sprintf("AUTOTRANSLATED--> %s", baseTitle)
+
+			// Check if a page with this exact title already exists
+			// Use collection constraint from destination WikiTarget if available
+			if destTarget.Status.CollectionID != "" {
+				logger.V(1).Info("checking title uniqueness in destination collection", "collectionID", destTarget.Status.CollectionID, "collectionName", destTarget.Status.CollectionName)
+			} else {
+				logger.V(1).Info("checking title uniqueness in all destination pages (no collection constraint)")
+			}
+			var destPages []outline.PageSummary
+			destPages, err = translatedPagesFor(ctx, destClient, destTarget.Status.CollectionID, baseTitle)
+			uniqueTitle := translatedTitle
+			counter := 1
+			if err == nil {
+				for {
+					titleExists := false
+					for _, dp := range destPages {
+						if dp.Title == uniqueTitle {
+							titleExists = true
+							break
+						}
+					}
+					if !titleExists {
+						break
+					}
+					// Title exists - make it unique
+					uniqueTitle = fmt.Sprintf("AUTOTRANSLATED--> %s (%d)", baseTitle, counter)
+					counter++
+					if counter > 100 {
+						// Safety limit
+						logger.Error(nil, "unable to generate unique title after 100 attempts")
+						break
+					}
+				}
+			}
+
+			if uniqueTitle != translatedTitle {
+				logger.Info("using unique title to avoid overwrite",
+					"original", translatedTitle,
+					"unique", uniqueTitle)
+			}
+
+			pageText := r.withTranslationFooter(ctx, job, &destTarget, sourceTarget, sourcePage, updated.Engine, baseTitle, translatedMarkdown, now)
+
+			// Create the page, unless the job's publish strategy rewrites an earlier translation
+			createReq := outline.CreatePageRequest{
+				Title:        uniqueTitle,
+				Text:         pageText,
+				CollectionID: sourceCollectionID, // Same collection as source
+			}
+
+			createResp, pageUpdated, err := r.publishTranslatedPage(ctx, destClient, job, translatedTitle, createReq)
+			if err != nil {
+				logger.Error(err, "failed to publish translated page",
+					"title", uniqueTitle, "strategy", job.Spec.PublishStrategy)
+				meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+					Type:               "Ready",
+					Status:             metav1.ConditionFalse,
+					Reason:             "PublishFailed",
+					Message:            fmt.Sprintf("Failed to publish page: %v", err),
+					LastTransitionTime: now,
+				})
+				updated.State = wikiv1alpha1.TranslationJobStateFailed
+				updated.Message = fmt.Sprintf("Failed to publish translated page: %v", err)
+				updated.FinishedAt = &now
+			} else {
+				logger.Info("translated page published successfully",
+					"page_id", createResp.Data.ID,
+					"title", createResp.Data.Title,
+					"slug", createResp.Data.Slug,
+					"updated", pageUpdated)
+				updated.State = wikiv1alpha1.TranslationJobStateCompleted
+				updated.FinishedAt = &now
+				updated.Message = fmt.Sprintf("Translation completed and published (page: %s)", createResp.Data.Slug)
+				readyMessage := fmt.Sprintf("Translation published as: %s", createResp.Data.Title)
+				if pageUpdated {
+					updated.Message = fmt.Sprintf("Translation completed and updated in place (page: %s)", createResp.Data.Slug)
+					readyMessage = fmt.Sprintf("Translation updated in place: %s", createResp.Data.Title)
+				}
+				meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+					Type:               "Ready",
+					Status:             metav1.ConditionTrue,
+					Reason:             "Completed",
+					Message:            readyMessage,
+					LastTransitionTime: now,
+				})
+
+				// Build page URL from destination target
+				pageURL := outline.DocumentURL(destTarget.Spec.URI, createResp.Data.Title, createResp.Data.Slug)
+
+				// Record provenance, so later jobs can find and update this page
+				provenance := client.MergeFrom(job.DeepCopy())
+				wikiv1alpha1.SetPublishedPage(job, wikiv1alpha1.PublishedPage{
+					ID:    createResp.Data.ID,
+					Slug:  createResp.Data.Slug,
+					URL:   pageURL,
+					Title: createResp.Data.Title,
+				})
+				if updated.Engine != nil {
+					wikiv1alpha1.SetTranslationEngine(job, *updated.Engine)
+				}
+				if err := r.Patch(ctx, job, provenance); err != nil {
+					logger.Error(err, "failed to record the published page on the job")
+				}
+				updated.SetLanguageResult(wikiv1alpha1.LanguageResult{
+					Lang:    languageTagForJob(job),
+					State:   wikiv1alpha1.TranslationJobStateCompleted,
+					PageURL: pageURL,
+				})
+
+				// Send translation_complete SSE event
+				if r.TranslationJobEventCh != nil {
+					select {
+					case r.TranslationJobEventCh <- TranslationJobEvent{
+						Type:      "translation_complete",
+						JobName:   job.Name,
+						Namespace: job.Namespace,
+						PageURL:   pageURL,
+						PageID:    createResp.Data.ID,
+						PageTitle: createResp.Data.Title,
+						State:     string(updated.State),
+						Message:   updated.Message,
+					}:
+					default:
+						// Channel full, skip (non-blocking)
+					}
+				}
+			}
+		}
+	}
+}
+
+// publishTranslatedPage publishes the translation as the job's PublishStrategy
+// says. Updates rewrite the page an earlier job published for the same source
+// page, destination and language, titled title; creates use req, nested under
+// the parent document the job names, if any. updated reports which happened.
+func (r *TranslationJobReconciler) publishTranslatedPage(ctx context.Context, destClient *outline.Client, job *wikiv1alpha1.TranslationJob,
+	title string, req outline.CreatePageRequest) (resp *outline.CreatePageResponse, updated bool, err error) {
+	strategy := job.Spec.PublishStrategy
+	if strategy == wikiv1alpha1.PublishStrategyUpdate || strategy == wikiv1alpha1.PublishStrategyCreateOrUpdate {
+		pageID, previous, err := r.earlierTranslatedPage(ctx, destClient, job, title, req.CollectionID)
+		if err != nil {
+			return nil, false, err
+		}
+		if pageID == "" && strategy == wikiv1alpha1.PublishStrategyUpdate {
+			return nil, false, fmt.Errorf("no earlier translation of this page to update")
+		}
+		if pageID != "" {
+			updateResp, err := destClient.UpdatePage(ctx, outline.UpdatePageRequest{ID: pageID, Title: title, Text: req.Text})
+			if err == nil {
+				log.FromContext(ctx).Info("updated earlier translation in place", "page_id", pageID, "previousJob", previous)
+				return &outline.CreatePageResponse{Data: updateResp.Data}, true, nil
+			}
+			if !outline.IsNotFound(err) || strategy == wikiv1alpha1.PublishStrategyUpdate {
+				if previous == "" {
+					return nil, false, fmt.Errorf("update earlier translation %s: %w", pageID, err)
+				}
+				return nil, false, fmt.Errorf("update page %s published by TranslationJob %s: %w", pageID, previous, err)
+			}
+			log.FromContext(ctx).Info("earlier translation was deleted, creating a new page", "page_id", pageID, "previousJob", previous)
+		}
+	}
+
+	resp, err = createTranslatedPage(ctx, destClient, job, req)
+	return resp, false, err
+}
+
+// earlierTranslatedPage returns the ID of the page an earlier translation of
+// job's source page published to the same destination and language: the one
+// its TranslationJob recorded, named by previous, or, once those jobs have
+// been deleted, the one destination page titled title (or retitled from it),
+// with previous empty.
+// pageID is empty when there is no such page.
+func (r *TranslationJobReconciler) earlierTranslatedPage(ctx context.Context, destClient *outline.Client, job *wikiv1alpha1.TranslationJob,
+	title, collectionID string) (pageID, previous string, err error) {
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := r.List(ctx, &jobs, client.InNamespace(job.Namespace)); err != nil {
+		return "", "", fmt.Errorf("list jobs to find the earlier translation: %w", err)
+	}
+	if earlier := job.PreviousTranslation(jobs.Items); earlier != nil {
+		return wikiv1alpha1.GetPublishedPage(earlier).ID, earlier.Name, nil
+	}
+
+	pages, err := translatedPagesFor(ctx, destClient, collectionID, strings.TrimPrefix(title, "AUTOTRANSLATED--> "))
+	if err != nil {
+		return "", "", fmt.Errorf("look for the earlier translation in the destination: %w", err)
+	}
+	var matches []string
+	for _, page := range pages {
+		// A retitle keeps the source title and appends its translation
+		if page.Title == title || strings.HasPrefix(page.Title, title+" --> ") {
+			matches = append(matches, page.ID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", "", nil
+	case 1:
+		log.FromContext(ctx).Info("found the earlier translation by its title", "page_id", matches[0], "title", title)
+		return matches[0], "", nil
+	}
+	return "", "", fmt.Errorf("%d destination pages are titled %q; can't tell which earlier translation to update", len(matches), title)
+}
+
+// createTranslatedPage creates req, nested under the parent document the job
+// names, if any.
+func createTranslatedPage(ctx context.Context, destClient *outline.Client, job *wikiv1alpha1.TranslationJob,
+	req outline.CreatePageRequest) (*outline.CreatePageResponse, error) {
+	if job.Spec.Destination != nil && job.Spec.Destination.ParentDocument != "" {
+		parentRef := job.Spec.Destination.ParentDocument
+		parent, err := destClient.ResolveDocument(ctx, parentRef, req.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve parent document: %w", err)
+		}
+		log.FromContext(ctx).Info("publishing under parent document", "parent", parentRef, "parentID", parent.ID)
+		req.ParentDocumentID = parent.ID
+	}
+	return destClient.CreatePage(ctx, req)
+}
+
+// sourceCollectionID returns the ID of the collection sourcePage is in, so its
+// translation can be created next to it, or "" when that isn't known. Only
+// sourceTarget's cached collection is recognized; sourceClient, when set, is
+// used to look the page up when the catalogue's collection doesn't match it.
+// Publishing and validation both use it, so they check the collection the
+// page is written to.
+func sourceCollectionID(ctx context.Context, job *wikiv1alpha1.TranslationJob,
+	sourceTarget *wikiv1alpha1.WikiTarget, sourcePage *catalog.Page, sourceClient *outline.Client) string {
+	if sourcePage == nil {
+		return ""
+	}
+	if id := sourceTarget.Status.CollectionIDFor(sourcePage.Collection); id != "" {
+		log.FromContext(ctx).V(1).Info("using cached collection ID for source page", "collectionID", id, "collectionName", sourceTarget.Status.CollectionName)
+		return id
+	}
+	// The catalogue may be stale; look the page up again. Only the cached
+	// collection can be recognized, so without one there is nothing to find
+	if sourceClient == nil || sourceTarget.Status.CollectionID == "" {
+		return ""
+	}
+	sourcePages, err := sourceClient.ListPages(ctx, sourceTarget.Status.CollectionID)
+	if err != nil {
+		return ""
+	}
+	for _, sp := range sourcePages {
+		if sp.ID == job.Spec.Source.PageID {
+			return sourceTarget.Status.CollectionIDFor(sp.Collection)
+		}
+	}
+	return ""
+}
+
+// withTranslationFooter appends the destination's translation footer to
+// translatedMarkdown, the translation of sourcePage titled sourceTitle. The
+// text is returned as it is when there is no footer, for diagnostic jobs, and
+// when the footer doesn't render.
+func (r *TranslationJobReconciler) withTranslationFooter(ctx context.Context, job *wikiv1alpha1.TranslationJob, destTarget, sourceTarget *wikiv1alpha1.WikiTarget,
+	sourcePage *catalog.Page, engine *wikiv1alpha1.TranslationEngine, sourceTitle, translatedMarkdown string, now metav1.Time) string {
+	footer := job.TranslationFooter(destTarget)
+	if footer == nil || job.IsDiagnostic() {
+		return translatedMarkdown
+	}
+	footerData := markdown.FooterData{
+		SourceTitle:    sourceTitle,
+		TargetLanguage: languageTagForJob(job),
+		TranslatedAt:   now.Time,
+	}
+	if engine != nil {
+		footerData.Engine = engine.Name
+		footerData.EngineVersion = engine.Version
+	}
+	if sourcePage != nil {
+		footerData.SourceURL = outline.DocumentURL(sourceTarget.Spec.URI, sourcePage.Title, sourcePage.Slug)
+		footerData.SourceLanguage = sourceTarget.Spec.SourceLanguage(sourcePage.DetectedLanguage)
+	}
+	withFooter, err := markdown.AppendFooter(translatedMarkdown, footer.Template, footerData)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to render translation footer, publishing without it")
+		if r.Recorder != nil {
+			r.Recorder.Eventf(job, "Warning", "FooterFailed", "Published without the translation footer: %v", err)
+		}
+		return translatedMarkdown
+	}
+	return withFooter
+}
+
+// translationServiceWired reports whether the reconciler was given a
+// translation service client, or a way to look one up. One wired without
+// either can't tell whether a service exists.
+func (r *TranslationJobReconciler) translationServiceWired() bool {
+	return r.GetNanabushClient != nil || r.Nanabush != nil
+}
+
+// translationServiceWaitStart marks updated as waiting for a translation
+// service and returns when the wait began. That is kept in its own condition,
+// since a held job goes back through validation, which resets Ready, before
+// each retry.
+func translationServiceWaitStart(updated *wikiv1alpha1.TranslationJobStatus, now metav1.Time) metav1.Time {
+	meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+		Type:               translationServiceConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             translationServiceUnavailableReason,
+		Message:            translationServiceWaitingMessage,
+		LastTransitionTime: now,
+	})
+	meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionFalse,
+		Reason:             translationServiceUnavailableReason,
+		Message:            translationServiceWaitingMessage,
+		LastTransitionTime: now,
+	})
+	return meta.FindStatusCondition(updated.Conditions, translationServiceConditionType).LastTransitionTime
+}
+
+// currentNanabushClient returns the live translation service client, which may
+// change at runtime when the TranslationService is reconfigured.
+func (r *TranslationJobReconciler) currentNanabushClient() *nanabush.Client {
+	if r.GetNanabushClient != nil {
+		return r.GetNanabushClient()
+	}
+	return r.Nanabush // Fallback to direct reference
+}
+
+// translatedPageSearchLimit caps the documents.search_titles results
+// translatedPagesFor looks through; a search filling it may have missed some,
+// so the listing is scanned instead.
+const translatedPageSearchLimit = 100
+
+// translatedPagesFor returns the pages in the destination collection (the
+// whole wiki when collectionID is empty) whose title is the AUTOTRANSLATED
+// title for sourceTitle, numbered copies included. It looks titles up with
+// documents.search_titles, which matches titles directly rather than through
+// the full-text index, so a page published moments ago is found. Every page
+// is scanned instead when that search fails, e.g. on an instance without it.
+func translatedPagesFor(ctx context.Context, destClient *outline.Client, collectionID, sourceTitle string) ([]outline.PageSummary, error) {
+	logger := log.FromContext(ctx)
+	prefix := "AUTOTRANSLATED--> " + sourceTitle
+
+	// A blank title has nothing to search for
+	searched := false
+	var pages []outline.PageSummary
+	if strings.TrimSpace(sourceTitle) != "" {
+		found, err := destClient.SearchTitles(ctx, sourceTitle, outline.SearchOptions{
+			CollectionID:  collectionID,
+			Limit:         translatedPageSearchLimit,
+			IncludeDrafts: true,
+		})
+		switch {
+		case err != nil:
+			logger.V(1).Info("title search unavailable, scanning destination pages", "error", err.Error())
+		case len(found) >= translatedPageSearchLimit:
+			logger.V(1).Info("title search results may be incomplete, scanning destination pages", "query", sourceTitle)
+		default:
+			pages, searched = found, true
+		}
+	}
+	if !searched {
+		var err error
+		if pages, err = destClient.ListPages(ctx, collectionID); err != nil {
+			return nil, err
+		}
+	}
+
+	var matches []outline.PageSummary
+	for _, page := range pages {
+		if strings.HasPrefix(page.Title, prefix) {
+			matches = append(matches, page)
+		}
+	}
+	return matches, nil
+}
+
+// translationEngine is the engine resp reports, nil when it reports none.
+func translationEngine(resp *nanabush.TranslateResponse) *wikiv1alpha1.TranslationEngine {
+	if resp.Engine == "" && resp.EngineVersion == "" {
+		return nil
+	}
+	return &wikiv1alpha1.TranslationEngine{Name: resp.Engine, Version: resp.EngineVersion}
+}
+
 func languageTagForJob(job *wikiv1alpha1.TranslationJob) string {
 	if job.Spec.Destination != nil && job.Spec.Destination.LanguageTag != "" {
 		return job.Spec.Destination.LanguageTag
@@ -1050,6 +1621,59 @@ func languageTagForJob(job *wikiv1alpha1.TranslationJob) string {
 	return "fr-CA"
 }
 
+// canonicalizeLanguageTag rewrites the target language of job in canonical
+// BCP 47 form and reports whether it changed. Tags that don't parse are left
+// for validation to reject.
+func canonicalizeLanguageTag(job *wikiv1alpha1.TranslationJob) bool {
+	canonical := func(tag string) (string, bool) {
+		c, err := langtag.Canonical(tag)
+		return c, tag != "" && err == nil && c != tag
+	}
+	if job.Spec.Destination != nil && job.Spec.Destination.LanguageTag != "" {
+		tag, changed := canonical(job.Spec.Destination.LanguageTag)
+		if changed {
+			job.Spec.Destination.LanguageTag = tag
+		}
+		return changed
+	}
+	tag, changed := canonical(job.Spec.Parameters["languageTag"])
+	if changed {
+		job.Spec.Parameters["languageTag"] = tag
+	}
+	return changed
+}
+
+// recordLanguageResult records the outcome of a finished job for its language,
+// unless a result with the same state is already present, then derives the
+// job state from all results so mixed outcomes become PartiallyCompleted.
+func recordLanguageResult(job *wikiv1alpha1.TranslationJob, status *wikiv1alpha1.TranslationJobStatus) {
+	lang := languageTagForJob(job)
+	recorded := false
+	for _, res := range status.LanguageResults {
+		if res.Lang == lang && res.State == status.State {
+			recorded = true
+			break
+		}
+	}
+	if !recorded {
+		result := wikiv1alpha1.LanguageResult{Lang: lang, State: status.State}
+		if status.State == wikiv1alpha1.TranslationJobStateFailed {
+			result.Error = status.Message
+		} else {
+			result.PageURL = job.Annotations[wikiv1alpha1.AnnotationPublishedPageURL]
+		}
+		status.SetLanguageResult(result)
+	}
+	if state := status.LanguageResultsState(); state != "" {
+		status.State = state
+	}
+}
+
+// isDiagnosticJob reports whether job was created by the diagnostic runnable.
+func isDiagnosticJob(job *wikiv1alpha1.TranslationJob) bool {
+	return job.IsDiagnostic()
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *TranslationJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -1058,9 +1682,45 @@ func (r *TranslationJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// Limit concurrent reconciles to prevent overwhelming the translation service
 		// This helps when many jobs are queued after a restart
 		WithOptions(controller.Options{MaxConcurrentReconciles: 3}).
+		WithEventFilter(translationJobChangePredicate()).
 		Complete(r)
 }
 
+// reconcileAnnotations are the TranslationJob annotations the reconciler acts on;
+// changes to any other annotation don't warrant a reconcile.
+var reconcileAnnotations = []string{
+	wikiv1alpha1.AnnotationDuplicateApproved,
+	wikiv1alpha1.AnnotationPublishJob,
+	wikiv1alpha1.AnnotationSupersededBy,
+}
+
+// translationJobChangePredicate drops TranslationJob updates that only touch
+// status details, resourceVersion, managed fields or bookkeeping annotations.
+// Spec changes, deletion, state transitions (e.g. a runner finishing the job)
+// and the annotations in reconcileAnnotations still trigger a reconcile.
+func translationJobChangePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldJob, okOld := e.ObjectOld.(*wikiv1alpha1.TranslationJob)
+			newJob, okNew := e.ObjectNew.(*wikiv1alpha1.TranslationJob)
+			if !okOld || !okNew {
+				return true
+			}
+			if oldJob.Generation != newJob.Generation ||
+				oldJob.Status.State != newJob.Status.State ||
+				!newJob.DeletionTimestamp.Equal(oldJob.DeletionTimestamp) {
+				return true
+			}
+			for _, key := range reconcileAnnotations {
+				if oldJob.Annotations[key] != newJob.Annotations[key] {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
 func jobStatusChanged(previous *wikiv1alpha1.TranslationJobStatus, updated *wikiv1alpha1.TranslationJobStatus) bool {
 	return !equality.Semantic.DeepEqual(previous, updated)
 }
@@ -18,16 +18,32 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+	"github.com/dasmlab/glooscap-operator/pkg/jobarchive"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
 )
 
 var _ = Describe("TranslationJob Controller", func() {
@@ -81,4 +97,866 @@ var _ = Describe("TranslationJob Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When two jobs translate the same page concurrently", func() {
+		ctx := context.Background()
+		target := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "dup-target", Namespace: "default"},
+			Spec: wikiv1alpha1.WikiTargetSpec{
+				URI:                     "https://wiki.example.com",
+				ServiceAccountSecretRef: wikiv1alpha1.SecretKeyRef{Name: "outline-token"},
+				Mode:                    wikiv1alpha1.WikiTargetModeReadWrite,
+			},
+		}
+		newJob := func(name string) *wikiv1alpha1.TranslationJob {
+			return &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source:      wikiv1alpha1.TranslationSourceSpec{TargetRef: target.Name, PageID: "page-1"},
+					Destination: &wikiv1alpha1.TranslationDestinationSpec{LanguageTag: "fr-CA"},
+				},
+			}
+		}
+		setState := func(job *wikiv1alpha1.TranslationJob, state wikiv1alpha1.TranslationJobState) {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: job.Namespace, Name: job.Name}, job)).To(Succeed())
+			job.Status.State = state
+			Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+		}
+
+		first, second := newJob("dup-a"), newJob("dup-b")
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, target.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, first)).To(Succeed())
+			Expect(k8sClient.Create(ctx, second)).To(Succeed())
+			setState(first, wikiv1alpha1.TranslationJobStateValidating)
+			setState(second, wikiv1alpha1.TranslationJobStateValidating)
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, first)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, second)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, target.DeepCopy())).To(Succeed())
+		})
+
+		It("should hold the newer job until the older one finishes", func() {
+			reconciler := &TranslationJobReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: second.Name}}
+
+			duplicate, err := reconciler.validator().activeDuplicateJob(ctx, first)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(duplicate).To(BeNil())
+
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, request.NamespacedName, second)).To(Succeed())
+			Expect(second.Status.State).To(Equal(wikiv1alpha1.TranslationJobStateAwaitingApproval))
+			cond := meta.FindStatusCondition(second.Status.Conditions, "Ready")
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal(duplicateInProgressReason))
+
+			setState(first, wikiv1alpha1.TranslationJobStateCompleted)
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, request.NamespacedName, second)).To(Succeed())
+			Expect(second.Status.State).To(Equal(wikiv1alpha1.TranslationJobStateValidating))
+		})
+	})
+
+	Context("When validating a job", func() {
+		ctx := context.Background()
+		target := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "readonly-target", Namespace: "default"},
+			Spec: wikiv1alpha1.WikiTargetSpec{
+				URI:                     "https://wiki.example.com",
+				ServiceAccountSecretRef: wikiv1alpha1.SecretKeyRef{Name: "outline-token"},
+				Mode:                    wikiv1alpha1.WikiTargetModeReadOnly,
+			},
+		}
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, target.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, target.DeepCopy())).To(Succeed())
+		})
+
+		It("should report every issue without creating the job", func() {
+			validator := &JobValidator{Client: k8sClient}
+			job := &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "preflight", Namespace: "default"},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source: wikiv1alpha1.TranslationSourceSpec{TargetRef: target.Name, PageID: "page-1"},
+					Destination: &wikiv1alpha1.TranslationDestinationSpec{
+						TargetRef:   "missing-target",
+						LanguageTag: "fr-CA",
+					},
+				},
+			}
+			issues, err := validator.Validate(ctx, job)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(FirstIssue(issues, ValidationError)).NotTo(BeNil())
+			Expect(FirstIssue(issues, ValidationError).Reason).To(Equal("DestinationMissing"))
+
+			job.Spec.Destination.TargetRef = target.Name
+			issues, err = validator.Validate(ctx, job)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(FirstIssue(issues, ValidationError).Reason).To(Equal("DestinationReadOnly"))
+		})
+
+		It("should warn that jobs wait when no translation service is configured", func() {
+			validator := &JobValidator{Client: k8sClient, GetNanabushClient: func() *nanabush.Client { return nil }}
+			job := &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-service", Namespace: "default"},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source:      wikiv1alpha1.TranslationSourceSpec{TargetRef: target.Name, PageID: "page-1"},
+					Destination: &wikiv1alpha1.TranslationDestinationSpec{LanguageTag: "fr-CA"},
+				},
+			}
+			issues, err := validator.Validate(ctx, job)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(FirstIssue(issues, ValidationError)).To(BeNil())
+			Expect(FirstIssue(issues, ValidationWarning).Reason).To(Equal(translationServiceUnavailableReason))
+		})
+
+		It("should store canonical language tags and reject malformed ones", func() {
+			job := &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "language", Namespace: "default"},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source:      wikiv1alpha1.TranslationSourceSpec{TargetRef: target.Name, PageID: "page-1"},
+					Destination: &wikiv1alpha1.TranslationDestinationSpec{LanguageTag: "fr_ca"},
+				},
+			}
+			Expect(canonicalizeLanguageTag(job)).To(BeTrue())
+			Expect(job.Spec.Destination.LanguageTag).To(Equal("fr-CA"))
+			Expect(canonicalizeLanguageTag(job)).To(BeFalse())
+
+			job.Spec.Destination.LanguageTag = "french"
+			Expect(canonicalizeLanguageTag(job)).To(BeFalse())
+			issues, err := (&JobValidator{Client: k8sClient}).Validate(ctx, job)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(FirstIssue(issues, ValidationError).Reason).To(Equal("InvalidLanguageTag"))
+		})
+
+		It("should find the earlier translation a publish strategy updates", func() {
+			translation := func(name, lang, pageID string, created int64) wikiv1alpha1.TranslationJob {
+				job := wikiv1alpha1.TranslationJob{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.Unix(created, 0)},
+					Spec: wikiv1alpha1.TranslationJobSpec{
+						Source:      wikiv1alpha1.TranslationSourceSpec{TargetRef: "docs", PageID: "page-1"},
+						Destination: &wikiv1alpha1.TranslationDestinationSpec{LanguageTag: lang},
+					},
+				}
+				if pageID != "" {
+					wikiv1alpha1.SetPublishedPage(&job, wikiv1alpha1.PublishedPage{ID: pageID})
+				}
+				return job
+			}
+			jobs := []wikiv1alpha1.TranslationJob{
+				translation("old", "fr-CA", "fr-old", 1),
+				translation("newer", "FR-ca", "fr-new", 2),
+				translation("german", "de", "de-1", 3),
+				translation("unpublished", "fr-CA", "", 4),
+			}
+			job := translation("current", "fr-CA", "", 5)
+			Expect(job.PreviousTranslation(jobs).Name).To(Equal("newer"))
+
+			job.Spec.Destination.TargetRef = "other-wiki"
+			Expect(job.PreviousTranslation(jobs)).To(BeNil())
+		})
+
+		It("should only reject the same language when the source language is known", func() {
+			store := catalog.NewStore()
+			validator := &JobValidator{Client: k8sClient, Catalogue: store}
+			job := &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "same-language", Namespace: "default"},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source:      wikiv1alpha1.TranslationSourceSpec{TargetRef: target.Name, PageID: "page-1"},
+					Destination: &wikiv1alpha1.TranslationDestinationSpec{LanguageTag: "en-GB"},
+				},
+			}
+			reasons := func() []string {
+				issues, err := validator.Validate(ctx, job)
+				Expect(err).NotTo(HaveOccurred())
+				var reasons []string
+				for _, issue := range issues {
+					reasons = append(reasons, issue.Reason)
+				}
+				return reasons
+			}
+
+			// "EN" is only the catalogue's display default for a page Outline gave no language
+			store.Update("default/"+target.Name, catalog.Target{ID: "default/" + target.Name}, []catalog.Page{
+				{ID: "page-1", Title: "Guide", URI: "https://wiki.example.com/doc/guide", Language: "EN"},
+			})
+			Expect(reasons()).NotTo(ContainElement("SameLanguage"))
+
+			store.Update("default/"+target.Name, catalog.Target{ID: "default/" + target.Name}, []catalog.Page{
+				{ID: "page-1", Title: "Guide", URI: "https://wiki.example.com/doc/guide", Language: "en", DetectedLanguage: "en"},
+			})
+			Expect(reasons()).To(ContainElement("SameLanguage"))
+		})
+
+		It("should compare primary language subtags", func() {
+			Expect(sameLanguage("FR", "fr-CA")).To(BeTrue())
+			Expect(sameLanguage("en_US", "en")).To(BeTrue())
+			Expect(sameLanguage("en", "fr-CA")).To(BeFalse())
+			Expect(sameLanguage("", "fr-CA")).To(BeFalse())
+		})
+	})
+
+	Context("When filtering update events", func() {
+		pred := translationJobChangePredicate()
+		base := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default", Generation: 1},
+			Status:     wikiv1alpha1.TranslationJobStatus{State: wikiv1alpha1.TranslationJobStateAwaitingApproval},
+		}
+		updated := func(mutate func(*wikiv1alpha1.TranslationJob)) event.UpdateEvent {
+			newJob := base.DeepCopy()
+			mutate(newJob)
+			return event.UpdateEvent{ObjectOld: base, ObjectNew: newJob}
+		}
+
+		It("should reconcile approvals, state changes and spec changes", func() {
+			Expect(pred.Update(updated(func(j *wikiv1alpha1.TranslationJob) {
+				j.Annotations = map[string]string{wikiv1alpha1.AnnotationDuplicateApproved: "true"}
+			}))).To(BeTrue())
+			Expect(pred.Update(updated(func(j *wikiv1alpha1.TranslationJob) {
+				j.Annotations = map[string]string{wikiv1alpha1.AnnotationPublishJob: "true"}
+			}))).To(BeTrue())
+			Expect(pred.Update(updated(func(j *wikiv1alpha1.TranslationJob) {
+				j.Status.State = wikiv1alpha1.TranslationJobStateCompleted
+			}))).To(BeTrue())
+			Expect(pred.Update(updated(func(j *wikiv1alpha1.TranslationJob) {
+				j.Generation = 2
+			}))).To(BeTrue())
+		})
+
+		It("should skip status-only and bookkeeping annotation updates", func() {
+			Expect(pred.Update(updated(func(j *wikiv1alpha1.TranslationJob) {
+				j.Status.Message = "still waiting"
+			}))).To(BeFalse())
+			Expect(pred.Update(updated(func(j *wikiv1alpha1.TranslationJob) {
+				j.Annotations = map[string]string{wikiv1alpha1.AnnotationNotifiedState: "AwaitingApproval"}
+			}))).To(BeFalse())
+		})
+	})
+
+	Context("When admitting diagnostic jobs", func() {
+		ctx := context.Background()
+
+		diagnostic := func(name string, state wikiv1alpha1.TranslationJobState, content string) *wikiv1alpha1.TranslationJob {
+			return &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "glooscap-system",
+					Labels:    map[string]string{wikiv1alpha1.LabelDiagnostic: "true"},
+				},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source:     wikiv1alpha1.TranslationSourceSpec{TargetRef: "docs", PageID: "test"},
+					Parameters: map[string]string{"testContent": content},
+				},
+				Status: wikiv1alpha1.TranslationJobStatus{State: state},
+			}
+		}
+		reconcilerWith := func(jobs ...*wikiv1alpha1.TranslationJob) *TranslationJobReconciler {
+			builder := fake.NewClientBuilder().WithScheme(k8sClient.Scheme())
+			for _, job := range jobs {
+				builder = builder.WithObjects(job)
+			}
+			return &TranslationJobReconciler{Client: builder.Build(), Diagnostics: &DiagnosticLimits{MaxConcurrent: 1, MaxPerMinute: 60}}
+		}
+
+		It("should fold a repeated diagnostic into the unfinished one", func() {
+			running := diagnostic("diag-a", wikiv1alpha1.TranslationJobStateRunning, "hello")
+			repeat := diagnostic("diag-b", wikiv1alpha1.TranslationJobStateQueued, "hello")
+			admission, err := reconcilerWith(running, repeat).admitDiagnosticJob(ctx, repeat)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(admission.Admit).To(BeFalse())
+			Expect(admission.DuplicateOf).To(Equal("diag-a"))
+		})
+
+		It("should hold diagnostics beyond the concurrency limit", func() {
+			running := diagnostic("diag-a", wikiv1alpha1.TranslationJobStateRunning, "hello")
+			other := diagnostic("diag-b", wikiv1alpha1.TranslationJobStateQueued, "different")
+			r := reconcilerWith(running, other)
+			admission, err := r.admitDiagnosticJob(ctx, other)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(admission).To(Equal(diagnosticAdmission{Active: 1}))
+
+			r = reconcilerWith(other)
+			admission, err = r.admitDiagnosticJob(ctx, other)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(admission.Admit).To(BeTrue())
+		})
+	})
+
+	Context("When a destination WikiTarget limits concurrent translations", func() {
+		ctx := context.Background()
+
+		translation := func(name string, state wikiv1alpha1.TranslationJobState, destination string) *wikiv1alpha1.TranslationJob {
+			return &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "glooscap-system"},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source:      wikiv1alpha1.TranslationSourceSpec{TargetRef: "docs", PageID: name},
+					Destination: &wikiv1alpha1.TranslationDestinationSpec{TargetRef: destination},
+				},
+				Status: wikiv1alpha1.TranslationJobStatus{State: state},
+			}
+		}
+		small := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "glooscap-system"},
+			Spec:       wikiv1alpha1.WikiTargetSpec{MaxConcurrentTranslations: 2},
+		}
+
+		It("should hold jobs beyond the limit, counting ones still in a reconcile", func() {
+			running := translation("running", wikiv1alpha1.TranslationJobStateRunning, "small")
+			elsewhere := translation("elsewhere", wikiv1alpha1.TranslationJobStatePublishing, "large")
+			first := translation("first", wikiv1alpha1.TranslationJobStateQueued, "small")
+			second := translation("second", wikiv1alpha1.TranslationJobStateQueued, "small")
+			r := &TranslationJobReconciler{Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+				WithObjects(small.DeepCopy(), running, elsewhere, first, second).Build()}
+
+			admission, err := r.admitTargetJob(ctx, first, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(admission.Admit).To(BeTrue())
+			Expect(admission.Active).To(Equal(1))
+
+			// first is still Queued in the cache while its reconcile translates
+			held, err := r.admitTargetJob(ctx, second, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held.Admit).To(BeFalse())
+			Expect(held.Active).To(Equal(2))
+			Expect(held.Limit).To(Equal(2))
+
+			admission.Release()
+			admission, err = r.admitTargetJob(ctx, second, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(admission.Admit).To(BeTrue())
+		})
+	})
+
+	Context("When a namespace has spent its token budget", func() {
+		jobs := catalog.NewJobStore()
+		jobs.SetTokenBudgets(map[string]int64{"": 100})
+		jobs.RecordTokens(&wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "spent", Namespace: "budget"},
+			Status:     wikiv1alpha1.TranslationJobStatus{TokensUsed: 120},
+		})
+		job := &wikiv1alpha1.TranslationJob{ObjectMeta: metav1.ObjectMeta{Name: "next", Namespace: "budget"}}
+		budgetIssue := func(issues []ValidationIssue) *ValidationIssue {
+			for i := range issues {
+				if issues[i].Reason == BudgetExceededReason {
+					return &issues[i]
+				}
+			}
+			return nil
+		}
+
+		It("should fail new jobs unless dispatch pauses", func() {
+			issues, err := (&JobValidator{Client: k8sClient, Jobs: jobs}).Validate(ctx, job)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(budgetIssue(issues)).NotTo(BeNil())
+			Expect(budgetIssue(issues).Severity).To(Equal(ValidationError))
+
+			issues, err = (&JobValidator{Client: k8sClient, Jobs: jobs, PauseOnTokenBudget: true}).Validate(ctx, job)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(budgetIssue(issues).Severity).To(Equal(ValidationWarning))
+		})
+
+		It("should count a held job once", func() {
+			counter := budgetExceeded.WithLabelValues("budget", budgetScopeNamespace)
+			before := testutil.ToFloat64(counter)
+			var status wikiv1alpha1.TranslationJobStatus
+			markBudgetExceeded(&status, "budget", budgetScopeNamespace, "held", metav1.Now())
+			markBudgetExceeded(&status, "budget", budgetScopeNamespace, "still held", metav1.Now())
+			Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+			Expect(meta.IsStatusConditionTrue(status.Conditions, ConditionBudgetExceeded)).To(BeTrue())
+
+			clearBudgetExceeded(&status, metav1.Now())
+			Expect(meta.IsStatusConditionFalse(status.Conditions, ConditionBudgetExceeded)).To(BeTrue())
+		})
+	})
+
+	Context("When archiving finished jobs", func() {
+		It("should archive each finished job once and only delete archived ones past retention", func() {
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			finished := func(name string, state wikiv1alpha1.TranslationJobState, ago time.Duration) *wikiv1alpha1.TranslationJob {
+				job := &wikiv1alpha1.TranslationJob{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "archive"},
+					Status:     wikiv1alpha1.TranslationJobStatus{State: state},
+				}
+				if state.IsTerminal() {
+					at := metav1.NewTime(now.Add(-ago))
+					job.Status.FinishedAt = &at
+				}
+				return job
+			}
+			c := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(
+				finished("expired", wikiv1alpha1.TranslationJobStateCompleted, 48*time.Hour),
+				finished("recent", wikiv1alpha1.TranslationJobStateFailed, time.Hour),
+				finished("running", wikiv1alpha1.TranslationJobStateRunning, 0),
+			).Build()
+			sink, err := jobarchive.NewFileSink(filepath.Join(GinkgoT().TempDir(), "jobs.jsonl"))
+			Expect(err).NotTo(HaveOccurred())
+			archiver := &JobArchiver{Client: c, Sink: sink, Retention: 24 * time.Hour, Clock: clocktesting.NewFakePassiveClock(now)}
+
+			Expect(archiver.sweep(ctx)).To(Succeed())
+			Expect(archiver.sweep(ctx)).To(Succeed())
+
+			records, err := sink.Query(ctx, jobarchive.Query{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+
+			var jobs wikiv1alpha1.TranslationJobList
+			Expect(c.List(ctx, &jobs)).To(Succeed())
+			names := []string{}
+			for _, job := range jobs.Items {
+				names = append(names, job.Name)
+			}
+			Expect(names).To(ConsistOf("recent", "running"))
+		})
+	})
+
+	Context("When following links", func() {
+		It("should create one child per linked page and stop at the depth", func() {
+			catalogue := []*catalog.Page{
+				{ID: "page-root", Slug: "Rr1Rr1Rr1R", Title: "Root"},
+				{ID: "page-a", Slug: "Aa1Aa1Aa1A", Title: "A"},
+				{ID: "page-b", Slug: "Bb1Bb1Bb1B", Title: "B"},
+				{ID: "page-tpl", Slug: "Tt1Tt1Tt1T", Title: "Template", IsTemplate: true},
+				{ID: "page-fr", Slug: "Ff1Ff1Ff1F", Title: translatedTitlePrefix + "--> A"},
+			}
+			// Links back to the root page, repeats, templates, translations
+			// and pages outside the catalogue are dropped
+			pages, truncated := linkedPages(
+				[]string{"Aa1Aa1Aa1A", "Rr1Rr1Rr1R", "page-b", "Aa1Aa1Aa1A", "Tt1Tt1Tt1T", "Ff1Ff1Ff1F", "Zz9Zz9Zz9Z"},
+				catalogue, map[string]bool{"page-root": true})
+			Expect(truncated).To(BeFalse())
+			Expect(pages).To(HaveLen(2))
+			Expect(pages[0].ID).To(Equal("page-a"))
+			Expect(pages[1].ID).To(Equal("page-b"))
+
+			parent := &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "translation-root", Namespace: "links"},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source:      wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki", PageID: "page-root", RevisionID: "rev-1"},
+					Destination: &wikiv1alpha1.TranslationDestinationSpec{TargetRef: "wiki-fr", LanguageTag: "fr-CA"},
+					Parameters:  map[string]string{"pageTitle": "Root"},
+					FollowLinks: &wikiv1alpha1.FollowLinksSpec{Depth: 2},
+				},
+			}
+			child := newLinkedJob(parent, rootJobName(parent), pages[0])
+			Expect(child.Name).To(Equal(linkedJobName("translation-root", "page-a")))
+			Expect(child.Annotations).To(HaveKeyWithValue(wikiv1alpha1.AnnotationParentJob, "translation-root"))
+			Expect(child.Spec.Source).To(Equal(wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki", PageID: "page-a"}))
+			Expect(child.Spec.Destination.LanguageTag).To(Equal("fr-CA"))
+			Expect(child.Spec.Parameters).To(HaveKeyWithValue("pageTitle", "A"))
+			Expect(parent.Spec.Parameters).To(HaveKeyWithValue("pageTitle", "Root"))
+			Expect(child.Spec.LinkDepth()).To(BeEquivalentTo(1))
+
+			// The grandchild keeps the root's naming and follows no further
+			grandchild := newLinkedJob(child, rootJobName(child), pages[1])
+			Expect(grandchild.Name).To(Equal(linkedJobName("translation-root", "page-b")))
+			Expect(grandchild.Annotations).To(HaveKeyWithValue(wikiv1alpha1.AnnotationParentJob, child.Name))
+			Expect(grandchild.Spec.FollowLinks).To(BeNil())
+		})
+	})
+
+	Context("When publishing an approved draft", func() {
+		It("should recreate a deleted draft from the stored translation", func() {
+			var created map[string]any
+			var published []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/api/documents.update":
+					var update map[string]any
+					_ = json.NewDecoder(r.Body).Decode(&update)
+					if update["id"] == "page-draft" {
+						// The draft was deleted before approval
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"ok":false,"error":"not_found"}`))
+						return
+					}
+					published = append(published, update["id"].(string))
+					_, _ = w.Write([]byte(`{"data":{"id":"page-new","title":"AUTOTRANSLATED--> Guide","urlId":"guide-Nn1Nn1Nn1N"}}`))
+				case "/api/documents.create":
+					_ = json.NewDecoder(r.Body).Decode(&created)
+					_, _ = w.Write([]byte(`{"data":{"id":"page-new","title":"AUTOTRANSLATED--> Guide","urlId":"guide-Nn1Nn1Nn1N"}}`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+			outlineClient, err := outline.NewClient(outline.Config{BaseURL: srv.URL, Token: "token"})
+			Expect(err).NotTo(HaveOccurred())
+
+			target := &wikiv1alpha1.WikiTarget{
+				ObjectMeta: metav1.ObjectMeta{Name: "wiki-fr", Namespace: "publish"},
+				Spec:       wikiv1alpha1.WikiTargetSpec{URI: srv.URL},
+				Status:     wikiv1alpha1.WikiTargetStatus{CollectionID: "col-1"},
+			}
+			// The recreated page goes where the original job would have put it
+			source := &wikiv1alpha1.WikiTarget{
+				ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "publish"},
+				Spec:       wikiv1alpha1.WikiTargetSpec{URI: srv.URL},
+				Status:     wikiv1alpha1.WikiTargetStatus{CollectionID: "col-guides", CollectionName: "Guides"},
+			}
+			store := catalog.NewStore()
+			store.Update("publish/wiki", catalog.Target{ID: "publish/wiki"}, []catalog.Page{
+				{ID: "page-guide", Title: "Guide", URI: srv.URL + "/doc/guide", Collection: "Guides"},
+			})
+			original := &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "translation-guide", Namespace: "publish"},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source: wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki", PageID: "page-guide"},
+				},
+				Status: wikiv1alpha1.TranslationJobStatus{
+					State:             wikiv1alpha1.TranslationJobStateAwaitingApproval,
+					TokensUsed:        1200,
+					TranslatedContent: &wikiv1alpha1.TranslatedContent{Title: "Guide", Markdown: "# Bonjour"},
+				},
+			}
+			wikiv1alpha1.SetPublishedPage(original, wikiv1alpha1.PublishedPage{ID: "page-draft", Title: "AUTOTRANSLATED--> Guide", IsDraft: true})
+			publish := &wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "publish-translation-guide", Namespace: "publish"},
+				Spec: wikiv1alpha1.TranslationJobSpec{
+					Source:   wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki-fr", PageID: "page-draft"},
+					Pipeline: wikiv1alpha1.TranslationPipelineModeTektonJob,
+					Parameters: map[string]string{
+						"publish": "true", "originalJob": original.Name, "pageId": "page-draft", "targetRef": "wiki-fr",
+					},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+				WithObjects(target, source, original, publish).
+				WithStatusSubresource(&wikiv1alpha1.TranslationJob{}).Build()
+			// No translation service and no dispatcher: neither may be needed
+			r := &TranslationJobReconciler{
+				Client:        c,
+				Recorder:      record.NewFakeRecorder(10),
+				OutlineClient: staticOutlineClient{outlineClient},
+				Catalogue:     store,
+			}
+
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "publish", Name: publish.Name}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(created).To(HaveKeyWithValue("text", "# Bonjour"))
+			Expect(created).To(HaveKeyWithValue("title", "AUTOTRANSLATED--> Guide"))
+			Expect(created).To(HaveKeyWithValue("collectionId", "col-guides"))
+			Expect(published).To(Equal([]string{"page-new"}))
+
+			var done wikiv1alpha1.TranslationJob
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: "publish", Name: publish.Name}, &done)).To(Succeed())
+			Expect(done.Status.State).To(Equal(wikiv1alpha1.TranslationJobStateCompleted))
+			Expect(done.Status.TokensUsed).To(BeZero())
+			Expect(wikiv1alpha1.GetPublishedPage(&done).ID).To(Equal("page-new"))
+
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: "publish", Name: original.Name}, &done)).To(Succeed())
+			Expect(wikiv1alpha1.GetPublishedPage(&done).ID).To(Equal("page-new"))
+		})
+	})
+})
+
+var _ = Describe("TranslationJob destination validation", func() {
+	It("should check the collection the translation is created in", func() {
+		var checked []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			switch r.URL.Path {
+			case "/api/collections.info":
+				id := body["id"].(string)
+				checked = append(checked, id)
+				if id == "col-guides" {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"ok":false}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":{"id":"` + id + `","name":"French","permission":"read_write"}}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer srv.Close()
+		outlineClient, err := outline.NewClient(outline.Config{BaseURL: srv.URL, Token: "token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		source := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "validate"},
+			Spec:       wikiv1alpha1.WikiTargetSpec{URI: srv.URL},
+			Status:     wikiv1alpha1.WikiTargetStatus{CollectionID: "col-guides", CollectionName: "Guides"},
+		}
+		// The destination's own collection is writable, but pages aren't created there
+		dest := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "wiki-fr", Namespace: "validate"},
+			Spec:       wikiv1alpha1.WikiTargetSpec{URI: srv.URL},
+			Status:     wikiv1alpha1.WikiTargetStatus{CollectionID: "col-fr", CollectionName: "French"},
+		}
+		store := catalog.NewStore()
+		store.Update("validate/wiki", catalog.Target{ID: "validate/wiki"}, []catalog.Page{
+			{ID: "page-guide", Title: "Guide", URI: srv.URL + "/doc/guide", Collection: "Guides"},
+		})
+		validator := &JobValidator{
+			Client:        fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(source, dest).Build(),
+			Catalogue:     store,
+			OutlineClient: staticOutlineClient{outlineClient},
+		}
+		job := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "translation-guide", Namespace: "validate"},
+			Spec: wikiv1alpha1.TranslationJobSpec{
+				Source:      wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki", PageID: "page-guide"},
+				Destination: &wikiv1alpha1.TranslationDestinationSpec{TargetRef: "wiki-fr", LanguageTag: "fr-CA"},
+			},
+		}
+
+		issues, err := validator.Validate(ctx, job)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checked).To(Equal([]string{"col-guides"}))
+		issue := FirstIssue(issues, ValidationError)
+		Expect(issue).NotTo(BeNil())
+		Expect(issue.Reason).To(Equal("CollectionNotWritable"))
+		Expect(issue.Message).To(ContainSubstring("col-guides"))
+	})
+
+	It("should look the parent document up in the collection publishing uses", func() {
+		var listed []any
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			switch r.URL.Path {
+			case "/api/documents.list":
+				listed = append(listed, body["collectionId"])
+				if body["collectionId"] == "col-guides" {
+					_, _ = w.Write([]byte(`{"data":[{"id":"doc-parent","title":"Guides FR","collectionId":"col-guides"}]}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":[]}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer srv.Close()
+		outlineClient, err := outline.NewClient(outline.Config{BaseURL: srv.URL, Token: "token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		source := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "validate"},
+			Spec:       wikiv1alpha1.WikiTargetSpec{URI: srv.URL},
+			Status:     wikiv1alpha1.WikiTargetStatus{CollectionID: "col-guides", CollectionName: "Guides"},
+		}
+		dest := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "wiki-fr", Namespace: "validate"},
+			Spec:       wikiv1alpha1.WikiTargetSpec{URI: srv.URL},
+			Status:     wikiv1alpha1.WikiTargetStatus{CollectionID: "col-fr", CollectionName: "French"},
+		}
+		store := catalog.NewStore()
+		store.Update("validate/wiki", catalog.Target{ID: "validate/wiki"}, []catalog.Page{
+			{ID: "page-guide", Title: "Guide", URI: srv.URL + "/doc/guide", Collection: "Guides"},
+		})
+		validator := &JobValidator{
+			Client:        fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(source, dest).Build(),
+			Catalogue:     store,
+			OutlineClient: staticOutlineClient{outlineClient},
+		}
+		job := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "translation-guide", Namespace: "validate"},
+			Spec: wikiv1alpha1.TranslationJobSpec{
+				Source: wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki", PageID: "page-guide"},
+				Destination: &wikiv1alpha1.TranslationDestinationSpec{
+					TargetRef: "wiki-fr", LanguageTag: "fr-CA", ParentDocument: "Guides FR",
+				},
+			},
+		}
+
+		issues, err := validator.Validate(ctx, job)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listed).To(Equal([]any{"col-guides"}))
+		Expect(FirstIssue(issues, ValidationError)).To(BeNil())
+	})
+})
+
+var _ = Describe("TranslationJob resumed publishing", func() {
+	It("should not publish again when the cache missed the published page", func() {
+		var creates int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			creates++
+			http.NotFound(w, r)
+		}))
+		defer srv.Close()
+		outlineClient, err := outline.NewClient(outline.Config{BaseURL: srv.URL, Token: "token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		source := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "resume"},
+			Spec:       wikiv1alpha1.WikiTargetSpec{URI: srv.URL},
+		}
+		job := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "translation-guide", Namespace: "resume"},
+			Spec: wikiv1alpha1.TranslationJobSpec{
+				Source: wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki", PageID: "page-guide"},
+			},
+			Status: wikiv1alpha1.TranslationJobStatus{
+				State:             wikiv1alpha1.TranslationJobStatePublishing,
+				TranslatedContent: &wikiv1alpha1.TranslatedContent{Title: "Guide", Markdown: "# Bonjour"},
+			},
+		}
+		// The API server already has the page the interrupted reconcile published
+		published := job.DeepCopy()
+		wikiv1alpha1.SetPublishedPage(published, wikiv1alpha1.PublishedPage{ID: "page-fr", Slug: "guide-fr", Title: "AUTOTRANSLATED--> Guide"})
+
+		cached := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+			WithObjects(source, job).WithStatusSubresource(&wikiv1alpha1.TranslationJob{}).Build()
+		r := &TranslationJobReconciler{
+			Client:        cached,
+			APIReader:     fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(source, published).Build(),
+			Recorder:      record.NewFakeRecorder(10),
+			OutlineClient: staticOutlineClient{outlineClient},
+		}
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "resume", Name: job.Name}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creates).To(BeZero())
+
+		var done wikiv1alpha1.TranslationJob
+		Expect(cached.Get(ctx, types.NamespacedName{Namespace: "resume", Name: job.Name}, &done)).To(Succeed())
+		Expect(done.Status.State).To(Equal(wikiv1alpha1.TranslationJobStateCompleted))
+	})
+})
+
+var _ = Describe("TranslationJob without a translation service", func() {
+	It("should stay Queued until the wait for a service runs out", func() {
+		source := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "no-service"},
+			Spec:       wikiv1alpha1.WikiTargetSpec{URI: "https://wiki.example.com"},
+		}
+		job := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "translation-guide", Namespace: "no-service"},
+			Spec: wikiv1alpha1.TranslationJobSpec{
+				Source: wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki", PageID: "page-guide"},
+			},
+			Status: wikiv1alpha1.TranslationJobStatus{State: wikiv1alpha1.TranslationJobStateValidating},
+		}
+		c := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+			WithObjects(source, job).WithStatusSubresource(&wikiv1alpha1.TranslationJob{}).Build()
+		fakeClock := clocktesting.NewFakePassiveClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		r := &TranslationJobReconciler{
+			Client:            c,
+			Recorder:          record.NewFakeRecorder(10),
+			GetNanabushClient: func() *nanabush.Client { return nil },
+			Clock:             fakeClock,
+		}
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "no-service", Name: job.Name}}
+
+		var got wikiv1alpha1.TranslationJob
+		// A held job goes back through validation before it is tried again
+		reconcileHeld := func() reconcile.Result {
+			result, err := r.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, request.NamespacedName, &got)).To(Succeed())
+			if got.Status.State == wikiv1alpha1.TranslationJobStateValidating {
+				result, err = r.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Get(ctx, request.NamespacedName, &got)).To(Succeed())
+			}
+			return result
+		}
+		for _, elapsed := range []time.Duration{0, translationServiceWait / 2} {
+			fakeClock.SetTime(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).Add(elapsed))
+			Expect(reconcileHeld().RequeueAfter).To(Equal(translationServiceRequeueInterval))
+			Expect(got.Status.State).To(Equal(wikiv1alpha1.TranslationJobStateQueued))
+			Expect(meta.FindStatusCondition(got.Status.Conditions, "Ready").Reason).To(Equal(translationServiceUnavailableReason))
+		}
+
+		fakeClock.SetTime(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).Add(translationServiceWait))
+		reconcileHeld()
+		Expect(got.Status.State).To(Equal(wikiv1alpha1.TranslationJobStateFailed))
+		Expect(got.Status.Message).To(Equal(translationServiceUnavailableMessage))
+	})
 })
+
+var _ = Describe("TranslationJob publish strategies", func() {
+	It("should update the earlier translation by title once its job is gone", func() {
+		var updated []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/documents.search_titles":
+				_, _ = w.Write([]byte(`{"data":[` +
+					`{"id":"fr-1","title":"AUTOTRANSLATED--> Guide"},` +
+					`{"id":"fr-2","title":"AUTOTRANSLATED--> Guide (1)"}]}`))
+			case "/api/documents.update":
+				var update map[string]any
+				_ = json.NewDecoder(r.Body).Decode(&update)
+				updated = append(updated, update["id"].(string))
+				_, _ = w.Write([]byte(`{"data":{"id":"fr-1","title":"AUTOTRANSLATED--> Guide","urlId":"guide-Nn1Nn1Nn1N"}}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer srv.Close()
+		outlineClient, err := outline.NewClient(outline.Config{BaseURL: srv.URL, Token: "token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		job := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "translation-guide-2", Namespace: "strategy"},
+			Spec: wikiv1alpha1.TranslationJobSpec{
+				Source:          wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki", PageID: "page-guide"},
+				PublishStrategy: wikiv1alpha1.PublishStrategyUpdate,
+			},
+		}
+		// The job that published fr-1 has been deleted
+		r := &TranslationJobReconciler{Client: fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(job).Build()}
+
+		resp, inPlace, err := r.publishTranslatedPage(ctx, outlineClient, job, "AUTOTRANSLATED--> Guide",
+			outline.CreatePageRequest{Title: "AUTOTRANSLATED--> Guide", Text: "# Bonjour"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inPlace).To(BeTrue())
+		Expect(resp.Data.ID).To(Equal("fr-1"))
+		Expect(updated).To(Equal([]string{"fr-1"}))
+	})
+
+	It("should list the destination when title search is unavailable", func() {
+		var listed int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/documents.list":
+				listed++
+				_, _ = w.Write([]byte(`{"data":[` +
+					`{"id":"fr-1","title":"AUTOTRANSLATED--> Guide"},` +
+					`{"id":"fr-2","title":"AUTOTRANSLATED--> Guide for admins"},` +
+					`{"id":"en-1","title":"Guide"}]}`))
+			default:
+				// An instance without documents.search_titles
+				http.NotFound(w, r)
+			}
+		}))
+		defer srv.Close()
+		outlineClient, err := outline.NewClient(outline.Config{BaseURL: srv.URL, Token: "token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		pages, err := translatedPagesFor(ctx, outlineClient, "col-1", "Guide")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listed).To(Equal(1))
+		var ids []string
+		for _, page := range pages {
+			ids = append(ids, page.ID)
+		}
+		Expect(ids).To(Equal([]string{"fr-1", "fr-2"}))
+	})
+})
+
+// staticOutlineClient hands out one Outline client for every target.
+type staticOutlineClient struct {
+	client *outline.Client
+}
+
+func (f staticOutlineClient) New(context.Context, client.Client, *wikiv1alpha1.WikiTarget) (*outline.Client, error) {
+	return f.client, nil
+}
@@ -23,20 +23,38 @@ import (
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
 	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
 )
 
+// translationServiceFinalizer holds TranslationService deletion until the
+// shared translation service client has been closed.
+const translationServiceFinalizer = "glooscap.dasmlab.org/translation-service-client"
+
+const (
+	// translationServiceResyncInterval is how often the status is recomputed from the live client.
+	translationServiceResyncInterval = 10 * time.Second
+
+	// heartbeatStaleIntervals and minHeartbeatStaleAfter bound how old the last
+	// heartbeat may be before a connected client is reported as stale.
+	heartbeatStaleIntervals = 3
+	minHeartbeatStaleAfter  = time.Minute
+)
+
 // TranslationServiceReconciler reconciles a TranslationService object
 type TranslationServiceReconciler struct {
 	client.Client
@@ -52,6 +70,8 @@ type TranslationServiceReconciler struct {
 	NanabushStatusCh chan<- struct{}
 	// CreateTranslationServiceClient is a function to create a new translation service client
 	CreateTranslationServiceClient func(address, serviceType string, secure bool) (*nanabush.Client, error)
+	// Clock judges heartbeat staleness. Nil uses the real clock.
+	Clock clock.PassiveClock
 }
 
 // +kubebuilder:rbac:groups=wiki.glooscap.dasmlab.org,resources=translationservices,verbs=get;list;watch;create;update;patch;delete
@@ -62,40 +82,66 @@ type TranslationServiceReconciler struct {
 func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx).WithValues("translationservice", req.NamespacedName)
 
+	// The shared client belongs to the singleton; other instances (created
+	// before admission enforced the name) must never touch it.
+	if req.Name != wikiv1alpha1.TranslationServiceName {
+		return r.reconcileIgnored(ctx, req)
+	}
+
 	var ts wikiv1alpha1.TranslationService
 	if err := r.Get(ctx, req.NamespacedName, &ts); err != nil {
 		if errors.IsNotFound(err) {
-			// TranslationService was deleted - close and clear the client
+			// Normally handled by the finalizer; this covers objects created
+			// before the finalizer existed or whose finalizer was removed by hand.
 			logger.Info("TranslationService deleted, closing client")
-			r.NanabushClientMu.Lock()
-			if *r.NanabushClient != nil {
-				if err := (*r.NanabushClient).Close(); err != nil {
-					logger.Error(err, "error closing translation service client")
-				}
-				*r.NanabushClient = nil
-			}
-			r.NanabushClientMu.Unlock()
-
-			// Trigger SSE broadcast
-			select {
-			case r.NanabushStatusCh <- struct{}{}:
-			default:
-			}
+			r.closeClient(logger)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
+	if !ts.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(&ts, translationServiceFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		// Block deletion until the client's heartbeat goroutines and gRPC
+		// connection are torn down, then release the object.
+		logger.Info("TranslationService deleting, closing client")
+		r.closeClient(logger)
+		controllerutil.RemoveFinalizer(&ts, translationServiceFinalizer)
+		if err := r.Update(ctx, &ts); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.AddFinalizer(&ts, translationServiceFinalizer) {
+		if err := r.Update(ctx, &ts); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	status := ts.Status.DeepCopy()
-	now := metav1.Now()
+	now := nowFrom(r.Clock)
 
 	// Check if we need to recreate the client
 	// We'll track the last applied spec in an annotation to detect changes
 	lastAppliedSpec := ""
 	if ts.Annotations != nil {
-		lastAppliedSpec = ts.Annotations["glooscap.dasmlab.org/last-applied-spec"]
+		lastAppliedSpec = ts.Annotations[wikiv1alpha1.AnnotationLastAppliedSpec]
 	}
 	currentSpec := fmt.Sprintf("%s|%s|%v", ts.Spec.Address, ts.Spec.Type, ts.Spec.Secure)
+	var fallbacks []nanabush.Endpoint
+	for _, fb := range ts.Spec.Fallbacks {
+		fallbacks = append(fallbacks, nanabush.Endpoint{Address: fb.Address, Secure: fb.Secure})
+		currentSpec += fmt.Sprintf("|%s|%v", fb.Address, fb.Secure)
+	}
+
+	// Requested through POST /api/v1/translation-service/reconcile; cleared
+	// once this reconcile has saved the status the new client reports
+	if requested := ts.Annotations[wikiv1alpha1.AnnotationReconcileRequested]; requested != "" {
+		defer r.clearReconcileRequest(ctx, req.NamespacedName, requested)
+	}
 
 	specChanged := false
 	r.NanabushClientMu.RLock()
@@ -109,7 +155,10 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	// Check if spec has changed or client doesn't exist
 	// Only recreate if client doesn't exist OR spec actually changed (not just annotation missing)
-	if !hasClient || (!clientMatches && lastAppliedSpec != "" && lastAppliedSpec != currentSpec) {
+	if ts.Annotations[wikiv1alpha1.AnnotationReconcileRequested] != "" {
+		specChanged = true
+		logger.Info("TranslationService reconcile requested, recreating client", "address", ts.Spec.Address)
+	} else if !hasClient || (!clientMatches && lastAppliedSpec != "" && lastAppliedSpec != currentSpec) {
 		specChanged = true
 		logger.Info("TranslationService spec changed or client missing, recreating client",
 			"address", ts.Spec.Address,
@@ -158,14 +207,19 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 			// Capture req for the callback
 			reconcileReq := req
-			client, err := nanabush.NewClient(nanabush.Config{
-				Address:       ts.Spec.Address,
-				Secure:        ts.Spec.Secure,
-				Timeout:       30 * time.Second,
-				ClientName:    "glooscap",
-				ClientVersion: os.Getenv("OPERATOR_VERSION"),
-				Namespace:     namespace,
-				Metadata:      metadata,
+			client, err := nanabush.NewClientWithFailover(nanabush.Config{
+				Address:            ts.Spec.Address,
+				Secure:             ts.Spec.Secure,
+				Timeout:            30 * time.Second,
+				ServiceType:        ts.Spec.Type,
+				ClientName:         "glooscap",
+				ClientVersion:      os.Getenv("OPERATOR_VERSION"),
+				Namespace:          namespace,
+				Metadata:           metadata,
+				Heartbeat:          nanabush.HeartbeatConfigFromEnv(),
+				Circuit:            nanabush.CircuitConfigFromEnv(),
+				SupportedLanguages: ts.Spec.SupportedLanguages,
+				Fallbacks:          fallbacks,
 				OnStatusChange: func(status nanabush.Status) {
 					// Trigger SSE broadcast immediately
 					select {
@@ -190,6 +244,7 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 						statusCopy.Status = status.Status
 						statusCopy.MissedHeartbeats = status.MissedHeartbeats
 						statusCopy.HeartbeatIntervalSeconds = int(status.HeartbeatInterval)
+						statusCopy.ActiveEndpoint = status.ActiveEndpoint
 						if !status.LastHeartbeat.IsZero() {
 							lastHeartbeat := metav1.NewTime(status.LastHeartbeat)
 							statusCopy.LastHeartbeat = &lastHeartbeat
@@ -197,34 +252,8 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 							statusCopy.LastHeartbeat = nil
 						}
 						// Update conditions
-						now := metav1.Now()
-						if status.Connected && status.Registered {
-							meta.SetStatusCondition(&statusCopy.Conditions, metav1.Condition{
-								Type:               "Ready",
-								Status:             metav1.ConditionTrue,
-								Reason:             "Connected",
-								Message:            fmt.Sprintf("Connected and registered with client ID: %s", status.ClientID),
-								LastTransitionTime: now,
-							})
-						} else if status.Connected && !status.Registered {
-							meta.SetStatusCondition(&statusCopy.Conditions, metav1.Condition{
-								Type:               "Ready",
-								Status:             metav1.ConditionFalse,
-								Reason:             "Connecting",
-								Message:            "Connected but not yet registered",
-								LastTransitionTime: now,
-							})
-						} else {
-							meta.SetStatusCondition(&statusCopy.Conditions, metav1.Condition{
-								Type:               "Ready",
-								Status:             metav1.ConditionFalse,
-								Reason:             "Disconnected",
-								Message:            "Not connected to translation service",
-								LastTransitionTime: now,
-							})
-						}
-						tsCopy.Status = *statusCopy
-						if err := r.Status().Update(bgCtx, &tsCopy); err != nil {
+						setTranslationServiceReadyCondition(statusCopy, nowFrom(r.Clock))
+						if err := updateTranslationServiceStatus(bgCtx, r.Client, &tsCopy, *statusCopy); err != nil {
 							bgLogger.V(1).Info("Failed to update TranslationService status from callback", "error", err)
 						} else {
 							bgLogger.Info("TranslationService status updated from callback",
@@ -253,8 +282,7 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 				if !translationServiceStatusChanged(&ts.Status, status) {
 					return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 				}
-				ts.Status = *status
-				if err := r.Status().Update(ctx, &ts); err != nil {
+				if err := updateTranslationServiceStatus(ctx, r.Client, &ts, *status); err != nil {
 					return ctrl.Result{}, err
 				}
 				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
@@ -311,7 +339,7 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 					if tsCopy.Annotations == nil {
 						tsCopy.Annotations = make(map[string]string)
 					}
-					tsCopy.Annotations["glooscap.dasmlab.org/last-applied-spec"] = currentSpec
+					tsCopy.Annotations[wikiv1alpha1.AnnotationLastAppliedSpec] = currentSpec
 					if err := r.Update(bgCtx, &tsCopy); err != nil {
 						cancel()
 						if errors.IsConflict(err) && retry < 2 {
@@ -333,6 +361,8 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 	r.NanabushClientMu.RLock()
 	var clientStatus nanabush.Status
 	if *r.NanabushClient != nil {
+		// Language list changes don't need a new connection; refresh the cached list
+		(*r.NanabushClient).SetSupportedLanguages(ts.Spec.SupportedLanguages)
 		clientStatus = (*r.NanabushClient).Status()
 	} else {
 		clientStatus = nanabush.Status{
@@ -350,6 +380,7 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 	status.Status = clientStatus.Status
 	status.MissedHeartbeats = clientStatus.MissedHeartbeats
 	status.HeartbeatIntervalSeconds = int(clientStatus.HeartbeatInterval) // HeartbeatInterval is already int64 in seconds
+	status.ActiveEndpoint = clientStatus.ActiveEndpoint
 
 	if !clientStatus.LastHeartbeat.IsZero() {
 		lastHeartbeat := metav1.NewTime(clientStatus.LastHeartbeat)
@@ -358,8 +389,77 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 		status.LastHeartbeat = nil
 	}
 
-	// Update conditions
-	if status.Connected && status.Registered {
+	// Update conditions. This runs on every resync, so a client whose heartbeat
+	// goroutines have wedged is reported as stale instead of staying Ready.
+	wasStale := isHeartbeatStaleCondition(ts.Status.Conditions)
+	if setTranslationServiceReadyCondition(status, now) && !wasStale && r.Recorder != nil {
+		r.Recorder.Eventf(&ts, corev1.EventTypeWarning, "HeartbeatStale",
+			"No heartbeat from the translation service since %s", status.LastHeartbeat.Time.Format(time.RFC3339))
+	}
+
+	// Only update if status changed
+	if !translationServiceStatusChanged(&ts.Status, status) {
+		// Requeue periodically to update status from client
+		return ctrl.Result{RequeueAfter: translationServiceResyncInterval}, nil
+	}
+
+	if err := updateTranslationServiceStatus(ctx, r.Client, &ts, *status); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Trigger SSE broadcast on status update
+	select {
+	case r.NanabushStatusCh <- struct{}{}:
+	default:
+	}
+
+	logger.Info("TranslationService status updated",
+		"client_id", status.ClientID,
+		"connected", status.Connected,
+		"registered", status.Registered,
+		"status", status.Status)
+
+	return ctrl.Result{RequeueAfter: translationServiceResyncInterval}, nil
+}
+
+// clearReconcileRequest removes the reconcile-requested annotation, unless a
+// newer request has replaced the one this reconcile handled.
+func (r *TranslationServiceReconciler) clearReconcileRequest(ctx context.Context, key client.ObjectKey, handled string) {
+	logger := log.FromContext(ctx)
+	var ts wikiv1alpha1.TranslationService
+	if err := r.Get(ctx, key, &ts); err != nil {
+		logger.Error(err, "failed to get TranslationService to clear the reconcile request")
+		return
+	}
+	if ts.Annotations[wikiv1alpha1.AnnotationReconcileRequested] != handled {
+		return
+	}
+	patch := client.MergeFrom(ts.DeepCopy())
+	delete(ts.Annotations, wikiv1alpha1.AnnotationReconcileRequested)
+	if err := r.Patch(ctx, &ts, patch); err != nil {
+		logger.Error(err, "failed to clear the reconcile request")
+	}
+}
+
+// setTranslationServiceReadyCondition derives the Ready condition from the
+// client fields in status. A connected client whose last heartbeat is older
+// than heartbeatStaleAfter is marked not ready and its status set to "stale";
+// the return value reports whether that happened.
+func setTranslationServiceReadyCondition(status *wikiv1alpha1.TranslationServiceStatus, now metav1.Time) bool {
+	stale := false
+	switch {
+	case status.Connected && status.Registered && status.LastHeartbeat != nil &&
+		now.Sub(status.LastHeartbeat.Time) > heartbeatStaleAfter(status.HeartbeatIntervalSeconds):
+		stale = true
+		status.Status = "stale"
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			Reason:             "HeartbeatStale",
+			Message:            fmt.Sprintf("No heartbeat since %s", status.LastHeartbeat.Time.Format(time.RFC3339)),
+			LastTransitionTime: now,
+		})
+	case status.Connected && status.Registered:
 		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
 			Type:               "Ready",
 			Status:             metav1.ConditionTrue,
@@ -367,7 +467,7 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 			Message:            fmt.Sprintf("Connected and registered with client ID: %s", status.ClientID),
 			LastTransitionTime: now,
 		})
-	} else if status.Connected && !status.Registered {
+	case status.Connected:
 		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
 			Type:               "Ready",
 			Status:             metav1.ConditionFalse,
@@ -375,7 +475,7 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 			Message:            "Connected but not yet registered",
 			LastTransitionTime: now,
 		})
-	} else {
+	default:
 		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
 			Type:               "Ready",
 			Status:             metav1.ConditionFalse,
@@ -384,31 +484,78 @@ func (r *TranslationServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 			LastTransitionTime: now,
 		})
 	}
+	return stale
+}
 
-	// Only update if status changed
+// heartbeatStaleAfter is how old the last heartbeat may get before the status
+// is considered stale: heartbeatStaleIntervals heartbeats, but never less than
+// minHeartbeatStaleAfter.
+func heartbeatStaleAfter(intervalSeconds int) time.Duration {
+	staleAfter := time.Duration(intervalSeconds) * time.Second * heartbeatStaleIntervals
+	if staleAfter < minHeartbeatStaleAfter {
+		staleAfter = minHeartbeatStaleAfter
+	}
+	return staleAfter
+}
+
+func isHeartbeatStaleCondition(conditions []metav1.Condition) bool {
+	cond := meta.FindStatusCondition(conditions, "Ready")
+	return cond != nil && cond.Reason == "HeartbeatStale"
+}
+
+// reconcileIgnored marks a TranslationService that isn't the singleton as
+// ignored, so users see why it has no effect instead of a silent no-op.
+func (r *TranslationServiceReconciler) reconcileIgnored(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var ts wikiv1alpha1.TranslationService
+	if err := r.Get(ctx, req.NamespacedName, &ts); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !ts.DeletionTimestamp.IsZero() {
+		if controllerutil.RemoveFinalizer(&ts, translationServiceFinalizer) {
+			return ctrl.Result{}, r.Update(ctx, &ts)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	message := fmt.Sprintf("Only the TranslationService named %q is used; this instance is ignored", wikiv1alpha1.TranslationServiceName)
+	status := ts.Status.DeepCopy()
+	status.Status = "ignored"
+	status.Connected = false
+	status.Registered = false
+	status.ClientID = ""
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionFalse,
+		Reason:             "NotSingleton",
+		Message:            message,
+		LastTransitionTime: nowFrom(r.Clock),
+	})
 	if !translationServiceStatusChanged(&ts.Status, status) {
-		// Requeue periodically to update status from client
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		return ctrl.Result{}, nil
+	}
+	if r.Recorder != nil {
+		r.Recorder.Event(&ts, "Warning", "NotSingleton", message)
 	}
+	return ctrl.Result{}, updateTranslationServiceStatus(ctx, r.Client, &ts, *status)
+}
 
-	ts.Status = *status
-	if err := r.Status().Update(ctx, &ts); err != nil {
-		return ctrl.Result{}, err
+// closeClient closes and clears the shared translation service client, if any,
+// and notifies SSE subscribers. Close waits for heartbeat goroutines to exit.
+func (r *TranslationServiceReconciler) closeClient(logger logr.Logger) {
+	r.NanabushClientMu.Lock()
+	if *r.NanabushClient != nil {
+		if err := (*r.NanabushClient).Close(); err != nil {
+			logger.Error(err, "error closing translation service client")
+		}
+		*r.NanabushClient = nil
 	}
+	r.NanabushClientMu.Unlock()
 
-	// Trigger SSE broadcast on status update
+	// Trigger SSE broadcast
 	select {
 	case r.NanabushStatusCh <- struct{}{}:
 	default:
 	}
-
-	logger.Info("TranslationService status updated",
-		"client_id", status.ClientID,
-		"connected", status.Connected,
-		"registered", status.Registered,
-		"status", status.Status)
-
-	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 }
 
 func translationServiceStatusChanged(oldStatus *wikiv1alpha1.TranslationServiceStatus, newStatus *wikiv1alpha1.TranslationServiceStatus) bool {
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+)
+
+var _ = Describe("TranslationService Controller", func() {
+	Context("When deleting a resource", func() {
+		const resourceName = wikiv1alpha1.TranslationServiceName
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+		newReconciler := func() (*TranslationServiceReconciler, chan struct{}) {
+			var nanabushClient *nanabush.Client
+			statusCh := make(chan struct{}, 4)
+			return &TranslationServiceReconciler{
+				Client:           k8sClient,
+				Scheme:           k8sClient.Scheme(),
+				NanabushClientMu: &sync.RWMutex{},
+				NanabushClient:   &nanabushClient,
+				NanabushStatusCh: statusCh,
+			}, statusCh
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind TranslationService")
+			resource := &wikiv1alpha1.TranslationService{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			if err != nil && errors.IsNotFound(err) {
+				resource = &wikiv1alpha1.TranslationService{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+					Spec: wikiv1alpha1.TranslationServiceSpec{
+						Type: "iskoces",
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		It("should add a finalizer and release it once the client is closed", func() {
+			controllerReconciler, statusCh := newReconciler()
+
+			By("reconciling the created resource")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &wikiv1alpha1.TranslationService{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(controllerutil.ContainsFinalizer(resource, translationServiceFinalizer)).To(BeTrue())
+
+			By("deleting the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.DeletionTimestamp).NotTo(BeNil())
+
+			By("reconciling the deletion")
+			for len(statusCh) > 0 {
+				<-statusCh
+			}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*controllerReconciler.NanabushClient).To(BeNil())
+			Expect(statusCh).To(Receive())
+
+			err = k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When the heartbeat goes stale", func() {
+		It("should stop reporting a connected client as ready", func() {
+			now := metav1.Now()
+			recent := metav1.NewTime(now.Add(-20 * time.Second))
+			status := &wikiv1alpha1.TranslationServiceStatus{
+				Connected:                true,
+				Registered:               true,
+				Status:                   "healthy",
+				HeartbeatIntervalSeconds: 30,
+				LastHeartbeat:            &recent,
+			}
+			Expect(setTranslationServiceReadyCondition(status, now)).To(BeFalse())
+			Expect(meta.IsStatusConditionTrue(status.Conditions, "Ready")).To(BeTrue())
+
+			old := metav1.NewTime(now.Add(-2 * time.Minute))
+			status.LastHeartbeat = &old
+			Expect(setTranslationServiceReadyCondition(status, now)).To(BeTrue())
+			cond := meta.FindStatusCondition(status.Conditions, "Ready")
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("HeartbeatStale"))
+			Expect(status.Status).To(Equal("stale"))
+		})
+	})
+
+	Context("When gating readiness on the translation service", func() {
+		It("should wait for the client only while a TranslationService exists, up to the timeout", func() {
+			clk := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			noClient := func() *nanabush.Client { return nil }
+
+			empty := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).Build()
+			gate := &TranslationServiceGate{Reader: empty, GetNanabushClient: noClient, Timeout: time.Minute, Clock: clk}
+			Expect(gate.Check(req)).To(Succeed())
+
+			configured := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(&wikiv1alpha1.TranslationService{
+				ObjectMeta: metav1.ObjectMeta{Name: wikiv1alpha1.TranslationServiceName},
+			}).Build()
+			gate = &TranslationServiceGate{Reader: configured, GetNanabushClient: noClient, Timeout: time.Minute, Clock: clk}
+			Expect(gate.Check(req)).NotTo(Succeed())
+			clk.SetTime(clk.Now().Add(30 * time.Second))
+			Expect(gate.Check(req)).NotTo(Succeed())
+			clk.SetTime(clk.Now().Add(30 * time.Second))
+			Expect(gate.Check(req)).To(Succeed())
+			// Ready for good once passed
+			clk.SetTime(clk.Now().Add(time.Hour))
+			Expect(gate.Check(req)).To(Succeed())
+		})
+	})
+})
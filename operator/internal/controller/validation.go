@@ -0,0 +1,331 @@
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+	"github.com/dasmlab/glooscap-operator/pkg/langtag"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+)
+
+// ValidationSeverity says what a validation issue does to a job.
+type ValidationSeverity string
+
+const (
+	// ValidationError fails the job.
+	ValidationError ValidationSeverity = "error"
+	// ValidationBlocked holds the job in AwaitingApproval until it clears.
+	ValidationBlocked ValidationSeverity = "blocked"
+	// ValidationWarning is reported by the validate endpoint but doesn't stop the job.
+	ValidationWarning ValidationSeverity = "warning"
+)
+
+// ValidationIssue is one problem found while validating a TranslationJob.
+type ValidationIssue struct {
+	// Reason is the Ready condition reason the reconciler reports for the issue.
+	Reason   string             `json:"reason"`
+	Message  string             `json:"message"`
+	Severity ValidationSeverity `json:"severity"`
+}
+
+// JobValidator runs the checks a TranslationJob must pass before dispatch. The
+// reconciler and the API's validate endpoint share it so they can't diverge.
+type JobValidator struct {
+	Client    client.Client
+	Catalogue *catalog.Store
+	Jobs      *catalog.JobStore
+	// OutlineClient, when set, is used to check the destination collection's
+	// permissions before any inference is spent.
+	OutlineClient OutlineClientFactory
+	// GetNanabushClient returns the current translation service client, if any.
+	// When GetNanabushClient itself is nil the translation service checks are
+	// skipped.
+	GetNanabushClient func() *nanabush.Client
+	// PauseOnTokenBudget reports an exhausted namespace budget as a warning,
+	// since the reconciler then holds the job instead of failing it.
+	PauseOnTokenBudget bool
+}
+
+const (
+	translationServiceUnavailableReason  = "TranslationServiceUnavailable"
+	translationServiceUnavailableMessage = "No translation service is configured; create a TranslationService or configure one in the UI, then retry"
+	translationServiceWaitingMessage     = "Waiting for a translation service; create a TranslationService or configure one in the UI"
+)
+
+// validator returns a JobValidator backed by the reconciler's dependencies.
+func (r *TranslationJobReconciler) validator() *JobValidator {
+	v := &JobValidator{
+		Client:             r.Client,
+		Catalogue:          r.Catalogue,
+		Jobs:               r.Jobs,
+		OutlineClient:      r.OutlineClient,
+		PauseOnTokenBudget: r.PauseOnTokenBudget,
+	}
+	if r.translationServiceWired() {
+		v.GetNanabushClient = r.currentNanabushClient
+	}
+	return v
+}
+
+// Validate returns every issue found for job. An error is only returned when
+// the checks themselves couldn't run.
+func (v *JobValidator) Validate(ctx context.Context, job *wikiv1alpha1.TranslationJob) ([]ValidationIssue, error) {
+	var issues []ValidationIssue
+	add := func(severity ValidationSeverity, reason, format string, args ...any) {
+		issues = append(issues, ValidationIssue{Reason: reason, Message: fmt.Sprintf(format, args...), Severity: severity})
+	}
+	lang := languageTagForJob(job)
+	// A malformed tag would otherwise surface as a confusing error from the
+	// translation service, or slip past the same-language check
+	if canonical, err := langtag.Canonical(lang); err != nil {
+		add(ValidationError, "InvalidLanguageTag", "Invalid target language: %v", err)
+	} else {
+		lang = canonical
+	}
+
+	// Fail fast rather than ask the service for a language it doesn't support.
+	// Without a service the reconciler holds the job for a while before
+	// failing it, in case one is being set up or reconnected.
+	if v.GetNanabushClient != nil {
+		switch ns := v.GetNanabushClient(); {
+		case ns == nil:
+			add(ValidationWarning, translationServiceUnavailableReason, translationServiceWaitingMessage)
+		case !ns.SupportsLanguage(lang):
+			add(ValidationError, "UnsupportedLanguage", "Target language %q is not supported by the translation service", lang)
+		}
+	}
+
+	// Diagnostic jobs translate embedded test content, not a wiki page
+	if isDiagnosticJob(job) {
+		return issues, nil
+	}
+
+	var sourceTarget *wikiv1alpha1.WikiTarget
+	if job.Spec.Source.TargetRef == "" {
+		add(ValidationError, "SourceMissing", "Source TargetRef is required")
+	} else {
+		sourceTarget = &wikiv1alpha1.WikiTarget{}
+		if err := v.Client.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: job.Spec.Source.TargetRef}, sourceTarget); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, err
+			}
+			add(ValidationError, "TargetMissing", "WikiTarget %s not found", job.Spec.Source.TargetRef)
+			sourceTarget = nil
+		}
+	}
+
+	// The collection a translation is created in; see sourceCollectionID
+	var publishCollectionID string
+	if sourceTarget != nil {
+		var page *catalog.Page
+		var pages []*catalog.Page
+		if v.Catalogue != nil {
+			pages = v.Catalogue.List(fmt.Sprintf("%s/%s", sourceTarget.Namespace, sourceTarget.Name))
+			for i := range pages {
+				if pages[i].ID == job.Spec.Source.PageID {
+					page = pages[i]
+					break
+				}
+			}
+		}
+
+		publishCollectionID = sourceCollectionID(ctx, job, sourceTarget, page, nil)
+
+		// Only compare languages that are actually known, not the display
+		// default the catalogue gives pages without one
+		sourceLang := ""
+		if page != nil {
+			sourceLang = page.DetectedLanguage
+			if sourceLang == "" {
+				sourceLang = sourceTarget.Spec.DefaultSourceLanguage
+			}
+		}
+
+		switch {
+		case page != nil && page.IsTemplate:
+			add(ValidationError, "TemplateRejected", "Page is a template and cannot be translated")
+		case sameLanguage(sourceLang, lang):
+			add(ValidationError, "SameLanguage", "Source page is already in %s; choose a different target language", sourceLang)
+		case page == nil && len(pages) > 0:
+			// The catalogue may lag behind the wiki, so this doesn't stop the job
+			add(ValidationWarning, "PageNotInCatalogue", "Page %s is not in the catalogue of %s; it may have been deleted or not discovered yet", job.Spec.Source.PageID, sourceTarget.Name)
+		}
+
+		// Refuse to translate glooscap's own output (translations of translations)
+		if job.Annotations[wikiv1alpha1.AnnotationAllowTranslatedSource] != "true" {
+			if reason := v.translatedOutputReason(ctx, job, page); reason != "" {
+				add(ValidationError, "AlreadyTranslatedOutput", "Source page is already a translation output (%s); set annotation %s=true to override", reason, wikiv1alpha1.AnnotationAllowTranslatedSource)
+			}
+		}
+	}
+
+	// Don't start new work once the namespace has spent its token budget
+	if v.Jobs != nil {
+		if usage := v.Jobs.NamespaceTokens(job.Namespace); usage.Exceeded {
+			if v.PauseOnTokenBudget {
+				add(ValidationWarning, BudgetExceededReason, "Namespace token budget exhausted (%d/%d tokens); the job will wait in Queued until there is budget again", usage.Used, usage.Budget)
+			} else {
+				add(ValidationError, BudgetExceededReason, "Namespace token budget exhausted (%d/%d tokens)", usage.Used, usage.Budget)
+			}
+		}
+	}
+
+	destTargetRef := job.Spec.Source.TargetRef
+	if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
+		destTargetRef = job.Spec.Destination.TargetRef
+	}
+	if destTargetRef != "" {
+		var destTarget wikiv1alpha1.WikiTarget
+		if err := v.Client.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: destTargetRef}, &destTarget); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, err
+			}
+			add(ValidationError, "DestinationMissing", "Destination WikiTarget %s not found", destTargetRef)
+		} else if destTarget.Spec.Mode == wikiv1alpha1.WikiTargetModeReadOnly {
+			add(ValidationError, "DestinationReadOnly", "Destination WikiTarget is read-only and cannot accept translations")
+		} else {
+			if collection := v.unwritableCollection(ctx, &destTarget, publishCollectionID); collection != "" {
+				add(ValidationError, "CollectionNotWritable", "The token of WikiTarget %s cannot create pages in collection %s", destTargetRef, collection)
+			}
+			if job.Spec.Destination != nil && job.Spec.Destination.ParentDocument != "" {
+				if reason, err := v.parentDocumentIssue(ctx, &destTarget, job.Spec.Destination.ParentDocument, publishCollectionID); reason != "" {
+					add(ValidationError, reason, "Parent document on WikiTarget %s: %v", destTargetRef, err)
+				}
+			}
+		}
+	}
+
+	// Only one job at a time may translate a page into a language, otherwise
+	// both publish near-identical pages. Jobs that supersede older ones have
+	// already marked them for cancellation.
+	if !job.Spec.SupersedeOlderJobs &&
+		job.Annotations[wikiv1alpha1.AnnotationPublishJob] != "true" &&
+		job.Annotations[wikiv1alpha1.AnnotationDuplicateApproved] != "true" {
+		duplicate, err := v.activeDuplicateJob(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+		if duplicate != nil {
+			add(ValidationBlocked, duplicateInProgressReason, "Waiting for TranslationJob %s to finish; set annotation %s=true to run anyway", duplicate.Name, wikiv1alpha1.AnnotationDuplicateApproved)
+		}
+	}
+
+	return issues, nil
+}
+
+// unwritableCollection returns the name of collection collectionID, where the
+// job's translation is created, when Outline confirms target's token can't
+// create pages in it, and "" otherwise. The check is skipped when it can't be
+// made, since publishing reports the failure anyway.
+func (v *JobValidator) unwritableCollection(ctx context.Context, target *wikiv1alpha1.WikiTarget, collectionID string) string {
+	if v.OutlineClient == nil || collectionID == "" {
+		return ""
+	}
+	logger := log.FromContext(ctx)
+	outlineClient, err := v.OutlineClient.New(ctx, v.Client, target)
+	if err != nil {
+		logger.V(1).Info("skipping collection permission check", "target", target.Name, "error", err.Error())
+		return ""
+	}
+	info, err := outlineClient.CollectionInfo(ctx, collectionID)
+	if err != nil {
+		var statusErr *outline.StatusError
+		if stderrors.As(err, &statusErr) && statusErr.StatusCode == http.StatusForbidden {
+			return collectionLabel(target, collectionID)
+		}
+		logger.V(1).Info("skipping collection permission check", "target", target.Name, "error", err.Error())
+		return ""
+	}
+	if info.Writable() {
+		return ""
+	}
+	if info.Name != "" {
+		return info.Name
+	}
+	return collectionLabel(target, collectionID)
+}
+
+// parentDocumentIssue returns a reason, and the lookup error, when ref names no
+// document on target or a title several documents share. Titles are looked up
+// in collectionID, the collection publishing resolves them in. Like the
+// collection check, it is skipped when the lookup can't be made.
+func (v *JobValidator) parentDocumentIssue(ctx context.Context, target *wikiv1alpha1.WikiTarget, ref, collectionID string) (string, error) {
+	if v.OutlineClient == nil {
+		return "", nil
+	}
+	logger := log.FromContext(ctx)
+	outlineClient, err := v.OutlineClient.New(ctx, v.Client, target)
+	if err != nil {
+		logger.V(1).Info("skipping parent document check", "target", target.Name, "error", err.Error())
+		return "", nil
+	}
+	_, err = outlineClient.ResolveDocument(ctx, ref, collectionID)
+	switch {
+	case err == nil:
+		return "", nil
+	case stderrors.Is(err, outline.ErrDocumentNotFound):
+		return "ParentDocumentNotFound", err
+	case stderrors.Is(err, outline.ErrAmbiguousDocument):
+		return "ParentDocumentAmbiguous", err
+	}
+	logger.V(1).Info("skipping parent document check", "target", target.Name, "error", err.Error())
+	return "", nil
+}
+
+// collectionLabel names collection collectionID for messages, by the name
+// target cached for it when there is one.
+func collectionLabel(target *wikiv1alpha1.WikiTarget, collectionID string) string {
+	if collectionID == target.Status.CollectionID && target.Status.CollectionName != "" {
+		return target.Status.CollectionName
+	}
+	return collectionID
+}
+
+// FirstIssue returns the first issue with the given severity, or nil.
+func FirstIssue(issues []ValidationIssue, severity ValidationSeverity) *ValidationIssue {
+	for i := range issues {
+		if issues[i].Severity == severity {
+			return &issues[i]
+		}
+	}
+	return nil
+}
+
+// translatedOutputReason returns a non-empty explanation when the job's source
+// page is itself a translation: either its title carries the translated-page
+// prefix, or another TranslationJob records it as its published page.
+func (v *JobValidator) translatedOutputReason(ctx context.Context, job *wikiv1alpha1.TranslationJob, page *catalog.Page) string {
+	pageID := job.Spec.Source.PageID
+
+	if page != nil && strings.HasPrefix(page.Title, translatedTitlePrefix) {
+		return fmt.Sprintf("title %q has the %s prefix", page.Title, translatedTitlePrefix)
+	}
+
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := v.Client.List(ctx, &jobs, client.InNamespace(job.Namespace)); err != nil {
+		log.FromContext(ctx).V(1).Info("failed to list jobs for provenance check", "error", err.Error())
+		return ""
+	}
+	for _, other := range jobs.Items {
+		if other.Name != job.Name && other.Annotations[wikiv1alpha1.AnnotationPublishedPageID] == pageID {
+			return fmt.Sprintf("page was produced by TranslationJob %s", other.Name)
+		}
+	}
+	return ""
+}
+
+// sameLanguage reports whether two language tags share their base language,
+// e.g. "FR" and "fr-CA".
+func sameLanguage(a, b string) bool {
+	return langtag.SameLanguage(a, b)
+}
@@ -18,10 +18,14 @@ package controller
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"path"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/api/meta"
@@ -29,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -41,8 +46,17 @@ import (
 const (
 	// DefaultRefreshInterval is the default time between catalog refreshes
 	DefaultRefreshInterval = 15 * time.Second
+	// MaxDiscoveryBackoff caps the retry delay for targets whose discovery keeps failing
+	MaxDiscoveryBackoff = 5 * time.Minute
 	// SSEBroadcastInterval is how often to send cached data over SSE (independent of refresh)
 	SSEBroadcastInterval = 30 * time.Second
+
+	// CollectionNotFoundReason marks a target whose collectionFilter matches
+	// no collection in the wiki
+	CollectionNotFoundReason = "CollectionNotFound"
+	// TLSVerificationFailedReason marks a target whose certificate failed
+	// verification when skipping it was turned off on purpose
+	TLSVerificationFailedReason = "TLSVerificationFailed"
 )
 
 // WikiTargetReconciler reconciles a WikiTarget object
@@ -53,13 +67,29 @@ type WikiTargetReconciler struct {
 	Recorder record.EventRecorder
 
 	Catalogue     *catalog.Store
+	Jobs          *catalog.JobStore
 	OutlineClient OutlineClientFactory
+
+	// Pipeline is the pipeline auto-translation jobs run in, the one the
+	// operator dispatches to. Empty leaves it to the TranslationJob default.
+	Pipeline wikiv1alpha1.TranslationPipelineMode
+
+	// Clock times refreshes and discovery backoff. Nil uses the real clock.
+	Clock clock.PassiveClock
+
+	// pendingAutoTranslate holds changed pages deferred by the auto-translate
+	// caps, keyed by target ID then page ID.
+	autoTranslateMu      sync.Mutex
+	pendingAutoTranslate map[string]map[string]outline.PageSummary
+	// autoTranslateLimiters rate-limits auto-translation job creation per target ID.
+	autoTranslateLimiters map[string]*rate.Limiter
 }
 
 // +kubebuilder:rbac:groups=wiki.glooscap.dasmlab.org,resources=wikitargets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=wiki.glooscap.dasmlab.org,resources=wikitargets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=wiki.glooscap.dasmlab.org,resources=wikitargets/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -76,28 +106,36 @@ func (r *WikiTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	var target wikiv1alpha1.WikiTarget
 	if err := r.Get(ctx, req.NamespacedName, &target); err != nil {
 		if errors.IsNotFound(err) {
+			r.forgetTarget(ctx, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
 	status := target.Status.DeepCopy()
-	now := metav1.Now()
-
-	// Ensure InsecureSkipTLSVerify is set to true by default (for now, to handle self-signed certs)
-	// Update if it's false (default for bool is false, so this catches unset values)
-	if !target.Spec.InsecureSkipTLSVerify {
-		logger.Info("Setting InsecureSkipTLSVerify=true for WikiTarget (default for self-signed certs)")
-		target.Spec.InsecureSkipTLSVerify = true
+	now := nowFrom(r.Clock)
+
+	// Default InsecureSkipTLSVerify to true (for now, to handle self-signed
+	// certs) once per target. The annotation records that it was done, so a
+	// target explicitly set to verify isn't flipped back on every reconcile.
+	// Targets with a CA bundle are verified properly and are left alone.
+	if _, defaulted := target.Annotations[wikiv1alpha1.AnnotationTLSDefaulted]; !defaulted && target.Spec.CABundleRef == nil {
+		if !target.Spec.InsecureSkipTLSVerify {
+			logger.Info("Setting InsecureSkipTLSVerify=true for WikiTarget (default for self-signed certs)")
+			target.Spec.InsecureSkipTLSVerify = true
+		}
+		if target.Annotations == nil {
+			target.Annotations = map[string]string{}
+		}
+		target.Annotations[wikiv1alpha1.AnnotationTLSDefaulted] = "true"
 		if err := r.Update(ctx, &target); err != nil {
-			logger.Error(err, "failed to update WikiTarget with InsecureSkipTLSVerify=true")
+			logger.Error(err, "failed to record the InsecureSkipTLSVerify default")
 			// Continue anyway - will try again next reconcile
 		} else {
-			logger.Info("Updated WikiTarget with InsecureSkipTLSVerify=true")
-			// Re-fetch to get updated version
-			if err := r.Get(ctx, req.NamespacedName, &target); err != nil {
-				return ctrl.Result{}, err
-			}
+			// Update refreshed target from the API server. Re-reading it from the
+			// informer cache here could return the stale spec and run the first
+			// discovery without the TLS override.
+			logger.Info("Recorded InsecureSkipTLSVerify default", "insecureSkipTLSVerify", target.Spec.InsecureSkipTLSVerify)
 		}
 	}
 
@@ -116,8 +154,7 @@ func (r *WikiTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if !statusChanged(&target.Status, status) {
 			return ctrl.Result{RequeueAfter: DefaultRefreshInterval}, nil
 		}
-		target.Status = *status
-		if err := r.Status().Update(ctx, &target); err != nil {
+		if err := updateWikiTargetStatus(ctx, r.Client, &target, *status); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{RequeueAfter: DefaultRefreshInterval}, nil
@@ -130,11 +167,11 @@ func (r *WikiTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Check for force-refresh annotation
 	if target.Annotations != nil {
-		if _, hasForceRefresh := target.Annotations["glooscap.dasmlab.org/force-refresh"]; hasForceRefresh {
+		if _, hasForceRefresh := target.Annotations[wikiv1alpha1.AnnotationForceRefresh]; hasForceRefresh {
 			shouldRefresh = true
 			refreshReason = "force refresh requested"
 			// Remove the annotation after processing
-			delete(target.Annotations, "glooscap.dasmlab.org/force-refresh")
+			delete(target.Annotations, wikiv1alpha1.AnnotationForceRefresh)
 			if err := r.Update(ctx, &target); err != nil {
 				logger.Error(err, "failed to remove force-refresh annotation")
 				return ctrl.Result{}, err
@@ -145,28 +182,12 @@ func (r *WikiTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	if !shouldRefresh {
-		if !status.Ready || status.LastSyncTime == nil {
-			// First discovery - always refresh
-			shouldRefresh = true
-			refreshReason = "initial discovery"
-		} else if status.Ready {
-			// Check if we've been ready for more than 15 seconds
-			timeSinceLastSync := now.Time.Sub(status.LastSyncTime.Time)
-			if timeSinceLastSync >= DefaultRefreshInterval {
-				shouldRefresh = true
-				refreshReason = "periodic refresh"
-			}
-		}
-	}
-
-	if !shouldRefresh {
-		// Not time to refresh yet, requeue for the remaining time
-		timeSinceLastSync := now.Time.Sub(status.LastSyncTime.Time)
-		requeueAfter := DefaultRefreshInterval - timeSinceLastSync
-		if requeueAfter < time.Second {
-			requeueAfter = time.Second
+		reason, wait := discoveryDue(status, now.Time)
+		if reason == "" {
+			// Not time to refresh yet, requeue for the remaining time
+			return ctrl.Result{RequeueAfter: wait}, nil
 		}
-		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		refreshReason = reason
 	}
 
 	// Set status to "Refreshing Catalog" if we were previously Ready
@@ -194,35 +215,100 @@ func (r *WikiTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	logger.Info("refreshing catalogue", "reason", refreshReason)
 
-	if err := r.refreshCatalogue(ctx, &target, status); err != nil {
-		logger.Error(err, "failed to refresh catalogue", "uri", target.Spec.URI)
+	requeueAfter := DefaultRefreshInterval
+	refreshErr := r.refreshCatalogue(ctx, &target, status)
+	if refreshErr != nil {
+		status.ConsecutiveFailures++
+		status.LastFailureTime = &now
+		requeueAfter = discoveryBackoff(status.ConsecutiveFailures)
+		logger.Error(refreshErr, "failed to refresh catalogue", "uri", target.Spec.URI,
+			"consecutiveFailures", status.ConsecutiveFailures, "retryAfter", requeueAfter)
 		status.Ready = false
+		reason := "DiscoveryFailed"
+		var discErr *discoveryError
+		if stderrors.As(refreshErr, &discErr) {
+			reason = discErr.reason
+		}
 		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
 			Type:               "Ready",
 			Status:             metav1.ConditionFalse,
-			Reason:             "DiscoveryFailed",
-			Message:            err.Error(),
+			Reason:             reason,
+			Message:            refreshErr.Error(),
 			LastTransitionTime: now,
 		})
 	} else {
 		status.Ready = true
 		status.LastSyncTime = &now
+		status.ConsecutiveFailures = 0
+		status.LastFailureTime = nil
 		logger.Info("successfully refreshed catalogue", "uri", target.Spec.URI, "pages", status.CatalogRevision)
 	}
 
 	if !statusChanged(&target.Status, status) {
-		return ctrl.Result{RequeueAfter: DefaultRefreshInterval}, nil
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
-	target.Status = *status
-	if err := r.Status().Update(ctx, &target); err != nil {
+	if err := updateWikiTargetStatus(ctx, r.Client, &target, *status); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if refreshErr != nil {
+		if r.Recorder != nil {
+			r.Recorder.Eventf(&target, "Warning", "DiscoveryFailed", "Discovery failed %d time(s) in a row, retrying in %s", status.ConsecutiveFailures, requeueAfter)
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	r.Recorder.Event(&target, "Normal", "DiscoverySync", "WikiTarget discovery refreshed")
 	logger.Info("refreshed WikiTarget status")
 
-	return ctrl.Result{RequeueAfter: DefaultRefreshInterval}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// discoveryError is a discovery failure with its own Ready condition reason.
+type discoveryError struct {
+	reason string
+	err    error
+}
+
+func (e *discoveryError) Error() string { return e.err.Error() }
+
+func (e *discoveryError) Unwrap() error { return e.err }
+
+// discoveryBackoff returns the retry delay after failures consecutive failed
+// discovery runs: the refresh interval, doubled per failure, capped at
+// MaxDiscoveryBackoff.
+func discoveryBackoff(failures int32) time.Duration {
+	backoff := DefaultRefreshInterval
+	for i := int32(1); i < failures; i++ {
+		backoff *= 2
+		if backoff >= MaxDiscoveryBackoff {
+			return MaxDiscoveryBackoff
+		}
+	}
+	return backoff
+}
+
+// forgetTarget drops what the operator keeps in memory for a deleted
+// WikiTarget: its cached Outline client, its catalogue pages, the JobStore
+// entries of jobs translating them and its auto-translation queue. Removing
+// the pages notifies the SSE stream, so the UI drops the target too.
+func (r *WikiTargetReconciler) forgetTarget(ctx context.Context, key types.NamespacedName) {
+	if evictor, ok := r.OutlineClient.(interface{ Evict(namespace, name string) }); ok {
+		evictor.Evict(key.Namespace, key.Name)
+	}
+	targetID := fmt.Sprintf("%s/%s", key.Namespace, key.Name)
+	r.forgetAutoTranslate(targetID)
+	removed, droppedJobs := false, 0
+	if r.Catalogue != nil {
+		removed = r.Catalogue.DeleteTarget(targetID)
+	}
+	if r.Jobs != nil {
+		droppedJobs = r.Jobs.DeleteTarget(key.Namespace, key.Name)
+	}
+	if removed || droppedJobs > 0 {
+		log.FromContext(ctx).Info("dropped deleted WikiTarget from the catalogue", "wikitarget", targetID, "jobs", droppedJobs)
+	}
 }
 
 func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wikiv1alpha1.WikiTarget, status *wikiv1alpha1.WikiTargetStatus) error {
@@ -238,90 +324,70 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 		logger.Error(err, "failed to create outline client")
 		return fmt.Errorf("create outline client: %w", err)
 	}
+	if server := client.Server(); server.Version != status.OutlineVersion {
+		logger.Info("outline version detected", "version", server.Version,
+			"templateFlag", server.TemplateFlag, "publishOnCreate", server.PublishOnCreate)
+		status.OutlineVersion = server.Version
+	}
 
 	logger.Info("fetching pages from outline", "uri", target.Spec.URI, "InsecureSkipTLSVerify", target.Spec.InsecureSkipTLSVerify)
 	
-	// MVP: Prefer "Maurice (PDG)" or "Maurice PDG Collection" if it exists, otherwise get all pages
+	// Constrain discovery to the collection spec.collectionFilter names, if any
 	var pages []outline.PageSummary
 	var collectionID string
 	var collectionName string
-	
-	// Use cached collectionID from status if available (avoids re-searching every time)
-	if status.CollectionID != "" {
+
+	// Use the cached collection while it still matches the filter (avoids re-searching every time)
+	if status.CollectionID != "" && matchesCollectionFilter(target.Spec.CollectionFilter, status.CollectionName) {
 		collectionID = status.CollectionID
 		collectionName = status.CollectionName
 		logger.Info("Using cached collection ID", "collectionID", collectionID, "collectionName", collectionName)
 	} else {
-		// Target collection names to search for (variations)
-		// Note: Wiki shows "Maurice (PGD)" - prioritize this exact match
-		targetNames := []string{
-			"Maurice (PGD)",           // Actual name in wiki (PGD)
-			"Maurice (PDG)",           // Alternative spelling (PDG)
-			"Maurice PGD Collection",  // With Collection suffix (PGD)
-			"Maurice PDG Collection",  // Alternative spelling with Collection suffix (PDG)
-			"Maurice PGD",             // Without parentheses or Collection (PGD)
-			"Maurice PDG",             // Alternative spelling without suffix (PDG)
-		}
-		
-		// Helper function to normalize collection names for comparison
-		// This handles variations in parentheses, "Collection" suffix, and case
-		normalizeCollectionName := func(name string) string {
-			// Remove common suffixes
-			name = strings.TrimSuffix(name, " Collection")
-			name = strings.TrimSuffix(name, " collection")
-			// Remove parentheses (but keep the text inside)
-			name = strings.ReplaceAll(name, "(", "")
-			name = strings.ReplaceAll(name, ")", "")
-			// Normalize whitespace and convert to lowercase
-			name = strings.ToLower(strings.TrimSpace(name))
-			return name
-		}
-		
-		// First, try to find the target collection by fuzzy matching
-	collections, collErr := client.ListCollections(ctx)
-	if collErr == nil {
-		for _, coll := range collections {
-				normalizedCollName := normalizeCollectionName(coll.Name)
-				// Check if this collection matches any of our target names
-				for _, targetName := range targetNames {
-					normalizedTarget := normalizeCollectionName(targetName)
-					if normalizedCollName == normalizedTarget {
-				collectionID = coll.ID
-						collectionName = coll.Name
-						logger.Info("Found matching collection, constraining search", 
-							"collectionName", coll.Name, 
-							"collectionID", collectionID,
-							"matchedTarget", targetName)
-						// Store in status for future use
-						status.CollectionID = collectionID
-						status.CollectionName = collectionName
-						break
-					}
+		status.CollectionID = ""
+		status.CollectionName = ""
+		if len(target.Spec.CollectionFilter) > 0 {
+			// Listing the whole wiki would catalogue pages the filter excludes,
+			// so discovery fails until the collection can be found
+			collections, collErr := client.ListCollections(ctx)
+			if collErr != nil {
+				return fmt.Errorf("list collections for the collection filter: %w", collErr)
+			}
+			coll, entry, ok := findFilteredCollection(target.Spec.CollectionFilter, collections)
+			if !ok {
+				return &discoveryError{
+					reason: CollectionNotFoundReason,
+					err:    fmt.Errorf("no collection matches collectionFilter %q", target.Spec.CollectionFilter),
 				}
-				if collectionID != "" {
-				break
 			}
-		}
-	} else {
-		logger.Info("Failed to list collections, will search all pages", "error", collErr)
+			collectionID = coll.ID
+			collectionName = coll.Name
+			logger.Info("Found matching collection, constraining search",
+				"collectionName", coll.Name,
+				"collectionID", collectionID,
+				"matchedFilter", entry)
+			// Store in status for future use
+			status.CollectionID = collectionID
+			status.CollectionName = collectionName
 		}
 	}
-	
+
+	// Drafts are excluded from the catalogue unless the target opts in
+	listOpts := outline.ListPagesOptions{
+		CollectionID:  collectionID,
+		IncludeDrafts: target.Spec.IncludeDrafts,
+	}
+
 	// Fetch pages (with collection filter if found)
-	// CRITICAL: If we have a collectionID (either cached or just discovered), ALWAYS use it
-	// Never fetch all pages if we have a collection constraint
+	// CRITICAL: If we have a collectionID (either cached or just discovered), listOpts
+	// ALWAYS carries it; never fetch all pages if we have a collection constraint
 	if collectionID != "" {
-		pages, err = client.ListPages(ctx, collectionID)
 		logger.Info("Fetching pages from specific collection", "collectionID", collectionID, "collectionName", collectionName)
-		if err != nil {
-			logger.Error(err, "failed to fetch pages from collection, will retry", "collectionID", collectionID)
-		}
 	} else {
-		logger.Info("Target collection not found, fetching all pages (this should only happen on initial discovery)")
-		pages, err = client.ListPages(ctx)
-		if err == nil && len(pages) > 0 {
-			logger.Info("fetched all pages without collection constraint", "pageCount", len(pages), "warning", "this should be constrained to collection once discovered")
-		}
+		logger.Info("Fetching pages from all collections")
+	}
+	pages, err = client.ListPagesWithOptions(ctx, listOpts)
+	if err != nil && collectionID != "" {
+		logger.Error(err, "failed to fetch pages from collection, will retry", "collectionID", collectionID)
 	}
 	if err != nil {
 		// Check if this is a TLS certificate error and we haven't enabled skip verification yet
@@ -338,6 +404,15 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 		
 		logger.Info("ListPages error detected", "error", errStr, "isCertError", isCertError, "InsecureSkipTLSVerify", target.Spec.InsecureSkipTLSVerify)
 		
+		// Verification was chosen once the default was applied or a CA bundle
+		// given; don't turn it off behind the owner's back
+		_, tlsDefaulted := target.Annotations[wikiv1alpha1.AnnotationTLSDefaulted]
+		if isCertError && !target.Spec.InsecureSkipTLSVerify && (tlsDefaulted || target.Spec.CABundleRef != nil) {
+			return &discoveryError{
+				reason: TLSVerificationFailedReason,
+				err:    fmt.Errorf("list pages: %w (set spec.caBundleRef to the wiki's CA, or spec.insecureSkipTLSVerify to skip verification)", err),
+			}
+		}
 		if isCertError && !target.Spec.InsecureSkipTLSVerify {
 			logger.Info("TLS certificate error detected, automatically enabling InsecureSkipTLSVerify and retrying",
 				"error", errStr)
@@ -370,12 +445,8 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 			}
 			
 			logger.Info("Retrying ListPages with TLS skip verification enabled", "collectionID", collectionID)
-			// Retry ListPages - preserve collection constraint if we have one
-			if collectionID != "" {
-				pages, retryErr = client.ListPages(ctx, collectionID)
-			} else {
-			pages, retryErr = client.ListPages(ctx)
-			}
+			// Retry ListPages - listOpts preserves the collection constraint if we have one
+			pages, retryErr = client.ListPagesWithOptions(ctx, listOpts)
 			if retryErr != nil {
 				logger.Error(retryErr, "failed to list pages from outline even with TLS skip enabled")
 				return fmt.Errorf("list pages (with TLS skip): %w", retryErr)
@@ -393,6 +464,18 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 	}
 	logger.Info("fetched pages from outline", "count", len(pages))
 
+	// Resolve the document hierarchy so catalog pages know their parent.
+	// documents.list doesn't reliably expose nesting, so ask the collection directly.
+	var parentIndex map[string]string
+	if collectionID != "" {
+		tree, treeErr := client.GetCollectionStructure(ctx, collectionID)
+		if treeErr != nil {
+			logger.Info("failed to fetch collection structure, pages will have no parent", "collectionID", collectionID, "error", treeErr.Error())
+		} else {
+			parentIndex = outline.ParentIndex(tree)
+		}
+	}
+
 	if r.Catalogue != nil {
 		targetID := fmt.Sprintf("%s/%s", target.Namespace, target.Name)
 		baseURI := strings.TrimSuffix(target.Spec.URI, "/")
@@ -407,15 +490,22 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 
 		// Track if there are any changes
 		hasChanges := false
+		// Pages whose content changed since the last refresh (candidates for auto-translation).
+		// New pages only count once the catalogue has been populated, so the initial
+		// discovery doesn't translate the whole wiki.
+		var changedPages []outline.PageSummary
 		newPageCount := 0
 		updatedPageCount := 0
 
 		for i, page := range pages {
 			// Build full URI for the page
-			pageURI := fmt.Sprintf("%s/doc/%s", baseURI, page.Slug)
+			pageURI := outline.DocumentURL(baseURI, page.Title, page.Slug)
 
-			// Default language to EN if not provided by Outline
+			// Default to the target's source language, or EN, if not provided by Outline
 			language := page.Language
+			if language == "" {
+				language = target.Spec.DefaultSourceLanguage
+			}
 			if language == "" {
 				language = "EN"
 			}
@@ -424,6 +514,9 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 			if existingPage, exists := existingPagesByID[page.ID]; exists {
 				// Page exists - check if it was updated
 				if !existingPage.UpdatedAt.Equal(page.UpdatedAt) {
+					changedPages = append(changedPages, page)
+				}
+				if !existingPage.UpdatedAt.Equal(page.UpdatedAt) || existingPage.ParentID != parentIndex[page.ID] {
 					hasChanges = true
 					updatedPageCount++
 					logger.V(1).Info("page updated",
@@ -437,6 +530,9 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 				// New page
 				hasChanges = true
 				newPageCount++
+				if len(existingPages) > 0 {
+					changedPages = append(changedPages, page)
+				}
 				logger.Info("discovered new page",
 					"index", i+1,
 					"title", page.Title,
@@ -448,16 +544,19 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 			}
 
 			catalogPages = append(catalogPages, catalog.Page{
-				ID:         page.ID,
-				Title:      page.Title,
-				Slug:       page.Slug,
-				URI:        pageURI,
-				UpdatedAt:  page.UpdatedAt,
-				Language:   language,
-				HasAssets:  page.HasAssets,
-				Collection: page.Collection,
-				Template:   page.Template,
-				IsTemplate: page.IsTemplate,
+				ID:               page.ID,
+				Title:            page.Title,
+				Slug:             page.Slug,
+				URI:              pageURI,
+				UpdatedAt:        page.UpdatedAt,
+				Language:         language,
+				HasAssets:        page.HasAssets,
+				Collection:       page.Collection,
+				Template:         page.Template,
+				IsTemplate:       page.IsTemplate,
+				ParentID:         parentIndex[page.ID],
+				IsDraft:          page.IsDraft,
+				DetectedLanguage: page.Language,
 			})
 		}
 
@@ -481,6 +580,10 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 			}
 		}
 
+		status.LastSyncAdded = int32(newPageCount)
+		status.LastSyncUpdated = int32(updatedPageCount)
+		status.LastSyncDeleted = int32(deletedPageCount)
+
 		// Only update catalogue if there are actual changes
 		if hasChanges {
 			logger.Info("catalogue changes detected, updating cache",
@@ -499,6 +602,8 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 		} else {
 			logger.V(1).Info("no catalogue changes detected, skipping update", "totalPages", len(catalogPages))
 		}
+
+		r.autoTranslate(ctx, target, client, changedPages)
 	}
 
 	status.CatalogRevision++
@@ -507,11 +612,80 @@ func (r *WikiTargetReconciler) refreshCatalogue(ctx context.Context, target *wik
 		Status:             metav1.ConditionTrue,
 		Reason:             "DiscoverySucceeded",
 		Message:            fmt.Sprintf("Discovered %d pages", len(pages)),
-		LastTransitionTime: metav1.Now(),
+		LastTransitionTime: nowFrom(r.Clock),
 	})
 	return nil
 }
 
+// normalizeCollectionName folds the variations people write a collection
+// name in: case, parentheses and a trailing " Collection".
+func normalizeCollectionName(name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) > len(" collection") && strings.EqualFold(name[len(name)-len(" collection"):], " collection") {
+		name = name[:len(name)-len(" collection")]
+	}
+	name = strings.ReplaceAll(name, "(", "")
+	name = strings.ReplaceAll(name, ")", "")
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// collectionFilterMatches reports whether the collection filter entry
+// matches the collection called name.
+func collectionFilterMatches(entry, name string) bool {
+	if strings.ContainsAny(entry, "*?[") {
+		matched, err := path.Match(strings.ToLower(entry), strings.ToLower(name))
+		return err == nil && matched
+	}
+	return normalizeCollectionName(entry) == normalizeCollectionName(name)
+}
+
+// matchesCollectionFilter reports whether any filter entry matches the
+// collection called name. An empty filter matches nothing.
+func matchesCollectionFilter(filter []string, name string) bool {
+	for _, entry := range filter {
+		if collectionFilterMatches(entry, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// findFilteredCollection returns the collection the filter selects: the
+// first matched by the earliest entry that matches any.
+func findFilteredCollection(filter []string, collections []outline.Collection) (outline.Collection, string, bool) {
+	for _, entry := range filter {
+		for _, coll := range collections {
+			if collectionFilterMatches(entry, coll.Name) {
+				return coll, entry, true
+			}
+		}
+	}
+	return outline.Collection{}, "", false
+}
+
+// discoveryDue reports why target's catalogue should be rediscovered at now:
+// for the first time, periodically, or to retry failed discovery once its
+// backoff has passed. reason is empty when no discovery is due, and wait is
+// the time until one is.
+func discoveryDue(status *wikiv1alpha1.WikiTargetStatus, now time.Time) (reason string, wait time.Duration) {
+	if status.ConsecutiveFailures > 0 && status.LastFailureTime != nil {
+		// Discovery is failing - retry on an exponential backoff instead of every refresh interval
+		backoff := discoveryBackoff(status.ConsecutiveFailures)
+		if wait := backoff - now.Sub(status.LastFailureTime.Time); wait > 0 {
+			return "", wait
+		}
+		return fmt.Sprintf("retry after %d failed discovery run(s)", status.ConsecutiveFailures), 0
+	}
+	if !status.Ready || status.LastSyncTime == nil {
+		return "initial discovery", 0
+	}
+	timeSinceLastSync := now.Sub(status.LastSyncTime.Time)
+	if timeSinceLastSync >= DefaultRefreshInterval {
+		return "periodic refresh", 0
+	}
+	return "", max(DefaultRefreshInterval-timeSinceLastSync, time.Second)
+}
+
 func statusChanged(oldStatus *wikiv1alpha1.WikiTargetStatus, newStatus *wikiv1alpha1.WikiTargetStatus) bool {
 	return !equality.Semantic.DeepEqual(oldStatus, newStatus)
 }
@@ -18,16 +18,26 @@ package controller
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
 )
 
 var _ = Describe("WikiTarget Controller", func() {
@@ -80,5 +90,240 @@ var _ = Describe("WikiTarget Controller", func() {
 			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
+
+		It("should back off while discovery keeps failing", func() {
+			// No Outline client factory is configured, so every discovery run fails
+			controllerReconciler := &WikiTargetReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			By("recording the first failure")
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(DefaultRefreshInterval))
+
+			resource := &wikiv1alpha1.WikiTarget{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ConsecutiveFailures).To(Equal(int32(1)))
+			Expect(resource.Status.LastFailureTime).NotTo(BeNil())
+
+			By("not retrying before the backoff elapses")
+			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", DefaultRefreshInterval))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ConsecutiveFailures).To(Equal(int32(1)))
+		})
+
+		It("should forget a deleted target's catalogue and jobs", func() {
+			store := catalog.NewStore()
+			jobs := catalog.NewJobStore()
+			controllerReconciler := &WikiTargetReconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				Catalogue: store,
+				Jobs:      jobs,
+			}
+			deleted := types.NamespacedName{Name: "deleted-target", Namespace: "default"}
+			store.Update("default/deleted-target", catalog.Target{ID: "default/deleted-target", Namespace: "default", Name: "deleted-target"},
+				[]catalog.Page{{ID: "page-1", URI: "https://wiki.example.com/doc/page-1"}})
+			store.Update("default/other", catalog.Target{ID: "default/other", Namespace: "default", Name: "other"},
+				[]catalog.Page{{ID: "page-2", URI: "https://wiki.example.com/doc/page-2"}})
+			jobs.Update(&wikiv1alpha1.TranslationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "translation-1", Namespace: "default"},
+				Spec:       wikiv1alpha1.TranslationJobSpec{Source: wikiv1alpha1.TranslationSourceSpec{TargetRef: "deleted-target", PageID: "page-1"}},
+			})
+			// Drain the notifications of the setup above
+			select {
+			case <-store.NotifyUpdate():
+			default:
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: deleted})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(store.List("default/deleted-target")).To(BeEmpty())
+			Expect(store.List("default/other")).To(HaveLen(1))
+			_, found := store.GetPage("https://wiki.example.com/doc/page-1")
+			Expect(found).To(BeFalse())
+			Expect(store.Targets()).To(HaveLen(1))
+			Expect(jobs.List()).To(BeEmpty())
+			Expect(store.NotifyUpdate()).To(Receive())
+		})
+
+		It("should default InsecureSkipTLSVerify only once", func() {
+			countingClient := &updateCounter{Client: k8sClient}
+			controllerReconciler := &WikiTargetReconciler{
+				Client: countingClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(countingClient.updates).To(Equal(1))
+			resource := &wikiv1alpha1.WikiTarget{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Annotations).To(HaveKey(wikiv1alpha1.AnnotationTLSDefaulted))
+			Expect(resource.Spec.InsecureSkipTLSVerify).To(BeTrue())
+
+			By("not writing the target again on later reconciles")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(countingClient.updates).To(Equal(1))
+		})
+
+		It("should cap the discovery backoff", func() {
+			Expect(discoveryBackoff(1)).To(Equal(DefaultRefreshInterval))
+			Expect(discoveryBackoff(2)).To(Equal(2 * DefaultRefreshInterval))
+			Expect(discoveryBackoff(3)).To(Equal(4 * DefaultRefreshInterval))
+			Expect(discoveryBackoff(100)).To(Equal(MaxDiscoveryBackoff))
+		})
+
+		It("should pick the collection the filter names", func() {
+			collections := []outline.Collection{
+				{ID: "c1", Name: "Engineering"},
+				{ID: "c2", Name: "Team Docs (PGD)"},
+				{ID: "c3", Name: "Docs Archive"},
+			}
+			coll, entry, ok := findFilteredCollection([]string{"team docs pgd collection", "Docs*"}, collections)
+			Expect(ok).To(BeTrue())
+			Expect(coll.ID).To(Equal("c2"))
+			Expect(entry).To(Equal("team docs pgd collection"))
+
+			coll, _, ok = findFilteredCollection([]string{"missing", "docs*"}, collections)
+			Expect(ok).To(BeTrue())
+			Expect(coll.ID).To(Equal("c3"))
+
+			_, _, ok = findFilteredCollection(nil, collections)
+			Expect(ok).To(BeFalse())
+			Expect(matchesCollectionFilter(nil, "Engineering")).To(BeFalse())
+		})
+
+		It("should schedule discovery from the injected clock", func() {
+			fakeClock := clocktesting.NewFakePassiveClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+			synced := metav1.NewTime(fakeClock.Now())
+			status := &wikiv1alpha1.WikiTargetStatus{Ready: true, LastSyncTime: &synced}
+
+			fakeClock.SetTime(synced.Add(DefaultRefreshInterval / 3))
+			reason, wait := discoveryDue(status, nowFrom(fakeClock).Time)
+			Expect(reason).To(BeEmpty())
+			Expect(wait).To(Equal(DefaultRefreshInterval - DefaultRefreshInterval/3))
+
+			fakeClock.SetTime(synced.Add(DefaultRefreshInterval))
+			reason, _ = discoveryDue(status, nowFrom(fakeClock).Time)
+			Expect(reason).To(Equal("periodic refresh"))
+
+			// Failed discovery waits out its backoff rather than the refresh interval
+			failed := metav1.NewTime(fakeClock.Now())
+			status.ConsecutiveFailures = 2
+			status.LastFailureTime = &failed
+			fakeClock.SetTime(failed.Add(DefaultRefreshInterval))
+			reason, wait = discoveryDue(status, nowFrom(fakeClock).Time)
+			Expect(reason).To(BeEmpty())
+			Expect(wait).To(Equal(DefaultRefreshInterval))
+
+			fakeClock.SetTime(failed.Add(discoveryBackoff(2)))
+			reason, _ = discoveryDue(status, nowFrom(fakeClock).Time)
+			Expect(reason).To(ContainSubstring("retry after 2"))
+		})
+	})
+})
+
+// updateCounter counts the object (not status) updates made through it.
+type updateCounter struct {
+	client.Client
+	updates int
+}
+
+func (c *updateCounter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.updates++
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+var _ = Describe("WikiTarget collection filter", func() {
+	It("should fail discovery rather than list the whole wiki when no collection matches", func() {
+		var listedPages bool
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/collections.list":
+				_, _ = w.Write([]byte(`{"data":[{"id":"c1","name":"Engineering"}]}`))
+			default:
+				listedPages = true
+				_, _ = w.Write([]byte(`{"data":[]}`))
+			}
+		}))
+		defer srv.Close()
+		outlineClient, err := outline.NewClient(outline.Config{BaseURL: srv.URL, Token: "token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		target := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "filtered"},
+			Spec:       wikiv1alpha1.WikiTargetSpec{URI: srv.URL, CollectionFilter: []string{"Team Docs"}},
+		}
+		c := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+			WithObjects(target).WithStatusSubresource(&wikiv1alpha1.WikiTarget{}).Build()
+		r := &WikiTargetReconciler{
+			Client:        c,
+			Scheme:        k8sClient.Scheme(),
+			Recorder:      record.NewFakeRecorder(10),
+			Catalogue:     catalog.NewStore(),
+			OutlineClient: staticOutlineClient{outlineClient},
+		}
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "filtered", Name: "wiki"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listedPages).To(BeFalse())
+
+		var got wikiv1alpha1.WikiTarget
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "filtered", Name: "wiki"}, &got)).To(Succeed())
+		Expect(got.Status.Ready).To(BeFalse())
+		Expect(got.Status.ConsecutiveFailures).To(Equal(int32(1)))
+		ready := meta.FindStatusCondition(got.Status.Conditions, "Ready")
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal(CollectionNotFoundReason))
+	})
+})
+
+var _ = Describe("WikiTarget TLS verification", func() {
+	It("should not turn verification off once the owner has chosen it", func() {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}))
+		defer srv.Close()
+		// The client verifies certificates, and the test server's isn't trusted
+		outlineClient, err := outline.NewClient(outline.Config{BaseURL: srv.URL, Token: "token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		target := &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "wiki", Namespace: "tls",
+				Annotations: map[string]string{wikiv1alpha1.AnnotationTLSDefaulted: "true"},
+			},
+			Spec: wikiv1alpha1.WikiTargetSpec{URI: srv.URL},
+		}
+		c := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).
+			WithObjects(target).WithStatusSubresource(&wikiv1alpha1.WikiTarget{}).Build()
+		r := &WikiTargetReconciler{
+			Client:        c,
+			Scheme:        k8sClient.Scheme(),
+			Recorder:      record.NewFakeRecorder(10),
+			Catalogue:     catalog.NewStore(),
+			OutlineClient: staticOutlineClient{outlineClient},
+		}
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "tls", Name: "wiki"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		var got wikiv1alpha1.WikiTarget
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "tls", Name: "wiki"}, &got)).To(Succeed())
+		Expect(got.Spec.InsecureSkipTLSVerify).To(BeFalse())
+		ready := meta.FindStatusCondition(got.Status.Conditions, "Ready")
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal(TLSVerificationFailedReason))
+		Expect(ready.Message).To(ContainSubstring("caBundleRef"))
 	})
 })
@@ -26,13 +26,12 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	manager "sigs.k8s.io/controller-runtime/pkg/manager"
 
 	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/flags"
 	"github.com/dasmlab/glooscap-operator/pkg/outline"
 )
 
@@ -41,7 +40,7 @@ import (
 // to verify that we can always write to the target wiki.
 type WikiTargetDiagnosticRunnable struct {
 	Client        client.Client
-	APIReader     client.Reader // Uncached client for reading ConfigMaps (avoids cache watch requirements)
+	Flags         *flags.Store
 	OutlineClient OutlineClientFactory
 	// Track master keys and last page IDs per target (in-memory cache)
 	masterKeys   map[string]string // target name -> master key (e.g., "GLOODIAG TEST abc123")
@@ -57,9 +56,6 @@ const (
 	diagnosticPageTitlePrefix = "GLOODIAG TEST"
 	// How often to run the diagnostic (every 5 minutes after startup)
 	diagnosticInterval = 5 * time.Minute
-	// Annotation keys for storing master key and last page ID
-	annotationMasterKey  = "glooscap.dasmlab.org/diagnostic-master-key"
-	annotationLastPageID = "glooscap.dasmlab.org/diagnostic-last-page-id"
 )
 
 // Start implements manager.Runnable
@@ -96,37 +92,6 @@ func (r *WikiTargetDiagnosticRunnable) Start(ctx context.Context) error {
 	}
 }
 
-// isDiagnosticEnabled checks if write diagnostic is enabled via ConfigMap
-func (r *WikiTargetDiagnosticRunnable) isDiagnosticEnabled(ctx context.Context, logger logr.Logger) bool {
-	configMapName := "glooscap-config"
-	namespace := "glooscap-system"
-
-	var cm corev1.ConfigMap
-	// Use APIReader (uncached client) to avoid requiring cluster-wide ConfigMap watch permissions
-	reader := r.APIReader
-	if reader == nil {
-		// Fallback to cached client if APIReader not set
-		reader = r.Client
-	}
-	err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: configMapName}, &cm)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// ConfigMap doesn't exist, default to enabled
-			return true
-		}
-		logger.V(1).Info("failed to get config map, defaulting to enabled", "error", err)
-		return true // Default to enabled on error
-	}
-
-	// Check the diagnostic-write-enabled key
-	if val, exists := cm.Data["diagnostic-write-enabled"]; exists {
-		return val == "true"
-	}
-
-	// Key doesn't exist, default to enabled
-	return true
-}
-
 // runDiagnostic checks all readWrite WikiTargets and creates/updates diagnostic pages
 func (r *WikiTargetDiagnosticRunnable) runDiagnostic(ctx context.Context, logger logr.Logger) {
 	// Failures are ok - just log and continue
@@ -137,7 +102,7 @@ func (r *WikiTargetDiagnosticRunnable) runDiagnostic(ctx context.Context, logger
 	}()
 
 	// Check if diagnostic is enabled
-	if !r.isDiagnosticEnabled(ctx, logger) {
+	if !r.Flags.GetFlag(ctx, flags.DiagnosticWriteEnabled) {
 		logger.V(1).Info("write diagnostic is disabled, skipping")
 		return
 	}
@@ -192,7 +157,7 @@ func (r *WikiTargetDiagnosticRunnable) getOrCreateMasterKey(ctx context.Context,
 
 	// Check annotations
 	if target.Annotations != nil {
-		if key, exists := target.Annotations[annotationMasterKey]; exists && key != "" {
+		if key, exists := target.Annotations[wikiv1alpha1.AnnotationDiagnosticMasterKey]; exists && key != "" {
 			// Store in cache
 			r.keysMu.Lock()
 			r.masterKeys[target.Name] = key
@@ -212,7 +177,7 @@ func (r *WikiTargetDiagnosticRunnable) getOrCreateMasterKey(ctx context.Context,
 	if target.Annotations == nil {
 		target.Annotations = make(map[string]string)
 	}
-	target.Annotations[annotationMasterKey] = masterKey
+	target.Annotations[wikiv1alpha1.AnnotationDiagnosticMasterKey] = masterKey
 
 	// Update the target
 	if err := r.Client.Update(ctx, target); err != nil {
@@ -299,7 +264,7 @@ This page is automatically updated every 5 minutes to verify that Glooscap can w
 
 	// Also check annotations (in case cache was cleared)
 	if existingPageID == "" && target.Annotations != nil {
-		if id, exists := target.Annotations[annotationLastPageID]; exists {
+		if id, exists := target.Annotations[wikiv1alpha1.AnnotationDiagnosticLastPageID]; exists {
 			existingPageID = id
 		}
 	}
@@ -328,7 +293,7 @@ This page is automatically updated every 5 minutes to verify that Glooscap can w
 			if target.Annotations == nil {
 				target.Annotations = make(map[string]string)
 			}
-			target.Annotations[annotationLastPageID] = updateResp.Data.ID
+			target.Annotations[wikiv1alpha1.AnnotationDiagnosticLastPageID] = updateResp.Data.ID
 			if err := r.Client.Update(ctx, target); err != nil {
 				targetLogger.Error(err, "failed to update target with page ID")
 			}
@@ -363,7 +328,7 @@ This page is automatically updated every 5 minutes to verify that Glooscap can w
 	if target.Annotations == nil {
 		target.Annotations = make(map[string]string)
 	}
-	target.Annotations[annotationLastPageID] = newPageID
+	target.Annotations[wikiv1alpha1.AnnotationDiagnosticLastPageID] = newPageID
 	if err := r.Client.Update(ctx, target); err != nil {
 		targetLogger.Error(err, "failed to update target with last page ID")
 		// Continue anyway - cache will help
@@ -376,10 +341,10 @@ This page is automatically updated every 5 minutes to verify that Glooscap can w
 }
 
 // SetupWikiTargetDiagnosticRunnable sets up the WikiTarget diagnostic runnable with the Manager.
-func SetupWikiTargetDiagnosticRunnable(mgr manager.Manager, outlineClient OutlineClientFactory) error {
+func SetupWikiTargetDiagnosticRunnable(mgr manager.Manager, outlineClient OutlineClientFactory, flagStore *flags.Store) error {
 	runnable := &WikiTargetDiagnosticRunnable{
 		Client:        mgr.GetClient(),
-		APIReader:     mgr.GetAPIReader(), // Use uncached client for ConfigMap reads
+		Flags:         flagStore,
 		OutlineClient: outlineClient,
 	}
 	return mgr.Add(runnable)
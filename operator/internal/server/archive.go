@@ -0,0 +1,48 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/dasmlab/glooscap-operator/pkg/jobarchive"
+)
+
+// maxArchiveQueryLimit caps the records one archive query returns.
+const maxArchiveQueryLimit = 1000
+
+// archiveQuery builds the job archive query for the namespace, target,
+// pageId, state, engine, engineVersion, since (RFC3339) and limit parameters. Without a namespace
+// the query is limited to the namespaces the API may read.
+func archiveQuery(values url.Values, namespaces namespaceAllowlist) (jobarchive.Query, error) {
+	q := jobarchive.Query{
+		Namespace: values.Get("namespace"),
+		Target:    values.Get("target"),
+		PageID:    values.Get("pageId"),
+		State:     values.Get("state"),
+		// Find the jobs a model produced, e.g. to retranslate them
+		Engine:        values.Get("engine"),
+		EngineVersion: values.Get("engineVersion"),
+	}
+	if q.Namespace == "" {
+		for ns := range namespaces {
+			q.Namespaces = append(q.Namespaces, ns)
+		}
+	}
+	if raw := values.Get("since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return q, fmt.Errorf("since must be an RFC3339 timestamp: %w", err)
+		}
+		q.Since = since
+	}
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxArchiveQueryLimit {
+			return q, fmt.Errorf("limit must be between 1 and %d", maxArchiveQueryLimit)
+		}
+		q.Limit = limit
+	}
+	return q, nil
+}
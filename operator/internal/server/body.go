@@ -0,0 +1,32 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+)
+
+// defaultMaxRequestBodyBytes bounds request bodies when Options.MaxRequestBodyBytes is unset.
+const defaultMaxRequestBodyBytes int64 = 1 << 20
+
+// limitRequestBody caps every request body at maxBytes so an oversized payload
+// fails the JSON decode instead of being buffered into memory.
+func limitRequestBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// decodeErrorStatus maps a request body decode error to its response code:
+// 413 when the body exceeded the limit, 400 otherwise.
+func decodeErrorStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimitRequestBody(t *testing.T) {
+	handler := limitRequestBody(64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
+			return
+		}
+		writeJSON(w, req)
+	}))
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"within limit", `{"targetRef":"wiki"}`, http.StatusOK},
+		{"malformed", `{"targetRef":`, http.StatusBadRequest},
+		{"oversized", `{"pad":"` + strings.Repeat("x", 128) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/jobs", strings.NewReader(tt.body)))
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"net/url"
 	"sync"
 )
 
@@ -52,3 +53,55 @@ func (s *ConfigStore) SetTranslationServiceConfig(config *TranslationServiceConf
 	cfg := *config
 	s.translationServiceConfig = &cfg
 }
+
+const (
+	// defaultNamespace is used when a request doesn't name a namespace.
+	defaultNamespace = "glooscap-system"
+	// defaultLanguageTag is the destination language when a job request omits one.
+	defaultLanguageTag = "fr-CA"
+)
+
+// RuntimeConfig is the configuration the operator resolved at startup from its
+// flags and environment. It is reported as-is by GET /api/v1/config, so it must
+// never carry credentials.
+type RuntimeConfig struct {
+	APIAddr                  string   `json:"apiAddr"`
+	DispatcherMode           string   `json:"dispatcherMode"`
+	RunnerNamespace          string   `json:"runnerNamespace"`
+	RunnerImage              string   `json:"runnerImage"`
+	RunnerAPIServerURL       string   `json:"runnerApiServerUrl"`
+	OutlineSlowCallThreshold string   `json:"outlineSlowCallThreshold"`
+	StdoutLogLevel           string   `json:"stdoutLogLevel"`
+	TranslationCacheSize     int      `json:"translationCacheSize"`
+	TranslationCacheTTL      string   `json:"translationCacheTTL"`
+	APIMaxBodyBytes          int64    `json:"apiMaxBodyBytes"`
+	StateMaxPages            int      `json:"stateMaxPages"`
+	StateMaxJobs             int      `json:"stateMaxJobs"`
+	AllowedNamespaces        []string `json:"allowedNamespaces,omitempty"`
+	DiagnosticMaxConcurrent  int      `json:"diagnosticMaxConcurrent"`
+	DiagnosticMaxPerMinute   int      `json:"diagnosticMaxPerMinute"`
+	PageFetchWorkers         int      `json:"pageFetchWorkers"`
+	PauseOnTokenBudget       bool     `json:"pauseOnTokenBudget"`
+	JobArchivePath           string   `json:"jobArchivePath,omitempty"`
+	JobRetention             string   `json:"jobRetention"`
+	SSEMaxSubscribers        int      `json:"sseMaxSubscribers"`
+	SSEStallTimeout          string   `json:"sseStallTimeout"`
+	LeaderElection           bool     `json:"leaderElection"`
+	SecureMetrics            bool     `json:"secureMetrics"`
+	EnableHTTP2              bool     `json:"enableHttp2"`
+}
+
+// redactURL strips credentials and query parameters from a URL for display.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "[unparseable]"
+	}
+	if u.User != nil {
+		u.User = url.User("REDACTED")
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = "REDACTED"
+	}
+	return u.String()
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// jobSubmitTimeout bounds the API calls that create TranslationJobs on behalf of a request.
+const jobSubmitTimeout = 30 * time.Second
+
+// requestContext returns a context for work whose result is written back to the
+// caller (e.g. synchronous translation). It is cancelled as soon as the client
+// disconnects, so abandoned requests stop consuming translation capacity.
+func requestContext(r *http.Request, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(r.Context(), timeout)
+}
+
+// detachedContext returns a context for work that must complete regardless of
+// whether the submitting client stays connected (e.g. creating a TranslationJob).
+// It keeps the request's values but not its cancellation.
+func detachedContext(r *http.Request, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(r.Context()), timeout)
+}
+
+// clientGone reports whether the client behind r has disconnected.
+func clientGone(r *http.Request) bool {
+	return r.Context().Err() == context.Canceled
+}
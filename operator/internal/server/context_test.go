@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+	nanabushv1 "github.com/dasmlab/glooscap-operator/pkg/nanabush/proto/v1"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+)
+
+// blockingTranslationService registers every client and holds each
+// translation until its caller goes away.
+type blockingTranslationService struct {
+	nanabushv1.UnimplementedTranslationServiceServer
+	started   chan struct{}
+	cancelled chan error
+}
+
+func (blockingTranslationService) RegisterClient(context.Context, *nanabushv1.RegisterClientRequest) (*nanabushv1.RegisterClientResponse, error) {
+	return &nanabushv1.RegisterClientResponse{Success: true, ClientId: "client-1", HeartbeatIntervalSeconds: 60}, nil
+}
+
+func (blockingTranslationService) Heartbeat(context.Context, *nanabushv1.HeartbeatRequest) (*nanabushv1.HeartbeatResponse, error) {
+	return &nanabushv1.HeartbeatResponse{Success: true}, nil
+}
+
+func (s blockingTranslationService) Translate(ctx context.Context, _ *nanabushv1.TranslateRequest) (*nanabushv1.TranslateResponse, error) {
+	close(s.started)
+	select {
+	case <-ctx.Done():
+		s.cancelled <- ctx.Err()
+		return nil, ctx.Err()
+	case <-time.After(10 * time.Second):
+		s.cancelled <- nil
+		return &nanabushv1.TranslateResponse{Success: true}, nil
+	}
+}
+
+// staticOutlineFactory hands out one Outline client for every target.
+type staticOutlineFactory struct{ client *outline.Client }
+
+func (f staticOutlineFactory) New(context.Context, client.Client, *wikiv1alpha1.WikiTarget) (*outline.Client, error) {
+	return f.client, nil
+}
+
+// serveUntilCancelled serves req through router, cancelling it once started
+// is closed, and waits for the handler to return.
+func serveUntilCancelled(t *testing.T, router http.Handler, req *http.Request, started <-chan struct{}, cancelled chan<- struct{}) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(req.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		router.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+	}()
+
+	select {
+	case <-started:
+	case <-done:
+		t.Fatal("handler returned before the work started")
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the work to start")
+	}
+	cancel()
+	if cancelled != nil {
+		close(cancelled)
+	}
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("handler did not return after the client disconnected")
+	}
+}
+
+func TestTranslateCancelledOnDisconnect(t *testing.T) {
+	service := blockingTranslationService{started: make(chan struct{}), cancelled: make(chan error, 1)}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	nanabushv1.RegisterTranslationServiceServer(srv, service)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+	translator, err := nanabush.NewClient(nanabush.Config{Address: lis.Addr().String(), Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("connect to the translation service: %v", err)
+	}
+	defer translator.Close()
+
+	wiki := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":"# Guide"}`))
+	}))
+	defer wiki.Close()
+	outlineClient, err := outline.NewClient(outline.Config{BaseURL: wiki.URL, Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	target := &wikiv1alpha1.WikiTarget{ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "docs"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	router := newRouter(ctx, Options{
+		Client:               fake.NewClientBuilder().WithScheme(scheme).WithObjects(target).Build(),
+		Nanabush:             translator,
+		OutlineClientFactory: staticOutlineFactory{client: outlineClient},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/translate",
+		strings.NewReader(`{"targetRef":"wiki","namespace":"docs","pageId":"page-1","languageTag":"fr-CA"}`))
+	serveUntilCancelled(t, router, req, service.started, nil)
+
+	select {
+	case err := <-service.cancelled:
+		if err != context.Canceled {
+			t.Fatalf("translation ended with %v, want it cancelled", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the translation call was not cancelled")
+	}
+}
+
+func TestCreateJobSurvivesDisconnect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	var createErr error
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			// Let the client go away while the job is being created
+			close(started)
+			<-cancelled
+			if createErr = ctx.Err(); createErr != nil {
+				return createErr
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	router := newRouter(ctx, Options{Client: c})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs",
+		strings.NewReader(`{"targetRef":"wiki","namespace":"docs","pageId":"page-1","languageTag":"fr-CA"}`))
+	serveUntilCancelled(t, router, req, started, cancelled)
+
+	if createErr != nil {
+		t.Fatalf("job creation context ended with %v after the client disconnected", createErr)
+	}
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := c.List(context.Background(), &jobs, client.InNamespace("docs")); err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs.Items) != 1 {
+		t.Fatalf("%d TranslationJobs created, want 1", len(jobs.Items))
+	}
+}
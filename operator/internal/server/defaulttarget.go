@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// errDuplicateDefaultTarget is returned when a WikiTarget would become a
+// second default in its namespace.
+var errDuplicateDefaultTarget = stderrors.New("namespace already has a default WikiTarget")
+
+// isDefaultTarget reports whether target carries LabelDefaultTarget=true.
+func isDefaultTarget(target *wikiv1alpha1.WikiTarget) bool {
+	return target.Labels[wikiv1alpha1.LabelDefaultTarget] == "true"
+}
+
+// defaultTargets returns the sorted names of namespace's WikiTargets labelled
+// as its default.
+func defaultTargets(ctx context.Context, reader client.Reader, namespace string) ([]string, error) {
+	var list wikiv1alpha1.WikiTargetList
+	if err := reader.List(ctx, &list, client.InNamespace(namespace),
+		client.MatchingLabels{wikiv1alpha1.LabelDefaultTarget: "true"}); err != nil {
+		return nil, fmt.Errorf("list default WikiTargets: %w", err)
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, target := range list.Items {
+		names = append(names, target.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// checkDefaultTarget returns errDuplicateDefaultTarget when target is labelled
+// as the default but another WikiTarget in its namespace already is.
+func checkDefaultTarget(ctx context.Context, reader client.Reader, target *wikiv1alpha1.WikiTarget) error {
+	if !isDefaultTarget(target) {
+		return nil
+	}
+	names, err := defaultTargets(ctx, reader, target.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name != target.Name {
+			return fmt.Errorf("%w: %s is the default of %s; remove its %s label first",
+				errDuplicateDefaultTarget, name, target.Namespace, wikiv1alpha1.LabelDefaultTarget)
+		}
+	}
+	return nil
+}
+
+// resolveTargetRef fills an empty *targetRef with namespace's default
+// WikiTarget. It writes a 400 and returns false when there is no default to
+// fall back to, or more than one.
+func resolveTargetRef(ctx context.Context, w http.ResponseWriter, reader client.Reader, namespace string, targetRef *string) bool {
+	if *targetRef != "" {
+		return true
+	}
+	names, err := defaultTargets(ctx, reader, namespace)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error(), nil)
+		return false
+	}
+	switch len(names) {
+	case 1:
+		*targetRef = names[0]
+		return true
+	case 0:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf(
+			"targetRef is required: namespace %q has no default WikiTarget (label one %s=true)",
+			namespace, wikiv1alpha1.LabelDefaultTarget), nil)
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf(
+			"targetRef is required: namespace %q has several default WikiTargets (%s); keep the label on exactly one",
+			namespace, strings.Join(names, ", ")), map[string]any{"defaultTargets": names})
+	}
+	return false
+}
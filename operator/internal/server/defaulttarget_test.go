@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+func wikiTarget(name string, isDefault bool) *wikiv1alpha1.WikiTarget {
+	target := &wikiv1alpha1.WikiTarget{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "glooscap-system"}}
+	if isDefault {
+		target.Labels = map[string]string{wikiv1alpha1.LabelDefaultTarget: "true"}
+	}
+	return target
+}
+
+func TestResolveTargetRef(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		targets    []*wikiv1alpha1.WikiTarget
+		targetRef  string
+		want       string
+		wantStatus int
+	}{
+		{name: "explicit target", targets: []*wikiv1alpha1.WikiTarget{wikiTarget("docs", true)}, targetRef: "other", want: "other"},
+		{name: "single default", targets: []*wikiv1alpha1.WikiTarget{wikiTarget("docs", true), wikiTarget("other", false)}, want: "docs"},
+		{name: "no default", targets: []*wikiv1alpha1.WikiTarget{wikiTarget("docs", false)}, wantStatus: http.StatusBadRequest},
+		{name: "several defaults", targets: []*wikiv1alpha1.WikiTarget{wikiTarget("docs", true), wikiTarget("other", true)}, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, target := range tt.targets {
+				builder = builder.WithObjects(target)
+			}
+			c := builder.Build()
+
+			rec := httptest.NewRecorder()
+			targetRef := tt.targetRef
+			ok := resolveTargetRef(ctx, rec, c, "glooscap-system", &targetRef)
+			if tt.wantStatus != 0 {
+				if ok || rec.Code != tt.wantStatus {
+					t.Errorf("ok=%v status=%d, want a %d: %s", ok, rec.Code, tt.wantStatus, rec.Body.String())
+				}
+				return
+			}
+			if !ok || targetRef != tt.want {
+				t.Errorf("ok=%v targetRef=%q, want %q: %s", ok, targetRef, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCheckDefaultTarget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(wikiTarget("docs", true)).Build()
+	ctx := context.Background()
+
+	if err := checkDefaultTarget(ctx, c, wikiTarget("docs", true)); err != nil {
+		t.Errorf("re-applying the default: %v", err)
+	}
+	if err := checkDefaultTarget(ctx, c, wikiTarget("other", false)); err != nil {
+		t.Errorf("non-default target: %v", err)
+	}
+	if err := checkDefaultTarget(ctx, c, wikiTarget("other", true)); !stderrors.Is(err, errDuplicateDefaultTarget) {
+		t.Errorf("second default: err = %v, want errDuplicateDefaultTarget", err)
+	}
+}
@@ -3,23 +3,36 @@ package server
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
-	corev1 "k8s.io/api/core/v1"
+	"github.com/google/uuid"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
 	"github.com/dasmlab/glooscap-operator/internal/controller"
 	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+	"github.com/dasmlab/glooscap-operator/pkg/flags"
+	"github.com/dasmlab/glooscap-operator/pkg/jobarchive"
+	"github.com/dasmlab/glooscap-operator/pkg/jobcontent"
+	"github.com/dasmlab/glooscap-operator/pkg/langtag"
 	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+	"github.com/dasmlab/glooscap-operator/pkg/vllm"
 )
 
 // Options controls the API server.
@@ -42,46 +55,128 @@ type Options struct {
 	OutlineClientFactory controller.OutlineClientFactory
 	// TranslationJobEventCh is a channel that receives TranslationJob events to trigger SSE broadcasts
 	TranslationJobEventCh <-chan controller.TranslationJobEvent
+	// RuntimeConfig is the startup configuration reported by GET /api/v1/config
+	RuntimeConfig *RuntimeConfig
+	// MaxRequestBodyBytes caps request bodies; larger requests get 413.
+	// Defaults to 1 MiB.
+	MaxRequestBodyBytes int64
+	// Flags serves the feature flags. Built from APIReader and Client when nil.
+	Flags *flags.Store
+	// StateMaxPages is the page count above which a target's pages are left
+	// out of the SSE and /db/state payloads, and StateMaxJobs caps the jobs
+	// listed there. 0 uses the defaults; negative values disable the caps.
+	StateMaxPages int
+	StateMaxJobs  int
+	// Dispatcher is the reconciler's job dispatcher, used to report which
+	// pipeline modes can run
+	Dispatcher vllm.Dispatcher
+	// AllowedNamespaces restricts the namespaces the API operates on;
+	// requests for any other namespace get 403. Empty allows all namespaces.
+	AllowedNamespaces []string
+	// Notifier decides which notifyWebhook hosts jobs may be submitted
+	// with. Nil leaves the check to the reconciler.
+	Notifier *controller.JobNotifier
+	// PageFetchWorkers bounds the concurrent Outline exports of one batch
+	// page content request. 0 uses outline.DefaultFetchWorkers.
+	PageFetchWorkers int
+	// PauseOnTokenBudget tells the validate endpoint that jobs of a namespace
+	// over its token budget wait rather than fail, as the reconciler does.
+	PauseOnTokenBudget bool
+	// JobArchive serves /api/v1/jobs/archive. Nil when archiving is off.
+	JobArchive jobarchive.Sink
+	// MaxEventSubscribers caps the open SSE streams; more get 503. 0 uses
+	// the default (1000), a negative value removes the cap.
+	MaxEventSubscribers int
+	// EventStallTimeout closes an SSE stream whose queued events haven't
+	// been read for this long. 0 uses the default (1m), negative never does.
+	EventStallTimeout time.Duration
 }
 
 // eventBroadcaster manages SSE connections and broadcasts events.
 type eventBroadcaster struct {
-	mu          sync.RWMutex
-	subscribers map[chan []byte]struct{}
+	mu          sync.Mutex
+	subscribers map[chan []byte]*subscription
 	trigger     chan struct{} // Channel to trigger immediate event send
+	state       stateCache    // Last serialized state, shared by all subscribers
+	// maxSubscribers caps the open subscriptions; negative means no cap.
+	maxSubscribers int
+	// stallTimeout drops a subscriber whose channel stays full this long;
+	// 0 keeps it, skipping the events it has no room for.
+	stallTimeout time.Duration
+	now          func() time.Time
 }
 
 func newEventBroadcaster() *eventBroadcaster {
 	return &eventBroadcaster{
-		subscribers: make(map[chan []byte]struct{}),
-		trigger:     make(chan struct{}, 1),
+		subscribers:    make(map[chan []byte]*subscription),
+		trigger:        make(chan struct{}, 1),
+		state:          stateCache{ttl: stateCacheTTL},
+		maxSubscribers: defaultMaxEventSubscribers,
+		stallTimeout:   defaultEventStallTimeout,
+		now:            time.Now,
 	}
 }
 
-func (eb *eventBroadcaster) subscribe() chan []byte {
+// jobEventFilter selects the translation_job events a scoped subscriber gets.
+type jobEventFilter func(event controller.TranslationJobEvent) bool
+
+func (eb *eventBroadcaster) subscribe() (chan []byte, error) {
+	return eb.subscribeFiltered(nil)
+}
+
+// subscribeFiltered subscribes to the translation_job events accepted by
+// filter only; state events aren't delivered. A nil filter gets everything.
+// It returns errTooManySubscribers at the subscriber limit. The channel is
+// closed if the broadcaster drops a stalled subscriber.
+func (eb *eventBroadcaster) subscribeFiltered(filter jobEventFilter) (chan []byte, error) {
 	eb.mu.Lock()
 	defer eb.mu.Unlock()
-	ch := make(chan []byte, 10)
-	eb.subscribers[ch] = struct{}{}
-	return ch
+	if eb.maxSubscribers >= 0 && len(eb.subscribers) >= eb.maxSubscribers {
+		eventSubscribersDropped.WithLabelValues("rejected").Inc()
+		return nil, errTooManySubscribers
+	}
+	ch := make(chan []byte, eventSubscriberBuffer)
+	eb.subscribers[ch] = &subscription{filter: filter}
+	eventSubscribers.Inc()
+	return ch, nil
 }
 
 func (eb *eventBroadcaster) unsubscribe(ch chan []byte) {
 	eb.mu.Lock()
 	defer eb.mu.Unlock()
+	// Already gone if it was dropped for stalling
+	if _, ok := eb.subscribers[ch]; !ok {
+		return
+	}
 	delete(eb.subscribers, ch)
 	close(ch)
+	eventSubscribers.Dec()
 }
 
+// broadcast sends a state event to the unfiltered subscribers.
 func (eb *eventBroadcaster) broadcast(data []byte) {
-	eb.mu.RLock()
-	defer eb.mu.RUnlock()
-	for ch := range eb.subscribers {
-		select {
-		case ch <- data:
-		default:
-			// Channel full, skip this subscriber
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	now := eb.now()
+	for ch, sub := range eb.subscribers {
+		if sub.filter != nil {
+			continue
+		}
+		eb.deliver(ch, sub, data, now)
+	}
+}
+
+// broadcastJobEvent sends data, the encoded translation_job event, to the
+// unfiltered subscribers and to the filtered ones that accept event.
+func (eb *eventBroadcaster) broadcastJobEvent(event controller.TranslationJobEvent, data []byte) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	now := eb.now()
+	for ch, sub := range eb.subscribers {
+		if sub.filter != nil && !sub.filter(event) {
+			continue
 		}
+		eb.deliver(ch, sub, data, now)
 	}
 }
 
@@ -99,7 +194,68 @@ func Start(ctx context.Context, opts Options) error {
 		opts.Addr = ":3000"
 	}
 
+	server := &http.Server{
+		Addr:              opts.Addr,
+		Handler:           newRouter(ctx, opts),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		_ = server.Shutdown(context.Background())
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// newRouter builds the API's routes. The state broadcaster it starts runs
+// until ctx is cancelled.
+func newRouter(ctx context.Context, opts Options) http.Handler {
+	flagStore := opts.Flags
+	if flagStore == nil && opts.Client != nil {
+		reader := opts.APIReader
+		if reader == nil {
+			reader = opts.Client
+		}
+		flagStore = flags.NewStore(reader, opts.Client, "")
+	}
+
+	namespaces := newNamespaceAllowlist(opts.AllowedNamespaces)
+
+	// apiReader reads Secrets and ConfigMaps without going through the cache
+	var apiReader client.Reader = opts.Client
+	if opts.APIReader != nil {
+		apiReader = opts.APIReader
+	}
+
+	validator := &controller.JobValidator{
+		Client:             opts.Client,
+		Catalogue:          opts.Catalogue,
+		Jobs:               opts.Jobs,
+		OutlineClient:      opts.OutlineClientFactory,
+		GetNanabushClient:  opts.GetNanabushClient,
+		PauseOnTokenBudget: opts.PauseOnTokenBudget,
+	}
+	if validator.GetNanabushClient == nil && opts.Nanabush != nil {
+		validator.GetNanabushClient = func() *nanabush.Client { return opts.Nanabush }
+	}
+
 	broadcaster := newEventBroadcaster()
+	broadcaster.maxSubscribers = stateLimit(opts.MaxEventSubscribers, defaultMaxEventSubscribers)
+	switch {
+	case opts.EventStallTimeout > 0:
+		broadcaster.stallTimeout = opts.EventStallTimeout
+	case opts.EventStallTimeout < 0:
+		broadcaster.stallTimeout = 0
+	}
 
 	// Start background goroutine to send periodic events and listen for store updates
 	go func() {
@@ -111,28 +267,43 @@ func Start(ctx context.Context, opts Options) error {
 			updateCh = opts.Catalogue.NotifyUpdate()
 		}
 
+		// A trigger answered from the state cache may predate the change that
+		// caused it, so schedule one rebuild once the cached copy expires.
+		var trailing <-chan time.Time
+		send := func() {
+			if !sendStateEvent(broadcaster, opts) && trailing == nil {
+				trailing = time.After(stateCacheTTL)
+			}
+		}
+
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				sendStateEvent(broadcaster, opts)
+				send()
+			case <-trailing:
+				trailing = nil
+				send()
 			case <-broadcaster.trigger:
-				sendStateEvent(broadcaster, opts)
+				send()
 			case <-updateCh:
 				// Store was updated, send event immediately
-				sendStateEvent(broadcaster, opts)
+				send()
 			case <-opts.NanabushStatusCh:
 				// Nanabush status changed, send event immediately
-				sendStateEvent(broadcaster, opts)
+				send()
 			case jobEvent := <-opts.TranslationJobEventCh:
 				// TranslationJob event received, send it immediately
+				if !namespaces.allows(jobEvent.Namespace) {
+					continue
+				}
 				eventData := map[string]any{
 					"event": "translation_job",
 					"data":  jobEvent,
 				}
 				if data, err := json.Marshal(eventData); err == nil {
-					broadcaster.broadcast(data)
+					broadcaster.broadcastJobEvent(jobEvent, data)
 				}
 			}
 		}
@@ -140,15 +311,26 @@ func Start(ctx context.Context, opts Options) error {
 
 	router := chi.NewRouter()
 
-	// Request logging middleware - log ALL requests
+	// Request logging middleware - log ALL requests and tag each with a request ID
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			fmt.Fprintf(os.Stderr, "[http] %s %s %s\n", r.Method, r.URL.Path, r.RemoteAddr)
-			fmt.Printf("[http] %s %s %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+			requestID := r.Header.Get(requestIDHeader)
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+			w.Header().Set(requestIDHeader, requestID)
+			fmt.Fprintf(os.Stderr, "[http] %s %s %s id=%s\n", r.Method, r.URL.Path, r.RemoteAddr, requestID)
+			verbosity.Debugf("[http] %s %s %s id=%s\n", r.Method, r.URL.Path, r.RemoteAddr, requestID)
 			next.ServeHTTP(w, r)
 		})
 	})
 
+	maxBodyBytes := opts.MaxRequestBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = defaultMaxRequestBodyBytes
+	}
+	router.Use(limitRequestBody(maxBodyBytes))
+
 	// CORS headers for UI
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -187,8 +369,8 @@ func Start(ctx context.Context, opts Options) error {
 
 			w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept")
-			w.Header().Set("Access-Control-Expose-Headers", "Content-Type, Content-Length")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept, X-Request-ID")
+			w.Header().Set("Access-Control-Expose-Headers", "Content-Type, Content-Length, X-Request-ID")
 
 			if r.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusOK)
@@ -224,50 +406,62 @@ func Start(ctx context.Context, opts Options) error {
 			}
 		}
 
-		// Try to read from TranslationService CR status
-		// Prefer client status if it shows connected/registered but CR doesn't (handles startup race condition)
+		var ts *wikiv1alpha1.TranslationService
+		var readErr error
 		if opts.Client != nil {
-			tsName := "glooscap-translation-service"
-			var ts wikiv1alpha1.TranslationService
-			err := opts.Client.Get(r.Context(), client.ObjectKey{Name: tsName}, &ts)
-			if err == nil {
-				// CR exists - check if status is populated
-				if ts.Status.ClientID != "" || ts.Status.Status != "" {
-					// CR status is populated - but prefer client status if it's more accurate
-					// This handles the case where client is connected but CR hasn't been updated yet
-					if clientStatus.Connected && clientStatus.Registered && (!ts.Status.Connected || !ts.Status.Registered) {
-						// Client is connected but CR shows disconnected - prefer client status (more recent)
-						writeJSON(w, clientStatus)
-						return
-					}
-					// CR status is populated and matches client, or client is not connected - use CR status
-					var lastHeartbeat time.Time
-					if ts.Status.LastHeartbeat != nil {
-						lastHeartbeat = ts.Status.LastHeartbeat.Time
-					}
-					writeJSON(w, nanabush.Status{
-						ClientID:          ts.Status.ClientID,
-						Connected:         ts.Status.Connected,
-						Registered:        ts.Status.Registered,
-						Status:            ts.Status.Status,
-						MissedHeartbeats:  ts.Status.MissedHeartbeats,
-						HeartbeatInterval: int64(ts.Status.HeartbeatIntervalSeconds), // Already in seconds
-						LastHeartbeat:     lastHeartbeat,
-					})
-					return
-				}
+			ts, readErr = getTranslationService(r.Context(), opts.Client)
+			if readErr != nil {
+				verbosity.Printf("[http] GET /status/nanabush: failed to read TranslationService: %v\n", readErr)
 			}
 		}
+		writeJSON(w, resolveServiceStatus(clientStatus, ts, readErr))
+	})
+
+	// Circuit breaker of the translation service client. While it is open,
+	// translations fail fast instead of waiting on a failing service.
+	router.Get("/api/v1/status/nanabush/circuit", func(w http.ResponseWriter, r *http.Request) {
+		var nanabushClient *nanabush.Client
+		if opts.GetNanabushClient != nil {
+			nanabushClient = opts.GetNanabushClient()
+		} else if opts.Nanabush != nil {
+			nanabushClient = opts.Nanabush
+		}
+		if nanabushClient == nil {
+			writeError(w, http.StatusServiceUnavailable, "translation service client not configured", nil)
+			return
+		}
+		writeJSON(w, nanabushClient.Circuit())
+	})
 
-		// No CR or CR status not populated - use client status
-		writeJSON(w, clientStatus)
+	// Force-close the circuit breaker once an outage is known to be over,
+	// rather than waiting out the cooldown
+	router.Post("/api/v1/status/nanabush/circuit/reset", func(w http.ResponseWriter, r *http.Request) {
+		var nanabushClient *nanabush.Client
+		if opts.GetNanabushClient != nil {
+			nanabushClient = opts.GetNanabushClient()
+		} else if opts.Nanabush != nil {
+			nanabushClient = opts.Nanabush
+		}
+		if nanabushClient == nil {
+			writeError(w, http.StatusServiceUnavailable, "translation service client not configured", nil)
+			return
+		}
+		previous := nanabushClient.Circuit().State
+		nanabushClient.ResetCircuit()
+		verbosity.Printf("[http] POST /status/nanabush/circuit/reset: circuit breaker was %s\n", previous)
+		broadcaster.triggerBroadcast()
+		writeJSON(w, map[string]any{
+			"success":       true,
+			"previousState": previous,
+			"circuit":       nanabushClient.Circuit(),
+		})
 	})
 
 	// Generic translation service status endpoint (alias for backward compatibility)
 	router.Get("/api/v1/status/translation", func(w http.ResponseWriter, r *http.Request) {
 		// Try to read from TranslationService CR status first
 		if opts.Client != nil {
-			tsName := "glooscap-translation-service"
+			tsName := wikiv1alpha1.TranslationServiceName
 			var ts wikiv1alpha1.TranslationService
 			err := opts.Client.Get(r.Context(), client.ObjectKey{Name: tsName}, &ts)
 			if err == nil {
@@ -284,6 +478,7 @@ func Start(ctx context.Context, opts Options) error {
 					MissedHeartbeats:  ts.Status.MissedHeartbeats,
 					HeartbeatInterval: int64(ts.Status.HeartbeatIntervalSeconds), // Already in seconds
 					LastHeartbeat:     lastHeartbeat,
+					ActiveEndpoint:    ts.Status.ActiveEndpoint,
 				})
 				return
 			}
@@ -311,34 +506,103 @@ func Start(ctx context.Context, opts Options) error {
 
 	router.Get("/api/v1/catalogue", func(w http.ResponseWriter, r *http.Request) {
 		target := r.URL.Query().Get("target")
+		if target != "" {
+			namespace, _, ok := strings.Cut(target, "/")
+			if !ok {
+				writeError(w, http.StatusBadRequest, "target must be namespace/name", nil)
+				return
+			}
+			if !namespaces.check(w, namespace) {
+				return
+			}
+		}
 		var pages []*catalog.Page
 		if opts.Catalogue != nil {
-			pages = opts.Catalogue.List(target)
+			for _, page := range opts.Catalogue.List(target) {
+				if namespaces.allowsTarget(page.WikiTarget) {
+					pages = append(pages, page)
+				}
+			}
 		}
 		writeJSON(w, pages)
 	})
 
+	// Bulk export of a target's catalogue, for search indexers and link
+	// checkers, as a JSON or CSV download
+	router.Get("/api/v1/catalogue/manifest", func(w http.ResponseWriter, r *http.Request) {
+		target := r.URL.Query().Get("target")
+		namespace, _, ok := strings.Cut(target, "/")
+		if !ok {
+			writeError(w, http.StatusBadRequest, "target is required, as namespace/name", nil)
+			return
+		}
+		if !namespaces.check(w, namespace) {
+			return
+		}
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "json"
+		}
+		if format != "json" && format != "csv" {
+			writeError(w, http.StatusBadRequest, "format must be json or csv", nil)
+			return
+		}
+		known := false
+		if opts.Catalogue != nil {
+			for _, t := range opts.Catalogue.Targets() {
+				known = known || t.ID == target
+			}
+		}
+		if !known {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("target %s has no catalogue", target), nil)
+			return
+		}
+
+		entries := catalogueManifest(opts.Catalogue.List(target))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", manifestFilename(target, format)))
+		if format == "csv" {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			if err := writeManifestCSV(w, entries); err != nil {
+				verbosity.Printf("[http] GET /catalogue/manifest: writing CSV for %s: %v\n", target, err)
+			}
+			return
+		}
+		writeJSON(w, map[string]any{
+			"target":      target,
+			"generatedAt": time.Now().UTC().Format(time.RFC3339),
+			"pageCount":   len(entries),
+			"pages":       entries,
+		})
+	})
+
 	router.Get("/api/v1/targets", func(w http.ResponseWriter, r *http.Request) {
 		var targets []catalog.Target
 		if opts.Catalogue != nil {
-			targets = opts.Catalogue.Targets()
+			for _, target := range opts.Catalogue.Targets() {
+				if namespaces.allows(target.Namespace) {
+					targets = append(targets, target)
+				}
+			}
 		}
 		writeJSON(w, targets)
 	})
 
 	router.Get("/api/v1/wikitargets", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 		namespace := r.URL.Query().Get("namespace")
 		if namespace == "" {
-			namespace = "glooscap-system"
+			namespace = defaultNamespace
+		}
+		if !namespaces.check(w, namespace) {
+			return
 		}
 
 		var list wikiv1alpha1.WikiTargetList
 		if err := opts.Client.List(r.Context(), &list, client.InNamespace(namespace)); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
@@ -346,6 +610,9 @@ func Start(ctx context.Context, opts Options) error {
 		for _, item := range list.Items {
 			status := map[string]any{
 				"catalogRevision": item.Status.CatalogRevision,
+				"lastSyncAdded":   item.Status.LastSyncAdded,
+				"lastSyncUpdated": item.Status.LastSyncUpdated,
+				"lastSyncDeleted": item.Status.LastSyncDeleted,
 			}
 			if item.Status.LastSyncTime != nil {
 				status["lastSyncTime"] = item.Status.LastSyncTime.Time.Format(time.RFC3339)
@@ -373,84 +640,222 @@ func Start(ctx context.Context, opts Options) error {
 		writeJSON(w, map[string]any{"items": result})
 	})
 
+	// Health endpoint summarising the last discovery outcome of every WikiTarget.
+	// A target is unhealthy if its last refresh failed or it has not synced within staleAfter.
+	router.Get("/api/v1/health/targets", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
+			return
+		}
+		namespace := r.URL.Query().Get("namespace")
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		if !namespaces.check(w, namespace) {
+			return
+		}
+		staleAfter := defaultTargetStaleThreshold
+		if v := r.URL.Query().Get("staleAfter"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid staleAfter duration: %q", v), nil)
+				return
+			}
+			staleAfter = d
+		}
+
+		var list wikiv1alpha1.WikiTargetList
+		if err := opts.Client.List(r.Context(), &list, client.InNamespace(namespace)); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		now := time.Now()
+		healthy := true
+		targets := make([]map[string]any, 0, len(list.Items))
+		for i := range list.Items {
+			health := targetHealth(&list.Items[i], now, staleAfter)
+			if !health["healthy"].(bool) {
+				healthy = false
+			}
+			targets = append(targets, health)
+		}
+		writeJSON(w, map[string]any{
+			"healthy":    healthy,
+			"staleAfter": staleAfter.String(),
+			"targets":    targets,
+		})
+	})
+
 	router.Get("/api/v1/jobs", func(w http.ResponseWriter, _ *http.Request) {
 		result := map[string]any{}
 		if opts.Jobs != nil {
-			result["items"] = opts.Jobs.List()
+			result["items"] = namespaces.jobs(opts.Jobs.List())
 		} else {
 			result["items"] = map[string]any{}
 		}
 		writeJSON(w, result)
 	})
 
-	// SSE endpoint for real-time catalogue updates
-	// API endpoint to inspect DB state
-	router.Get("/api/v1/db/state", func(w http.ResponseWriter, r *http.Request) {
-		if opts.Catalogue == nil {
-			writeJSON(w, map[string]any{"error": "catalogue not available"})
+	// Archived history of finished jobs, newest first
+	router.Get("/api/v1/jobs/archive", func(w http.ResponseWriter, r *http.Request) {
+		if opts.JobArchive == nil {
+			writeError(w, http.StatusServiceUnavailable, "job archive not configured", nil)
+			return
+		}
+		q, err := archiveQuery(r.URL.Query(), namespaces)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if q.Namespace != "" && !namespaces.check(w, q.Namespace) {
+			return
+		}
+		records, err := opts.JobArchive.Query(r.Context(), q)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("query job archive: %v", err), nil)
+			return
+		}
+		writeJSON(w, map[string]any{"items": records, "count": len(records)})
+	})
+
+	// Job detail, including the translated content kept for preview
+	router.Get("/api/v1/jobs/{namespace}/{jobId}", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "client not configured", nil)
+			return
+		}
+		namespace := chi.URLParam(r, "namespace")
+		jobId := chi.URLParam(r, "jobId")
+		if !namespaces.check(w, namespace) {
+			return
+		}
+
+		ctx := r.Context()
+
+		var job wikiv1alpha1.TranslationJob
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: jobId}, &job); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "translation job not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
-		state := buildStateResponse(opts)
-		writeJSON(w, state)
+		result := map[string]any{
+			"name":        job.Name,
+			"namespace":   job.Namespace,
+			"uuid":        string(job.UID),
+			"annotations": job.Annotations,
+			"spec":        job.Spec,
+			"status":      job.Status,
+		}
+		if content := job.Status.TranslatedContent; content != nil {
+			// Large bodies live in a ConfigMap; read it uncached like the feature flags
+			reader := opts.APIReader
+			if reader == nil {
+				reader = opts.Client
+			}
+			title, markdown, ok, err := jobcontent.Load(ctx, reader, &job)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err.Error(), nil)
+				return
+			}
+			if ok {
+				result["translatedContent"] = map[string]any{
+					"title":    title,
+					"markdown": markdown,
+					"size":     content.Size,
+					"sha256":   content.SHA256,
+				}
+			}
+		}
+		writeJSON(w, result)
 	})
 
-	// SSE endpoint for real-time WikiTarget and page state updates
-	router.Get("/api/v1/events", func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers first
-		origin := r.Header.Get("Origin")
-		allowedOrigins := []string{
-			"https://web-glooscap.apps.ocp-ai-sno-2.rh.dasmlab.org",
-			"http://web-glooscap.apps.ocp-ai-sno-2.rh.dasmlab.org",
-			"http://localhost:9000",
-			"http://localhost:8080",
-		}
-		// When using credentials, we MUST use a specific origin, not "*"
-		allowOrigin := ""
-		if origin != "" {
-			for _, allowed := range allowedOrigins {
-				if origin == allowed {
-					allowOrigin = origin
-					break
+	// SSE endpoint for real-time catalogue updates
+	// API endpoint to inspect DB state
+	// Job and token usage statistics
+	router.Get("/api/v1/stats", func(w http.ResponseWriter, _ *http.Request) {
+		if opts.Jobs == nil {
+			writeError(w, http.StatusServiceUnavailable, "job store not configured", nil)
+			return
+		}
+		states := make(map[string]int)
+		diagnostics := map[string]int{"queued": 0, "active": 0}
+		jobs := namespaces.jobs(opts.Jobs.List())
+		for _, job := range jobs {
+			state := string(job.Status.State)
+			if state == "" {
+				state = "Pending"
+			}
+			states[state]++
+			if job.Diagnostic {
+				switch job.Status.State {
+				case wikiv1alpha1.TranslationJobStateDispatching, wikiv1alpha1.TranslationJobStateRunning,
+					wikiv1alpha1.TranslationJobStatePublishing:
+					diagnostics["active"]++
+				case "", wikiv1alpha1.TranslationJobStateValidating, wikiv1alpha1.TranslationJobStateQueued:
+					diagnostics["queued"]++
 				}
 			}
-			// If no match but we have an origin, allow it (for development flexibility)
-			if allowOrigin == "" {
-				allowOrigin = origin
+		}
+		usage := opts.Jobs.TokenUsage()
+		var totalTokens int64
+		for ns, u := range usage {
+			if !namespaces.allows(ns) {
+				delete(usage, ns)
+				continue
 			}
+			totalTokens += u.Used
 		}
+		writeJSON(w, map[string]any{
+			"jobs": map[string]any{
+				"total":   len(jobs),
+				"byState": states,
+			},
+			"tokens": map[string]any{
+				"total":       totalTokens,
+				"byNamespace": usage,
+			},
+			// Diagnostic jobs waiting for and holding diagnostic capacity
+			"diagnostics": diagnostics,
+		})
+	})
 
-		// If no origin header, default to wildcard (but can't use credentials then)
-		if allowOrigin == "" {
-			allowOrigin = "*"
-		} else {
-			w.Header().Set("Access-Control-Allow-Credentials", "true")
+	router.Get("/api/v1/db/state", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Catalogue == nil {
+			writeJSON(w, map[string]any{"error": "catalogue not available"})
+			return
 		}
 
-		// Set up SSE headers - MUST be set before any writes
-		w.Header().Set("Content-Type", "text/event-stream")
-		w.Header().Set("Cache-Control", "no-cache")
-		w.Header().Set("Connection", "keep-alive")
-		w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
-		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Cache-Control")
-		w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
-		// Additional headers to help with HTTP/2 SSE compatibility
-		w.Header().Set("X-Content-Type-Options", "nosniff")
-
-		flusher, ok := w.(http.Flusher)
-		if !ok {
-			http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		data, _, err := broadcaster.state.get(opts)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
 
+	// SSE endpoint for real-time WikiTarget and page state updates
+	router.Get("/api/v1/events", func(w http.ResponseWriter, r *http.Request) {
 		// Subscribe to events
-		eventCh := broadcaster.subscribe()
+		eventCh, err := broadcaster.subscribe()
+		if err != nil {
+			writeSubscribeError(w, err)
+			return
+		}
 		defer broadcaster.unsubscribe(eventCh)
 
+		flusher, ok := startEventStream(w, r)
+		if !ok {
+			return
+		}
+
 		// Send initial state immediately
-		initialState := buildStateResponse(opts)
-		if data, err := json.Marshal(initialState); err == nil {
+		if data, _, err := broadcaster.state.get(opts); err == nil {
 			fmt.Fprintf(w, "data: %s\n\n", data)
 			flusher.Flush()
 		}
@@ -468,13 +873,57 @@ func Start(ctx context.Context, opts Options) error {
 				// Send keepalive comment
 				fmt.Fprintf(w, ": keepalive\n\n")
 				flusher.Flush()
-			case data := <-eventCh:
+			case data, ok := <-eventCh:
+				if !ok {
+					// Dropped for falling behind; the client reconnects
+					return
+				}
 				fmt.Fprintf(w, "data: %s\n\n", data)
 				flusher.Flush()
 			}
 		}
 	})
 
+	// SSE endpoint for one job: its status changes and translation_job events,
+	// closed once the job finishes
+	router.Get("/api/v1/jobs/{namespace}/{jobId}/events", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "client not configured", nil)
+			return
+		}
+		namespace := chi.URLParam(r, "namespace")
+		jobId := chi.URLParam(r, "jobId")
+		if !namespaces.check(w, namespace) {
+			return
+		}
+
+		var job wikiv1alpha1.TranslationJob
+		if err := opts.Client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: jobId}, &job); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "translation job not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		eventCh, err := broadcaster.subscribeFiltered(func(event controller.TranslationJobEvent) bool {
+			return event.JobName == jobId && event.Namespace == namespace
+		})
+		if err != nil {
+			writeSubscribeError(w, err)
+			return
+		}
+		defer broadcaster.unsubscribe(eventCh)
+
+		flusher, ok := startEventStream(w, r)
+		if !ok {
+			return
+		}
+
+		streamJobEvents(r.Context(), w, flusher, opts.Client, &job, eventCh, jobEventsPollInterval)
+	})
+
 	// API endpoint to trigger immediate event broadcast
 	router.Post("/api/v1/events/refresh", func(w http.ResponseWriter, r *http.Request) {
 		broadcaster.triggerBroadcast()
@@ -484,23 +933,26 @@ func Start(ctx context.Context, opts Options) error {
 	// API endpoint to approve duplicate overwrite
 	router.Patch("/api/v1/jobs/{namespace}/{jobId}/approve-duplicate", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "job approval not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "job approval not configured", nil)
 			return
 		}
 		namespace := chi.URLParam(r, "namespace")
 		jobId := chi.URLParam(r, "jobId")
 		if namespace == "" || jobId == "" {
-			http.Error(w, "namespace and jobId are required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "namespace and jobId are required", nil)
+			return
+		}
+		if !namespaces.check(w, namespace) {
 			return
 		}
 
 		var job wikiv1alpha1.TranslationJob
 		if err := opts.Client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: jobId}, &job); err != nil {
 			if errors.IsNotFound(err) {
-				http.Error(w, "translation job not found", http.StatusNotFound)
+				writeError(w, http.StatusNotFound, "translation job not found", nil)
 				return
 			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
@@ -508,67 +960,340 @@ func Start(ctx context.Context, opts Options) error {
 		if job.Annotations == nil {
 			job.Annotations = make(map[string]string)
 		}
-		job.Annotations["glooscap.dasmlab.org/duplicate-approved"] = "true"
+		job.Annotations[wikiv1alpha1.AnnotationDuplicateApproved] = "true"
 
 		if err := opts.Client.Update(r.Context(), &job); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
 		writeJSON(w, map[string]string{"status": "approved"})
 	})
 
-	router.Post("/api/v1/jobs", func(w http.ResponseWriter, r *http.Request) {
+	// Pre-flight a job submission: run the reconciler's validation checks
+	// without creating a TranslationJob
+	router.Post("/api/v1/jobs/validate", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "job submission not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "job submission not configured", nil)
 			return
 		}
 		var req createJobRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
 			return
 		}
-		if err := req.validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		if err := req.validate(opts.Notifier); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if !namespaces.check(w, req.Namespace) {
+			return
+		}
+		if !resolveTargetRef(r.Context(), w, opts.Client, req.Namespace, &req.TargetRef) {
 			return
 		}
 
-		job := &wikiv1alpha1.TranslationJob{
-			ObjectMeta: metav1.ObjectMeta{
-				GenerateName: "translation-",
-				Namespace:    req.Namespace,
-			},
-			Spec: wikiv1alpha1.TranslationJobSpec{
-				Source: wikiv1alpha1.TranslationSourceSpec{
-					TargetRef: req.TargetRef,
-					PageID:    req.PageID,
-				},
-				Destination: &wikiv1alpha1.TranslationDestinationSpec{
-					TargetRef:   req.TargetRef,
-					LanguageTag: req.LanguageTag,
-				},
-				Pipeline: wikiv1alpha1.TranslationPipelineMode(req.Pipeline),
-				Parameters: map[string]string{
-					"pageTitle": req.PageTitle,
-				},
-			},
+		// The job would be created now, so every existing job counts as older
+		job := req.job()
+		job.CreationTimestamp = metav1.Now()
+		issues, err := validator.Validate(r.Context(), job)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("validation failed: %v", err), nil)
+			return
+		}
+		if issues == nil {
+			issues = []controller.ValidationIssue{}
 		}
+		writeJSON(w, map[string]any{
+			"valid":  controller.FirstIssue(issues, controller.ValidationError) == nil,
+			"issues": issues,
+		})
+	})
 
-		if err := opts.Client.Create(r.Context(), job); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+	router.Post("/api/v1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "job submission not configured", nil)
+			return
+		}
+		var req createJobRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
+			return
+		}
+		if err := req.validate(opts.Notifier); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if !namespaces.check(w, req.Namespace) {
+			return
+		}
+		if !resolveTargetRef(r.Context(), w, opts.Client, req.Namespace, &req.TargetRef) {
+			return
+		}
+
+		job := req.job()
+
+		// The job runs in the controller; don't let a client disconnect abort its creation
+		createCtx, cancel := detachedContext(r, jobSubmitTimeout)
+		defer cancel()
+		if err := opts.Client.Create(createCtx, job); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 		writeJSON(w, map[string]string{"name": job.Name})
 	})
 
+	// Sync endpoint - creates jobs for every page changed since a point in time
+	router.Post("/api/v1/jobs/sync", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "job submission not configured", nil)
+			return
+		}
+		if opts.OutlineClientFactory == nil || opts.Catalogue == nil {
+			writeError(w, http.StatusServiceUnavailable, "catalogue not configured", nil)
+			return
+		}
+		var req syncJobsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
+			return
+		}
+		if err := req.validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if !namespaces.check(w, req.Namespace) {
+			return
+		}
+
+		ctx := r.Context()
+		var target wikiv1alpha1.WikiTarget
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: req.TargetRef}, &target); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		targetID := fmt.Sprintf("%s/%s", target.Namespace, target.Name)
+		pages := opts.Catalogue.List(targetID)
+		if len(pages) == 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(controller.DefaultRefreshInterval.Seconds())))
+			writeError(w, http.StatusConflict, "target not yet synced, try again", map[string]any{
+				"retryable": true,
+				"target":    targetID,
+			})
+			return
+		}
+		candidates := syncCandidates(pages, req.since)
+
+		current, err := translatedHashes(ctx, opts.Client, req.Namespace, req.TargetRef, req.LanguageTag)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		outlineClient, err := opts.OutlineClientFactory.New(ctx, opts.Client, &target)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create outline client: %v", err), nil)
+			return
+		}
+
+		// Job creation is detached from the request so a disconnect doesn't leave
+		// the run half-submitted; content fetches stop with the client.
+		createCtx, cancel := detachedContext(r, jobSubmitTimeout)
+		defer cancel()
+
+		created := []map[string]string{}
+		failed := []map[string]string{}
+		skipped := 0
+		for _, page := range candidates {
+			if clientGone(r) {
+				break
+			}
+			content, err := outlineClient.GetPageContent(ctx, page.ID)
+			if stderrors.Is(err, outline.ErrEmptyContent) {
+				// Nothing to translate
+				skipped++
+				continue
+			}
+			if err != nil {
+				failed = append(failed, map[string]string{"pageId": page.ID, "error": err.Error()})
+				continue
+			}
+			hash := controller.ContentHash(content.Markdown)
+			if current[page.ID][hash] {
+				skipped++
+				continue
+			}
+			job := newSyncJob(req, page, hash)
+			if err := opts.Client.Create(createCtx, job); err != nil {
+				failed = append(failed, map[string]string{"pageId": page.ID, "error": err.Error()})
+				continue
+			}
+			created = append(created, map[string]string{
+				"name":      job.Name,
+				"pageId":    page.ID,
+				"pageTitle": page.Title,
+			})
+		}
+
+		verbosity.Printf("[http] POST /jobs/sync: %s since %s -> %d created, %d unchanged, %d failed\n",
+			targetID, req.Since, len(created), skipped, len(failed))
+		writeJSON(w, map[string]any{
+			"jobs":             created,
+			"created":          len(created),
+			"skippedUnchanged": skipped,
+			"failed":           failed,
+			"candidates":       len(candidates),
+		})
+	})
+
+	// Retitle endpoint - translates only the changed source titles of existing translations
+	router.Post("/api/v1/jobs/retitle", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil || opts.OutlineClientFactory == nil || opts.Catalogue == nil {
+			writeError(w, http.StatusServiceUnavailable, "job submission not configured", nil)
+			return
+		}
+		var req retitleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
+			return
+		}
+		if err := req.validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if !namespaces.check(w, req.Namespace) {
+			return
+		}
+
+		var nanabushClient *nanabush.Client
+		if opts.GetNanabushClient != nil {
+			nanabushClient = opts.GetNanabushClient()
+		} else if opts.Nanabush != nil {
+			nanabushClient = opts.Nanabush
+		}
+		if nanabushClient == nil {
+			writeError(w, http.StatusServiceUnavailable, "translation service not configured", nil)
+			return
+		}
+
+		ctx := r.Context()
+		targetID := fmt.Sprintf("%s/%s", req.Namespace, req.TargetRef)
+		pages := make(map[string]*catalog.Page)
+		for _, p := range opts.Catalogue.List(targetID) {
+			pages[p.ID] = p
+		}
+		if len(pages) == 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(controller.DefaultRefreshInterval.Seconds())))
+			writeError(w, http.StatusConflict, "target not yet synced, try again", map[string]any{
+				"retryable": true,
+				"target":    targetID,
+			})
+			return
+		}
+
+		jobs, err := latestTranslations(ctx, opts.Client, req.Namespace, req.TargetRef, req.PageIDs)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		// Recording the applied title must survive a disconnect once Outline was updated
+		patchCtx, cancel := detachedContext(r, jobSubmitTimeout)
+		defer cancel()
+
+		destClients := make(map[string]*outline.Client)
+		translated := make(map[string]bool)
+		updated := []retitleResult{}
+		failed := []retitleResult{}
+		unchanged, missingSource := 0, 0
+		for i := range jobs {
+			job := &jobs[i]
+			translated[job.Spec.Source.PageID] = true
+			result := retitleResult{
+				Job:          job.Name,
+				SourcePageID: job.Spec.Source.PageID,
+				PageID:       job.Annotations[wikiv1alpha1.AnnotationPublishedPageID],
+				LanguageTag:  jobLanguageTag(job),
+			}
+
+			page, ok := pages[job.Spec.Source.PageID]
+			if !ok {
+				missingSource++
+				continue
+			}
+			if page.Title == lastSourceTitle(job) {
+				unchanged++
+				continue
+			}
+			if clientGone(r) {
+				break
+			}
+
+			destRef := jobDestinationRef(job)
+			destClient, ok := destClients[destRef]
+			if !ok {
+				var destTarget wikiv1alpha1.WikiTarget
+				if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: destRef}, &destTarget); err != nil {
+					result.Error = fmt.Sprintf("get destination target: %v", err)
+					failed = append(failed, result)
+					continue
+				}
+				if destClient, err = opts.OutlineClientFactory.New(ctx, opts.Client, &destTarget); err != nil {
+					result.Error = fmt.Sprintf("create outline client: %v", err)
+					failed = append(failed, result)
+					continue
+				}
+				destClients[destRef] = destClient
+			}
+
+			title, err := retitleTranslation(ctx, nanabushClient, destClient, job, page.Title, page.Language)
+			if err != nil {
+				result.Error = err.Error()
+				failed = append(failed, result)
+				continue
+			}
+			result.Title = title
+
+			base := job.DeepCopy()
+			if job.Annotations == nil {
+				job.Annotations = make(map[string]string)
+			}
+			job.Annotations[wikiv1alpha1.AnnotationTranslatedSourceTitle] = page.Title
+			if err := opts.Client.Patch(patchCtx, job, client.MergeFrom(base)); err != nil {
+				verbosity.Printf("[http] POST /jobs/retitle: failed to record title on job %s: %v\n", job.Name, err)
+			}
+			updated = append(updated, result)
+		}
+
+		noTranslation := []string{}
+		for _, id := range req.PageIDs {
+			if !translated[id] {
+				noTranslation = append(noTranslation, id)
+			}
+		}
+
+		writeJSON(w, map[string]any{
+			"updated":              updated,
+			"failed":               failed,
+			"skippedUnchanged":     unchanged,
+			"skippedMissingSource": missingSource,
+			"noTranslation":        noTranslation,
+		})
+	})
+
 	// Get page content endpoint (for analysis)
 	router.Get("/api/v1/pages/{targetRef}/{pageId}/content", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "page content retrieval not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "page content retrieval not configured", nil)
 			return
 		}
 		if opts.OutlineClientFactory == nil {
-			http.Error(w, "outline client factory not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "outline client factory not configured", nil)
 			return
 		}
 
@@ -576,11 +1301,14 @@ func Start(ctx context.Context, opts Options) error {
 		pageID := chi.URLParam(r, "pageId")
 		namespace := r.URL.Query().Get("namespace")
 		if namespace == "" {
-			namespace = "glooscap-system"
+			namespace = defaultNamespace
+		}
+		if !namespaces.check(w, namespace) {
+			return
 		}
 
 		if targetRef == "" || pageID == "" {
-			http.Error(w, "targetRef and pageId are required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "targetRef and pageId are required", nil)
 			return
 		}
 
@@ -590,24 +1318,24 @@ func Start(ctx context.Context, opts Options) error {
 		var target wikiv1alpha1.WikiTarget
 		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: targetRef}, &target); err != nil {
 			if errors.IsNotFound(err) {
-				http.Error(w, "WikiTarget not found", http.StatusNotFound)
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
 				return
 			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
 		// Create Outline client
 		outlineClient, err := opts.OutlineClientFactory.New(ctx, opts.Client, &target)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to create outline client: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create outline client: %v", err), nil)
 			return
 		}
 
 		// Get page content
 		pageContent, err := outlineClient.GetPageContent(ctx, pageID)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to fetch page content: %v", err), http.StatusInternalServerError)
+		if err != nil && !stderrors.Is(err, outline.ErrEmptyContent) {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to fetch page content: %v", err), nil)
 			return
 		}
 
@@ -662,10 +1390,107 @@ func Start(ctx context.Context, opts Options) error {
 		})
 	})
 
+	// Batch page content endpoint - fetches several pages in one round-trip
+	router.Post("/api/v1/pages/{targetRef}/content/batch", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "page content retrieval not configured", nil)
+			return
+		}
+		if opts.OutlineClientFactory == nil {
+			writeError(w, http.StatusServiceUnavailable, "outline client factory not configured", nil)
+			return
+		}
+
+		targetRef := chi.URLParam(r, "targetRef")
+		namespace := r.URL.Query().Get("namespace")
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		if !namespaces.check(w, namespace) {
+			return
+		}
+
+		var req struct {
+			PageIDs []string `json:"pageIds"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, decodeErrorStatus(err), fmt.Sprintf("invalid request: %v", err), nil)
+			return
+		}
+
+		// De-duplicate while preserving the requested order
+		seen := make(map[string]bool, len(req.PageIDs))
+		pageIDs := make([]string, 0, len(req.PageIDs))
+		for _, id := range req.PageIDs {
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			pageIDs = append(pageIDs, id)
+		}
+		if len(pageIDs) == 0 {
+			writeError(w, http.StatusBadRequest, "pageIds is required", nil)
+			return
+		}
+		if len(pageIDs) > maxPageContentBatchSize {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("too many pages requested (max %d)", maxPageContentBatchSize), map[string]any{
+				"requested": len(pageIDs),
+				"max":       maxPageContentBatchSize,
+			})
+			return
+		}
+
+		ctx := r.Context()
+
+		// Get WikiTarget
+		var target wikiv1alpha1.WikiTarget
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: targetRef}, &target); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		// Create Outline client
+		outlineClient, err := opts.OutlineClientFactory.New(ctx, opts.Client, &target)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create outline client: %v", err), nil)
+			return
+		}
+
+		results := make([]map[string]any, 0, len(pageIDs))
+		failed := 0
+		for _, page := range outlineClient.GetPagesContent(ctx, pageIDs, opts.PageFetchWorkers) {
+			if page.Err != nil && !stderrors.Is(page.Err, outline.ErrEmptyContent) {
+				failed++
+				results = append(results, map[string]any{
+					"pageId": page.PageID,
+					"error":  page.Err.Error(),
+				})
+				continue
+			}
+			results = append(results, map[string]any{
+				"pageId":    page.Content.ID,
+				"title":     page.Content.Title,
+				"slug":      page.Content.Slug,
+				"markdown":  page.Content.Markdown,
+				"rawLength": len(page.Content.Markdown),
+			})
+		}
+
+		writeJSON(w, map[string]any{
+			"pages":  results,
+			"total":  len(results),
+			"failed": failed,
+		})
+	})
+
 	// Approve/publish draft page endpoint - creates a publish job
 	router.Post("/api/v1/approve-translation", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "client not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "client not configured", nil)
 			return
 		}
 
@@ -674,12 +1499,15 @@ func Start(ctx context.Context, opts Options) error {
 			Namespace string `json:"namespace"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
 			return
 		}
 
 		if req.JobName == "" || req.Namespace == "" {
-			http.Error(w, "jobName and namespace are required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "jobName and namespace are required", nil)
+			return
+		}
+		if !namespaces.check(w, req.Namespace) {
 			return
 		}
 
@@ -689,103 +1517,423 @@ func Start(ctx context.Context, opts Options) error {
 		var job wikiv1alpha1.TranslationJob
 		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: req.JobName}, &job); err != nil {
 			if errors.IsNotFound(err) {
-				http.Error(w, "TranslationJob not found", http.StatusNotFound)
+				writeError(w, http.StatusNotFound, "TranslationJob not found", nil)
 				return
 			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
 		// Verify job is in AwaitingApproval state
 		if job.Status.State != wikiv1alpha1.TranslationJobStateAwaitingApproval {
-			http.Error(w, fmt.Sprintf("job is not awaiting approval (current state: %s)", job.Status.State), http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("job is not awaiting approval (current state: %s)", job.Status.State), nil)
 			return
 		}
 
 		// Get page ID from annotations
 		pageID := ""
 		if job.Annotations != nil {
-			if id, ok := job.Annotations["glooscap.dasmlab.org/published-page-id"]; ok {
+			if id, ok := job.Annotations[wikiv1alpha1.AnnotationPublishedPageID]; ok {
 				pageID = id
 			}
 		}
 
 		if pageID == "" {
-			http.Error(w, "no published page ID found in job annotations", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "no published page ID found in job annotations", nil)
+			return
+		}
+
+		// Get destination WikiTarget
+		destTargetRef := job.Spec.Source.TargetRef
+		if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
+			destTargetRef = job.Spec.Destination.TargetRef
+		}
+
+		var destTarget wikiv1alpha1.WikiTarget
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: destTargetRef}, &destTarget); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get destination WikiTarget: %v", err), nil)
+			return
+		}
+
+		// Create a publish job (TranslationJob with Pipeline=Publish)
+		// For now, we'll use a special parameter to indicate this is a publish job
+		publishJobName := fmt.Sprintf("publish-%s", job.Name)
+		publishJob := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      publishJobName,
+				Namespace: req.Namespace,
+				Labels: map[string]string{
+					wikiv1alpha1.AnnotationPublishJob:  "true",
+					wikiv1alpha1.AnnotationOriginalJob: job.Name,
+				},
+			},
+			Spec: wikiv1alpha1.TranslationJobSpec{
+				Source: wikiv1alpha1.TranslationSourceSpec{
+					TargetRef: destTargetRef,
+					PageID:    pageID, // The draft page ID to publish
+				},
+				Pipeline: wikiv1alpha1.TranslationPipelineModeTektonJob,
+				Parameters: map[string]string{
+					"publish":      "true",
+					"originalJob":  job.Name,
+					"pageId":       pageID,
+					"targetRef":    destTargetRef,
+				},
+			},
+		}
+
+		// Create the publish job (independent of the submitting request)
+		createCtx, cancel := detachedContext(r, jobSubmitTimeout)
+		defer cancel()
+		if err := opts.Client.Create(createCtx, publishJob); err != nil {
+			if errors.IsAlreadyExists(err) {
+				writeError(w, http.StatusConflict, "publish job already exists", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create publish job: %v", err), nil)
+			return
+		}
+
+		// Update original job to mark approval
+		if job.Annotations == nil {
+			job.Annotations = make(map[string]string)
+		}
+		job.Annotations[wikiv1alpha1.AnnotationApprovedAt] = time.Now().Format(time.RFC3339)
+		job.Annotations[wikiv1alpha1.AnnotationPublishJob] = publishJobName
+		if err := opts.Client.Update(ctx, &job); err != nil {
+			verbosity.Printf("warning: failed to update job annotations: %v\n", err)
+		}
+
+		writeJSON(w, map[string]any{
+			"success":      true,
+			"publishJob":   publishJobName,
+			"originalJob":  job.Name,
+			"message":      "Publish job created successfully",
+		})
+	})
+
+	// Unpublish endpoint - reverts a job's translated page back to draft in Outline
+	router.Post("/api/v1/jobs/{namespace}/{jobId}/unpublish", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "client not configured", nil)
+			return
+		}
+		if opts.OutlineClientFactory == nil {
+			writeError(w, http.StatusServiceUnavailable, "outline client factory not configured", nil)
+			return
+		}
+		namespace := chi.URLParam(r, "namespace")
+		jobId := chi.URLParam(r, "jobId")
+		if namespace == "" || jobId == "" {
+			writeError(w, http.StatusBadRequest, "namespace and jobId are required", nil)
+			return
+		}
+		if !namespaces.check(w, namespace) {
+			return
+		}
+
+		ctx := r.Context()
+
+		var job wikiv1alpha1.TranslationJob
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: jobId}, &job); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "translation job not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		pageID := job.Annotations[wikiv1alpha1.AnnotationPublishedPageID]
+		if pageID == "" {
+			writeError(w, http.StatusBadRequest, "no published page ID found in job annotations", nil)
+			return
+		}
+		if job.Annotations[wikiv1alpha1.AnnotationIsDraft] == "true" {
+			writeError(w, http.StatusConflict, "translated page is already a draft", nil)
+			return
+		}
+
+		destTargetRef := job.Spec.Source.TargetRef
+		if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
+			destTargetRef = job.Spec.Destination.TargetRef
+		}
+
+		var destTarget wikiv1alpha1.WikiTarget
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: destTargetRef}, &destTarget); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get destination WikiTarget: %v", err), nil)
+			return
+		}
+
+		outlineClient, err := opts.OutlineClientFactory.New(ctx, opts.Client, &destTarget)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create outline client: %v", err), nil)
+			return
+		}
+
+		if _, err := outlineClient.UnpublishPage(ctx, pageID); err != nil {
+			writeError(w, http.StatusBadGateway, fmt.Sprintf("failed to unpublish page: %v", err), nil)
+			return
+		}
+
+		wikiv1alpha1.SetDraft(&job, true)
+		if err := opts.Client.Update(ctx, &job); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("page unpublished but failed to update job annotations: %v", err), nil)
+			return
+		}
+
+		broadcaster.triggerBroadcast()
+		writeJSON(w, map[string]any{
+			"success": true,
+			"job":     job.Name,
+			"pageId":  pageID,
+			"message": "Translated page reverted to draft",
+		})
+	})
+
+	// Restore a job's translated page after it was deleted or archived, e.g. by a
+	// reject or an orphan cleanup. Outline only keeps deleted pages in its trash
+	// for a limited time, so this can fail once the page has been purged.
+	router.Post("/api/v1/jobs/{namespace}/{jobId}/restore-draft", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "client not configured", nil)
+			return
+		}
+		if opts.OutlineClientFactory == nil {
+			writeError(w, http.StatusServiceUnavailable, "outline client factory not configured", nil)
+			return
+		}
+		namespace := chi.URLParam(r, "namespace")
+		jobId := chi.URLParam(r, "jobId")
+		if namespace == "" || jobId == "" {
+			writeError(w, http.StatusBadRequest, "namespace and jobId are required", nil)
+			return
+		}
+		if !namespaces.check(w, namespace) {
+			return
+		}
+
+		ctx := r.Context()
+
+		var job wikiv1alpha1.TranslationJob
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: jobId}, &job); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "translation job not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		pageID := job.Annotations[wikiv1alpha1.AnnotationPublishedPageID]
+		if pageID == "" {
+			writeError(w, http.StatusBadRequest, "no published page ID found in job annotations", nil)
+			return
+		}
+
+		destTargetRef := job.Spec.Source.TargetRef
+		if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
+			destTargetRef = job.Spec.Destination.TargetRef
+		}
+
+		var destTarget wikiv1alpha1.WikiTarget
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: destTargetRef}, &destTarget); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get destination WikiTarget: %v", err), nil)
+			return
+		}
+
+		outlineClient, err := opts.OutlineClientFactory.New(ctx, opts.Client, &destTarget)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create outline client: %v", err), nil)
+			return
+		}
+
+		if err := outlineClient.RestorePage(ctx, pageID); err != nil {
+			if stderrors.Is(err, outline.ErrPageNotRestorable) {
+				writeError(w, http.StatusGone, "translated page was permanently deleted and can no longer be restored; retry the translation instead", nil)
+				return
+			}
+			writeError(w, http.StatusBadGateway, fmt.Sprintf("failed to restore page: %v", err), nil)
+			return
+		}
+
+		verbosity.Printf("restored translated page %s for job %s/%s\n", pageID, namespace, jobId)
+		broadcaster.triggerBroadcast()
+		writeJSON(w, map[string]any{
+			"success": true,
+			"job":     job.Name,
+			"pageId":  pageID,
+			"message": "Translated page restored",
+		})
+	})
+
+	// Retry only the languages of a job that failed, one new job per language
+	router.Post("/api/v1/jobs/{namespace}/{jobId}/retry-failed-languages", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "job submission not configured", nil)
+			return
+		}
+		namespace := chi.URLParam(r, "namespace")
+		jobId := chi.URLParam(r, "jobId")
+		if !namespaces.check(w, namespace) {
+			return
+		}
+
+		var job wikiv1alpha1.TranslationJob
+		if err := opts.Client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: jobId}, &job); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "translation job not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+		if !job.Status.State.IsTerminal() {
+			writeError(w, http.StatusConflict, "translation job is still running", map[string]any{
+				"state": job.Status.State,
+			})
+			return
+		}
+		languages := failedLanguages(&job)
+		if len(languages) == 0 {
+			writeError(w, http.StatusConflict, "translation job has no failed languages", map[string]any{
+				"state": job.Status.State,
+			})
+			return
+		}
+
+		ctx, cancel := detachedContext(r, jobSubmitTimeout)
+		defer cancel()
+
+		created := []map[string]string{}
+		failed := []map[string]string{}
+		for _, lang := range languages {
+			retry := newLanguageRetryJob(&job, lang)
+			if err := opts.Client.Create(ctx, retry); err != nil {
+				failed = append(failed, map[string]string{"lang": lang, "error": err.Error()})
+				continue
+			}
+			created = append(created, map[string]string{"lang": lang, "name": retry.Name})
+		}
+
+		verbosity.Printf("[http] POST /jobs/%s/%s/retry-failed-languages: %d created, %d failed\n",
+			namespace, jobId, len(created), len(failed))
+		if len(created) > 0 {
+			broadcaster.triggerBroadcast()
+		}
+		writeJSON(w, map[string]any{
+			"job":     job.Name,
+			"retries": created,
+			"failed":  failed,
+		})
+	})
+
+	// Post a review comment on a job's translated page
+	router.Post("/api/v1/jobs/{namespace}/{jobId}/comment", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "client not configured", nil)
+			return
+		}
+		if opts.OutlineClientFactory == nil {
+			writeError(w, http.StatusServiceUnavailable, "outline client factory not configured", nil)
+			return
+		}
+		namespace := chi.URLParam(r, "namespace")
+		jobId := chi.URLParam(r, "jobId")
+		if !namespaces.check(w, namespace) {
+			return
+		}
+
+		var req struct {
+			Text     string `json:"text"`
+			Reviewer string `json:"reviewer"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, decodeErrorStatus(err), fmt.Sprintf("invalid request: %v", err), nil)
+			return
+		}
+		req.Text = strings.TrimSpace(req.Text)
+		if req.Text == "" {
+			writeError(w, http.StatusBadRequest, "text is required", nil)
+			return
+		}
+
+		ctx := r.Context()
+
+		var job wikiv1alpha1.TranslationJob
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: jobId}, &job); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "translation job not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		pageID := job.Annotations[wikiv1alpha1.AnnotationPublishedPageID]
+		if pageID == "" {
+			writeError(w, http.StatusBadRequest, "no published page ID found in job annotations", nil)
 			return
 		}
 
-		// Get destination WikiTarget
 		destTargetRef := job.Spec.Source.TargetRef
 		if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
 			destTargetRef = job.Spec.Destination.TargetRef
 		}
 
 		var destTarget wikiv1alpha1.WikiTarget
-		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: destTargetRef}, &destTarget); err != nil {
-			http.Error(w, fmt.Sprintf("failed to get destination WikiTarget: %v", err), http.StatusInternalServerError)
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: destTargetRef}, &destTarget); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get destination WikiTarget: %v", err), nil)
 			return
 		}
 
-		// Create a publish job (TranslationJob with Pipeline=Publish)
-		// For now, we'll use a special parameter to indicate this is a publish job
-		publishJobName := fmt.Sprintf("publish-%s", job.Name)
-		publishJob := &wikiv1alpha1.TranslationJob{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      publishJobName,
-				Namespace: req.Namespace,
-				Labels: map[string]string{
-					"glooscap.dasmlab.org/publish-job": "true",
-					"glooscap.dasmlab.org/original-job": job.Name,
-				},
-			},
-			Spec: wikiv1alpha1.TranslationJobSpec{
-				Source: wikiv1alpha1.TranslationSourceSpec{
-					TargetRef: destTargetRef,
-					PageID:    pageID, // The draft page ID to publish
-				},
-				Pipeline: wikiv1alpha1.TranslationPipelineModeTektonJob,
-				Parameters: map[string]string{
-					"publish":      "true",
-					"originalJob":  job.Name,
-					"pageId":       pageID,
-					"targetRef":    destTargetRef,
-				},
-			},
+		outlineClient, err := opts.OutlineClientFactory.New(ctx, opts.Client, &destTarget)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create outline client: %v", err), nil)
+			return
 		}
 
-		// Create the publish job
-		if err := opts.Client.Create(ctx, publishJob); err != nil {
-			if errors.IsAlreadyExists(err) {
-				http.Error(w, "publish job already exists", http.StatusConflict)
+		text := req.Text
+		if req.Reviewer != "" {
+			text = fmt.Sprintf("**Glooscap review (%s):** %s", req.Reviewer, req.Text)
+		}
+		comment, err := outlineClient.CreateComment(ctx, pageID, text)
+		if err != nil {
+			if stderrors.Is(err, outline.ErrCommentsDisabled) {
+				writeError(w, http.StatusNotImplemented, "comments are disabled on the destination Outline instance", map[string]any{
+					"target": destTargetRef,
+				})
+				return
+			}
+			if stderrors.Is(err, outline.ErrReadOnly) {
+				writeError(w, http.StatusConflict, "destination WikiTarget is read-only", map[string]any{
+					"target": destTargetRef,
+				})
+				return
+			}
+			if outline.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "translated page no longer exists on the destination", map[string]any{
+					"target": destTargetRef,
+					"pageId": pageID,
+				})
 				return
 			}
-			http.Error(w, fmt.Sprintf("failed to create publish job: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusBadGateway, fmt.Sprintf("failed to create comment: %v", err), nil)
 			return
 		}
 
-		// Update original job to mark approval
-		if job.Annotations == nil {
-			job.Annotations = make(map[string]string)
-		}
-		job.Annotations["glooscap.dasmlab.org/approved-at"] = time.Now().Format(time.RFC3339)
-		job.Annotations["glooscap.dasmlab.org/publish-job"] = publishJobName
-		if err := opts.Client.Update(ctx, &job); err != nil {
-			fmt.Printf("warning: failed to update job annotations: %v\n", err)
-		}
-
 		writeJSON(w, map[string]any{
-			"success":      true,
-			"publishJob":   publishJobName,
-			"originalJob":  job.Name,
-			"message":      "Publish job created successfully",
+			"success":   true,
+			"job":       job.Name,
+			"pageId":    pageID,
+			"commentId": comment.ID,
 		})
 	})
 
 	// Direct translation endpoint (MVP)
 	router.Post("/api/v1/translate", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "translation not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "translation not configured", nil)
 			return
 		}
 		// Use getter function if available (for runtime updates), otherwise use direct reference
@@ -797,11 +1945,11 @@ func Start(ctx context.Context, opts Options) error {
 		}
 
 		if nanabushClient == nil {
-			http.Error(w, "translation service not available", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "translation service not available", nil)
 			return
 		}
 		if opts.OutlineClientFactory == nil {
-			http.Error(w, "outline client factory not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "outline client factory not configured", nil)
 			return
 		}
 
@@ -811,14 +1959,20 @@ func Start(ctx context.Context, opts Options) error {
 			PageID      string `json:"pageId"`
 			PageTitle   string `json:"pageTitle"`
 			LanguageTag string `json:"languageTag"`
+			Context     string `json:"context"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
 			return
 		}
 
-		if req.TargetRef == "" || req.PageID == "" {
-			http.Error(w, "targetRef and pageId are required", http.StatusBadRequest)
+		if req.PageID == "" {
+			writeError(w, http.StatusBadRequest, "pageId is required", nil)
+			return
+		}
+		targetLang, err := canonicalLanguageTag(req.LanguageTag)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
 			return
 		}
 
@@ -828,32 +1982,25 @@ func Start(ctx context.Context, opts Options) error {
 		var target wikiv1alpha1.WikiTarget
 		namespace := req.Namespace
 		if namespace == "" {
-			namespace = "glooscap-system"
+			namespace = defaultNamespace
 		}
-		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: req.TargetRef}, &target); err != nil {
-			if errors.IsNotFound(err) {
-				http.Error(w, "WikiTarget not found", http.StatusNotFound)
-				return
-			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+		if !namespaces.check(w, namespace) {
 			return
 		}
-
-		// Create Outline client
-		outlineClient, err := opts.OutlineClientFactory.New(ctx, opts.Client, &target)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to create outline client: %v", err), http.StatusInternalServerError)
+		if !resolveTargetRef(ctx, w, opts.Client, namespace, &req.TargetRef) {
 			return
 		}
-
-		// Get page content
-		pageContent, err := outlineClient.GetPageContent(ctx, req.PageID)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to fetch page content: %v", err), http.StatusInternalServerError)
+		if err := opts.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: req.TargetRef}, &target); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
-		// Get page metadata from catalog if available
+		// Get page metadata from catalog. Without it the source language and title
+		// would be guessed, so refuse with a retryable status until discovery has run.
 		var sourcePage *catalog.Page
 		if opts.Catalogue != nil {
 			targetID := fmt.Sprintf("%s/%s", target.Namespace, target.Name)
@@ -864,6 +2011,37 @@ func Start(ctx context.Context, opts Options) error {
 					break
 				}
 			}
+			if sourcePage == nil {
+				message := "page not yet in catalogue, refresh the target and try again"
+				if len(pages) == 0 {
+					message = "target not yet synced, try again"
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(int(controller.DefaultRefreshInterval.Seconds())))
+				writeError(w, http.StatusConflict, message, map[string]any{
+					"retryable": true,
+					"target":    targetID,
+					"pageId":    req.PageID,
+				})
+				return
+			}
+		}
+
+		// Create Outline client
+		outlineClient, err := opts.OutlineClientFactory.New(ctx, opts.Client, &target)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create outline client: %v", err), nil)
+			return
+		}
+
+		// Get page content
+		pageContent, err := outlineClient.GetPageContent(ctx, req.PageID)
+		if stderrors.Is(err, outline.ErrEmptyContent) {
+			writeError(w, http.StatusUnprocessableEntity, "source page is empty; there is nothing to translate", nil)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to fetch page content: %v", err), nil)
+			return
 		}
 
 		// Enrich page content with title if available
@@ -876,16 +2054,12 @@ func Start(ctx context.Context, opts Options) error {
 		}
 
 		// Determine source language
-		sourceLang := "en"
-		if sourcePage != nil && sourcePage.Language != "" {
-			sourceLang = sourcePage.Language
+		sourceLang := target.Spec.SourceLanguage("")
+		if sourcePage != nil {
+			sourceLang = target.Spec.SourceLanguage(sourcePage.DetectedLanguage)
 		}
 
 		// Determine target language
-		targetLang := req.LanguageTag
-		if targetLang == "" {
-			targetLang = "fr-CA"
-		}
 
 		// Call translation service
 		grpcReq := nanabush.TranslateRequest{
@@ -902,19 +2076,25 @@ func Start(ctx context.Context, opts Options) error {
 			SourceWikiURI:  target.Spec.URI,
 			PageID:         req.PageID,
 			PageSlug:       pageContent.Slug,
+			Context:        req.Context,
 		}
 
-		// Use a longer timeout for translation (5 minutes) to handle large documents
-		translateCtx, translateCancel := context.WithTimeout(ctx, 5*time.Minute)
+		// Use a longer timeout for translation (5 minutes) to handle large documents.
+		// The result is only useful to this caller, so stop if they disconnect.
+		translateCtx, translateCancel := requestContext(r, 5*time.Minute)
 		defer translateCancel()
 		translateResp, err := nanabushClient.Translate(translateCtx, grpcReq)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("translation failed: %v", err), http.StatusInternalServerError)
+			if clientGone(r) {
+				verbosity.Printf("[http] POST /translate: client disconnected, cancelled translation of page %s\n", req.PageID)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("translation failed: %v", err), nil)
 			return
 		}
 
 		if !translateResp.Success {
-			http.Error(w, fmt.Sprintf("translation failed: %s", translateResp.ErrorMessage), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("translation failed: %s", translateResp.ErrorMessage), nil)
 			return
 		}
 
@@ -931,15 +2111,93 @@ func Start(ctx context.Context, opts Options) error {
 		})
 	})
 
+	// Pipeline modes a job may request and whether each can currently run
+	router.Get("/api/v1/pipelines", func(w http.ResponseWriter, _ *http.Request) {
+		var nanabushClient *nanabush.Client
+		if opts.GetNanabushClient != nil {
+			nanabushClient = opts.GetNanabushClient()
+		} else if opts.Nanabush != nil {
+			nanabushClient = opts.Nanabush
+		}
+		writeJSON(w, map[string]any{
+			"pipelines": pipelineModes(opts.Dispatcher, nanabushClient),
+		})
+	})
+
+	// Effective configuration - startup settings plus the live translation service config
+	router.Get("/api/v1/config", func(w http.ResponseWriter, r *http.Request) {
+		var runtimeCfg RuntimeConfig
+		if opts.RuntimeConfig != nil {
+			runtimeCfg = *opts.RuntimeConfig
+		}
+		runtimeCfg.APIAddr = opts.Addr
+		if runtimeCfg.RunnerAPIServerURL != "" {
+			runtimeCfg.RunnerAPIServerURL = redactURL(runtimeCfg.RunnerAPIServerURL)
+		}
+
+		translationService := map[string]any{"source": "none"}
+		insecureTargets := []string{}
+		if opts.Client != nil {
+			var ts wikiv1alpha1.TranslationService
+			if err := opts.Client.Get(r.Context(), client.ObjectKey{Name: wikiv1alpha1.TranslationServiceName}, &ts); err == nil {
+				fallbacks := make([]string, 0, len(ts.Spec.Fallbacks))
+				for _, fb := range ts.Spec.Fallbacks {
+					fallbacks = append(fallbacks, fb.Address)
+				}
+				translationService = map[string]any{
+					"source":         "TranslationService/" + ts.Name,
+					"address":        ts.Spec.Address,
+					"type":           ts.Spec.Type,
+					"secure":         ts.Spec.Secure,
+					"fallbacks":      fallbacks,
+					"activeEndpoint": ts.Status.ActiveEndpoint,
+				}
+			}
+
+			var targets wikiv1alpha1.WikiTargetList
+			if err := opts.Client.List(r.Context(), &targets); err == nil {
+				for _, t := range targets.Items {
+					if t.Spec.InsecureSkipTLSVerify {
+						insecureTargets = append(insecureTargets, t.Namespace+"/"+t.Name)
+					}
+				}
+			}
+		}
+		if translationService["source"] == "none" && opts.ConfigStore != nil {
+			cfg := opts.ConfigStore.GetTranslationServiceConfig()
+			translationService = map[string]any{
+				"source":  "defaults",
+				"address": cfg.Address,
+				"type":    cfg.Type,
+				"secure":  cfg.Secure,
+			}
+		}
+
+		writeJSON(w, map[string]any{
+			"runtime":            runtimeCfg,
+			"translationService": translationService,
+			"defaults": map[string]any{
+				"namespace":             defaultNamespace,
+				"languageTag":           defaultLanguageTag,
+				"pipeline":              string(wikiv1alpha1.TranslationPipelineModeTektonJob),
+				"insecureSkipTLSVerify": defaultInsecureSkipTLSVerify,
+			},
+			"security": map[string]any{
+				"strictOutlineTLS":   len(insecureTargets) == 0,
+				"insecureTLSTargets": insecureTargets,
+			},
+		})
+	})
+
 	// Translation Service Configuration CRUD endpoints
 	router.Get("/api/v1/translation-service", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 
 		// Try to read from TranslationService CR first
-		tsName := "glooscap-translation-service"
+		tsName := wikiv1alpha1.TranslationServiceName
 		var ts wikiv1alpha1.TranslationService
 		err := opts.Client.Get(r.Context(), client.ObjectKey{Name: tsName}, &ts)
 		if err == nil {
@@ -965,21 +2223,61 @@ func Start(ctx context.Context, opts Options) error {
 		writeJSON(w, TranslationServiceConfig{})
 	})
 
+	// Supported target languages, for populating the UI language dropdown
+	router.Get("/api/v1/translation-service/languages", func(w http.ResponseWriter, r *http.Request) {
+		var languages []string
+		source := "client"
+
+		var nanabushClient *nanabush.Client
+		if opts.GetNanabushClient != nil {
+			nanabushClient = opts.GetNanabushClient()
+		} else if opts.Nanabush != nil {
+			nanabushClient = opts.Nanabush
+		}
+
+		if nanabushClient != nil {
+			var err error
+			languages, err = nanabushClient.SupportedLanguages(r.Context())
+			if err != nil {
+				writeError(w, http.StatusBadGateway, fmt.Sprintf("failed to get supported languages: %v", err), nil)
+				return
+			}
+		} else if opts.Client != nil {
+			// No live client yet - fall back to the configured list on the CR
+			source = "config"
+			var ts wikiv1alpha1.TranslationService
+			if err := opts.Client.Get(r.Context(), client.ObjectKey{Name: wikiv1alpha1.TranslationServiceName}, &ts); err != nil && !errors.IsNotFound(err) {
+				writeError(w, http.StatusInternalServerError, err.Error(), nil)
+				return
+			}
+			languages = ts.Spec.SupportedLanguages
+		}
+
+		if languages == nil {
+			languages = []string{}
+		}
+		writeJSON(w, map[string]any{
+			"languages":  languages,
+			"restricted": len(languages) > 0,
+			"source":     source,
+		})
+	})
+
 	router.Post("/api/v1/translation-service", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 
 		var config TranslationServiceConfig
 		if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
 			return
 		}
 
 		// Validate required fields
 		if config.Address == "" {
-			http.Error(w, "address is required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "address is required", nil)
 			return
 		}
 		if config.Type == "" {
@@ -989,8 +2287,8 @@ func Start(ctx context.Context, opts Options) error {
 
 		// Create or update TranslationService CR
 		// Use a fixed name since TranslationService is cluster-scoped
-		tsName := "glooscap-translation-service"
-		fmt.Printf("[http] POST /translation-service: Creating/updating TranslationService CR '%s' with address=%s, type=%s, secure=%v\n", tsName, config.Address, config.Type, config.Secure)
+		tsName := wikiv1alpha1.TranslationServiceName
+		verbosity.Printf("[http] POST /translation-service: Creating/updating TranslationService CR '%s' with address=%s, type=%s, secure=%v\n", tsName, config.Address, config.Type, config.Secure)
 		var ts wikiv1alpha1.TranslationService
 		err := opts.Client.Get(r.Context(), client.ObjectKey{Name: tsName}, &ts)
 		if err != nil {
@@ -1007,14 +2305,14 @@ func Start(ctx context.Context, opts Options) error {
 					},
 				}
 				if err := opts.Client.Create(r.Context(), &ts); err != nil {
-					fmt.Printf("[http] ERROR: Failed to create TranslationService CR '%s': %v (error type: %T)\n", tsName, err, err)
-					http.Error(w, fmt.Sprintf("failed to create TranslationService: %v", err), http.StatusInternalServerError)
+					verbosity.Printf("[http] ERROR: Failed to create TranslationService CR '%s': %v (error type: %T)\n", tsName, err, err)
+					writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create TranslationService: %v", err), nil)
 					return
 				}
-				fmt.Printf("[http] Successfully created TranslationService CR: %s\n", tsName)
+				verbosity.Printf("[http] Successfully created TranslationService CR: %s\n", tsName)
 			} else {
-				fmt.Printf("[http] ERROR: Failed to get TranslationService CR '%s' (non-NotFound): %v (error type: %T)\n", tsName, err, err)
-				http.Error(w, fmt.Sprintf("failed to get TranslationService: %v", err), http.StatusInternalServerError)
+				verbosity.Printf("[http] ERROR: Failed to get TranslationService CR '%s' (non-NotFound): %v (error type: %T)\n", tsName, err, err)
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get TranslationService: %v", err), nil)
 				return
 			}
 		} else {
@@ -1023,11 +2321,11 @@ func Start(ctx context.Context, opts Options) error {
 			ts.Spec.Type = config.Type
 			ts.Spec.Secure = config.Secure
 			if err := opts.Client.Update(r.Context(), &ts); err != nil {
-				fmt.Printf("[http] ERROR: Failed to update TranslationService CR '%s': %v (error type: %T)\n", tsName, err, err)
-				http.Error(w, fmt.Sprintf("failed to update TranslationService: %v", err), http.StatusInternalServerError)
+				verbosity.Printf("[http] ERROR: Failed to update TranslationService CR '%s': %v (error type: %T)\n", tsName, err, err)
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update TranslationService: %v", err), nil)
 				return
 			}
-			fmt.Printf("[http] Successfully updated TranslationService CR: %s\n", tsName)
+			verbosity.Printf("[http] Successfully updated TranslationService CR: %s\n", tsName)
 		}
 
 		// Store configuration in config store for backward compatibility
@@ -1047,19 +2345,19 @@ func Start(ctx context.Context, opts Options) error {
 	router.Put("/api/v1/translation-service", func(w http.ResponseWriter, r *http.Request) {
 		// PUT is same as POST for this resource - reuse POST handler logic
 		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 
 		var config TranslationServiceConfig
 		if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
 			return
 		}
 
 		// Validate required fields
 		if config.Address == "" {
-			http.Error(w, "address is required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "address is required", nil)
 			return
 		}
 		if config.Type == "" {
@@ -1068,8 +2366,8 @@ func Start(ctx context.Context, opts Options) error {
 		}
 
 		// Create or update TranslationService CR
-		tsName := "glooscap-translation-service"
-		fmt.Printf("[http] PUT /translation-service: Creating/updating TranslationService CR '%s' with address=%s, type=%s, secure=%v\n", tsName, config.Address, config.Type, config.Secure)
+		tsName := wikiv1alpha1.TranslationServiceName
+		verbosity.Printf("[http] PUT /translation-service: Creating/updating TranslationService CR '%s' with address=%s, type=%s, secure=%v\n", tsName, config.Address, config.Type, config.Secure)
 		var ts wikiv1alpha1.TranslationService
 		err := opts.Client.Get(r.Context(), client.ObjectKey{Name: tsName}, &ts)
 		if err != nil {
@@ -1086,14 +2384,14 @@ func Start(ctx context.Context, opts Options) error {
 					},
 				}
 				if err := opts.Client.Create(r.Context(), &ts); err != nil {
-					fmt.Printf("[http] ERROR: Failed to create TranslationService CR '%s': %v (error type: %T)\n", tsName, err, err)
-					http.Error(w, fmt.Sprintf("failed to create TranslationService: %v", err), http.StatusInternalServerError)
+					verbosity.Printf("[http] ERROR: Failed to create TranslationService CR '%s': %v (error type: %T)\n", tsName, err, err)
+					writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create TranslationService: %v", err), nil)
 					return
 				}
-				fmt.Printf("[http] Successfully created TranslationService CR: %s\n", tsName)
+				verbosity.Printf("[http] Successfully created TranslationService CR: %s\n", tsName)
 			} else {
-				fmt.Printf("[http] ERROR: Failed to get TranslationService CR '%s' (non-NotFound): %v (error type: %T)\n", tsName, err, err)
-				http.Error(w, fmt.Sprintf("failed to get TranslationService: %v", err), http.StatusInternalServerError)
+				verbosity.Printf("[http] ERROR: Failed to get TranslationService CR '%s' (non-NotFound): %v (error type: %T)\n", tsName, err, err)
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get TranslationService: %v", err), nil)
 				return
 			}
 		} else {
@@ -1102,11 +2400,11 @@ func Start(ctx context.Context, opts Options) error {
 			ts.Spec.Type = config.Type
 			ts.Spec.Secure = config.Secure
 			if err := opts.Client.Update(r.Context(), &ts); err != nil {
-				fmt.Printf("[http] ERROR: Failed to update TranslationService CR '%s': %v (error type: %T)\n", tsName, err, err)
-				http.Error(w, fmt.Sprintf("failed to update TranslationService: %v", err), http.StatusInternalServerError)
+				verbosity.Printf("[http] ERROR: Failed to update TranslationService CR '%s': %v (error type: %T)\n", tsName, err, err)
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update TranslationService: %v", err), nil)
 				return
 			}
-			fmt.Printf("[http] Successfully updated TranslationService CR: %s\n", tsName)
+			verbosity.Printf("[http] Successfully updated TranslationService CR: %s\n", tsName)
 		}
 
 		// Store configuration in config store for backward compatibility
@@ -1123,14 +2421,61 @@ func Start(ctx context.Context, opts Options) error {
 		})
 	})
 
+	// Make the TranslationService controller recreate the client now, e.g.
+	// after the service restarted, instead of waiting for heartbeats to fail
+	router.Post("/api/v1/translation-service/reconcile", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
+			return
+		}
+
+		requested, err := requestTranslationServiceReconcile(r.Context(), opts.Client, time.Now())
+		if err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "translation service not configured", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to request reconcile: %v", err), nil)
+			return
+		}
+		verbosity.Printf("[http] POST /translation-service/reconcile: requested at %s\n", requested)
+
+		ts, done, err := awaitTranslationServiceReconcile(r.Context(), opts.Client, requested, translationServiceReconcileTimeout)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to read TranslationService: %v", err), nil)
+			return
+		}
+
+		var nanabushClient *nanabush.Client
+		if opts.GetNanabushClient != nil {
+			nanabushClient = opts.GetNanabushClient()
+		} else if opts.Nanabush != nil {
+			nanabushClient = opts.Nanabush
+		}
+		clientStatus := nanabush.Status{Status: "error"}
+		if nanabushClient != nil {
+			clientStatus = nanabushClient.Status()
+		}
+		response := map[string]any{
+			"reconciled":  done,
+			"requestedAt": requested,
+			"status":      resolveServiceStatus(clientStatus, ts, nil),
+		}
+		if !done {
+			response["note"] = fmt.Sprintf("the controller hasn't finished within %s; the status may predate the new client", translationServiceReconcileTimeout)
+		}
+		broadcaster.triggerBroadcast()
+		writeJSON(w, response)
+	})
+
 	router.Delete("/api/v1/translation-service", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 
 		// Delete TranslationService CR
-		tsName := "glooscap-translation-service"
+		tsName := wikiv1alpha1.TranslationServiceName
 		var ts wikiv1alpha1.TranslationService
 		err := opts.Client.Get(r.Context(), client.ObjectKey{Name: tsName}, &ts)
 		if err != nil {
@@ -1142,13 +2487,13 @@ func Start(ctx context.Context, opts Options) error {
 				})
 				return
 			}
-			http.Error(w, fmt.Sprintf("failed to get TranslationService: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get TranslationService: %v", err), nil)
 			return
 		}
 
 		// Delete the CR
 		if err := opts.Client.Delete(r.Context(), &ts); err != nil {
-			http.Error(w, fmt.Sprintf("failed to delete TranslationService: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to delete TranslationService: %v", err), nil)
 			return
 		}
 
@@ -1165,11 +2510,11 @@ func Start(ctx context.Context, opts Options) error {
 
 	router.Delete("/api/v1/translation-service-old", func(w http.ResponseWriter, r *http.Request) {
 		if opts.ConfigStore == nil {
-			http.Error(w, "configuration store not available", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "configuration store not available", nil)
 			return
 		}
 		if opts.ReconfigureTranslationService == nil {
-			http.Error(w, "translation service reconfiguration not available", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "translation service reconfiguration not available", nil)
 			return
 		}
 
@@ -1184,110 +2529,86 @@ func Start(ctx context.Context, opts Options) error {
 		}
 		if err := opts.ReconfigureTranslationService(emptyConfig); err != nil {
 			// Log but don't fail - client might already be closed
-			fmt.Printf("[http] Error clearing translation service: %v\n", err)
+			verbosity.Printf("[http] Error clearing translation service: %v\n", err)
 		}
 
 		writeJSON(w, map[string]string{"status": "deleted"})
 	})
 
-	// Diagnostic write enabled flag endpoints
-	router.Get("/api/v1/diagnostic/write-enabled", func(w http.ResponseWriter, r *http.Request) {
-		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+	// Feature flags kept in the glooscap-config ConfigMap
+	router.Get("/api/v1/flags", func(w http.ResponseWriter, r *http.Request) {
+		if flagStore == nil {
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
+		values, err := flagStore.Flags(r.Context())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get flags: %v", err), nil)
+			return
+		}
+		writeJSON(w, map[string]any{"flags": values})
+	})
 
-		ctx := r.Context()
-		configMapName := "glooscap-config"
-		namespace := "glooscap-system"
-
-		var cm corev1.ConfigMap
-		// Use APIReader (uncached client) to avoid requiring cluster-wide ConfigMap watch permissions
-		reader := opts.APIReader
-		if reader == nil {
-			// Fallback to cached client if APIReader not set
-			reader = opts.Client
+	router.Put("/api/v1/flags", func(w http.ResponseWriter, r *http.Request) {
+		if flagStore == nil {
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
+			return
 		}
-		err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: configMapName}, &cm)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				// ConfigMap doesn't exist, return default (enabled)
-				writeJSON(w, map[string]bool{"enabled": true})
+		var req map[string]bool
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
+			return
+		}
+		if len(req) == 0 {
+			writeError(w, http.StatusBadRequest, "at least one flag is required", nil)
+			return
+		}
+		if err := flagStore.SetFlags(r.Context(), req); err != nil {
+			if stderrors.Is(err, flags.ErrUnknownFlag) {
+				writeError(w, http.StatusBadRequest, err.Error(), nil)
 				return
 			}
-			http.Error(w, fmt.Sprintf("failed to get config: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update flags: %v", err), nil)
 			return
 		}
-
-		// Check the diagnostic-write-enabled key
-		enabled := true // Default to enabled
-		if val, exists := cm.Data["diagnostic-write-enabled"]; exists {
-			enabled = val == "true"
+		values, err := flagStore.Flags(r.Context())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get flags: %v", err), nil)
+			return
 		}
+		writeJSON(w, map[string]any{"flags": values})
+	})
 
-		writeJSON(w, map[string]bool{"enabled": enabled})
+	// Diagnostic write enabled flag endpoints, kept for the UI's diagnostic toggle
+	router.Get("/api/v1/diagnostic/write-enabled", func(w http.ResponseWriter, r *http.Request) {
+		if flagStore == nil {
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
+			return
+		}
+		writeJSON(w, map[string]bool{"enabled": flagStore.GetFlag(r.Context(), flags.DiagnosticWriteEnabled)})
 	})
 
 	router.Put("/api/v1/diagnostic/write-enabled", func(w http.ResponseWriter, r *http.Request) {
-		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+		if flagStore == nil {
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 
 		var req map[string]bool
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
 			return
 		}
 
 		enabled, exists := req["enabled"]
 		if !exists {
-			http.Error(w, "enabled field is required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "enabled field is required", nil)
 			return
 		}
 
-		ctx := r.Context()
-		configMapName := "glooscap-config"
-		namespace := "glooscap-system"
-
-		var cm corev1.ConfigMap
-		// Use APIReader (uncached client) for reads to avoid requiring cluster-wide ConfigMap watch permissions
-		// But use cached client for writes (Create/Update) as those don't trigger cache watches
-		reader := opts.APIReader
-		if reader == nil {
-			reader = opts.Client
-		}
-		err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: configMapName}, &cm)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				// Create new ConfigMap
-				cm = corev1.ConfigMap{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      configMapName,
-						Namespace: namespace,
-					},
-					Data: map[string]string{
-						"diagnostic-write-enabled": fmt.Sprintf("%v", enabled),
-					},
-				}
-				if err := opts.Client.Create(ctx, &cm); err != nil {
-					http.Error(w, fmt.Sprintf("failed to create config: %v", err), http.StatusInternalServerError)
-					return
-				}
-			} else {
-				http.Error(w, fmt.Sprintf("failed to get config: %v", err), http.StatusInternalServerError)
-				return
-			}
-		} else {
-			// Update existing ConfigMap
-			if cm.Data == nil {
-				cm.Data = make(map[string]string)
-			}
-			cm.Data["diagnostic-write-enabled"] = fmt.Sprintf("%v", enabled)
-			if err := opts.Client.Update(ctx, &cm); err != nil {
-				http.Error(w, fmt.Sprintf("failed to update config: %v", err), http.StatusInternalServerError)
-				return
-			}
+		if err := flagStore.SetFlags(r.Context(), map[string]bool{flags.DiagnosticWriteEnabled: enabled}); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update config: %v", err), nil)
+			return
 		}
 
 		writeJSON(w, map[string]bool{"enabled": enabled})
@@ -1298,18 +2619,18 @@ func Start(ctx context.Context, opts Options) error {
 		// Add panic recovery
 		defer func() {
 			if r := recover(); r != nil {
-				fmt.Printf("[http] PANIC in POST /wikitargets: %v\n", r)
-				http.Error(w, fmt.Sprintf("internal server error: %v", r), http.StatusInternalServerError)
+				verbosity.Printf("[http] PANIC in POST /wikitargets: %v\n", r)
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("internal server error: %v", r), nil)
 			}
 		}()
 
 		// Log immediately - this should always appear if request reaches handler
 		fmt.Fprintf(os.Stderr, "[http] POST /api/v1/wikitargets received - Method: %s, URL: %s, Content-Type: %s\n", 
 			r.Method, r.URL.String(), r.Header.Get("Content-Type"))
-		fmt.Printf("[http] POST /api/v1/wikitargets received\n")
+		verbosity.Printf("[http] POST /api/v1/wikitargets received\n")
 		if opts.Client == nil {
-			fmt.Printf("[http] ERROR: kubernetes client not configured\n")
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+			verbosity.Printf("[http] ERROR: kubernetes client not configured\n")
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 
@@ -1317,193 +2638,178 @@ func Start(ctx context.Context, opts Options) error {
 		// First decode into a map to extract secretToken separately
 		var requestData map[string]interface{}
 		if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
-			fmt.Printf("[http] ERROR: Failed to decode WikiTarget request: %v\n", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			verbosity.Printf("[http] ERROR: Failed to decode WikiTarget request: %v\n", err)
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
+			return
+		}
+		verbosity.Debugf("[http] Decoded request data, has secretToken: %v, has metadata: %v, has spec: %v\n",
+			requestData["secretToken"] != nil, requestData["metadata"] != nil, requestData["spec"] != nil)
+
+		target, secretToken, err := decodeWikiTargetRequest(requestData)
+		if err != nil {
+			verbosity.Printf("[http] ERROR: Invalid WikiTarget request: %v\n", err)
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if !namespaces.check(w, target.Namespace) {
+			return
+		}
+
+		if _, err := applyWikiTarget(r.Context(), opts.Client, target, secretToken); err != nil {
+			status := http.StatusInternalServerError
+			if stderrors.Is(err, errDuplicateDefaultTarget) {
+				status = http.StatusConflict
+			}
+			writeError(w, status, err.Error(), nil)
+			return
+		}
+
+		writeJSON(w, map[string]string{"name": target.Name, "namespace": target.Namespace})
+	})
+
+	// Test a WikiTarget before saving it: same body as POST /wikitargets, plus
+	// an optional insecure flag overriding insecureSkipTLSVerify for this probe
+	router.Post("/api/v1/wikitargets/test", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
+			return
+		}
+		var requestData map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
+			return
+		}
+		var insecure *bool
+		if raw, ok := requestData["insecure"]; ok {
+			value, isBool := raw.(bool)
+			if !isBool {
+				writeError(w, http.StatusBadRequest, "insecure must be true or false", nil)
+				return
+			}
+			insecure = &value
+			delete(requestData, "insecure")
+		}
+		target, secretToken, err := decodeWikiTargetRequest(requestData)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if !namespaces.check(w, target.Namespace) {
+			return
+		}
+		result, err := probeUnsavedWikiTarget(r.Context(), apiReader, target, secretToken, insecure)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
 			return
 		}
-		fmt.Printf("[http] Decoded request data, has secretToken: %v, has metadata: %v, has spec: %v\n",
-			requestData["secretToken"] != nil, requestData["metadata"] != nil, requestData["spec"] != nil)
+		writeJSON(w, result)
+	})
 
-		// Extract secretToken if provided
-		var secretToken string
-		if tokenVal, ok := requestData["secretToken"].(string); ok {
-			secretToken = tokenVal
-			fmt.Printf("[http] Extracted secretToken (length: %d)\n", len(secretToken))
+	// Test a saved WikiTarget; ?insecure=true|false overrides its
+	// insecureSkipTLSVerify for this probe without changing the WikiTarget
+	router.Post("/api/v1/wikitargets/{namespace}/{name}/test", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
+			return
 		}
-		// Remove secretToken from requestData before decoding into WikiTarget
-		delete(requestData, "secretToken")
-
-		// Decode the rest into WikiTarget (metadata and spec should be preserved)
-		targetBytes, marshalErr := json.Marshal(requestData)
-		if marshalErr != nil {
-			fmt.Printf("[http] ERROR: Failed to marshal request data: %v\n", marshalErr)
-			http.Error(w, fmt.Sprintf("failed to process request: %v", marshalErr), http.StatusBadRequest)
+		namespace := chi.URLParam(r, "namespace")
+		if !namespaces.check(w, namespace) {
 			return
 		}
-		previewLen := 200
-		if len(targetBytes) < previewLen {
-			previewLen = len(targetBytes)
+		insecure, err := parseInsecureOverride(r.URL.Query().Get("insecure"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
+			return
 		}
-		fmt.Printf("[http] Marshaled request (length: %d): %s\n", len(targetBytes), string(targetBytes)[:previewLen])
-
 		var target wikiv1alpha1.WikiTarget
-		if err := json.Unmarshal(targetBytes, &target); err != nil {
-			fmt.Printf("[http] ERROR: Failed to decode WikiTarget from request: %v\n", err)
-			http.Error(w, fmt.Sprintf("failed to decode WikiTarget: %v", err), http.StatusBadRequest)
+		if err := opts.Client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: chi.URLParam(r, "name")}, &target); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
-		fmt.Printf("[http] Decoded WikiTarget: name=%q, namespace=%q, uri=%q, secretName=%q\n",
-			target.Name, target.Namespace, target.Spec.URI, target.Spec.ServiceAccountSecretRef.Name)
-
-		// Set default namespace if not provided
-		if target.Namespace == "" {
-			target.Namespace = "glooscap-system"
-		}
+		writeJSON(w, probeWikiTarget(r.Context(), apiReader, &target, "", insecure))
+	})
 
-		// Validate required fields
-		if target.Name == "" {
-			http.Error(w, "metadata.name or name is required", http.StatusBadRequest)
+	// Bulk import: validate every WikiTarget first, then create or update each
+	// one and report per-item results
+	router.Post("/api/v1/wikitargets/import", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil {
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
-
-		// Normalize name to RFC 1123 compliant format (lowercase, alphanumeric, dashes)
-		normalizedName := normalizeRFC1123Name(target.Name)
-		if normalizedName != target.Name {
-			fmt.Printf("[http] Normalized WikiTarget name from %q to %q (RFC 1123 compliance)\n", target.Name, normalizedName)
-			target.Name = normalizedName
+		var req struct {
+			Items []map[string]any `json:"items"`
 		}
-		if target.Spec.URI == "" {
-			http.Error(w, "spec.uri is required", http.StatusBadRequest)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
 			return
 		}
-		if target.Spec.ServiceAccountSecretRef.Name == "" {
-			http.Error(w, "spec.serviceAccountSecretRef.name is required", http.StatusBadRequest)
+		if len(req.Items) == 0 {
+			writeError(w, http.StatusBadRequest, "items is required", nil)
 			return
 		}
-		if target.Spec.Mode == "" {
-			http.Error(w, "spec.mode is required", http.StatusBadRequest)
+		if len(req.Items) > maxWikiTargetImportItems {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("at most %d WikiTargets can be imported at once", maxWikiTargetImportItems), nil)
 			return
 		}
 
-		// Set default key if not provided
-		if target.Spec.ServiceAccountSecretRef.Key == "" {
-			target.Spec.ServiceAccountSecretRef.Key = "token"
-		}
-
-		// Set default InsecureSkipTLSVerify to true (for now, to handle self-signed certs)
-		// Check if the request explicitly set this field
-		_, hasInsecureSkipTLSVerify := getNestedBool(requestData, "spec", "insecureSkipTLSVerify")
-		if !hasInsecureSkipTLSVerify {
-			// Not explicitly set, default to true
-			target.Spec.InsecureSkipTLSVerify = true
-			fmt.Printf("[http] Setting InsecureSkipTLSVerify=true by default for WikiTarget '%s/%s'\n", target.Namespace, target.Name)
-		}
-
-		ctx := r.Context()
-		fmt.Printf("[http] POST /wikitargets: Creating/updating WikiTarget '%s/%s' with URI=%s, secret=%s, mode=%s\n",
-			target.Namespace, target.Name, target.Spec.URI, target.Spec.ServiceAccountSecretRef.Name, target.Spec.Mode)
-
-		// Create or update the Secret if token is provided
-		if secretToken != "" {
-			secretKey := target.Spec.ServiceAccountSecretRef.Key
-			if secretKey == "" {
-				secretKey = "token"
-			}
-			secret := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      target.Spec.ServiceAccountSecretRef.Name,
-					Namespace: target.Namespace,
-				},
-				Type: corev1.SecretTypeOpaque,
-				StringData: map[string]string{
-					secretKey: secretToken,
-				},
-			}
-
-			// Check if secret exists
-			var existingSecret corev1.Secret
-			err := opts.Client.Get(ctx, client.ObjectKey{Namespace: target.Namespace, Name: secret.Name}, &existingSecret)
-			if err != nil {
-				if errors.IsNotFound(err) {
-					// Create new secret
-					fmt.Printf("[http] Creating Secret '%s/%s' for WikiTarget\n", target.Namespace, secret.Name)
-					if err := opts.Client.Create(ctx, secret); err != nil {
-						fmt.Printf("[http] ERROR: Failed to create Secret '%s/%s': %v\n", target.Namespace, secret.Name, err)
-						http.Error(w, fmt.Sprintf("failed to create Secret: %v", err), http.StatusInternalServerError)
-						return
-					}
-					fmt.Printf("[http] Successfully created Secret: %s/%s\n", target.Namespace, secret.Name)
-				} else {
-					fmt.Printf("[http] ERROR: Failed to get Secret '%s/%s': %v\n", target.Namespace, secret.Name, err)
-					http.Error(w, fmt.Sprintf("failed to get Secret: %v", err), http.StatusInternalServerError)
-					return
-				}
-			} else {
-				// Update existing secret
-				fmt.Printf("[http] Updating Secret '%s/%s' for WikiTarget\n", target.Namespace, secret.Name)
-				// Update the secret data
-				if existingSecret.Data == nil {
-					existingSecret.Data = make(map[string][]byte)
-				}
-				existingSecret.Data[secretKey] = []byte(secretToken)
-				if err := opts.Client.Update(ctx, &existingSecret); err != nil {
-					fmt.Printf("[http] ERROR: Failed to update Secret '%s/%s': %v\n", target.Namespace, secret.Name, err)
-					http.Error(w, fmt.Sprintf("failed to update Secret: %v", err), http.StatusInternalServerError)
-					return
-				}
-				fmt.Printf("[http] Successfully updated Secret: %s/%s\n", target.Namespace, secret.Name)
-			}
-		}
-
-		// Get existing WikiTarget (if any)
-		var existing wikiv1alpha1.WikiTarget
-		err := opts.Client.Get(ctx, client.ObjectKey{Namespace: target.Namespace, Name: target.Name}, &existing)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				// Create new WikiTarget
-				fmt.Printf("[http] WikiTarget '%s/%s' not found, creating new one\n", target.Namespace, target.Name)
-				if err := opts.Client.Create(ctx, &target); err != nil {
-					fmt.Printf("[http] ERROR: Failed to create WikiTarget '%s/%s': %v (error type: %T)\n", target.Namespace, target.Name, err, err)
-					http.Error(w, fmt.Sprintf("failed to create WikiTarget: %v", err), http.StatusInternalServerError)
-					return
-				}
-				fmt.Printf("[http] Successfully created WikiTarget: %s/%s\n", target.Namespace, target.Name)
-			} else {
-				fmt.Printf("[http] ERROR: Failed to get WikiTarget '%s/%s' (non-NotFound): %v (error type: %T)\n", target.Namespace, target.Name, err, err)
-				http.Error(w, fmt.Sprintf("failed to get WikiTarget: %v", err), http.StatusInternalServerError)
-				return
-			}
-		} else {
-			// Update existing WikiTarget
-			fmt.Printf("[http] WikiTarget '%s/%s' exists, updating\n", target.Namespace, target.Name)
-			existing.Spec = target.Spec
-			if err := opts.Client.Update(ctx, &existing); err != nil {
-				fmt.Printf("[http] ERROR: Failed to update WikiTarget '%s/%s': %v (error type: %T)\n", target.Namespace, target.Name, err, err)
-				http.Error(w, fmt.Sprintf("failed to update WikiTarget: %v", err), http.StatusInternalServerError)
-				return
+		targets, tokens, results, ok := validateWikiTargetImport(req.Items, namespaces)
+		if !ok {
+			// Nothing is applied unless every item is valid
+			writeError(w, http.StatusBadRequest, "some WikiTargets are invalid; nothing was imported", map[string]any{"items": results})
+			return
+		}
+
+		// Keep going if the client disconnects so the import isn't left half done
+		ctx, cancel := detachedContext(r, wikiTargetImportTimeout)
+		defer cancel()
+		counts := map[string]int{}
+		for i, target := range targets {
+			created, err := applyWikiTarget(ctx, opts.Client, target, tokens[i])
+			switch {
+			case err != nil:
+				results[i].Status = "failed"
+				results[i].Error = err.Error()
+			case created:
+				results[i].Status = "created"
+			default:
+				results[i].Status = "updated"
 			}
-			fmt.Printf("[http] Successfully updated WikiTarget: %s/%s\n", target.Namespace, target.Name)
+			counts[results[i].Status]++
 		}
+		verbosity.Printf("[http] POST /wikitargets/import: %d created, %d updated, %d failed\n",
+			counts["created"], counts["updated"], counts["failed"])
 
-		writeJSON(w, map[string]string{"name": target.Name, "namespace": target.Namespace})
+		writeJSON(w, map[string]any{
+			"created": counts["created"],
+			"updated": counts["updated"],
+			"failed":  counts["failed"],
+			"items":   results,
+		})
 	})
 
 	router.Put("/api/v1/wikitargets/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 
 		namespace := chi.URLParam(r, "namespace")
 		name := chi.URLParam(r, "name")
 		if namespace == "" || name == "" {
-			http.Error(w, "namespace and name are required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "namespace and name are required", nil)
+			return
+		}
+		if !namespaces.check(w, namespace) {
 			return
 		}
 
 		var target wikiv1alpha1.WikiTarget
 		if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			writeError(w, decodeErrorStatus(err), err.Error(), nil)
 			return
 		}
 
@@ -1511,14 +2817,28 @@ func Start(ctx context.Context, opts Options) error {
 		target.Name = name
 		target.Namespace = namespace
 
+		if err := validateWikiTargetURI(target.Spec.URI); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+
 		// Get existing target to preserve metadata
 		var existing wikiv1alpha1.WikiTarget
 		if err := opts.Client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: name}, &existing); err != nil {
 			if errors.IsNotFound(err) {
-				http.Error(w, "WikiTarget not found", http.StatusNotFound)
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
 				return
 			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		if err := checkDefaultTarget(r.Context(), opts.Client, &target); err != nil {
+			status := http.StatusInternalServerError
+			if stderrors.Is(err, errDuplicateDefaultTarget) {
+				status = http.StatusConflict
+			}
+			writeError(w, status, err.Error(), nil)
 			return
 		}
 
@@ -1526,7 +2846,7 @@ func Start(ctx context.Context, opts Options) error {
 		target.ResourceVersion = existing.ResourceVersion
 
 		if err := opts.Client.Update(r.Context(), &target); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
@@ -1535,14 +2855,17 @@ func Start(ctx context.Context, opts Options) error {
 
 	router.Delete("/api/v1/wikitargets/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 
 		namespace := chi.URLParam(r, "namespace")
 		name := chi.URLParam(r, "name")
 		if namespace == "" || name == "" {
-			http.Error(w, "namespace and name are required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "namespace and name are required", nil)
+			return
+		}
+		if !namespaces.check(w, namespace) {
 			return
 		}
 
@@ -1552,10 +2875,10 @@ func Start(ctx context.Context, opts Options) error {
 
 		if err := opts.Client.Delete(r.Context(), &target); err != nil {
 			if errors.IsNotFound(err) {
-				http.Error(w, "WikiTarget not found", http.StatusNotFound)
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
 				return
 			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
@@ -1565,24 +2888,27 @@ func Start(ctx context.Context, opts Options) error {
 	// POST endpoint to trigger a WikiTarget refresh by adding a force-refresh annotation
 	router.Post("/api/v1/wikitargets/{namespace}/{name}/refresh", func(w http.ResponseWriter, r *http.Request) {
 		if opts.Client == nil {
-			http.Error(w, "kubernetes client not configured", http.StatusServiceUnavailable)
+			writeError(w, http.StatusServiceUnavailable, "kubernetes client not configured", nil)
 			return
 		}
 
 		namespace := chi.URLParam(r, "namespace")
 		name := chi.URLParam(r, "name")
 		if namespace == "" || name == "" {
-			http.Error(w, "namespace and name are required", http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "namespace and name are required", nil)
+			return
+		}
+		if !namespaces.check(w, namespace) {
 			return
 		}
 
 		var target wikiv1alpha1.WikiTarget
 		if err := opts.Client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: name}, &target); err != nil {
 			if errors.IsNotFound(err) {
-				http.Error(w, "WikiTarget not found", http.StatusNotFound)
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
 				return
 			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
 			return
 		}
 
@@ -1590,45 +2916,181 @@ func Start(ctx context.Context, opts Options) error {
 		if target.Annotations == nil {
 			target.Annotations = make(map[string]string)
 		}
-		target.Annotations["glooscap.dasmlab.org/force-refresh"] = metav1.Now().Format(time.RFC3339)
+		target.Annotations[wikiv1alpha1.AnnotationForceRefresh] = metav1.Now().Format(time.RFC3339)
 
 		// Clear LastSyncTime to force immediate refresh
 		target.Status.LastSyncTime = nil
 
 		if err := opts.Client.Status().Update(r.Context(), &target); err != nil {
-			http.Error(w, fmt.Sprintf("failed to update WikiTarget status: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update WikiTarget status: %v", err), nil)
 			return
 		}
 
 		// Also update the annotations
 		if err := opts.Client.Update(r.Context(), &target); err != nil {
-			http.Error(w, fmt.Sprintf("failed to update WikiTarget: %v", err), http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to update WikiTarget: %v", err), nil)
 			return
 		}
 
 		writeJSON(w, map[string]string{"status": "refresh triggered", "name": name, "namespace": namespace})
 	})
 
-	server := &http.Server{
-		Addr:              opts.Addr,
-		Handler:           router,
-		ReadHeaderTimeout: 5 * time.Second,
-	}
+	// Orphaned translations - AUTOTRANSLATED pages whose source page no longer exists
+	router.Get("/api/v1/wikitargets/{namespace}/{name}/orphans", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil || opts.Catalogue == nil {
+			writeError(w, http.StatusServiceUnavailable, "catalogue not configured", nil)
+			return
+		}
 
-	errCh := make(chan error, 1)
-	go func() {
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			errCh <- err
+		namespace := chi.URLParam(r, "namespace")
+		name := chi.URLParam(r, "name")
+		if !namespaces.check(w, namespace) {
+			return
 		}
-	}()
 
-	select {
-	case <-ctx.Done():
-		_ = server.Shutdown(context.Background())
-		return nil
-	case err := <-errCh:
-		return err
-	}
+		var target wikiv1alpha1.WikiTarget
+		if err := opts.Client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: name}, &target); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+
+		orphans, err := findOrphanPages(r.Context(), opts, &target)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+		if orphans == nil {
+			orphans = []orphanPage{}
+		}
+		writeJSON(w, map[string]any{
+			"target":  fmt.Sprintf("%s/%s", namespace, name),
+			"orphans": orphans,
+			"count":   len(orphans),
+		})
+	})
+
+	router.Post("/api/v1/wikitargets/{namespace}/{name}/orphans/cleanup", func(w http.ResponseWriter, r *http.Request) {
+		if opts.Client == nil || opts.Catalogue == nil {
+			writeError(w, http.StatusServiceUnavailable, "catalogue not configured", nil)
+			return
+		}
+		if opts.OutlineClientFactory == nil {
+			writeError(w, http.StatusServiceUnavailable, "outline client factory not configured", nil)
+			return
+		}
+
+		namespace := chi.URLParam(r, "namespace")
+		name := chi.URLParam(r, "name")
+		if !namespaces.check(w, namespace) {
+			return
+		}
+
+		var req struct {
+			// Mode is "archive" (default, restorable) or "delete"
+			Mode string `json:"mode"`
+			// PageIDs limits cleanup to these orphans; empty means all
+			PageIDs []string `json:"pageIds"`
+			DryRun  bool     `json:"dryRun"`
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, decodeErrorStatus(err), fmt.Sprintf("invalid request: %v", err), nil)
+				return
+			}
+		}
+		if req.Mode == "" {
+			req.Mode = "archive"
+		}
+		if req.Mode != "archive" && req.Mode != "delete" {
+			writeError(w, http.StatusBadRequest, "mode must be \"archive\" or \"delete\"", nil)
+			return
+		}
+
+		var target wikiv1alpha1.WikiTarget
+		if err := opts.Client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: name}, &target); err != nil {
+			if errors.IsNotFound(err) {
+				writeError(w, http.StatusNotFound, "WikiTarget not found", nil)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+		if target.Spec.Mode == wikiv1alpha1.WikiTargetModeReadOnly {
+			writeError(w, http.StatusConflict, "WikiTarget is read-only", nil)
+			return
+		}
+
+		// Re-detect orphans so callers can't archive arbitrary pages by ID
+		orphans, err := findOrphanPages(r.Context(), opts, &target)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error(), nil)
+			return
+		}
+		if len(req.PageIDs) > 0 {
+			wanted := make(map[string]bool, len(req.PageIDs))
+			for _, id := range req.PageIDs {
+				wanted[id] = true
+			}
+			selected := orphans[:0]
+			for _, o := range orphans {
+				if wanted[o.ID] {
+					selected = append(selected, o)
+				}
+			}
+			orphans = selected
+		}
+
+		matched := len(orphans)
+		skipped := map[string]string{}
+		selected := orphans[:0]
+		for _, o := range orphans {
+			if reason := orphanCleanupSkipReason(o, req.Mode); reason != "" {
+				skipped[o.ID] = reason
+				continue
+			}
+			selected = append(selected, o)
+		}
+		orphans = selected
+
+		cleaned := []string{}
+		failed := map[string]string{}
+		if !req.DryRun && len(orphans) > 0 {
+			outlineClient, err := opts.OutlineClientFactory.New(r.Context(), opts.Client, &target)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to create outline client: %v", err), nil)
+				return
+			}
+			for _, o := range orphans {
+				var err error
+				if req.Mode == "delete" {
+					err = outlineClient.DeletePage(r.Context(), o.ID)
+				} else {
+					err = outlineClient.ArchivePage(r.Context(), o.ID)
+				}
+				if err != nil {
+					failed[o.ID] = err.Error()
+					continue
+				}
+				cleaned = append(cleaned, o.ID)
+			}
+			verbosity.Printf("[http] POST /wikitargets/%s/%s/orphans/cleanup: %s %d page(s), %d failed\n", namespace, name, req.Mode, len(cleaned), len(failed))
+		}
+
+		writeJSON(w, map[string]any{
+			"mode":    req.Mode,
+			"dryRun":  req.DryRun,
+			"matched": matched,
+			"cleaned": cleaned,
+			"failed":  failed,
+			"skipped": skipped,
+		})
+	})
+
+	return router
 }
 
 type createJobRequest struct {
@@ -1638,6 +3100,98 @@ type createJobRequest struct {
 	LanguageTag string `json:"languageTag"`
 	Pipeline    string `json:"pipeline"`
 	PageTitle   string `json:"pageTitle"`
+	// SupersedeOlderJobs cancels older in-flight jobs for the same page and language
+	SupersedeOlderJobs bool `json:"supersedeOlderJobs"`
+	// MaxTokens caps the tokens the translation may consume (0 = unlimited)
+	MaxTokens int64 `json:"maxTokens"`
+	// NotifyWebhook receives a POST when the job finishes or waits for approval
+	NotifyWebhook string `json:"notifyWebhook"`
+	// Context is a note for the translation model, e.g. audience or terminology
+	Context string `json:"context"`
+	// ParentDocument places the translated page under an existing destination
+	// document, given by ID, URL, slug or title
+	ParentDocument string `json:"parentDocument"`
+	// PublishStrategy is create (default), update or createOrUpdate
+	PublishStrategy string `json:"publishStrategy"`
+	// RevisionID pins the source to an Outline revision of the page
+	RevisionID string `json:"revisionId"`
+	// FollowLinks also translates linked pages, this many levels deep (0 = off)
+	FollowLinks int32 `json:"followLinks"`
+}
+
+// maxFollowLinksDepth matches the Maximum of FollowLinksSpec.Depth.
+const maxFollowLinksDepth = 5
+
+// maxJobContextLength matches the MaxLength of TranslationJobSpec.Context.
+const maxJobContextLength = 2000
+
+const (
+	// maxPageContentBatchSize caps the number of pages fetched by a single batch request.
+	maxPageContentBatchSize = 25
+)
+
+// defaultTargetStaleThreshold is how long a WikiTarget may go without a successful
+// sync before /api/v1/health/targets reports it as stale.
+const defaultTargetStaleThreshold = 5 * time.Minute
+
+// targetHealth summarises the discovery health of a WikiTarget from its status.
+func targetHealth(target *wikiv1alpha1.WikiTarget, now time.Time, staleAfter time.Duration) map[string]any {
+	health := map[string]any{
+		"name":      target.Name,
+		"namespace": target.Namespace,
+		"uri":       target.Spec.URI,
+		"paused":    target.Spec.IsPaused,
+	}
+
+	lastRefreshSucceeded := target.Status.Ready
+	lastError := ""
+	if cond := meta.FindStatusCondition(target.Status.Conditions, "Ready"); cond != nil {
+		health["reason"] = cond.Reason
+		if cond.Reason == "DiscoveryFailed" {
+			lastRefreshSucceeded = false
+			lastError = cond.Message
+		} else if cond.Reason == "DiscoverySucceeded" {
+			lastRefreshSucceeded = true
+		}
+	}
+	health["lastRefreshSucceeded"] = lastRefreshSucceeded
+	health["lastError"] = lastError
+
+	stale := true
+	if target.Status.LastSyncTime != nil {
+		sinceSync := now.Sub(target.Status.LastSyncTime.Time)
+		health["lastSyncTime"] = target.Status.LastSyncTime.Time.Format(time.RFC3339)
+		health["secondsSinceLastSync"] = int64(sinceSync.Seconds())
+		stale = sinceSync > staleAfter
+	}
+	// Paused targets are expected not to sync, so they are never considered stale
+	if target.Spec.IsPaused {
+		stale = false
+	}
+	health["stale"] = stale
+	health["healthy"] = lastError == "" && !stale
+	return health
+}
+
+// validateWikiTargetURI ensures a user-supplied WikiTarget URI is an absolute http(s) URL.
+// The diagnostic:// scheme is reserved for the in-memory targets used by diagnostic jobs,
+// and anything else would only fail later, confusingly, inside the Outline client.
+func validateWikiTargetURI(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("spec.uri is not a valid URL: %v", err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+	case "diagnostic":
+		return fmt.Errorf("spec.uri scheme %q is reserved for internal diagnostic jobs", u.Scheme)
+	default:
+		return fmt.Errorf("spec.uri must use http or https, got scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("spec.uri must include a host")
+	}
+	return nil
 }
 
 // normalizeRFC1123Name normalizes a string to be RFC 1123 compliant:
@@ -1714,25 +3268,96 @@ func getNestedBool(data map[string]interface{}, keys ...string) (bool, bool) {
 	return false, false
 }
 
-func (r *createJobRequest) validate() error {
+func (r *createJobRequest) validate(notifier *controller.JobNotifier) error {
 	if r.Namespace == "" {
-		r.Namespace = "glooscap-system"
-	}
-	if r.TargetRef == "" {
-		return fmt.Errorf("targetRef is required")
+		r.Namespace = defaultNamespace
 	}
 	if r.PageID == "" {
 		return fmt.Errorf("pageId is required")
 	}
-	if r.LanguageTag == "" {
-		r.LanguageTag = "fr-CA"
+	lang, err := canonicalLanguageTag(r.LanguageTag)
+	if err != nil {
+		return err
 	}
+	r.LanguageTag = lang
 	if r.Pipeline == "" {
 		r.Pipeline = string(wikiv1alpha1.TranslationPipelineModeTektonJob)
 	}
+	if r.MaxTokens < 0 {
+		return fmt.Errorf("maxTokens must not be negative")
+	}
+	if r.NotifyWebhook != "" {
+		if notifier != nil {
+			if err := notifier.CheckWebhook(r.NotifyWebhook); err != nil {
+				return err
+			}
+		} else if u, err := url.Parse(r.NotifyWebhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("notifyWebhook must be an http(s) URL")
+		}
+	}
+	if utf8.RuneCountInString(r.Context) > maxJobContextLength {
+		return fmt.Errorf("context must be at most %d characters", maxJobContextLength)
+	}
+	switch wikiv1alpha1.PublishStrategy(r.PublishStrategy) {
+	case "", wikiv1alpha1.PublishStrategyCreate, wikiv1alpha1.PublishStrategyUpdate, wikiv1alpha1.PublishStrategyCreateOrUpdate:
+	default:
+		return fmt.Errorf("publishStrategy must be create, update or createOrUpdate")
+	}
+	if r.FollowLinks < 0 || r.FollowLinks > maxFollowLinksDepth {
+		return fmt.Errorf("followLinks must be between 0 and %d", maxFollowLinksDepth)
+	}
 	return nil
 }
 
+// canonicalLanguageTag returns tag in canonical BCP 47 form, or
+// defaultLanguageTag when it is empty.
+func canonicalLanguageTag(tag string) (string, error) {
+	if strings.TrimSpace(tag) == "" {
+		return defaultLanguageTag, nil
+	}
+	canonical, err := langtag.Canonical(tag)
+	if err != nil {
+		return "", fmt.Errorf("languageTag: %w", err)
+	}
+	return canonical, nil
+}
+
+// job returns the TranslationJob the request describes.
+func (r *createJobRequest) job() *wikiv1alpha1.TranslationJob {
+	var followLinks *wikiv1alpha1.FollowLinksSpec
+	if r.FollowLinks > 0 {
+		followLinks = &wikiv1alpha1.FollowLinksSpec{Depth: r.FollowLinks}
+	}
+	return &wikiv1alpha1.TranslationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "translation-",
+			Namespace:    r.Namespace,
+		},
+		Spec: wikiv1alpha1.TranslationJobSpec{
+			Source: wikiv1alpha1.TranslationSourceSpec{
+				TargetRef:  r.TargetRef,
+				PageID:     r.PageID,
+				RevisionID: strings.TrimSpace(r.RevisionID),
+			},
+			Destination: &wikiv1alpha1.TranslationDestinationSpec{
+				TargetRef:      r.TargetRef,
+				LanguageTag:    r.LanguageTag,
+				ParentDocument: strings.TrimSpace(r.ParentDocument),
+			},
+			Pipeline: wikiv1alpha1.TranslationPipelineMode(r.Pipeline),
+			Parameters: map[string]string{
+				"pageTitle": r.PageTitle,
+			},
+			SupersedeOlderJobs: r.SupersedeOlderJobs,
+			MaxTokens:          r.MaxTokens,
+			NotifyWebhook:      r.NotifyWebhook,
+			Context:            r.Context,
+			PublishStrategy:    wikiv1alpha1.PublishStrategy(r.PublishStrategy),
+			FollowLinks:        followLinks,
+		},
+	}
+}
+
 // buildStateResponse constructs the full state response with WikiTargets, pages, and nanabush status.
 func buildStateResponse(opts Options) map[string]any {
 	result := map[string]any{
@@ -1758,81 +3383,48 @@ func buildStateResponse(opts Options) map[string]any {
 		}
 	}
 
-	// Try to read status from TranslationService CR
-	// Prefer client status if it shows connected/registered but CR doesn't (handles startup race condition)
-	var nanabushStatus map[string]any
+	var ts *wikiv1alpha1.TranslationService
+	var readErr error
 	if opts.Client != nil {
-		tsName := "glooscap-translation-service"
-		var ts wikiv1alpha1.TranslationService
-		ctx := context.Background() // Use background context for SSE
-		err := opts.Client.Get(ctx, client.ObjectKey{Name: tsName}, &ts)
-		if err == nil {
-			// CR exists - check if status is populated
-			if ts.Status.ClientID != "" || ts.Status.Status != "" {
-				// CR status is populated - but prefer client status if it's more accurate
-				// This handles the case where client is connected but CR hasn't been updated yet
-				if clientStatus.Connected && clientStatus.Registered && (!ts.Status.Connected || !ts.Status.Registered) {
-					// Client is connected but CR shows disconnected - prefer client status (more recent)
-					var lastHeartbeatStr string
-					if !clientStatus.LastHeartbeat.IsZero() {
-						lastHeartbeatStr = clientStatus.LastHeartbeat.Format(time.RFC3339)
-					}
-					nanabushStatus = map[string]any{
-						"connected":                clientStatus.Connected,
-						"registered":               clientStatus.Registered,
-						"clientId":                 clientStatus.ClientID,
-						"lastHeartbeat":            lastHeartbeatStr,
-						"missedHeartbeats":         clientStatus.MissedHeartbeats,
-						"heartbeatIntervalSeconds": clientStatus.HeartbeatInterval,
-						"status":                   clientStatus.Status,
-					}
-				} else {
-					// CR status is populated and matches client, or client is not connected - use CR status
-					var lastHeartbeatStr string
-					if ts.Status.LastHeartbeat != nil {
-						lastHeartbeatStr = ts.Status.LastHeartbeat.Format(time.RFC3339)
-					}
-					nanabushStatus = map[string]any{
-						"connected":                ts.Status.Connected,
-						"registered":               ts.Status.Registered,
-						"clientId":                 ts.Status.ClientID,
-						"lastHeartbeat":            lastHeartbeatStr,
-						"missedHeartbeats":         ts.Status.MissedHeartbeats,
-						"heartbeatIntervalSeconds": ts.Status.HeartbeatIntervalSeconds,
-						"status":                   ts.Status.Status,
-					}
-				}
-			}
+		ts, readErr = getTranslationService(context.Background(), opts.Client) // Use background context for SSE
+		if readErr != nil {
+			verbosity.Printf("[http] state: failed to read TranslationService: %v\n", readErr)
 		}
 	}
+	status := resolveServiceStatus(clientStatus, ts, readErr)
 
-	// Fallback to client status if CR doesn't exist or doesn't have status populated yet
-	if nanabushStatus == nil {
-		// Only return error status if we have a client but it's not registered after reasonable time
-		// If clientId is empty but we just created the client, return "connecting" status
-		if clientStatus.ClientID == "" && clientStatus.Status != "error" {
-			// Client is still registering - return connecting status
-			nanabushStatus = map[string]any{
-				"connected":  false,
-				"registered": false,
-				"clientId":   "",
-				"status":     "connecting",
-			}
-		} else {
-			var lastHeartbeatStr string
-			if !clientStatus.LastHeartbeat.IsZero() {
-				lastHeartbeatStr = clientStatus.LastHeartbeat.Format(time.RFC3339)
-			}
-			nanabushStatus = map[string]any{
-				"connected":                clientStatus.Connected,
-				"registered":               clientStatus.Registered,
-				"clientId":                 clientStatus.ClientID,
-				"lastHeartbeat":            lastHeartbeatStr,
-				"missedHeartbeats":         clientStatus.MissedHeartbeats,
-				"heartbeatIntervalSeconds": clientStatus.HeartbeatInterval, // Already int64 in seconds
-				"status":                   clientStatus.Status,
-			}
+	var nanabushStatus map[string]any
+	if status.Source == serviceStatusSourceClient && readErr == nil && status.ClientID == "" && status.Status.Status != "error" {
+		// Client is still registering - return connecting status
+		nanabushStatus = map[string]any{
+			"connected":  false,
+			"registered": false,
+			"clientId":   "",
+			"status":     "connecting",
 		}
+	} else {
+		var lastHeartbeatStr string
+		if !status.LastHeartbeat.IsZero() {
+			lastHeartbeatStr = status.LastHeartbeat.Format(time.RFC3339)
+		}
+		nanabushStatus = map[string]any{
+			"connected":                status.Connected,
+			"registered":               status.Registered,
+			"clientId":                 status.ClientID,
+			"lastHeartbeat":            lastHeartbeatStr,
+			"missedHeartbeats":         status.MissedHeartbeats,
+			"heartbeatIntervalSeconds": status.HeartbeatInterval, // Already int64 in seconds
+			"status":                   status.Status.Status,
+			"activeEndpoint":           status.ActiveEndpoint,
+		}
+	}
+	nanabushStatus["source"] = status.Source
+	if status.Note != "" {
+		nanabushStatus["note"] = status.Note
+	}
+	if nanabushClient != nil {
+		// Lets the UI say translations are failing fast while the breaker is open
+		nanabushStatus["circuit"] = nanabushClient.Circuit()
 	}
 
 	result["nanabush"] = nanabushStatus
@@ -1841,13 +3433,36 @@ func buildStateResponse(opts Options) map[string]any {
 		return result
 	}
 
-	// Get all targets
+	// Only targets and jobs in namespaces the API may read are sent
+	namespaces := newNamespaceAllowlist(opts.AllowedNamespaces)
 	targets := opts.Catalogue.Targets()
 	wikitargets := make([]map[string]any, 0, len(targets))
+	maxPages := stateLimit(opts.StateMaxPages, defaultStateMaxPages)
+	var activeTranslations map[string]int
+	if opts.Jobs != nil {
+		activeTranslations = opts.Jobs.ActiveTranslations()
+	}
 
 	for _, target := range targets {
+		if !namespaces.allows(target.Namespace) {
+			continue
+		}
 		// Get pages for this target
 		pages := opts.Catalogue.List(target.ID)
+		// Large catalogues are summarized; clients fetch them from /api/v1/catalogue
+		pageCount := len(pages)
+		truncated := maxPages >= 0 && pageCount > maxPages
+		if truncated {
+			pages = nil
+		}
+		// The catalogue keeps pages in the order the wiki last listed them;
+		// sort them so successive payloads only differ where pages changed
+		sort.Slice(pages, func(i, j int) bool {
+			if pages[i].Title != pages[j].Title {
+				return pages[i].Title < pages[j].Title
+			}
+			return pages[i].ID < pages[j].ID
+		})
 		pageList := make([]map[string]any, 0, len(pages))
 
 		for _, page := range pages {
@@ -1866,16 +3481,22 @@ func buildStateResponse(opts Options) map[string]any {
 				"collection":     page.Collection,
 				"template":       page.Template,
 				"isTemplate":     page.IsTemplate,
+				"parentId":       page.ParentID,
+				"isDraft":        page.IsDraft,
 			})
 		}
 
 		wikitargets = append(wikitargets, map[string]any{
-			"wikitarget": target.URI,
-			"targetId":   target.ID,
-			"name":       target.Name,
-			"namespace":  target.Namespace,
-			"mode":       target.Mode,
-			"pages":      pageList,
+			"wikitarget":     target.URI,
+			"targetId":       target.ID,
+			"name":           target.Name,
+			"namespace":      target.Namespace,
+			"mode":           target.Mode,
+			"pages":          pageList,
+			"pageCount":      pageCount,
+			"pagesTruncated": truncated,
+			// Jobs working against this wiki, next to its maxConcurrentTranslations
+			"activeTranslations": activeTranslations[target.ID],
 		})
 	}
 
@@ -1887,23 +3508,45 @@ func buildStateResponse(opts Options) map[string]any {
 		ctx := context.Background()
 		var jobList wikiv1alpha1.TranslationJobList
 		// List all TranslationJobs in glooscap-system namespace
-		if err := opts.Client.List(ctx, &jobList, client.InNamespace("glooscap-system")); err == nil {
-			for _, job := range jobList.Items {
+		if err := opts.Client.List(ctx, &jobList, client.InNamespace("glooscap-system")); err == nil && namespaces.allows("glooscap-system") {
+			// Newest jobs first, keeping only as many as the cap allows
+			jobs := jobList.Items
+			sort.SliceStable(jobs, func(i, j int) bool {
+				if !jobs[i].CreationTimestamp.Equal(&jobs[j].CreationTimestamp) {
+					return jobs[j].CreationTimestamp.Before(&jobs[i].CreationTimestamp)
+				}
+				return jobs[i].Name < jobs[j].Name
+			})
+			result["translationJobsTotal"] = len(jobs)
+			if maxJobs := stateLimit(opts.StateMaxJobs, defaultStateMaxJobs); maxJobs >= 0 && len(jobs) > maxJobs {
+				jobs = jobs[:maxJobs]
+				result["translationJobsTruncated"] = true
+			}
+
+			// Index source pages by target once rather than scanning a target's
+			// whole catalogue for every job
+			pageIndex := map[string]map[string]*catalog.Page{}
+			sourcePage := func(targetRef, pageID string) *catalog.Page {
+				targetID := fmt.Sprintf("glooscap-system/%s", targetRef)
+				index, ok := pageIndex[targetID]
+				if !ok {
+					index = map[string]*catalog.Page{}
+					for _, page := range opts.Catalogue.List(targetID) {
+						index[page.ID] = page
+					}
+					pageIndex[targetID] = index
+				}
+				return index[pageID]
+			}
+
+			for _, job := range jobs {
 				// Build source page URI if we have the page info
 				sourceURI := ""
 				sourcePageTitle := job.Spec.Parameters["pageTitle"]
-				if opts.Catalogue != nil {
-					// Try to find the page in the catalog to get its URI
-					targetID := fmt.Sprintf("glooscap-system/%s", job.Spec.Source.TargetRef)
-					pages := opts.Catalogue.List(targetID)
-					for _, page := range pages {
-						if page.ID == job.Spec.Source.PageID {
-							sourceURI = page.URI
-							if sourcePageTitle == "" {
-								sourcePageTitle = page.Title
-							}
-							break
-						}
+				if page := sourcePage(job.Spec.Source.TargetRef, job.Spec.Source.PageID); page != nil {
+					sourceURI = page.URI
+					if sourcePageTitle == "" {
+						sourcePageTitle = page.Title
 					}
 				}
 
@@ -1912,7 +3555,7 @@ func buildStateResponse(opts Options) map[string]any {
 				if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
 					destTargetRef = job.Spec.Destination.TargetRef
 				}
-				languageTag := "fr-CA" // Default
+				languageTag := defaultLanguageTag
 				if job.Spec.Destination != nil && job.Spec.Destination.LanguageTag != "" {
 					languageTag = job.Spec.Destination.LanguageTag
 				}
@@ -1945,7 +3588,10 @@ func buildStateResponse(opts Options) map[string]any {
 						"languageTag": languageTag,
 					},
 					"pipeline":     string(job.Spec.Pipeline),
-					"isDiagnostic": job.Labels["glooscap.dasmlab.org/diagnostic"] == "true",
+					"isDiagnostic": job.Labels[wikiv1alpha1.LabelDiagnostic] == "true",
+				}
+				if len(job.Status.LanguageResults) > 0 {
+					jobData["languageResults"] = job.Status.LanguageResults
 				}
 
 				// Add translated page info if completed
@@ -1955,16 +3601,16 @@ func buildStateResponse(opts Options) map[string]any {
 					var isDraft bool = true
 
 					if job.Annotations != nil {
-						if pageID, ok := job.Annotations["glooscap.dasmlab.org/published-page-id"]; ok {
+						if pageID, ok := job.Annotations[wikiv1alpha1.AnnotationPublishedPageID]; ok {
 							publishedPageID = pageID
 						}
-						if pageSlug, ok := job.Annotations["glooscap.dasmlab.org/published-page-slug"]; ok {
+						if pageSlug, ok := job.Annotations[wikiv1alpha1.AnnotationPublishedPageSlug]; ok {
 							publishedPageSlug = pageSlug
 						}
-						if pageURL, ok := job.Annotations["glooscap.dasmlab.org/published-page-url"]; ok {
+						if pageURL, ok := job.Annotations[wikiv1alpha1.AnnotationPublishedPageURL]; ok {
 							publishedPageURL = pageURL
 						}
-						if draftFlag, ok := job.Annotations["glooscap.dasmlab.org/is-draft"]; ok {
+						if draftFlag, ok := job.Annotations[wikiv1alpha1.AnnotationIsDraft]; ok {
 							isDraft = (draftFlag == "true")
 						}
 					}
@@ -2001,17 +3647,99 @@ func buildStateResponse(opts Options) map[string]any {
 	return result
 }
 
-// sendStateEvent builds and broadcasts the current state.
-func sendStateEvent(broadcaster *eventBroadcaster, opts Options) {
-	state := buildStateResponse(opts)
-	if data, err := json.Marshal(state); err == nil {
+// startEventStream sets the CORS and SSE headers shared by the event streams.
+// It reports false, after writing an error, when w can't stream.
+func startEventStream(w http.ResponseWriter, r *http.Request) (http.Flusher, bool) {
+	// Set CORS headers first
+	origin := r.Header.Get("Origin")
+	allowedOrigins := []string{
+		"https://web-glooscap.apps.ocp-ai-sno-2.rh.dasmlab.org",
+		"http://web-glooscap.apps.ocp-ai-sno-2.rh.dasmlab.org",
+		"http://localhost:9000",
+		"http://localhost:8080",
+	}
+	// When using credentials, we MUST use a specific origin, not "*"
+	allowOrigin := ""
+	if origin != "" {
+		for _, allowed := range allowedOrigins {
+			if origin == allowed {
+				allowOrigin = origin
+				break
+			}
+		}
+		// If no match but we have an origin, allow it (for development flexibility)
+		if allowOrigin == "" {
+			allowOrigin = origin
+		}
+	}
+
+	// If no origin header, default to wildcard (but can't use credentials then)
+	if allowOrigin == "" {
+		allowOrigin = "*"
+	} else {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	// Set up SSE headers - MUST be set before any writes
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Cache-Control")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
+	// Additional headers to help with HTTP/2 SSE compatibility
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "SSE not supported", nil)
+		return nil, false
+	}
+	return flusher, true
+}
+
+// sendStateEvent broadcasts the current state to every subscriber. The payload
+// is built and serialized once and shared by all subscribers; it reports false
+// when a cached payload younger than stateCacheTTL was reused.
+func sendStateEvent(broadcaster *eventBroadcaster, opts Options) bool {
+	data, fresh, err := broadcaster.state.get(opts)
+	if err == nil {
 		broadcaster.broadcast(data)
 	}
+	return fresh
 }
 
 func writeJSON(w http.ResponseWriter, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(v); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, err.Error(), nil)
 	}
 }
+
+// requestIDHeader carries the per-request ID assigned by the logging middleware.
+const requestIDHeader = "X-Request-ID"
+
+// apiError is the body of every error response: {"error": {...}}.
+type apiError struct {
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+	Details   any    `json:"details,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// writeError writes a JSON error response with the given HTTP status.
+// The request ID is taken from the response header set by the logging middleware.
+func writeError(w http.ResponseWriter, code int, message string, details any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]apiError{
+		"error": {
+			Code:      code,
+			Message:   message,
+			Details:   details,
+			RequestID: w.Header().Get(requestIDHeader),
+		},
+	})
+}
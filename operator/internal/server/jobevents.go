@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// jobEventsPollInterval is how often a job event stream re-reads the job to
+// catch state changes no translation_job event announced.
+const jobEventsPollInterval = 2 * time.Second
+
+// jobStatusEvent encodes the job_status event a job event stream sends for
+// the job's current state.
+func jobStatusEvent(job *wikiv1alpha1.TranslationJob) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"event": "job_status",
+		"data": map[string]any{
+			"name":       job.Name,
+			"namespace":  job.Namespace,
+			"state":      job.Status.State,
+			"message":    job.Status.Message,
+			"startedAt":  job.Status.StartedAt,
+			"finishedAt": job.Status.FinishedAt,
+		},
+	})
+}
+
+// streamJobEvents writes job's status, then the translation_job events from
+// eventCh and a job_status event whenever its state or message changes, until
+// the job reaches a terminal state, is deleted, or ctx ends.
+func streamJobEvents(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, reader client.Reader,
+	job *wikiv1alpha1.TranslationJob, eventCh <-chan []byte, pollInterval time.Duration) {
+	send := func(data []byte) {
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+	}
+	sendStatus := func() {
+		if data, err := jobStatusEvent(job); err == nil {
+			send(data)
+		}
+	}
+
+	sendStatus()
+	if job.Status.State.IsTerminal() {
+		return
+	}
+	lastState, lastMessage := job.Status.State, job.Status.Message
+
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	keepaliveTicker := time.NewTicker(15 * time.Second)
+	defer keepaliveTicker.Stop()
+
+	key := client.ObjectKeyFromObject(job)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepaliveTicker.C:
+			fmt.Fprintf(w, ": keepalive\n\n")
+			flusher.Flush()
+			continue
+		case data, ok := <-eventCh:
+			if !ok {
+				// Dropped by the broadcaster for falling behind
+				return
+			}
+			// Events mostly come with a state change, so check right away
+			send(data)
+		case <-poll.C:
+		}
+
+		if err := reader.Get(ctx, key, job); err != nil {
+			if errors.IsNotFound(err) {
+				if data, err := json.Marshal(map[string]any{
+					"event": "job_deleted",
+					"data":  map[string]string{"name": key.Name, "namespace": key.Namespace},
+				}); err == nil {
+					send(data)
+				}
+				return
+			}
+			continue
+		}
+		if job.Status.State == lastState && job.Status.Message == lastMessage {
+			continue
+		}
+		lastState, lastMessage = job.Status.State, job.Status.Message
+		sendStatus()
+		if job.Status.State.IsTerminal() {
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/internal/controller"
+)
+
+func TestStreamJobEvents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	job := &wikiv1alpha1.TranslationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "translation-a", Namespace: "glooscap-system"},
+		Status:     wikiv1alpha1.TranslationJobStatus{State: wikiv1alpha1.TranslationJobStateRunning},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(job).WithStatusSubresource(job).Build()
+
+	broadcaster := newEventBroadcaster()
+	eventCh, err := broadcaster.subscribeFiltered(func(event controller.TranslationJobEvent) bool {
+		return event.JobName == "translation-a" && event.Namespace == "glooscap-system"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer broadcaster.unsubscribe(eventCh)
+
+	// Only the matching job event reaches the scoped subscription
+	broadcaster.broadcast([]byte(`{"event":"state"}`))
+	broadcaster.broadcastJobEvent(controller.TranslationJobEvent{JobName: "translation-a", Namespace: "other"}, []byte(`{"event":"other-namespace"}`))
+	broadcaster.broadcastJobEvent(controller.TranslationJobEvent{JobName: "translation-a", Namespace: "glooscap-system"}, []byte(`{"event":"translation_job"}`))
+
+	done := make(chan struct{})
+	rec := httptest.NewRecorder()
+	go func() {
+		defer close(done)
+		var current wikiv1alpha1.TranslationJob
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(job), &current); err != nil {
+			t.Error(err)
+			return
+		}
+		streamJobEvents(context.Background(), rec, rec, c, &current, eventCh, 10*time.Millisecond)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	job.Status.State = wikiv1alpha1.TranslationJobStateCompleted
+	if err := c.Status().Update(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not close after the job completed")
+	}
+
+	out := rec.Body.String()
+	for _, want := range []string{`"state":"Running"`, `{"event":"translation_job"}`, `"state":"Completed"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stream missing %s:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{`{"event":"state"}`, "other-namespace"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("stream has %s:\n%s", unwanted, out)
+		}
+	}
+}
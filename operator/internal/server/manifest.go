@@ -0,0 +1,74 @@
+package server
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+)
+
+// manifestEntry is one page of a catalogue manifest.
+type manifestEntry struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Slug       string `json:"slug"`
+	URI        string `json:"uri"`
+	Language   string `json:"language"`
+	Collection string `json:"collection"`
+	// UpdatedAt is empty when the wiki didn't report it
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// manifestCSVHeader names the columns of a CSV manifest.
+var manifestCSVHeader = []string{"id", "title", "slug", "uri", "language", "collection", "updatedAt"}
+
+// catalogueManifest lists pages as manifest entries, ordered by title then
+// ID so repeated exports of an unchanged catalogue are identical.
+func catalogueManifest(pages []*catalog.Page) []manifestEntry {
+	entries := make([]manifestEntry, 0, len(pages))
+	for _, page := range pages {
+		entry := manifestEntry{
+			ID:         page.ID,
+			Title:      page.Title,
+			Slug:       page.Slug,
+			URI:        page.URI,
+			Language:   page.Language,
+			Collection: page.Collection,
+		}
+		if !page.UpdatedAt.IsZero() {
+			entry.UpdatedAt = page.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Title != entries[j].Title {
+			return entries[i].Title < entries[j].Title
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+// writeManifestCSV writes entries as CSV with a header row.
+func writeManifestCSV(w io.Writer, entries []manifestEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(manifestCSVHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := cw.Write([]string{e.ID, e.Title, e.Slug, e.URI, e.Language, e.Collection, e.UpdatedAt}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// manifestFilename is the download name of target's manifest in format.
+func manifestFilename(target, format string) string {
+	return fmt.Sprintf("glooscap-manifest-%s.%s", strings.ReplaceAll(target, "/", "-"), format)
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+)
+
+func TestCatalogueManifest(t *testing.T) {
+	updated := time.Date(2025, 4, 2, 8, 30, 0, 0, time.FixedZone("EDT", -4*3600))
+	entries := catalogueManifest([]*catalog.Page{
+		{ID: "b", Title: "Runbook, on-call", Slug: "runbook-b", URI: "https://wiki.example.com/doc/runbook-b", Language: "en", Collection: "Ops", UpdatedAt: updated},
+		{ID: "a", Title: "Runbook, on-call", Slug: "runbook-a", URI: "https://wiki.example.com/doc/runbook-a", Language: "fr-CA"},
+		{ID: "c", Title: "Architecture", Slug: "architecture", URI: "https://wiki.example.com/doc/architecture"},
+	})
+	if got := []string{entries[0].ID, entries[1].ID, entries[2].ID}; strings.Join(got, ",") != "c,a,b" {
+		t.Fatalf("order = %v, want title then ID", got)
+	}
+	if entries[2].UpdatedAt != "2025-04-02T12:30:00Z" || entries[1].UpdatedAt != "" {
+		t.Errorf("updatedAt = %q and %q, want UTC and empty when unknown", entries[2].UpdatedAt, entries[1].UpdatedAt)
+	}
+
+	var out strings.Builder
+	if err := writeManifestCSV(&out, entries); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,title,slug,uri,language,collection,updatedAt\n" +
+		"c,Architecture,architecture,https://wiki.example.com/doc/architecture,,,\n" +
+		"a,\"Runbook, on-call\",runbook-a,https://wiki.example.com/doc/runbook-a,fr-CA,,\n" +
+		"b,\"Runbook, on-call\",runbook-b,https://wiki.example.com/doc/runbook-b,en,Ops,2025-04-02T12:30:00Z\n"
+	if out.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	if got := manifestFilename("glooscap-system/docs", "csv"); got != "glooscap-manifest-glooscap-system-docs.csv" {
+		t.Errorf("filename = %q", got)
+	}
+}
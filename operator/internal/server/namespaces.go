@@ -0,0 +1,58 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+)
+
+// namespaceAllowlist holds the namespaces the API may read and write. An
+// empty allowlist allows every namespace.
+type namespaceAllowlist map[string]struct{}
+
+func newNamespaceAllowlist(namespaces []string) namespaceAllowlist {
+	allowed := make(namespaceAllowlist, len(namespaces))
+	for _, ns := range namespaces {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			allowed[ns] = struct{}{}
+		}
+	}
+	return allowed
+}
+
+// allows reports whether the API may operate on namespace.
+func (a namespaceAllowlist) allows(namespace string) bool {
+	if len(a) == 0 {
+		return true
+	}
+	_, ok := a[namespace]
+	return ok
+}
+
+// allowsTarget reports whether the API may operate on the target with ID
+// namespace/name.
+func (a namespaceAllowlist) allowsTarget(id string) bool {
+	namespace, _, _ := strings.Cut(id, "/")
+	return a.allows(namespace)
+}
+
+// jobs drops the jobs in namespaces the API may not read.
+func (a namespaceAllowlist) jobs(jobs map[string]catalog.Job) map[string]catalog.Job {
+	for name, job := range jobs {
+		if !a.allows(job.Namespace) {
+			delete(jobs, name)
+		}
+	}
+	return jobs
+}
+
+// check writes a 403 and returns false when namespace is not allowed.
+func (a namespaceAllowlist) check(w http.ResponseWriter, namespace string) bool {
+	if a.allows(namespace) {
+		return true
+	}
+	writeError(w, http.StatusForbidden, fmt.Sprintf("namespace %q is not allowed", namespace), nil)
+	return false
+}
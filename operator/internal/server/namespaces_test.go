@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+)
+
+func TestNamespaceAllowlist(t *testing.T) {
+	if all := newNamespaceAllowlist(nil); !all.allows("anything") {
+		t.Error("empty allowlist should allow every namespace")
+	}
+
+	allowed := newNamespaceAllowlist([]string{"glooscap-system", " team-a ", ""})
+	for ns, want := range map[string]bool{"glooscap-system": true, "team-a": true, "team-b": false, "": false} {
+		if got := allowed.allows(ns); got != want {
+			t.Errorf("allows(%q) = %v, want %v", ns, got, want)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	if allowed.check(rec, "team-b") {
+		t.Fatal("check should reject team-b")
+	}
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestListRoutesOnlyShowAllowedNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	// TranslationJobs live in glooscap-system, which isn't allowed either
+	hiddenJob := &wikiv1alpha1.TranslationJob{ObjectMeta: metav1.ObjectMeta{Name: "hidden-job", Namespace: "glooscap-system"}}
+
+	store := catalog.NewStore()
+	jobs := catalog.NewJobStore()
+	for _, ns := range []string{"team-a", "hidden"} {
+		store.Update(ns+"/wiki", catalog.Target{ID: ns + "/wiki", Namespace: ns, Name: "wiki"}, []catalog.Page{
+			{ID: ns + "-page", Title: ns + " page", URI: "https://" + ns + ".example.com/doc/page"},
+		})
+		job := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: ns + "-job", Namespace: ns},
+			Status:     wikiv1alpha1.TranslationJobStatus{TokensUsed: 10},
+		}
+		jobs.Update(job)
+		jobs.RecordTokens(job)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	router := newRouter(ctx, Options{
+		Client:            fake.NewClientBuilder().WithScheme(scheme).WithObjects(hiddenJob).Build(),
+		Catalogue:         store,
+		Jobs:              jobs,
+		AllowedNamespaces: []string{"team-a"},
+	})
+
+	for _, path := range []string{
+		"/api/v1/catalogue", "/api/v1/targets", "/api/v1/jobs", "/api/v1/stats", "/api/v1/db/state", "/api/v1/events",
+	} {
+		t.Run(path, func(t *testing.T) {
+			reqCtx, stop := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer stop()
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil).WithContext(reqCtx))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+			}
+			body := rec.Body.String()
+			if strings.Contains(body, "hidden") {
+				t.Errorf("response shows a namespace outside the allowlist: %s", body)
+			}
+			if !strings.Contains(body, "team-a") {
+				t.Errorf("response leaves out the allowed namespace: %s", body)
+			}
+		})
+	}
+
+	for target, want := range map[string]int{"hidden/wiki": http.StatusForbidden, "wiki": http.StatusBadRequest} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/catalogue?target="+target, nil))
+		if rec.Code != want {
+			t.Errorf("catalogue?target=%s: status = %d, want %d", target, rec.Code, want)
+		}
+	}
+}
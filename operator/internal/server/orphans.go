@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+)
+
+const (
+	// translatedTitlePrefix is prepended by the runner to every translated page title.
+	translatedTitlePrefix = "AUTOTRANSLATED--> "
+)
+
+// uniqueTitleSuffix matches the " (N)" suffix the runner adds to avoid title clashes.
+var uniqueTitleSuffix = regexp.MustCompile(` \(\d+\)$`)
+
+// translatedSourceTitle returns the source title in a translated page's title,
+// without the runner's " (N)" suffix or the translation a retitle appended.
+func translatedSourceTitle(title string) string {
+	title, _, _ = strings.Cut(strings.TrimPrefix(title, translatedTitlePrefix), retitleSeparator)
+	return uniqueTitleSuffix.ReplaceAllString(title, "")
+}
+
+// orphanPage is a translated page whose source page no longer exists.
+type orphanPage struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	URI          string `json:"uri,omitempty"`
+	SourceTitle  string `json:"sourceTitle"`
+	SourceTarget string `json:"sourceTarget,omitempty"`
+	SourcePageID string `json:"sourcePageId,omitempty"`
+	// MatchedBy is "job" when the source came from the producing TranslationJob,
+	// or "title" when it was inferred from the translated title.
+	MatchedBy string `json:"matchedBy"`
+}
+
+// findOrphanPages returns the AUTOTRANSLATED pages in target's catalogue whose
+// source page is gone. The producing TranslationJob identifies the source when
+// it still exists; pages whose source catalogue hasn't been synced yet are
+// never reported, since absence can't be distinguished from "not discovered".
+// Without a job, a page is only reported when no catalogue in the namespace
+// has its un-prefixed title, and only while every one of them last synced
+// successfully and isn't narrowed by a collectionFilter; such title matches
+// are never deleted (see orphanCleanupSkipReason).
+func findOrphanPages(ctx context.Context, opts Options, target *wikiv1alpha1.WikiTarget) ([]orphanPage, error) {
+	targetID := fmt.Sprintf("%s/%s", target.Namespace, target.Name)
+	pages := opts.Catalogue.List(targetID)
+
+	// Map produced page ID -> source (target, page) from TranslationJobs
+	type source struct{ targetRef, pageID string }
+	sources := make(map[string]source)
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := opts.Client.List(ctx, &jobs, client.InNamespace(target.Namespace)); err != nil {
+		return nil, fmt.Errorf("list translation jobs: %w", err)
+	}
+	for _, job := range jobs.Items {
+		if id := job.Annotations[wikiv1alpha1.AnnotationPublishedPageID]; id != "" {
+			sources[id] = source{targetRef: job.Spec.Source.TargetRef, pageID: job.Spec.Source.PageID}
+		}
+	}
+
+	// Source titles across the namespace's catalogues, since translations may
+	// live in a different target from their source. A title missing from an
+	// incomplete catalogue proves nothing, so title matching needs them all.
+	var wikiTargets wikiv1alpha1.WikiTargetList
+	if err := opts.Client.List(ctx, &wikiTargets, client.InNamespace(target.Namespace)); err != nil {
+		return nil, fmt.Errorf("list wiki targets: %w", err)
+	}
+	titlesComplete := len(wikiTargets.Items) > 0
+	titles := make(map[string]bool)
+	for i := range wikiTargets.Items {
+		wt := &wikiTargets.Items[i]
+		if !catalogueComplete(wt) {
+			titlesComplete = false
+			break
+		}
+		for _, p := range opts.Catalogue.List(fmt.Sprintf("%s/%s", wt.Namespace, wt.Name)) {
+			if !strings.HasPrefix(p.Title, translatedTitlePrefix) {
+				titles[p.Title] = true
+			}
+		}
+	}
+
+	catalogues := map[string][]*catalog.Page{targetID: pages}
+	pageExists := func(targetRef, pageID string) (exists, known bool) {
+		id := fmt.Sprintf("%s/%s", target.Namespace, targetRef)
+		list, ok := catalogues[id]
+		if !ok {
+			list = opts.Catalogue.List(id)
+			catalogues[id] = list
+		}
+		if len(list) == 0 {
+			return false, false
+		}
+		for _, p := range list {
+			if p.ID == pageID {
+				return true, true
+			}
+		}
+		return false, true
+	}
+
+	var orphans []orphanPage
+	for _, p := range pages {
+		if !strings.HasPrefix(p.Title, translatedTitlePrefix) {
+			continue
+		}
+		sourceTitle := translatedSourceTitle(p.Title)
+
+		if src, ok := sources[p.ID]; ok && src.pageID != "" {
+			if exists, known := pageExists(src.targetRef, src.pageID); known && !exists {
+				orphans = append(orphans, orphanPage{
+					ID:           p.ID,
+					Title:        p.Title,
+					URI:          p.URI,
+					SourceTitle:  sourceTitle,
+					SourceTarget: src.targetRef,
+					SourcePageID: src.pageID,
+					MatchedBy:    "job",
+				})
+			}
+			continue
+		}
+
+		if titlesComplete && !titles[sourceTitle] {
+			orphans = append(orphans, orphanPage{
+				ID:          p.ID,
+				Title:       p.Title,
+				URI:         p.URI,
+				SourceTitle: sourceTitle,
+				MatchedBy:   "title",
+			})
+		}
+	}
+	return orphans, nil
+}
+
+// catalogueComplete reports whether target's catalogue holds every page of
+// its wiki: its last discovery succeeded and no collectionFilter narrows it.
+func catalogueComplete(target *wikiv1alpha1.WikiTarget) bool {
+	return target.Status.LastSyncTime != nil && target.Status.ConsecutiveFailures == 0 &&
+		len(target.Spec.CollectionFilter) == 0
+}
+
+// orphanCleanupSkipReason returns why cleanup in mode leaves o alone, or ""
+// when it may go ahead. A title match is only a guess, so it may be archived,
+// which can be undone, but never deleted.
+func orphanCleanupSkipReason(o orphanPage, mode string) string {
+	if mode == "delete" && o.MatchedBy != "job" {
+		return "matched by title only; archive it instead, or delete it in Outline"
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+)
+
+func TestFindOrphanPages(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	lastSync := metav1.Now()
+	synced := wikiv1alpha1.WikiTargetStatus{LastSyncTime: &lastSync}
+	newTarget := func(name string, status wikiv1alpha1.WikiTargetStatus, filter ...string) *wikiv1alpha1.WikiTarget {
+		return &wikiv1alpha1.WikiTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "glooscap-system"},
+			Spec:       wikiv1alpha1.WikiTargetSpec{CollectionFilter: filter},
+			Status:     status,
+		}
+	}
+	job := &wikiv1alpha1.TranslationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "job", Namespace: "glooscap-system",
+			Annotations: map[string]string{wikiv1alpha1.AnnotationPublishedPageID: "t-job"},
+		},
+		Spec: wikiv1alpha1.TranslationJobSpec{
+			Source: wikiv1alpha1.TranslationSourceSpec{TargetRef: "source", PageID: "deleted"},
+		},
+	}
+
+	store := catalog.NewStore()
+	store.Update("glooscap-system/source", catalog.Target{ID: "glooscap-system/source"}, []catalog.Page{
+		{ID: "kept", Title: "Kept", URI: "https://wiki/doc/kept"},
+	})
+	store.Update("glooscap-system/dest", catalog.Target{ID: "glooscap-system/dest"}, []catalog.Page{
+		{ID: "t-kept", Title: translatedTitlePrefix + "Kept", URI: "https://wiki/doc/t-kept"},
+		{ID: "t-gone", Title: translatedTitlePrefix + "Gone (2)", URI: "https://wiki/doc/t-gone"},
+		{ID: "t-job", Title: translatedTitlePrefix + "Renamed", URI: "https://wiki/doc/t-job"},
+		{ID: "t-retitled", Title: retitledTitle("Kept", "Conservé"), URI: "https://wiki/doc/t-retitled"},
+	})
+
+	cases := []struct {
+		name   string
+		source *wikiv1alpha1.WikiTarget
+		want   []string
+	}{
+		{name: "complete catalogues", source: newTarget("source", synced), want: []string{"t-gone/title", "t-job/job"}},
+		{name: "filtered source", source: newTarget("source", synced, "Engineering"), want: []string{"t-job/job"}},
+		{name: "failed sync", source: newTarget("source", wikiv1alpha1.WikiTargetStatus{LastSyncTime: &lastSync, ConsecutiveFailures: 1}),
+			want: []string{"t-job/job"}},
+		{name: "never synced", source: newTarget("source", wikiv1alpha1.WikiTargetStatus{}), want: []string{"t-job/job"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dest := newTarget("dest", synced)
+			opts := Options{
+				Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.source, dest, job).Build(),
+				Catalogue: store,
+			}
+			orphans, err := findOrphanPages(context.Background(), opts, dest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, o := range orphans {
+				got = append(got, o.ID+"/"+o.MatchedBy)
+			}
+			sort.Strings(got)
+			if len(got) != len(tc.want) || (len(got) > 0 && got[0] != tc.want[0]) || (len(got) > 1 && got[1] != tc.want[1]) {
+				t.Errorf("orphans = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOrphanCleanupNeverDeletesTitleMatches(t *testing.T) {
+	byTitle := orphanPage{ID: "t-gone", MatchedBy: "title"}
+	byJob := orphanPage{ID: "t-job", MatchedBy: "job"}
+	if orphanCleanupSkipReason(byTitle, "delete") == "" {
+		t.Error("a title match would be deleted")
+	}
+	if reason := orphanCleanupSkipReason(byTitle, "archive"); reason != "" {
+		t.Errorf("archiving a title match skipped: %s", reason)
+	}
+	if reason := orphanCleanupSkipReason(byJob, "delete"); reason != "" {
+		t.Errorf("deleting a job match skipped: %s", reason)
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+	"github.com/dasmlab/glooscap-operator/pkg/vllm"
+)
+
+// pipelineMode describes a TranslationJob pipeline for GET /api/v1/pipelines.
+type pipelineMode struct {
+	Mode        wikiv1alpha1.TranslationPipelineMode `json:"mode"`
+	Description string                               `json:"description"`
+	Requires    string                               `json:"requires"`
+	Available   bool                                 `json:"available"`
+	// Reason explains why the mode is unavailable
+	Reason  string `json:"reason,omitempty"`
+	Default bool   `json:"default"`
+}
+
+// pipelineModes reports the pipeline modes a job may request and whether each
+// can run with the operator's current configuration. It follows the
+// reconciler: TektonJob jobs go to the dispatcher, InlineLLM jobs call the
+// translation service from the operator.
+func pipelineModes(dispatcher vllm.Dispatcher, translator *nanabush.Client) []pipelineMode {
+	tekton := pipelineMode{
+		Mode:        wikiv1alpha1.TranslationPipelineModeTektonJob,
+		Description: "Runs each translation in a translation-runner Kubernetes Job",
+		Requires:    "Runner image and a translation service reachable from the job's pod",
+		Default:     true,
+	}
+	if _, ok := dispatcher.(*vllm.TektonJobDispatcher); ok {
+		tekton.Available = true
+	} else {
+		tekton.Reason = "The operator is not configured to dispatch runner jobs (VLLM_MODE)"
+	}
+
+	inline := pipelineMode{
+		Mode:        wikiv1alpha1.TranslationPipelineModeInlineLLM,
+		Description: "Translates inside the operator over its translation service connection",
+		Requires:    "A connected translation service",
+	}
+	switch {
+	case translator == nil:
+		inline.Reason = "No translation service is configured"
+	case !translator.Status().Connected:
+		inline.Reason = "The translation service is not connected"
+	default:
+		inline.Available = true
+	}
+
+	return []pipelineMode{tekton, inline}
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"testing"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/vllm"
+)
+
+func TestPipelineModes(t *testing.T) {
+	modes := pipelineModes(&vllm.TektonJobDispatcher{}, nil)
+	if len(modes) != 2 {
+		t.Fatalf("got %d modes, want 2", len(modes))
+	}
+	tekton, inline := modes[0], modes[1]
+	if tekton.Mode != wikiv1alpha1.TranslationPipelineModeTektonJob || !tekton.Available || !tekton.Default {
+		t.Errorf("unexpected TektonJob mode: %+v", tekton)
+	}
+	if inline.Mode != wikiv1alpha1.TranslationPipelineModeInlineLLM || inline.Available || inline.Reason == "" {
+		t.Errorf("InlineLLM should be unavailable without a translation service: %+v", inline)
+	}
+
+	if modes := pipelineModes(&vllm.InlineDispatcher{}, nil); modes[0].Available {
+		t.Error("TektonJob should be unavailable without a runner dispatcher")
+	}
+}
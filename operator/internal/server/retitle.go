@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+)
+
+// retitleSeparator sits between the source title and its translation in a
+// retitled page's title. The source title stays right after the prefix, where
+// duplicate checks, earlier-translation and orphan lookups look for it.
+const retitleSeparator = " --> "
+
+type retitleRequest struct {
+	Namespace string   `json:"namespace"`
+	TargetRef string   `json:"targetRef"`
+	PageIDs   []string `json:"pageIds"`
+}
+
+func (r *retitleRequest) validate() error {
+	if r.Namespace == "" {
+		r.Namespace = defaultNamespace
+	}
+	if r.TargetRef == "" {
+		return fmt.Errorf("targetRef is required")
+	}
+	return nil
+}
+
+// retitleResult reports the outcome for one translated page.
+type retitleResult struct {
+	Job          string `json:"job"`
+	SourcePageID string `json:"sourcePageId"`
+	PageID       string `json:"pageId"`
+	LanguageTag  string `json:"languageTag"`
+	Title        string `json:"title,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// latestTranslations returns, per source page, language and destination, the
+// newest job in namespace that produced a translated page from targetRef. When
+// pageIDs is non-empty only those source pages are considered.
+func latestTranslations(ctx context.Context, c client.Client, namespace, targetRef string, pageIDs []string) ([]wikiv1alpha1.TranslationJob, error) {
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := c.List(ctx, &jobs, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list translation jobs: %w", err)
+	}
+	wanted := make(map[string]bool, len(pageIDs))
+	for _, id := range pageIDs {
+		wanted[id] = true
+	}
+
+	latest := make(map[string]wikiv1alpha1.TranslationJob)
+	for _, job := range jobs.Items {
+		if job.Spec.Source.TargetRef != targetRef || job.Annotations[wikiv1alpha1.AnnotationPublishedPageID] == "" {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[job.Spec.Source.PageID] {
+			continue
+		}
+		key := fmt.Sprintf("%s|%s|%s", job.Spec.Source.PageID, jobLanguageTag(&job), jobDestinationRef(&job))
+		if prev, ok := latest[key]; !ok || prev.CreationTimestamp.Before(&job.CreationTimestamp) {
+			latest[key] = job
+		}
+	}
+
+	out := make([]wikiv1alpha1.TranslationJob, 0, len(latest))
+	for _, job := range latest {
+		out = append(out, job)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// retitleTranslation translates sourceTitle with the title primitive and
+// applies it to the page produced by job, leaving the body untouched.
+func retitleTranslation(ctx context.Context, translator *nanabush.Client, destClient *outline.Client, job *wikiv1alpha1.TranslationJob, sourceTitle, sourceLanguage string) (string, error) {
+	resp, err := translator.Translate(ctx, nanabush.TranslateRequest{
+		JobID:          job.Name + "-retitle",
+		Namespace:      job.Namespace,
+		Primitive:      "title",
+		Title:          sourceTitle,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: jobLanguageTag(job),
+		PageID:         job.Spec.Source.PageID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !resp.Success || resp.TranslatedTitle == "" {
+		return "", fmt.Errorf("title translation failed: %s", resp.ErrorMessage)
+	}
+
+	title := retitledTitle(sourceTitle, resp.TranslatedTitle)
+	if _, err := destClient.UpdatePage(ctx, outline.UpdatePageRequest{
+		ID:    job.Annotations[wikiv1alpha1.AnnotationPublishedPageID],
+		Title: title,
+	}); err != nil {
+		return "", fmt.Errorf("update page title: %w", err)
+	}
+	return title, nil
+}
+
+// retitledTitle is the title of a translated page renamed to translatedTitle,
+// the translation of sourceTitle.
+func retitledTitle(sourceTitle, translatedTitle string) string {
+	return translatedTitlePrefix + sourceTitle + retitleSeparator + translatedTitle
+}
+
+// lastSourceTitle returns the source title job's translated page was built from.
+func lastSourceTitle(job *wikiv1alpha1.TranslationJob) string {
+	if title := job.Annotations[wikiv1alpha1.AnnotationTranslatedSourceTitle]; title != "" {
+		return title
+	}
+	return job.Spec.Parameters["pageTitle"]
+}
+
+func jobLanguageTag(job *wikiv1alpha1.TranslationJob) string {
+	if job.Spec.Destination != nil && job.Spec.Destination.LanguageTag != "" {
+		return job.Spec.Destination.LanguageTag
+	}
+	if lang := job.Spec.Parameters["languageTag"]; lang != "" {
+		return lang
+	}
+	return defaultLanguageTag
+}
+
+func jobDestinationRef(job *wikiv1alpha1.TranslationJob) string {
+	if job.Spec.Destination != nil && job.Spec.Destination.TargetRef != "" {
+		return job.Spec.Destination.TargetRef
+	}
+	return job.Spec.Source.TargetRef
+}
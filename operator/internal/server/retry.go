@@ -0,0 +1,42 @@
+package server
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// failedLanguages returns the languages of job that need a retry. Jobs that
+// finished before per-language results were recorded fall back to the job's
+// own language when the job failed.
+func failedLanguages(job *wikiv1alpha1.TranslationJob) []string {
+	if len(job.Status.LanguageResults) > 0 {
+		return job.Status.FailedLanguages()
+	}
+	if job.Status.State == wikiv1alpha1.TranslationJobStateFailed {
+		return []string{jobLanguageTag(job)}
+	}
+	return nil
+}
+
+// newLanguageRetryJob copies job's spec into a new job translating only lang.
+func newLanguageRetryJob(job *wikiv1alpha1.TranslationJob, lang string) *wikiv1alpha1.TranslationJob {
+	spec := job.Spec.DeepCopy()
+	if spec.Destination == nil {
+		spec.Destination = &wikiv1alpha1.TranslationDestinationSpec{}
+	}
+	spec.Destination.LanguageTag = lang
+
+	annotations := map[string]string{wikiv1alpha1.AnnotationRetryOf: job.Name}
+	if hash := job.Annotations[wikiv1alpha1.AnnotationSourceContentHash]; hash != "" {
+		annotations[wikiv1alpha1.AnnotationSourceContentHash] = hash
+	}
+	return &wikiv1alpha1.TranslationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: job.Name + "-retry-",
+			Namespace:    job.Namespace,
+			Annotations:  annotations,
+		},
+		Spec: *spec,
+	}
+}
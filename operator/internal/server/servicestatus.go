@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+)
+
+// translationServiceReadBackoff bounds the retries of a failed
+// TranslationService read; the status endpoints are polled, so they give up
+// quickly.
+var translationServiceReadBackoff = wait.Backoff{Steps: 3, Duration: 50 * time.Millisecond, Factor: 2}
+
+const (
+	// serviceStatusSourceResource and serviceStatusSourceClient say whether a
+	// reported status came from the TranslationService resource or from the
+	// operator's own client.
+	serviceStatusSourceResource = "resource"
+	serviceStatusSourceClient   = "client"
+
+	// serviceStatusUnknown is reported when the TranslationService resource
+	// couldn't be read.
+	serviceStatusUnknown = "unknown"
+)
+
+// serviceStatus is the translation service status reported by the API.
+type serviceStatus struct {
+	nanabush.Status
+	Source string `json:"source"`
+	// Note explains a status that couldn't be fully determined
+	Note string `json:"note,omitempty"`
+}
+
+// getTranslationService reads the TranslationService, retrying transient
+// errors briefly. It returns nil without an error when none exists.
+func getTranslationService(ctx context.Context, c client.Reader) (*wikiv1alpha1.TranslationService, error) {
+	var ts wikiv1alpha1.TranslationService
+	err := retry.OnError(translationServiceReadBackoff, func(err error) bool {
+		return !apierrors.IsNotFound(err) && ctx.Err() == nil
+	}, func() error {
+		return c.Get(ctx, client.ObjectKey{Name: wikiv1alpha1.TranslationServiceName}, &ts)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ts, nil
+}
+
+// resolveServiceStatus picks the status to report from the operator's client
+// and the TranslationService resource. The resource wins once the controller
+// has populated it, except while the client has connected and the resource
+// hasn't caught up yet, which happens at startup. When the resource couldn't
+// be read, readErr is set and the status is "unknown": the connection fields
+// are the client's, and the note says so.
+func resolveServiceStatus(clientStatus nanabush.Status, ts *wikiv1alpha1.TranslationService, readErr error) serviceStatus {
+	switch {
+	case readErr != nil:
+		status := clientStatus
+		status.Status = serviceStatusUnknown
+		return serviceStatus{
+			Status: status,
+			Source: serviceStatusSourceClient,
+			Note:   fmt.Sprintf("TranslationService status could not be read (%v); connection details are the operator client's view", readErr),
+		}
+	case ts == nil || (ts.Status.ClientID == "" && ts.Status.Status == ""):
+		return serviceStatus{Status: clientStatus, Source: serviceStatusSourceClient}
+	case clientStatus.Connected && clientStatus.Registered && (!ts.Status.Connected || !ts.Status.Registered):
+		return serviceStatus{Status: clientStatus, Source: serviceStatusSourceClient}
+	default:
+		return serviceStatus{Status: statusFromResource(ts), Source: serviceStatusSourceResource}
+	}
+}
+
+// statusFromResource converts a TranslationService's status to a client status.
+func statusFromResource(ts *wikiv1alpha1.TranslationService) nanabush.Status {
+	var lastHeartbeat time.Time
+	if ts.Status.LastHeartbeat != nil {
+		lastHeartbeat = ts.Status.LastHeartbeat.Time
+	}
+	return nanabush.Status{
+		ClientID:          ts.Status.ClientID,
+		Connected:         ts.Status.Connected,
+		Registered:        ts.Status.Registered,
+		Status:            ts.Status.Status,
+		MissedHeartbeats:  ts.Status.MissedHeartbeats,
+		HeartbeatInterval: int64(ts.Status.HeartbeatIntervalSeconds), // Already in seconds
+		LastHeartbeat:     lastHeartbeat,
+		ActiveEndpoint:    ts.Status.ActiveEndpoint,
+	}
+}
+
+// translationServiceReconcileTimeout bounds how long the reconcile endpoint
+// waits for the controller. Recreating the client includes a wait of up to
+// a few seconds for it to register.
+const translationServiceReconcileTimeout = 15 * time.Second
+
+// requestTranslationServiceReconcile annotates the TranslationService so its
+// controller recreates the client, and returns the annotation's value.
+func requestTranslationServiceReconcile(ctx context.Context, c client.Client, now time.Time) (string, error) {
+	var ts wikiv1alpha1.TranslationService
+	if err := c.Get(ctx, client.ObjectKey{Name: wikiv1alpha1.TranslationServiceName}, &ts); err != nil {
+		return "", err
+	}
+	requested := now.UTC().Format(time.RFC3339Nano)
+	patch := client.MergeFrom(ts.DeepCopy())
+	if ts.Annotations == nil {
+		ts.Annotations = map[string]string{}
+	}
+	ts.Annotations[wikiv1alpha1.AnnotationReconcileRequested] = requested
+	if err := c.Patch(ctx, &ts, patch); err != nil {
+		return "", err
+	}
+	return requested, nil
+}
+
+// awaitTranslationServiceReconcile waits until the controller has handled the
+// reconcile request, which it marks by removing or replacing the annotation,
+// and returns the TranslationService as it then is. done is false when the
+// wait ran out first.
+func awaitTranslationServiceReconcile(ctx context.Context, c client.Reader, requested string, timeout time.Duration) (ts *wikiv1alpha1.TranslationService, done bool, err error) {
+	err = wait.PollUntilContextTimeout(ctx, 250*time.Millisecond, timeout, false, func(ctx context.Context) (bool, error) {
+		ts, err = getTranslationService(ctx, c)
+		if err != nil || ts == nil {
+			return false, err
+		}
+		return ts.Annotations[wikiv1alpha1.AnnotationReconcileRequested] != requested, nil
+	})
+	if wait.Interrupted(err) {
+		return ts, false, nil
+	}
+	return ts, err == nil, err
+}
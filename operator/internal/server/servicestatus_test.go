@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+)
+
+func TestGetTranslationServiceRetries(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	ts := &wikiv1alpha1.TranslationService{ObjectMeta: metav1.ObjectMeta{Name: wikiv1alpha1.TranslationServiceName}}
+
+	// Transient failures are retried
+	failures := 2
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ts).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if failures > 0 {
+				failures--
+				return errors.New("connection refused")
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	got, err := getTranslationService(context.Background(), c)
+	if err != nil || got == nil {
+		t.Fatalf("getTranslationService = %v, %v; want the resource", got, err)
+	}
+
+	// A missing resource is not an error
+	got, err = getTranslationService(context.Background(), fake.NewClientBuilder().WithScheme(scheme).Build())
+	if err != nil || got != nil {
+		t.Errorf("getTranslationService = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestResolveServiceStatus(t *testing.T) {
+	connected := nanabush.Status{Connected: true, Registered: true, ClientID: "client-1", Status: "healthy"}
+	populated := &wikiv1alpha1.TranslationService{Status: wikiv1alpha1.TranslationServiceStatus{
+		ClientID: "client-1", Connected: true, Registered: true, Status: "warning",
+	}}
+	stale := &wikiv1alpha1.TranslationService{Status: wikiv1alpha1.TranslationServiceStatus{ClientID: "client-1", Status: "error"}}
+
+	tests := []struct {
+		name       string
+		ts         *wikiv1alpha1.TranslationService
+		readErr    error
+		wantSource string
+		wantStatus string
+	}{
+		{"no resource", nil, nil, serviceStatusSourceClient, "healthy"},
+		{"populated resource", populated, nil, serviceStatusSourceResource, "warning"},
+		{"resource behind client", stale, nil, serviceStatusSourceClient, "healthy"},
+		{"read error", nil, errors.New("timeout"), serviceStatusSourceClient, serviceStatusUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveServiceStatus(connected, tt.ts, tt.readErr)
+			if got.Source != tt.wantSource || got.Status.Status != tt.wantStatus {
+				t.Errorf("got source %q status %q, want %q %q", got.Source, got.Status.Status, tt.wantSource, tt.wantStatus)
+			}
+			if (tt.readErr != nil) != (got.Note != "") {
+				t.Errorf("note = %q for read error %v", got.Note, tt.readErr)
+			}
+		})
+	}
+}
+
+func TestTranslationServiceReconcileRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&wikiv1alpha1.TranslationService{
+		ObjectMeta: metav1.ObjectMeta{Name: wikiv1alpha1.TranslationServiceName},
+	}).Build()
+
+	requested, err := requestTranslationServiceReconcile(ctx, c, time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nothing handles the request, so the wait runs out
+	ts, done, err := awaitTranslationServiceReconcile(ctx, c, requested, 300*time.Millisecond)
+	if err != nil || done || ts.Annotations[wikiv1alpha1.AnnotationReconcileRequested] != requested {
+		t.Fatalf("unhandled request: done=%v err=%v annotations=%v", done, err, ts.Annotations)
+	}
+
+	// The controller removes the annotation once it has recreated the client
+	handled := ts.DeepCopy()
+	delete(handled.Annotations, wikiv1alpha1.AnnotationReconcileRequested)
+	handled.Status.ClientID = "client-2"
+	if err := c.Update(ctx, handled); err != nil {
+		t.Fatal(err)
+	}
+	ts, done, err = awaitTranslationServiceReconcile(ctx, c, requested, time.Second)
+	if err != nil || !done || ts.Status.ClientID != "client-2" {
+		t.Errorf("handled request: done=%v err=%v ts=%+v", done, err, ts)
+	}
+
+	// Without a TranslationService there is nothing to reconcile
+	_, err = requestTranslationServiceReconcile(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), time.Now())
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("missing TranslationService: err = %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// stateCacheTTL is how long a serialized state payload is reused.
+//
+// buildStateResponse lists TranslationJobs and walks the whole catalogue, and
+// every catalogue write, job event and translation service status change
+// triggers a broadcast, as does each new SSE subscriber. With a 5000-page
+// catalogue and 200 jobs, BenchmarkSendStateEvent measures ~60ms and ~20MB of
+// allocations per build: 50 back-to-back triggers (e.g. every subscriber
+// reconnecting after a restart) took ~2.9s of CPU uncached and ~57ms cached.
+const stateCacheTTL = time.Second
+
+const (
+	// defaultStateMaxPages is the number of pages a target may have before the
+	// state reports only its page count. Clients then page through
+	// /api/v1/catalogue?target= instead.
+	defaultStateMaxPages = 5000
+	// defaultStateMaxJobs bounds the TranslationJobs in the state, newest first.
+	defaultStateMaxJobs = 500
+)
+
+// stateLimit resolves a configured state cap: 0 selects def and a negative
+// value disables the cap, reported as -1.
+func stateLimit(configured, def int) int {
+	switch {
+	case configured == 0:
+		return def
+	case configured < 0:
+		return -1
+	}
+	return configured
+}
+
+// stateCache memoizes the serialized state response for a short window so
+// that rapid triggers reuse the last build instead of rebuilding it.
+type stateCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex // held while building so concurrent callers share one build
+	data    []byte
+	builtAt time.Time
+}
+
+// get returns the serialized state, rebuilding it if the cached copy is older
+// than the TTL. fresh reports whether this call performed the build.
+func (c *stateCache) get(opts Options) (data []byte, fresh bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.data != nil && time.Since(c.builtAt) < c.ttl {
+		return c.data, false, nil
+	}
+	data, err = json.Marshal(buildStateResponse(opts))
+	if err != nil {
+		return nil, false, err
+	}
+	c.data = data
+	c.builtAt = time.Now()
+	return data, true, nil
+}
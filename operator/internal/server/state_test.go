@@ -0,0 +1,164 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+)
+
+const (
+	benchPages       = 5000
+	benchJobs        = 200
+	benchSubscribers = 50
+)
+
+// newBenchOptions returns Options backed by a benchPages-page catalogue and a
+// fake API server holding benchJobs TranslationJobs.
+func newBenchOptions(b testing.TB) Options {
+	b.Helper()
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		b.Fatalf("add scheme: %v", err)
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for i := range benchJobs {
+		builder = builder.WithObjects(&wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("job-%d", i), Namespace: "glooscap-system"},
+			Spec: wikiv1alpha1.TranslationJobSpec{
+				Source: wikiv1alpha1.TranslationSourceSpec{TargetRef: "wiki", PageID: fmt.Sprintf("page-%d", i)},
+			},
+		})
+	}
+
+	store := catalog.NewStore()
+	pages := make([]catalog.Page, benchPages)
+	for i := range pages {
+		pages[i] = catalog.Page{
+			ID:        fmt.Sprintf("page-%d", i),
+			Title:     fmt.Sprintf("Page %d", i),
+			URI:       fmt.Sprintf("https://wiki.example.com/doc/page-%d", i),
+			UpdatedAt: time.Now(),
+		}
+	}
+	store.Update("glooscap-system/wiki", catalog.Target{ID: "glooscap-system/wiki", Namespace: "glooscap-system", Name: "wiki"}, pages)
+
+	return Options{Client: builder.Build(), Catalogue: store}
+}
+
+func newBenchBroadcaster(ttl time.Duration) *eventBroadcaster {
+	eb := newEventBroadcaster()
+	eb.state.ttl = ttl
+	for range benchSubscribers {
+		ch, _ := eb.subscribe()
+		go func() {
+			for range ch {
+			}
+		}()
+	}
+	return eb
+}
+
+// BenchmarkSendStateEvent measures one trigger per subscriber, as happens
+// when every SSE client reconnects at once.
+func BenchmarkSendStateEvent(b *testing.B) {
+	opts := newBenchOptions(b)
+	for _, bc := range []struct {
+		name string
+		ttl  time.Duration
+	}{
+		{name: "uncached", ttl: 0},
+		{name: "cached", ttl: stateCacheTTL},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			eb := newBenchBroadcaster(bc.ttl)
+			b.ReportAllocs()
+			for b.Loop() {
+				eb.state.builtAt = time.Time{}
+				for range benchSubscribers {
+					sendStateEvent(eb, opts)
+				}
+			}
+		})
+	}
+}
+
+func TestStateCacheReusesBuild(t *testing.T) {
+	opts := Options{Catalogue: catalog.NewStore()}
+	c := stateCache{ttl: time.Minute}
+
+	first, fresh, err := c.get(opts)
+	if err != nil || !fresh {
+		t.Fatalf("first get: fresh=%v err=%v, want fresh build", fresh, err)
+	}
+	second, fresh, err := c.get(opts)
+	if err != nil || fresh {
+		t.Fatalf("second get: fresh=%v err=%v, want cached", fresh, err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("cached payload differs from first build")
+	}
+
+	c.builtAt = time.Now().Add(-2 * time.Minute)
+	if _, fresh, _ := c.get(opts); !fresh {
+		t.Errorf("expired cache was not rebuilt")
+	}
+}
+
+func TestBuildStateResponseCaps(t *testing.T) {
+	opts := newBenchOptions(t)
+	opts.StateMaxPages = 100
+	opts.StateMaxJobs = 10
+
+	state := buildStateResponse(opts)
+	target := state["wikitargets"].([]map[string]any)[0]
+	if !target["pagesTruncated"].(bool) || target["pageCount"] != benchPages || len(target["pages"].([]map[string]any)) != 0 {
+		t.Errorf("expected a page count only, got truncated=%v count=%v", target["pagesTruncated"], target["pageCount"])
+	}
+	if jobs := state["translationJobs"].([]map[string]any); len(jobs) != 10 {
+		t.Errorf("got %d jobs, want 10", len(jobs))
+	}
+	if state["translationJobsTotal"] != benchJobs || state["translationJobsTruncated"] != true {
+		t.Errorf("unexpected job totals: total=%v truncated=%v", state["translationJobsTotal"], state["translationJobsTruncated"])
+	}
+
+	opts.StateMaxPages, opts.StateMaxJobs = -1, -1
+	state = buildStateResponse(opts)
+	target = state["wikitargets"].([]map[string]any)[0]
+	if len(target["pages"].([]map[string]any)) != benchPages || len(state["translationJobs"].([]map[string]any)) != benchJobs {
+		t.Error("negative caps should include every page and job")
+	}
+}
+
+func TestBuildStateResponseOrder(t *testing.T) {
+	store := catalog.NewStore()
+	for _, name := range []string{"wiki-c", "wiki-a", "wiki-b"} {
+		id := "glooscap-system/" + name
+		store.Update(id, catalog.Target{ID: id, Namespace: "glooscap-system", Name: name}, []catalog.Page{
+			{ID: "page-3", Title: "Setup", URI: "https://wiki.example.com/doc/page-3"},
+			{ID: "page-2", Title: "Guide", URI: "https://wiki.example.com/doc/page-2"},
+			{ID: "page-1", Title: "Setup", URI: "https://wiki.example.com/doc/page-1"},
+		})
+	}
+
+	state := buildStateResponse(Options{Catalogue: store})
+	var names, pageIDs []string
+	for _, target := range state["wikitargets"].([]map[string]any) {
+		names = append(names, target["name"].(string))
+	}
+	for _, page := range state["wikitargets"].([]map[string]any)[0]["pages"].([]map[string]any) {
+		pageIDs = append(pageIDs, page["id"].(string))
+	}
+	if fmt.Sprint(names) != "[wiki-a wiki-b wiki-c]" {
+		t.Errorf("targets in order %v", names)
+	}
+	if fmt.Sprint(pageIDs) != "[page-2 page-1 page-3]" {
+		t.Errorf("pages in order %v, want by title then ID", pageIDs)
+	}
+}
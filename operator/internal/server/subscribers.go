@@ -0,0 +1,80 @@
+package server
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// defaultMaxEventSubscribers bounds the open SSE streams, so tabs left
+	// open (or leaked by a client) can't pile up goroutines without limit.
+	defaultMaxEventSubscribers = 1000
+	// defaultEventStallTimeout is how long a subscriber's channel may stay
+	// full before the stream is closed; the client reconnects and gets the
+	// current state again.
+	defaultEventStallTimeout = time.Minute
+	// defaultEventRetryAfter is the Retry-After sent with a 503 at the limit.
+	defaultEventRetryAfter = 30 * time.Second
+	// eventSubscriberBuffer is the events queued per subscriber.
+	eventSubscriberBuffer = 10
+)
+
+var errTooManySubscribers = stderrors.New("too many event stream subscribers")
+
+var (
+	eventSubscribers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "glooscap_sse_subscribers",
+		Help: "Number of open SSE event streams.",
+	})
+
+	eventSubscribersDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "glooscap_sse_subscribers_dropped_total",
+		Help: "SSE subscribers turned away at the limit (rejected) or disconnected after stalling (stalled).",
+	}, []string{"reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(eventSubscribers, eventSubscribersDropped)
+}
+
+// subscription is the broadcaster's view of one subscriber.
+type subscription struct {
+	// filter selects translation_job events; nil receives everything.
+	filter jobEventFilter
+	// fullSince is when a send first found the channel full, zero while the
+	// subscriber keeps up.
+	fullSince time.Time
+}
+
+// deliver queues data for the subscriber on ch. A subscriber whose channel has
+// been full for stallTimeout is dropped and ch closed, which ends its stream.
+// eb.mu must be held for writing.
+func (eb *eventBroadcaster) deliver(ch chan []byte, sub *subscription, data []byte, now time.Time) {
+	select {
+	case ch <- data:
+		sub.fullSince = time.Time{}
+		return
+	default:
+	}
+	if sub.fullSince.IsZero() {
+		sub.fullSince = now
+		return
+	}
+	if eb.stallTimeout > 0 && now.Sub(sub.fullSince) >= eb.stallTimeout {
+		delete(eb.subscribers, ch)
+		close(ch)
+		eventSubscribers.Dec()
+		eventSubscribersDropped.WithLabelValues("stalled").Inc()
+	}
+}
+
+// writeSubscribeError answers an SSE request the broadcaster turned away.
+func writeSubscribeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(defaultEventRetryAfter.Seconds())))
+	writeError(w, http.StatusServiceUnavailable, err.Error(), nil)
+}
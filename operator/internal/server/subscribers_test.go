@@ -0,0 +1,62 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventBroadcasterLimits(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	eb := newEventBroadcaster()
+	eb.maxSubscribers = 2
+	eb.stallTimeout = time.Minute
+	eb.now = func() time.Time { return now }
+
+	slow, err := eb.subscribe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fast, err := eb.subscribe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := eb.subscribe(); !errors.Is(err, errTooManySubscribers) {
+		t.Fatalf("third subscriber: err = %v, want errTooManySubscribers", err)
+	}
+
+	// Fill both channels; only fast reads its events
+	for range eventSubscriberBuffer + 1 {
+		eb.broadcast([]byte("state"))
+	}
+	for range eventSubscriberBuffer {
+		<-fast
+	}
+	now = now.Add(30 * time.Second)
+	eb.broadcast([]byte("state"))
+	if len(eb.subscribers) != 2 {
+		t.Fatalf("slow subscriber dropped before the stall timeout")
+	}
+
+	<-fast
+	now = now.Add(time.Minute)
+	eb.broadcast([]byte("state"))
+	if _, ok := eb.subscribers[slow]; ok {
+		t.Fatalf("slow subscriber kept after stalling for %v", eb.stallTimeout)
+	}
+	if _, ok := eb.subscribers[fast]; !ok {
+		t.Fatalf("fast subscriber dropped")
+	}
+	// The stalled channel is closed once drained, ending its stream
+	for range slow {
+	}
+	eb.unsubscribe(slow)
+
+	// Its slot is free again
+	ch, err := eb.subscribe()
+	if err != nil {
+		t.Fatalf("subscribe after drop: %v", err)
+	}
+	eb.unsubscribe(ch)
+	eb.unsubscribe(fast)
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/catalog"
+)
+
+type syncJobsRequest struct {
+	Namespace   string `json:"namespace"`
+	TargetRef   string `json:"targetRef"`
+	Since       string `json:"since"`
+	LanguageTag string `json:"languageTag"`
+	Pipeline    string `json:"pipeline"`
+
+	since time.Time
+}
+
+func (r *syncJobsRequest) validate() error {
+	if r.Namespace == "" {
+		r.Namespace = defaultNamespace
+	}
+	if r.TargetRef == "" {
+		return fmt.Errorf("targetRef is required")
+	}
+	if r.Since == "" {
+		return fmt.Errorf("since is required")
+	}
+	since, err := time.Parse(time.RFC3339, r.Since)
+	if err != nil {
+		return fmt.Errorf("since must be an RFC3339 timestamp: %w", err)
+	}
+	r.since = since
+	if r.LanguageTag, err = canonicalLanguageTag(r.LanguageTag); err != nil {
+		return err
+	}
+	if r.Pipeline == "" {
+		r.Pipeline = string(wikiv1alpha1.TranslationPipelineModeTektonJob)
+	}
+	return nil
+}
+
+// syncCandidates returns the catalogue pages updated after since,
+// excluding templates and glooscap's own translated output. Pages whose update
+// time is unknown are included; the content hash check filters them later.
+func syncCandidates(pages []*catalog.Page, since time.Time) []*catalog.Page {
+	var out []*catalog.Page
+	for _, p := range pages {
+		if p.IsTemplate || strings.HasPrefix(p.Title, translatedTitlePrefix) {
+			continue
+		}
+		if !p.UpdatedAt.IsZero() && !p.UpdatedAt.After(since) {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// translatedHashes returns, per source page, the content hashes that already
+// have a non-failed job for the target and language.
+func translatedHashes(ctx context.Context, c client.Client, namespace, targetRef, languageTag string) (map[string]map[string]bool, error) {
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := c.List(ctx, &jobs, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list translation jobs: %w", err)
+	}
+	hashes := make(map[string]map[string]bool)
+	for _, job := range jobs.Items {
+		hash := job.Annotations[wikiv1alpha1.AnnotationSourceContentHash]
+		if hash == "" || job.Spec.Source.TargetRef != targetRef {
+			continue
+		}
+		if job.Spec.Destination == nil || !strings.EqualFold(job.Spec.Destination.LanguageTag, languageTag) {
+			continue
+		}
+		if job.Status.State == wikiv1alpha1.TranslationJobStateFailed || job.Status.State == wikiv1alpha1.TranslationJobStateCancelled {
+			continue
+		}
+		if hashes[job.Spec.Source.PageID] == nil {
+			hashes[job.Spec.Source.PageID] = make(map[string]bool)
+		}
+		hashes[job.Spec.Source.PageID][hash] = true
+	}
+	return hashes, nil
+}
+
+// newSyncJob builds the TranslationJob for one page changed since the sync window.
+func newSyncJob(req syncJobsRequest, page *catalog.Page, contentHash string) *wikiv1alpha1.TranslationJob {
+	return &wikiv1alpha1.TranslationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "sync-",
+			Namespace:    req.Namespace,
+			Annotations: map[string]string{
+				wikiv1alpha1.AnnotationSourceContentHash: contentHash,
+			},
+		},
+		Spec: wikiv1alpha1.TranslationJobSpec{
+			Source: wikiv1alpha1.TranslationSourceSpec{
+				TargetRef: req.TargetRef,
+				PageID:    page.ID,
+			},
+			Destination: &wikiv1alpha1.TranslationDestinationSpec{
+				TargetRef:   req.TargetRef,
+				LanguageTag: req.LanguageTag,
+			},
+			Pipeline: wikiv1alpha1.TranslationPipelineMode(req.Pipeline),
+			Parameters: map[string]string{
+				"pageTitle": page.Title,
+			},
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/credentials"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+// wikiTargetProbeTimeout bounds one connection test.
+const wikiTargetProbeTimeout = 15 * time.Second
+
+// parseInsecureOverride reads the insecure query parameter of a connection
+// test. Empty means no override.
+func parseInsecureOverride(raw string) (*bool, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	insecure, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("insecure must be true or false")
+	}
+	return &insecure, nil
+}
+
+// errUnsavedTargetToken is returned when an unsaved WikiTarget is tested
+// without an inline token. Its serviceAccountSecretRef isn't resolved: the
+// request chooses the URI, so that would send a stored credential wherever
+// the caller pointed it.
+var errUnsavedTargetToken = stderrors.New("secretToken is required to test an unsaved WikiTarget; test a saved one with POST /api/v1/wikitargets/{namespace}/{name}/test")
+
+// probeUnsavedWikiTarget probes a WikiTarget taken from a request body. Only
+// the inline token is used; stored credentials are only ever resolved for a
+// saved WikiTarget, against the URI it was saved with.
+func probeUnsavedWikiTarget(ctx context.Context, reader client.Reader, target *wikiv1alpha1.WikiTarget, token string, insecure *bool) (map[string]any, error) {
+	if token == "" {
+		return nil, errUnsavedTargetToken
+	}
+	return probeWikiTarget(ctx, reader, target, token, insecure), nil
+}
+
+// probeWikiTarget checks that target's Outline answers and accepts its token,
+// and reports what it found. token, when set, is used instead of the target's
+// credentials, so an unsaved target can be tried with the token it will be
+// saved with; it must be set unless target was read back from the API server
+// (see probeUnsavedWikiTarget). insecure, when set, replaces the target's InsecureSkipTLSVerify
+// for this probe only; the target itself is never changed.
+func probeWikiTarget(ctx context.Context, reader client.Reader, target *wikiv1alpha1.WikiTarget, token string, insecure *bool) map[string]any {
+	skipVerify := target.Spec.InsecureSkipTLSVerify
+	if insecure != nil {
+		skipVerify = *insecure
+		if skipVerify && !target.Spec.InsecureSkipTLSVerify {
+			verbosity.Printf("[http] WARNING: testing WikiTarget %s/%s (%s) WITHOUT TLS verification (one-off override, not saved)\n",
+				target.Namespace, target.Name, target.Spec.URI)
+		} else {
+			verbosity.Printf("[http] Testing WikiTarget %s/%s with insecureSkipTLSVerify=%v overridden for this request\n",
+				target.Namespace, target.Name, skipVerify)
+		}
+	}
+	result := map[string]any{
+		"name":                  target.Name,
+		"namespace":             target.Namespace,
+		"uri":                   target.Spec.URI,
+		"insecureSkipTLSVerify": skipVerify,
+		"insecureOverride":      insecure != nil,
+		"ok":                    false,
+	}
+	fail := func(err error) map[string]any {
+		result["error"] = err.Error()
+		result["certificateError"] = isCertificateError(err)
+		return result
+	}
+
+	if token == "" {
+		var err error
+		if token, err = credentials.OutlineToken(ctx, reader, target); err != nil {
+			return fail(err)
+		}
+	}
+	caBundle, err := credentials.CABundle(ctx, reader, target)
+	if err != nil {
+		return fail(err)
+	}
+	if caBundle != nil && skipVerify {
+		// The outline client always verifies against a CA bundle
+		result["insecureSkipTLSVerify"] = false
+		result["note"] = "caBundleRef is set, so the certificate is verified against it"
+	}
+	outlineClient, err := outline.NewClient(outline.Config{
+		BaseURL:               target.Spec.URI,
+		Token:                 token,
+		InsecureSkipTLSVerify: skipVerify,
+		CABundle:              caBundle,
+		Timeout:               wikiTargetProbeTimeout,
+		MaxRetries:            1,
+		ReadOnly:              true,
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, wikiTargetProbeTimeout)
+	defer cancel()
+	info, err := outlineClient.DetectServer(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	result["ok"] = true
+	result["outlineVersion"] = info.Version
+	return result
+}
+
+// isCertificateError reports whether err comes from verifying the server's
+// certificate, the failures skipping verification would get past.
+func isCertificateError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	return stderrors.As(err, &verifyErr) || stderrors.As(err, &unknownAuthority) ||
+		stderrors.As(err, &hostname) || stderrors.As(err, &invalid)
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+func TestProbeWikiTargetInsecureOverride(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/auth.info":
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		case "/api/installation.info":
+			_, _ = w.Write([]byte(`{"data":{"version":"0.80.2"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).Build()
+	target := &wikiv1alpha1.WikiTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "glooscap-system"},
+		Spec:       wikiv1alpha1.WikiTargetSpec{URI: srv.URL},
+	}
+
+	// The test server's certificate is self-signed, so verification fails...
+	result := probeWikiTarget(context.Background(), reader, target, "token", nil)
+	if result["ok"] != false || result["certificateError"] != true {
+		t.Fatalf("verified probe = %v, want a certificate error", result)
+	}
+
+	// ...and the one-off override gets past it without touching the target
+	insecure := true
+	result = probeWikiTarget(context.Background(), reader, target, "token", &insecure)
+	if result["ok"] != true || result["outlineVersion"] != "0.80.2" || result["insecureOverride"] != true {
+		t.Fatalf("insecure probe = %v", result)
+	}
+	if target.Spec.InsecureSkipTLSVerify {
+		t.Error("override changed the WikiTarget")
+	}
+
+	if _, err := parseInsecureOverride("maybe"); err == nil {
+		t.Error("parseInsecureOverride accepted maybe")
+	}
+}
+
+func TestProbeUnsavedWikiTargetNeedsToken(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).Build()
+	target := &wikiv1alpha1.WikiTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "glooscap-system"},
+		Spec: wikiv1alpha1.WikiTargetSpec{
+			URI: srv.URL,
+			ServiceAccountSecretRef: wikiv1alpha1.SecretKeyRef{
+				TokenProviderType: wikiv1alpha1.TokenProviderEnv,
+				Key:               "GLOOSCAP_TOKEN_OUTLINE",
+			},
+		},
+	}
+	t.Setenv("GLOOSCAP_TOKEN_OUTLINE", "stored-token")
+
+	if _, err := probeUnsavedWikiTarget(context.Background(), reader, target, "", nil); err != errUnsavedTargetToken {
+		t.Errorf("err = %v, want errUnsavedTargetToken", err)
+	}
+	if calls != 0 {
+		t.Errorf("the stored token was sent to the request's URI (%d calls)", calls)
+	}
+}
@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/credentials"
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+const (
+	// maxWikiTargetImportItems caps the WikiTargets accepted by one import.
+	maxWikiTargetImportItems = 200
+	// wikiTargetImportTimeout bounds the API calls made by one import.
+	wikiTargetImportTimeout = 2 * time.Minute
+	// defaultInsecureSkipTLSVerify is applied to WikiTargets submitted without
+	// spec.insecureSkipTLSVerify, and reported by /api/v1/config.
+	defaultInsecureSkipTLSVerify = true
+)
+
+// decodeWikiTargetRequest turns a WikiTarget submission - {metadata: {name,
+// namespace}, spec: {...}, secretToken: "..."} - into the WikiTarget to apply
+// and the token to store in its Secret, if any. The returned error describes
+// what is wrong with the submission.
+func decodeWikiTargetRequest(requestData map[string]any) (*wikiv1alpha1.WikiTarget, string, error) {
+	// Extract secretToken if provided
+	var secretToken string
+	if tokenVal, ok := requestData["secretToken"].(string); ok {
+		secretToken = tokenVal
+		verbosity.Debugf("[http] Extracted secretToken (length: %d)\n", len(secretToken))
+	}
+	// Decode the rest into WikiTarget (metadata and spec should be preserved)
+	targetData := make(map[string]any, len(requestData))
+	for k, v := range requestData {
+		if k != "secretToken" {
+			targetData[k] = v
+		}
+	}
+	targetBytes, err := json.Marshal(targetData)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to process request: %v", err)
+	}
+	previewLen := min(len(targetBytes), 200)
+	verbosity.Debugf("[http] Marshaled request (length: %d): %s\n", len(targetBytes), string(targetBytes)[:previewLen])
+
+	var target wikiv1alpha1.WikiTarget
+	if err := json.Unmarshal(targetBytes, &target); err != nil {
+		return nil, "", fmt.Errorf("failed to decode WikiTarget: %v", err)
+	}
+	verbosity.Debugf("[http] Decoded WikiTarget: name=%q, namespace=%q, uri=%q, secretName=%q\n",
+		target.Name, target.Namespace, target.Spec.URI, target.Spec.ServiceAccountSecretRef.Name)
+
+	// Set default namespace if not provided
+	if target.Namespace == "" {
+		target.Namespace = defaultNamespace
+	}
+
+	// Validate required fields
+	if target.Name == "" {
+		return nil, "", fmt.Errorf("metadata.name or name is required")
+	}
+
+	// Normalize name to RFC 1123 compliant format (lowercase, alphanumeric, dashes)
+	normalizedName := normalizeRFC1123Name(target.Name)
+	if normalizedName != target.Name {
+		verbosity.Printf("[http] Normalized WikiTarget name from %q to %q (RFC 1123 compliance)\n", target.Name, normalizedName)
+		target.Name = normalizedName
+	}
+	if target.Spec.URI == "" {
+		return nil, "", fmt.Errorf("spec.uri is required")
+	}
+	if err := validateWikiTargetURI(target.Spec.URI); err != nil {
+		return nil, "", err
+	}
+	ref := &target.Spec.ServiceAccountSecretRef
+	switch ref.TokenProviderType {
+	case "", wikiv1alpha1.TokenProviderSecret:
+		if ref.Name == "" {
+			return nil, "", fmt.Errorf("spec.serviceAccountSecretRef.name is required")
+		}
+		// Set default key if not provided
+		if ref.Key == "" {
+			ref.Key = "token"
+		}
+	case wikiv1alpha1.TokenProviderFile:
+		if ref.Path == "" {
+			return nil, "", fmt.Errorf("spec.serviceAccountSecretRef.path is required for the file token provider")
+		}
+	case wikiv1alpha1.TokenProviderEnv:
+		if ref.Key == "" {
+			return nil, "", fmt.Errorf("spec.serviceAccountSecretRef.key is required for the env token provider")
+		}
+	default:
+		return nil, "", fmt.Errorf("unsupported spec.serviceAccountSecretRef.tokenProviderType %q", ref.TokenProviderType)
+	}
+	if err := credentials.ValidateTokenRef(*ref); err != nil {
+		return nil, "", fmt.Errorf("spec.serviceAccountSecretRef: %w", err)
+	}
+	if secretToken != "" && ref.TokenProviderType != "" && ref.TokenProviderType != wikiv1alpha1.TokenProviderSecret {
+		return nil, "", fmt.Errorf("a token can only be stored for the secret token provider")
+	}
+	if target.Spec.Mode == "" {
+		return nil, "", fmt.Errorf("spec.mode is required")
+	}
+
+	// Default InsecureSkipTLSVerify (for now, to handle self-signed certs)
+	// Check if the request explicitly set this field
+	_, hasInsecureSkipTLSVerify := getNestedBool(requestData, "spec", "insecureSkipTLSVerify")
+	if !hasInsecureSkipTLSVerify {
+		// Not explicitly set, use the default
+		target.Spec.InsecureSkipTLSVerify = defaultInsecureSkipTLSVerify
+		verbosity.Printf("[http] Setting InsecureSkipTLSVerify=%t by default for WikiTarget '%s/%s'\n", defaultInsecureSkipTLSVerify, target.Namespace, target.Name)
+	}
+	// The default is settled here, so the controller mustn't apply its own
+	if target.Annotations == nil {
+		target.Annotations = map[string]string{}
+	}
+	target.Annotations[wikiv1alpha1.AnnotationTLSDefaulted] = "true"
+	return &target, secretToken, nil
+}
+
+// applyWikiTarget stores secretToken in target's Secret, when set, then
+// creates target or updates the existing WikiTarget's spec, marking it as the
+// namespace's default if target is labelled so. created reports whether the
+// WikiTarget was new.
+func applyWikiTarget(ctx context.Context, c client.Client, target *wikiv1alpha1.WikiTarget, secretToken string) (created bool, err error) {
+	verbosity.Printf("[http] Creating/updating WikiTarget '%s/%s' with URI=%s, secret=%s, mode=%s\n",
+		target.Namespace, target.Name, target.Spec.URI, target.Spec.ServiceAccountSecretRef.Name, target.Spec.Mode)
+
+	if err := checkDefaultTarget(ctx, c, target); err != nil {
+		return false, err
+	}
+
+	// Create or update the Secret if token is provided
+	if secretToken != "" {
+		if err := storeWikiTargetToken(ctx, c, target, secretToken); err != nil {
+			return false, err
+		}
+	}
+
+	// Get existing WikiTarget (if any)
+	var existing wikiv1alpha1.WikiTarget
+	err = c.Get(ctx, client.ObjectKey{Namespace: target.Namespace, Name: target.Name}, &existing)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			verbosity.Printf("[http] ERROR: Failed to get WikiTarget '%s/%s' (non-NotFound): %v (error type: %T)\n", target.Namespace, target.Name, err, err)
+			return false, fmt.Errorf("failed to get WikiTarget: %v", err)
+		}
+		// Create new WikiTarget
+		verbosity.Printf("[http] WikiTarget '%s/%s' not found, creating new one\n", target.Namespace, target.Name)
+		if err := c.Create(ctx, target); err != nil {
+			verbosity.Printf("[http] ERROR: Failed to create WikiTarget '%s/%s': %v (error type: %T)\n", target.Namespace, target.Name, err, err)
+			return false, fmt.Errorf("failed to create WikiTarget: %v", err)
+		}
+		verbosity.Printf("[http] Successfully created WikiTarget: %s/%s\n", target.Namespace, target.Name)
+		return true, nil
+	}
+
+	// Update existing WikiTarget
+	verbosity.Printf("[http] WikiTarget '%s/%s' exists, updating\n", target.Namespace, target.Name)
+	// Clear LastSyncTime first so the reconcile triggered by the spec update
+	// runs discovery right away instead of waiting for the next refresh
+	if existing.Status.LastSyncTime != nil {
+		existing.Status.LastSyncTime = nil
+		if err := c.Status().Update(ctx, &existing); err != nil {
+			verbosity.Printf("[http] WARNING: Failed to reset LastSyncTime for WikiTarget '%s/%s': %v\n", target.Namespace, target.Name, err)
+		}
+	}
+	existing.Spec = target.Spec
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	existing.Annotations[wikiv1alpha1.AnnotationTLSDefaulted] = "true"
+	if isDefaultTarget(target) {
+		if existing.Labels == nil {
+			existing.Labels = map[string]string{}
+		}
+		existing.Labels[wikiv1alpha1.LabelDefaultTarget] = "true"
+	}
+	if err := c.Update(ctx, &existing); err != nil {
+		verbosity.Printf("[http] ERROR: Failed to update WikiTarget '%s/%s': %v (error type: %T)\n", target.Namespace, target.Name, err, err)
+		return false, fmt.Errorf("failed to update WikiTarget: %v", err)
+	}
+	verbosity.Printf("[http] Successfully updated WikiTarget: %s/%s\n", target.Namespace, target.Name)
+	return false, nil
+}
+
+// storeWikiTargetToken creates or updates the Secret target reads its token from.
+func storeWikiTargetToken(ctx context.Context, c client.Client, target *wikiv1alpha1.WikiTarget, secretToken string) error {
+	secretKey := target.Spec.ServiceAccountSecretRef.Key
+	if secretKey == "" {
+		secretKey = "token"
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.Spec.ServiceAccountSecretRef.Name,
+			Namespace: target.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			secretKey: secretToken,
+		},
+	}
+
+	// Check if secret exists
+	var existingSecret corev1.Secret
+	err := c.Get(ctx, client.ObjectKey{Namespace: target.Namespace, Name: secret.Name}, &existingSecret)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			verbosity.Printf("[http] ERROR: Failed to get Secret '%s/%s': %v\n", target.Namespace, secret.Name, err)
+			return fmt.Errorf("failed to get Secret: %v", err)
+		}
+		// Create new secret
+		verbosity.Printf("[http] Creating Secret '%s/%s' for WikiTarget\n", target.Namespace, secret.Name)
+		if err := c.Create(ctx, secret); err != nil {
+			verbosity.Printf("[http] ERROR: Failed to create Secret '%s/%s': %v\n", target.Namespace, secret.Name, err)
+			return fmt.Errorf("failed to create Secret: %v", err)
+		}
+		verbosity.Printf("[http] Successfully created Secret: %s/%s\n", target.Namespace, secret.Name)
+		return nil
+	}
+
+	// Update existing secret
+	verbosity.Printf("[http] Updating Secret '%s/%s' for WikiTarget\n", target.Namespace, secret.Name)
+	if existingSecret.Data == nil {
+		existingSecret.Data = make(map[string][]byte)
+	}
+	existingSecret.Data[secretKey] = []byte(secretToken)
+	if err := c.Update(ctx, &existingSecret); err != nil {
+		verbosity.Printf("[http] ERROR: Failed to update Secret '%s/%s': %v\n", target.Namespace, secret.Name, err)
+		return fmt.Errorf("failed to update Secret: %v", err)
+	}
+	verbosity.Printf("[http] Successfully updated Secret: %s/%s\n", target.Namespace, secret.Name)
+	return nil
+}
+
+// wikiTargetImportResult reports what happened to one imported WikiTarget.
+type wikiTargetImportResult struct {
+	Index     int    `json:"index"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Status is "created", "updated", "failed" or "invalid"
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// validateWikiTargetImport decodes every item of an import. Items that can't
+// be imported - invalid, in a disallowed namespace, or naming the same
+// WikiTarget as an earlier item - get an "invalid" result; ok is false when
+// there are any.
+func validateWikiTargetImport(items []map[string]any, namespaces namespaceAllowlist) (targets []*wikiv1alpha1.WikiTarget, tokens []string, results []wikiTargetImportResult, ok bool) {
+	ok = true
+	seen := make(map[string]int, len(items))
+	for i, item := range items {
+		result := wikiTargetImportResult{Index: i}
+		target, token, err := decodeWikiTargetRequest(item)
+		if target != nil {
+			result.Name, result.Namespace = target.Name, target.Namespace
+			key := target.Namespace + "/" + target.Name
+			switch first, dup := seen[key]; {
+			case !namespaces.allows(target.Namespace):
+				err = fmt.Errorf("namespace %q is not allowed", target.Namespace)
+			case dup:
+				err = fmt.Errorf("WikiTarget %s is also item %d", key, first)
+			default:
+				seen[key] = i
+			}
+		}
+		if err != nil {
+			result.Status = "invalid"
+			result.Error = err.Error()
+			ok = false
+		}
+		targets = append(targets, target)
+		tokens = append(tokens, token)
+		results = append(results, result)
+	}
+	return targets, tokens, results, ok
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+func importItem(name, namespace string) map[string]any {
+	return map[string]any{
+		"metadata": map[string]any{"name": name, "namespace": namespace},
+		"spec": map[string]any{
+			"uri":                     "https://wiki.example.com",
+			"mode":                    "ReadWrite",
+			"serviceAccountSecretRef": map[string]any{"name": name + "-token"},
+		},
+		"secretToken": "tok-" + name,
+	}
+}
+
+func TestValidateWikiTargetImport(t *testing.T) {
+	allowed := newNamespaceAllowlist([]string{"glooscap-system"})
+	items := []map[string]any{
+		importItem("Team Docs", ""),
+		importItem("team-docs", "glooscap-system"), // same target once normalized
+		importItem("other", "elsewhere"),
+		{"metadata": map[string]any{"name": "no-uri"}},
+	}
+	_, _, results, ok := validateWikiTargetImport(items, allowed)
+	if ok {
+		t.Fatal("expected the import to be rejected")
+	}
+	want := []string{"", "invalid", "invalid", "invalid"}
+	for i, r := range results {
+		if r.Status != want[i] {
+			t.Errorf("item %d: status %q (%s), want %q", i, r.Status, r.Error, want[i])
+		}
+	}
+
+	// Token sources outside what the operator allows are refused
+	envItem := importItem("env-token", "glooscap-system")
+	delete(envItem, "secretToken")
+	envItem["spec"].(map[string]any)["serviceAccountSecretRef"] = map[string]any{"tokenProviderType": "env", "key": "KUBERNETES_SERVICE_HOST"}
+	fileItem := importItem("file-token", "glooscap-system")
+	delete(fileItem, "secretToken")
+	fileItem["spec"].(map[string]any)["serviceAccountSecretRef"] = map[string]any{"tokenProviderType": "file", "path": "/var/run/secrets/kubernetes.io/serviceaccount/token"}
+	if _, _, results, ok := validateWikiTargetImport([]map[string]any{envItem, fileItem}, allowed); ok {
+		t.Errorf("accepted token sources outside the allowlist: %+v", results)
+	}
+
+	targets, tokens, _, ok := validateWikiTargetImport(items[:1], allowed)
+	if !ok || targets[0].Name != "team-docs" || targets[0].Namespace != defaultNamespace || tokens[0] != "tok-Team Docs" {
+		t.Errorf("unexpected decode: ok=%v target=%+v tokens=%v", ok, targets[0], tokens)
+	}
+}
+
+func TestApplyWikiTarget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := wikiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&wikiv1alpha1.WikiTarget{}).Build()
+	ctx := context.Background()
+
+	target, token, err := decodeWikiTargetRequest(importItem("docs", "glooscap-system"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created, err := applyWikiTarget(ctx, c, target, token); err != nil || !created {
+		t.Fatalf("first apply: created=%v err=%v", created, err)
+	}
+
+	target, _, _ = decodeWikiTargetRequest(importItem("docs", "glooscap-system"))
+	target.Spec.URI = "https://wiki2.example.com"
+	if created, err := applyWikiTarget(ctx, c, target, "rotated"); err != nil || created {
+		t.Fatalf("second apply: created=%v err=%v", created, err)
+	}
+
+	var got wikiv1alpha1.WikiTarget
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "glooscap-system", Name: "docs"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Spec.URI != "https://wiki2.example.com" {
+		t.Errorf("URI = %q, want the updated one", got.Spec.URI)
+	}
+	var secret corev1.Secret
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "glooscap-system", Name: "docs-token"}, &secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["token"]) != "rotated" {
+		t.Errorf("token = %q, want rotated", secret.Data["token"])
+	}
+}
@@ -6,26 +6,36 @@ import (
 	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
 )
 
-// JobStore keeps translation job statuses for UI consumption.
+// JobStore keeps translation job statuses for UI consumption, along with
+// per-namespace token usage and budgets.
 type JobStore struct {
-	mu   sync.RWMutex
-	jobs map[string]Job
+	mu      sync.RWMutex
+	jobs    map[string]Job
+	tokens  map[string]map[string]int64 // namespace -> job -> tokens used
+	budgets map[string]int64            // namespace ("" = default) -> token budget
 }
 
 // NewJobStore returns a new JobStore.
 func NewJobStore() *JobStore {
 	return &JobStore{
-		jobs: make(map[string]Job),
+		jobs:    make(map[string]Job),
+		tokens:  make(map[string]map[string]int64),
+		budgets: make(map[string]int64),
 	}
 }
 
 // Job aggregates spec metadata with status for UI consumption.
 type Job struct {
 	Status    wikiv1alpha1.TranslationJobStatus `json:"status"`
+	Namespace string                            `json:"namespace"`
 	Pipeline  string                            `json:"pipeline"`
 	TargetRef string                            `json:"targetRef"`
 	PageID    string                            `json:"pageId"`
 	PageTitle string                            `json:"pageTitle"`
+	// DestinationRef is the WikiTarget the job publishes to
+	DestinationRef string `json:"destinationRef"`
+	// Diagnostic marks diagnostic jobs
+	Diagnostic bool `json:"diagnostic,omitempty"`
 }
 
 // Update records the latest status for the job.
@@ -33,13 +43,40 @@ func (s *JobStore) Update(job *wikiv1alpha1.TranslationJob) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	status := job.Status.DeepCopy()
+	// Translated bodies are served by the job detail endpoint; keep the
+	// in-memory copy (and /api/v1/jobs) small
+	if status.TranslatedContent != nil {
+		status.TranslatedContent.Markdown = ""
+	}
 	s.jobs[job.Name] = Job{
-		Status:    *status,
-		Pipeline:  string(job.Spec.Pipeline),
-		TargetRef: job.Spec.Source.TargetRef,
-		PageID:    job.Spec.Source.PageID,
-		PageTitle: job.Spec.Parameters["pageTitle"],
+		Status:         *status,
+		Namespace:      job.Namespace,
+		Pipeline:       string(job.Spec.Pipeline),
+		TargetRef:      job.Spec.Source.TargetRef,
+		PageID:         job.Spec.Source.PageID,
+		PageTitle:      job.Spec.Parameters["pageTitle"],
+		DestinationRef: job.DestinationTargetRef(),
+		Diagnostic:     job.IsDiagnostic(),
+	}
+}
+
+// ActiveTranslations counts, per destination WikiTarget ID
+// (namespace/name), the translation jobs dispatched, running or publishing.
+func (s *JobStore) ActiveTranslations() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]int)
+	for _, job := range s.jobs {
+		if job.Diagnostic {
+			continue
+		}
+		switch job.Status.State {
+		case wikiv1alpha1.TranslationJobStateDispatching, wikiv1alpha1.TranslationJobStateRunning,
+			wikiv1alpha1.TranslationJobStatePublishing:
+			out[job.Namespace+"/"+job.DestinationRef]++
+		}
 	}
+	return out
 }
 
 // List returns all job statuses.
@@ -52,3 +89,18 @@ func (s *JobStore) List() map[string]Job {
 	}
 	return out
 }
+
+// DeleteTarget forgets the jobs translating pages of the WikiTarget
+// namespace/targetRef and returns how many were dropped.
+func (s *JobStore) DeleteTarget(namespace, targetRef string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dropped := 0
+	for name, job := range s.jobs {
+		if job.Namespace == namespace && job.TargetRef == targetRef {
+			delete(s.jobs, name)
+			dropped++
+		}
+	}
+	return dropped
+}
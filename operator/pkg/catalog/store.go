@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 )
@@ -25,11 +26,18 @@ type Page struct {
 	TranslationURI string `json:"translationURI,omitempty"` // URI to translated page if exists
 
 	// Metadata
-	Language   string `json:"language"`             // Language code (EN, FR, ES, etc.)
+	Language   string `json:"language"`             // Language code (EN, FR, ES, etc.), defaulted for display
 	HasAssets  bool   `json:"hasAssets"`            // Whether page has embedded assets
 	Collection string `json:"collection,omitempty"` // Collection name the page belongs to
 	Template   string `json:"template,omitempty"`   // Template type (e.g., "Feature Completion Template")
 	IsTemplate bool   `json:"isTemplate,omitempty"` // True if this is a template definition
+	ParentID   string `json:"parentId,omitempty"`   // Parent document ID within the collection (empty for top-level)
+	IsDraft    bool   `json:"isDraft,omitempty"`    // True if the page is an unpublished draft
+
+	// DetectedLanguage is the language Outline reported for the page, empty
+	// when it didn't say. Language falls back to a default for display, so
+	// compare languages with this instead.
+	DetectedLanguage string `json:"detectedLanguage,omitempty"`
 }
 
 // Store maintains in-memory catalogues of wiki targets with CRUD operations.
@@ -92,29 +100,35 @@ func (s *Store) Update(target string, info Target, pages []Page) {
 			existing.UpdatedAt = page.UpdatedAt
 			existing.LastChecked = now
 			existing.Language = page.Language
+			existing.DetectedLanguage = page.DetectedLanguage
 			existing.HasAssets = page.HasAssets
 			existing.Collection = page.Collection
 			existing.Template = page.Template
 			existing.IsTemplate = page.IsTemplate
+			existing.ParentID = page.ParentID
+			existing.IsDraft = page.IsDraft
 			existing.State = "discovered"
 			targetPages = append(targetPages, existing)
 		} else {
 			// Create new page entry
 			newPage := &Page{
-				ID:             page.ID,
-				Title:          page.Title,
-				Slug:           page.Slug,
-				URI:            page.URI,
-				WikiTarget:     target,
-				State:          "discovered",
-				LastChecked:    now,
-				UpdatedAt:      page.UpdatedAt,
-				AutoTranslated: false,
-				Language:       page.Language,
-				HasAssets:      page.HasAssets,
-				Collection:     page.Collection,
-				Template:       page.Template,
-				IsTemplate:     page.IsTemplate,
+				ID:               page.ID,
+				Title:            page.Title,
+				Slug:             page.Slug,
+				URI:              page.URI,
+				WikiTarget:       target,
+				State:            "discovered",
+				LastChecked:      now,
+				UpdatedAt:        page.UpdatedAt,
+				AutoTranslated:   false,
+				Language:         page.Language,
+				DetectedLanguage: page.DetectedLanguage,
+				HasAssets:        page.HasAssets,
+				Collection:       page.Collection,
+				Template:         page.Template,
+				IsTemplate:       page.IsTemplate,
+				ParentID:         page.ParentID,
+				IsDraft:          page.IsDraft,
 			}
 			s.pages[page.URI] = newPage
 			targetPages = append(targetPages, newPage)
@@ -130,6 +144,29 @@ func (s *Store) Update(target string, info Target, pages []Page) {
 	}
 }
 
+// DeleteTarget drops a target's metadata and pages, e.g. once its WikiTarget
+// is deleted, and notifies listeners. It reports whether the target was known.
+func (s *Store) DeleteTarget(target string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, known := s.meta[target]
+	pages, hasPages := s.targets[target]
+	if !known && !hasPages {
+		return false
+	}
+	for _, page := range pages {
+		delete(s.pages, page.URI)
+	}
+	delete(s.targets, target)
+	delete(s.meta, target)
+
+	select {
+	case s.updateNotifier <- struct{}{}:
+	default:
+	}
+	return true
+}
+
 // GetPage retrieves a page by URI.
 func (s *Store) GetPage(uri string) (*Page, bool) {
 	s.mu.RLock()
@@ -174,7 +211,7 @@ func (s *Store) DeletePage(uri string) {
 	}
 }
 
-// Targets returns the list of known target identifiers.
+// Targets returns the known targets, ordered by ID.
 func (s *Store) Targets() []Target {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -182,6 +219,7 @@ func (s *Store) Targets() []Target {
 	for _, meta := range s.meta {
 		out = append(out, meta)
 	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
 	return out
 }
 
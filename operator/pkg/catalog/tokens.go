@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// TokenUsage summarises token consumption for one namespace.
+type TokenUsage struct {
+	Used int64 `json:"used"`
+	// Budget is the namespace's token budget; zero means unlimited.
+	Budget int64 `json:"budget"`
+	// Remaining is the budget left, zero once it is exhausted; nil when the
+	// namespace is unlimited.
+	Remaining *int64 `json:"remaining,omitempty"`
+	Exceeded  bool   `json:"exceeded"`
+}
+
+// ParseTokenBudgets parses a budget specification such as
+// "500000,team-a=100000,team-b=0". A bare number sets the budget for every
+// namespace without an explicit entry; zero means unlimited.
+func ParseTokenBudgets(spec string) (map[string]int64, error) {
+	budgets := make(map[string]int64)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		namespace, value := "", entry
+		if ns, v, ok := strings.Cut(entry, "="); ok {
+			namespace, value = strings.TrimSpace(ns), strings.TrimSpace(v)
+			if namespace == "" {
+				return nil, fmt.Errorf("token budget %q: namespace is empty", entry)
+			}
+		}
+		budget, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || budget < 0 {
+			return nil, fmt.Errorf("token budget %q: want a non-negative integer", entry)
+		}
+		budgets[namespace] = budget
+	}
+	return budgets, nil
+}
+
+// SetTokenBudgets replaces the per-namespace token budgets. The "" key is the
+// default for namespaces without their own entry.
+func (s *JobStore) SetTokenBudgets(budgets map[string]int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.budgets = make(map[string]int64, len(budgets))
+	for ns, b := range budgets {
+		s.budgets[ns] = b
+	}
+}
+
+// RecordTokens records the tokens reported in job's status. Usage is kept per
+// job, so recording the same job again doesn't double count.
+func (s *JobStore) RecordTokens(job *wikiv1alpha1.TranslationJob) {
+	if job.Status.TokensUsed <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens[job.Namespace] == nil {
+		s.tokens[job.Namespace] = make(map[string]int64)
+	}
+	s.tokens[job.Namespace][job.Name] = job.Status.TokensUsed
+}
+
+// NamespaceTokens returns the token usage and budget for namespace.
+func (s *JobStore) NamespaceTokens(namespace string) TokenUsage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.usageLocked(namespace)
+}
+
+// TokenUsage returns token usage for every namespace that has consumed tokens
+// or has an explicit budget.
+func (s *JobStore) TokenUsage() map[string]TokenUsage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]TokenUsage)
+	for ns := range s.tokens {
+		out[ns] = s.usageLocked(ns)
+	}
+	for ns := range s.budgets {
+		if ns != "" {
+			out[ns] = s.usageLocked(ns)
+		}
+	}
+	return out
+}
+
+func (s *JobStore) usageLocked(namespace string) TokenUsage {
+	var usage TokenUsage
+	for _, n := range s.tokens[namespace] {
+		usage.Used += n
+	}
+	budget, ok := s.budgets[namespace]
+	if !ok {
+		budget = s.budgets[""]
+	}
+	usage.Budget = budget
+	if budget > 0 {
+		usage.Exceeded = usage.Used >= budget
+		remaining := max(budget-usage.Used, 0)
+		usage.Remaining = &remaining
+	}
+	return usage
+}
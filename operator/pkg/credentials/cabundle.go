@@ -0,0 +1,62 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// defaultCABundleKey is the key read when CABundleRef.Key is unset.
+const defaultCABundleKey = "ca.crt"
+
+// CABundle returns the PEM CA bundle referenced by target's caBundleRef, or
+// nil when the target doesn't reference one.
+func CABundle(ctx context.Context, c client.Reader, target *wikiv1alpha1.WikiTarget) ([]byte, error) {
+	ref := target.Spec.CABundleRef
+	if ref == nil {
+		return nil, nil
+	}
+	keyName := ref.Key
+	if keyName == "" {
+		keyName = defaultCABundleKey
+	}
+	key := types.NamespacedName{Namespace: target.Namespace, Name: ref.Name}
+
+	var data []byte
+	switch ref.Kind {
+	case "", "ConfigMap":
+		var cm corev1.ConfigMap
+		if err := c.Get(ctx, key, &cm); err != nil {
+			return nil, fmt.Errorf("get CA bundle configmap %s: %w", key, err)
+		}
+		if value, ok := cm.Data[keyName]; ok {
+			data = []byte(value)
+		} else if value, ok := cm.BinaryData[keyName]; ok {
+			data = value
+		} else {
+			return nil, fmt.Errorf("key %q not found in configmap %s", keyName, key)
+		}
+	case "Secret":
+		var secret corev1.Secret
+		if err := c.Get(ctx, key, &secret); err != nil {
+			return nil, fmt.Errorf("get CA bundle secret %s: %w", key, err)
+		}
+		value, ok := secret.Data[keyName]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in secret %s", keyName, key)
+		}
+		data = value
+	default:
+		return nil, fmt.Errorf("unsupported CA bundle kind %q", ref.Kind)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("CA bundle %s/%s is empty", key, keyName)
+	}
+	return data, nil
+}
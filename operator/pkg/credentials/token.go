@@ -0,0 +1,161 @@
+// Package credentials resolves the API tokens and CA bundles WikiTargets connect with.
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// defaultSecretKey is the secret key read when SecretKeyRef.Key is unset.
+const defaultSecretKey = "token"
+
+const (
+	// EnvTokenPrefix starts the name of every environment variable the env
+	// token provider may read, so a WikiTarget can't send the operator's other
+	// variables to its wiki.
+	EnvTokenPrefix = "GLOOSCAP_TOKEN_"
+	// DefaultTokenDir holds the token files the file provider may read, unless
+	// GLOOSCAP_TOKEN_DIR names another directory.
+	DefaultTokenDir = "/var/run/glooscap/tokens"
+)
+
+// TokenDir is the directory file provider tokens must live in.
+func TokenDir() string {
+	if dir := strings.TrimSpace(os.Getenv("GLOOSCAP_TOKEN_DIR")); dir != "" {
+		return filepath.Clean(dir)
+	}
+	return DefaultTokenDir
+}
+
+// ValidateTokenRef rejects file and env references outside what the operator
+// allows: a file must be inside TokenDir (relative paths are taken from
+// there) and a variable must start with EnvTokenPrefix. Anyone who can create
+// a WikiTarget chooses its URI, so anything else would let them have the
+// operator send its own credentials, such as the service account token, to
+// their server.
+func ValidateTokenRef(ref wikiv1alpha1.SecretKeyRef) error {
+	switch ref.TokenProviderType {
+	case wikiv1alpha1.TokenProviderFile:
+		_, err := tokenFilePath(ref.Path, false)
+		return err
+	case wikiv1alpha1.TokenProviderEnv:
+		if !strings.HasPrefix(ref.Key, EnvTokenPrefix) || len(ref.Key) == len(EnvTokenPrefix) {
+			return fmt.Errorf("token environment variable %q must start with %s", ref.Key, EnvTokenPrefix)
+		}
+	}
+	return nil
+}
+
+// tokenFilePath resolves path against TokenDir and checks it stays inside.
+// With resolveLinks, symlinks are followed first so a link can't lead out;
+// links within the directory, as in projected volumes, are fine.
+func tokenFilePath(path string, resolveLinks bool) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("token file path is empty")
+	}
+	dir := TokenDir()
+	full := path
+	if !filepath.IsAbs(full) {
+		full = filepath.Join(dir, full)
+	}
+	full = filepath.Clean(full)
+	if resolveLinks {
+		var err error
+		if dir, err = filepath.EvalSymlinks(dir); err != nil {
+			return "", fmt.Errorf("token directory %s: %w", TokenDir(), err)
+		}
+		if full, err = filepath.EvalSymlinks(full); err != nil {
+			return "", fmt.Errorf("read token file: %w", err)
+		}
+	}
+	if rel, err := filepath.Rel(dir, full); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("token file %q is outside the token directory %s", path, TokenDir())
+	}
+	return full, nil
+}
+
+// TokenFile is the path of a file provider token relative to TokenDir.
+func TokenFile(ref wikiv1alpha1.SecretKeyRef) (string, error) {
+	full, err := tokenFilePath(ref.Path, false)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(TokenDir(), full)
+}
+
+// OutlineToken returns the Outline API token for target from the provider
+// selected by its serviceAccountSecretRef. Surrounding whitespace is trimmed.
+func OutlineToken(ctx context.Context, c client.Reader, target *wikiv1alpha1.WikiTarget) (string, error) {
+	ref := target.Spec.ServiceAccountSecretRef
+
+	var token string
+	switch ref.TokenProviderType {
+	case "", wikiv1alpha1.TokenProviderSecret:
+		if ref.Name == "" {
+			return "", fmt.Errorf("service account secret ref is empty")
+		}
+		var secret corev1.Secret
+		key := types.NamespacedName{Namespace: target.Namespace, Name: ref.Name}
+		if err := c.Get(ctx, key, &secret); err != nil {
+			return "", fmt.Errorf("get secret %s: %w", key, err)
+		}
+		keyName := ref.Key
+		if keyName == "" {
+			keyName = defaultSecretKey
+		}
+		data, ok := secret.Data[keyName]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in secret %s", keyName, key)
+		}
+		token = string(data)
+
+	case wikiv1alpha1.TokenProviderFile:
+		path, err := tokenFilePath(ref.Path, true)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("read token file: %w", err)
+		}
+		token = string(data)
+
+	case wikiv1alpha1.TokenProviderEnv:
+		if ref.Key == "" {
+			return "", fmt.Errorf("token environment variable name is empty")
+		}
+		if err := ValidateTokenRef(ref); err != nil {
+			return "", err
+		}
+		value, ok := os.LookupEnv(ref.Key)
+		if !ok {
+			return "", fmt.Errorf("token environment variable %s is not set", ref.Key)
+		}
+		token = value
+
+	default:
+		return "", fmt.Errorf("unsupported token provider %q", ref.TokenProviderType)
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("%s token for %s/%s is empty", providerName(ref.TokenProviderType), target.Namespace, target.Name)
+	}
+	return token, nil
+}
+
+func providerName(t wikiv1alpha1.TokenProviderType) string {
+	if t == "" {
+		return string(wikiv1alpha1.TokenProviderSecret)
+	}
+	return string(t)
+}
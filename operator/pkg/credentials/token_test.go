@@ -0,0 +1,79 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+func TestOutlineToken(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "outline", Namespace: "glooscap-system"},
+		Data:       map[string][]byte{"token": []byte("from-secret\n"), "alt": []byte("alt-key")},
+	}).Build()
+
+	tokenDir := t.TempDir()
+	t.Setenv("GLOOSCAP_TOKEN_DIR", tokenDir)
+	tokenFile := filepath.Join(tokenDir, "token")
+	if err := os.WriteFile(tokenFile, []byte(" from-file \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(t.TempDir(), "sa-token")
+	if err := os.WriteFile(outside, []byte("service-account"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(tokenDir, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GLOOSCAP_TOKEN_OUTLINE", "from-env")
+	t.Setenv("GLOOSCAP_TEST_SECRET", "operator-secret")
+
+	tests := []struct {
+		name    string
+		ref     wikiv1alpha1.SecretKeyRef
+		want    string
+		wantErr bool
+	}{
+		{"secret default key", wikiv1alpha1.SecretKeyRef{Name: "outline"}, "from-secret", false},
+		{"secret explicit key", wikiv1alpha1.SecretKeyRef{Name: "outline", Key: "alt", TokenProviderType: wikiv1alpha1.TokenProviderSecret}, "alt-key", false},
+		{"secret missing key", wikiv1alpha1.SecretKeyRef{Name: "outline", Key: "nope"}, "", true},
+		{"secret missing name", wikiv1alpha1.SecretKeyRef{}, "", true},
+		{"file", wikiv1alpha1.SecretKeyRef{TokenProviderType: wikiv1alpha1.TokenProviderFile, Path: tokenFile}, "from-file", false},
+		{"file relative to the token directory", wikiv1alpha1.SecretKeyRef{TokenProviderType: wikiv1alpha1.TokenProviderFile, Path: "token"}, "from-file", false},
+		{"file missing", wikiv1alpha1.SecretKeyRef{TokenProviderType: wikiv1alpha1.TokenProviderFile, Path: tokenFile + ".missing"}, "", true},
+		{"file outside the token directory", wikiv1alpha1.SecretKeyRef{TokenProviderType: wikiv1alpha1.TokenProviderFile, Path: outside}, "", true},
+		{"file escaping with dot-dot", wikiv1alpha1.SecretKeyRef{TokenProviderType: wikiv1alpha1.TokenProviderFile, Path: "../" + filepath.Base(outside)}, "", true},
+		{"file escaping through a symlink", wikiv1alpha1.SecretKeyRef{TokenProviderType: wikiv1alpha1.TokenProviderFile, Path: "escape"}, "", true},
+		{"env", wikiv1alpha1.SecretKeyRef{TokenProviderType: wikiv1alpha1.TokenProviderEnv, Key: "GLOOSCAP_TOKEN_OUTLINE"}, "from-env", false},
+		{"env unset", wikiv1alpha1.SecretKeyRef{TokenProviderType: wikiv1alpha1.TokenProviderEnv, Key: "GLOOSCAP_TOKEN_UNSET"}, "", true},
+		{"env without the token prefix", wikiv1alpha1.SecretKeyRef{TokenProviderType: wikiv1alpha1.TokenProviderEnv, Key: "GLOOSCAP_TEST_SECRET"}, "", true},
+		{"unknown provider", wikiv1alpha1.SecretKeyRef{TokenProviderType: "vault"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &wikiv1alpha1.WikiTarget{
+				ObjectMeta: metav1.ObjectMeta{Name: "wiki", Namespace: "glooscap-system"},
+				Spec:       wikiv1alpha1.WikiTargetSpec{ServiceAccountSecretRef: tt.ref},
+			}
+			got, err := OutlineToken(context.Background(), c, target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("token = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
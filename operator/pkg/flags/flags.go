@@ -0,0 +1,178 @@
+// Package flags reads and writes the operator's feature flags, which are kept
+// as "true"/"false" keys in the glooscap-config ConfigMap.
+package flags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+const (
+	// ConfigMapName is the ConfigMap holding the flags. It also carries
+	// non-flag settings such as translation-service-addr.
+	ConfigMapName = "glooscap-config"
+	// DefaultNamespace is where the ConfigMap lives unless NewStore is given another namespace.
+	DefaultNamespace = "glooscap-system"
+	// DefaultTTL is how long flag values are cached before the ConfigMap is read again.
+	DefaultTTL = 15 * time.Second
+)
+
+// DiagnosticWriteEnabled turns the periodic WikiTarget write diagnostic on or off.
+const DiagnosticWriteEnabled = "diagnostic-write-enabled"
+
+// Defaults lists every known flag with the value used when the ConfigMap
+// doesn't set it, or sets it to something that isn't a boolean.
+var Defaults = map[string]bool{
+	DiagnosticWriteEnabled: true,
+}
+
+// ErrUnknownFlag is returned by SetFlags for a name missing from Defaults.
+var ErrUnknownFlag = errors.New("unknown feature flag")
+
+// Store serves flag values from the ConfigMap, caching them for TTL so flags
+// can be checked on hot paths while changes still apply without a restart.
+// A nil Store reports every flag's default.
+type Store struct {
+	reader    client.Reader
+	writer    client.Writer
+	namespace string
+	// TTL overrides DefaultTTL when set.
+	TTL time.Duration
+
+	mu        sync.Mutex
+	data      map[string]string
+	fetchedAt time.Time
+}
+
+// NewStore creates a Store reading through reader and writing through writer.
+// Pass an uncached reader (the manager's APIReader) so reads don't require a
+// cluster-wide ConfigMap watch.
+func NewStore(reader client.Reader, writer client.Writer, namespace string) *Store {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	return &Store{reader: reader, writer: writer, namespace: namespace}
+}
+
+// GetFlag returns the current value of the named flag. Read errors fall back
+// to the last known values, or to the flag's default.
+func (s *Store) GetFlag(ctx context.Context, name string) bool {
+	if s == nil {
+		return Defaults[name]
+	}
+	data, err := s.load(ctx)
+	if err != nil {
+		verbosity.Debugf("[flags] read %s/%s: %v\n", s.namespace, ConfigMapName, err)
+	}
+	return flagValue(data, name)
+}
+
+// Flags returns the current value of every known flag.
+func (s *Store) Flags(ctx context.Context) (map[string]bool, error) {
+	var data map[string]string
+	if s != nil {
+		var err error
+		if data, err = s.load(ctx); err != nil && data == nil {
+			return nil, err
+		}
+	}
+	values := make(map[string]bool, len(Defaults))
+	for name := range Defaults {
+		values[name] = flagValue(data, name)
+	}
+	return values, nil
+}
+
+// SetFlags writes values to the ConfigMap, creating it when missing. Keys in
+// the ConfigMap that aren't being set are left untouched.
+func (s *Store) SetFlags(ctx context.Context, values map[string]bool) error {
+	for name := range values {
+		if _, ok := Defaults[name]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownFlag, name)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var cm corev1.ConfigMap
+	err := s.reader.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: ConfigMapName}, &cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("get %s: %w", ConfigMapName, err)
+	}
+	exists := err == nil
+	if !exists {
+		cm = corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: s.namespace}}
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	for name, value := range values {
+		cm.Data[name] = strconv.FormatBool(value)
+	}
+
+	if exists {
+		err = s.writer.Update(ctx, &cm)
+	} else {
+		err = s.writer.Create(ctx, &cm)
+	}
+	if err != nil {
+		return fmt.Errorf("write %s: %w", ConfigMapName, err)
+	}
+	s.data = maps.Clone(cm.Data)
+	s.fetchedAt = time.Now()
+	return nil
+}
+
+// load returns the ConfigMap data, re-reading it once the cache is older than
+// the TTL. On error the previous data, possibly nil, is returned with it.
+func (s *Store) load(ctx context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ttl := s.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if !s.fetchedAt.IsZero() && time.Since(s.fetchedAt) < ttl {
+		return s.data, nil
+	}
+	// Errors are cached for the TTL too, so a failing API server isn't
+	// queried on every check
+	s.fetchedAt = time.Now()
+
+	var cm corev1.ConfigMap
+	if err := s.reader.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: ConfigMapName}, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			s.data = map[string]string{}
+			return s.data, nil
+		}
+		return s.data, err
+	}
+	s.data = cm.Data
+	return s.data, nil
+}
+
+func flagValue(data map[string]string, name string) bool {
+	raw, ok := data[name]
+	if !ok {
+		return Defaults[name]
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return Defaults[name]
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package flags
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	store := NewStore(c, c, "")
+
+	if !store.GetFlag(ctx, DiagnosticWriteEnabled) {
+		t.Error("missing ConfigMap should report the default")
+	}
+	if err := store.SetFlags(ctx, map[string]bool{DiagnosticWriteEnabled: false}); err != nil {
+		t.Fatal(err)
+	}
+	if store.GetFlag(ctx, DiagnosticWriteEnabled) {
+		t.Error("flag still enabled after SetFlags")
+	}
+	if err := store.SetFlags(ctx, map[string]bool{"no-such-flag": true}); !errors.Is(err, ErrUnknownFlag) {
+		t.Errorf("expected ErrUnknownFlag, got %v", err)
+	}
+
+	// Changes made behind the store's back show up once the TTL expires
+	store.TTL = time.Millisecond
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKey{Namespace: DefaultNamespace, Name: ConfigMapName}, &cm); err != nil {
+		t.Fatal(err)
+	}
+	cm.Data[DiagnosticWriteEnabled] = "true"
+	if err := c.Update(ctx, &cm); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if !store.GetFlag(ctx, DiagnosticWriteEnabled) {
+		t.Error("external change not picked up after TTL")
+	}
+
+	var nilStore *Store
+	if !nilStore.GetFlag(ctx, DiagnosticWriteEnabled) {
+		t.Error("nil store should report the default")
+	}
+}
+
+func TestFlagValue(t *testing.T) {
+	data := map[string]string{DiagnosticWriteEnabled: "maybe"}
+	if !flagValue(data, DiagnosticWriteEnabled) {
+		t.Error("unparseable value should fall back to the default")
+	}
+	if flagValue(nil, "unknown") {
+		t.Error("unknown flags should default to false")
+	}
+}
@@ -0,0 +1,273 @@
+// Package jobarchive keeps a durable record of finished TranslationJobs, so
+// the history of what was translated outlives the jobs themselves. Records go
+// to a Sink; FileSink appends them to a JSONL file, typically on a PVC, and
+// other stores only need to implement the two Sink methods.
+package jobarchive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+// DefaultQueryLimit caps the records Query returns when Query.Limit is 0.
+const DefaultQueryLimit = 100
+
+// maxRecordBytes bounds one archived line; records are a few hundred bytes.
+const maxRecordBytes = 1 << 20
+
+// Record is the archived summary of one finished TranslationJob.
+type Record struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+	// Origin is how the job was requested: manual (API or kubectl),
+	// auto-translate, diagnostic, retry, publish or follow-links.
+	Origin string `json:"origin"`
+
+	SourceTarget      string `json:"sourceTarget"`
+	PageID            string `json:"pageId"`
+	PageTitle         string `json:"pageTitle,omitempty"`
+	RevisionID        string `json:"revisionId,omitempty"`
+	DestinationTarget string `json:"destinationTarget"`
+	LanguageTag       string `json:"languageTag,omitempty"`
+	Pipeline          string `json:"pipeline,omitempty"`
+
+	State            string `json:"state"`
+	Message          string `json:"message,omitempty"`
+	TokensUsed       int64  `json:"tokensUsed,omitempty"`
+	PublishedPageID  string `json:"publishedPageId,omitempty"`
+	PublishedPageURL string `json:"publishedPageUrl,omitempty"`
+	// Engine and EngineVersion identify what produced the translation.
+	Engine        string `json:"engine,omitempty"`
+	EngineVersion string `json:"engineVersion,omitempty"`
+
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	ArchivedAt time.Time  `json:"archivedAt"`
+}
+
+// FromJob summarises job for the archive.
+func FromJob(job *wikiv1alpha1.TranslationJob, archivedAt time.Time) Record {
+	record := Record{
+		Name:              job.Name,
+		Namespace:         job.Namespace,
+		UID:               string(job.UID),
+		Origin:            origin(job),
+		SourceTarget:      job.Spec.Source.TargetRef,
+		PageID:            job.Spec.Source.PageID,
+		PageTitle:         job.Spec.Parameters["pageTitle"],
+		RevisionID:        job.Spec.Source.PinnedRevision(),
+		DestinationTarget: job.DestinationTargetRef(),
+		Pipeline:          string(job.Spec.Pipeline),
+		State:             string(job.Status.State),
+		Message:           job.Status.Message,
+		TokensUsed:        job.Status.TokensUsed,
+		CreatedAt:         job.CreationTimestamp.UTC(),
+		ArchivedAt:        archivedAt.UTC(),
+	}
+	if job.Spec.Destination != nil {
+		record.LanguageTag = job.Spec.Destination.LanguageTag
+	}
+	if published := wikiv1alpha1.GetPublishedPage(job); published.ID != "" {
+		record.PublishedPageID = published.ID
+		record.PublishedPageURL = published.URL
+	}
+	if job.Status.Engine != nil {
+		record.Engine = job.Status.Engine.Name
+		record.EngineVersion = job.Status.Engine.Version
+	}
+	if job.Status.StartedAt != nil {
+		started := job.Status.StartedAt.UTC()
+		record.StartedAt = &started
+	}
+	if job.Status.FinishedAt != nil {
+		finished := job.Status.FinishedAt.UTC()
+		record.FinishedAt = &finished
+	}
+	return record
+}
+
+func origin(job *wikiv1alpha1.TranslationJob) string {
+	switch {
+	case job.IsDiagnostic():
+		return "diagnostic"
+	case job.Labels[wikiv1alpha1.LabelAutoTranslate] == "true":
+		return "auto-translate"
+	case job.Annotations[wikiv1alpha1.AnnotationRetryOf] != "":
+		return "retry"
+	case job.Annotations[wikiv1alpha1.AnnotationParentJob] != "":
+		return "follow-links"
+	case job.IsPublishJob():
+		return "publish"
+	default:
+		return "manual"
+	}
+}
+
+// Query selects archived records. Empty fields match everything.
+type Query struct {
+	Namespace string
+	// Namespaces, when set, restricts results to these namespaces.
+	Namespaces []string
+	// Target matches the source or destination WikiTarget.
+	Target string
+	PageID string
+	State  string
+	// Engine and EngineVersion match the translation engine, so pages made by
+	// a model can be found and retranslated once a better one is deployed.
+	Engine        string
+	EngineVersion string
+	// Since keeps records of jobs that finished (or were archived) at or after it.
+	Since time.Time
+	// Limit caps the result, newest first; 0 uses DefaultQueryLimit.
+	Limit int
+}
+
+// Matches reports whether record is selected by q.
+func (q Query) Matches(record Record) bool {
+	if q.Namespace != "" && record.Namespace != q.Namespace {
+		return false
+	}
+	if len(q.Namespaces) > 0 && !slices.Contains(q.Namespaces, record.Namespace) {
+		return false
+	}
+	if q.Target != "" && record.SourceTarget != q.Target && record.DestinationTarget != q.Target {
+		return false
+	}
+	if q.PageID != "" && record.PageID != q.PageID {
+		return false
+	}
+	if q.State != "" && !strings.EqualFold(record.State, q.State) {
+		return false
+	}
+	if q.Engine != "" && !strings.EqualFold(record.Engine, q.Engine) {
+		return false
+	}
+	if q.EngineVersion != "" && record.EngineVersion != q.EngineVersion {
+		return false
+	}
+	if !q.Since.IsZero() {
+		finished := record.ArchivedAt
+		if record.FinishedAt != nil {
+			finished = *record.FinishedAt
+		}
+		if finished.Before(q.Since) {
+			return false
+		}
+	}
+	return true
+}
+
+// Sink stores archived records and answers queries over them. Append must
+// keep every record it accepted; Query returns matches newest first.
+type Sink interface {
+	Append(ctx context.Context, records []Record) error
+	Query(ctx context.Context, q Query) ([]Record, error)
+}
+
+// FileSink archives records as JSON lines appended to a file.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink returns a sink appending to path, creating its directory if needed.
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("jobarchive: create directory: %w", err)
+	}
+	return &FileSink{path: path}, nil
+}
+
+// Append writes records to the end of the file and syncs it.
+func (s *FileSink) Append(_ context.Context, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var buf strings.Builder
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("jobarchive: encode %s/%s: %w", record.Namespace, record.Name, err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("jobarchive: open: %w", err)
+	}
+	if _, err := f.WriteString(buf.String()); err != nil {
+		f.Close()
+		return fmt.Errorf("jobarchive: write: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("jobarchive: sync: %w", err)
+	}
+	return f.Close()
+}
+
+// Query scans the file for records matching q. Lines that don't decode, such
+// as one cut short by a crash, are skipped.
+func (s *FileSink) Query(ctx context.Context, q Query) ([]Record, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return []Record{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jobarchive: open: %w", err)
+	}
+	defer f.Close()
+
+	// Keep the newest matches only: the file is in archive order
+	var matches []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64<<10), maxRecordBytes)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if !q.Matches(record) {
+			continue
+		}
+		matches = append(matches, record)
+		if len(matches) > limit {
+			matches = matches[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("jobarchive: read: %w", err)
+	}
+
+	out := make([]Record, len(matches))
+	for i, record := range matches {
+		out[len(matches)-1-i] = record
+	}
+	return out, nil
+}
@@ -0,0 +1,84 @@
+package jobarchive
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+func TestFileSink(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "archive", "jobs.jsonl")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if records, err := sink.Query(ctx, Query{}); err != nil || len(records) != 0 {
+		t.Fatalf("empty archive: %v, %v", records, err)
+	}
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var records []Record
+	for i, ns := range []string{"team-a", "team-b", "team-a", "team-a"} {
+		finished := metav1.NewTime(base.Add(time.Duration(i) * time.Hour))
+		job := &wikiv1alpha1.TranslationJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "translation-" + string(rune('a'+i)), Namespace: ns},
+			Spec: wikiv1alpha1.TranslationJobSpec{
+				Source:      wikiv1alpha1.TranslationSourceSpec{TargetRef: "docs", PageID: "page-1"},
+				Destination: &wikiv1alpha1.TranslationDestinationSpec{TargetRef: "docs-fr", LanguageTag: "fr-CA"},
+			},
+			Status: wikiv1alpha1.TranslationJobStatus{
+				State:      wikiv1alpha1.TranslationJobStateCompleted,
+				TokensUsed: 100,
+				FinishedAt: &finished,
+				Engine:     &wikiv1alpha1.TranslationEngine{Name: "nanabush", Version: fmt.Sprintf("model-v%d", i%2+1)},
+			},
+		}
+		records = append(records, FromJob(job, base.Add(2*time.Hour)))
+	}
+	if err := sink.Append(ctx, records[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Append(ctx, records[2:]); err != nil {
+		t.Fatal(err)
+	}
+	// A line cut short by a crash is skipped
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"name":"trunc`)
+	f.Close()
+
+	got, err := sink.Query(ctx, Query{Namespace: "team-a", Target: "docs-fr", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Name != "translation-d" || got[1].Name != "translation-c" {
+		t.Errorf("newest team-a records = %+v", got)
+	}
+	if got[0].Origin != "manual" || got[0].DestinationTarget != "docs-fr" || got[0].TokensUsed != 100 {
+		t.Errorf("record = %+v", got[0])
+	}
+
+	got, err = sink.Query(ctx, Query{Namespaces: []string{"team-b"}, Since: base})
+	if err != nil || len(got) != 1 || got[0].Namespace != "team-b" {
+		t.Errorf("team-b records = %+v, %v", got, err)
+	}
+	got, err = sink.Query(ctx, Query{Since: base.Add(150 * time.Minute)})
+	if err != nil || len(got) != 1 || got[0].Name != "translation-d" {
+		t.Errorf("records since 14:30 = %+v, %v", got, err)
+	}
+	got, err = sink.Query(ctx, Query{Engine: "Nanabush", EngineVersion: "model-v2"})
+	if err != nil || len(got) != 2 || got[0].Name != "translation-d" || got[1].Name != "translation-b" {
+		t.Errorf("records by model-v2 = %+v, %v", got, err)
+	}
+}
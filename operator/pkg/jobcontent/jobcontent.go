@@ -0,0 +1,106 @@
+// Package jobcontent stores a TranslationJob's translated page so it survives
+// the run that produced it. Bodies up to InlineLimit live in the job's status;
+// larger ones spill to a ConfigMap owned by the job, which Kubernetes garbage
+// collects along with it.
+package jobcontent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+const (
+	// InlineLimit is the largest body, in bytes, kept in the job's status.
+	// Status is rewritten on every reconcile, so it stays small.
+	InlineLimit = 16 << 10
+	// MaxSize is the largest body that can be stored. ConfigMaps are capped
+	// at 1MiB including metadata.
+	MaxSize = 1000 << 10
+
+	// markdownKey is the ConfigMap key holding the body.
+	markdownKey = "markdown"
+)
+
+// ErrTooLarge is returned by Store when the body exceeds MaxSize.
+var ErrTooLarge = errors.New("translated content too large to store")
+
+// ConfigMapName returns the name of the ConfigMap holding jobName's content.
+func ConfigMapName(jobName string) string {
+	return jobName + "-content"
+}
+
+// Store records title and markdown for job and returns the value to set as
+// job.Status.TranslatedContent. Only the ConfigMap is written here; the
+// caller persists the status with its next status update.
+func Store(ctx context.Context, c client.Writer, job *wikiv1alpha1.TranslationJob, title, markdown string) (*wikiv1alpha1.TranslatedContent, error) {
+	sum := sha256.Sum256([]byte(markdown))
+	content := &wikiv1alpha1.TranslatedContent{
+		Title:  title,
+		Size:   int64(len(markdown)),
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+	if len(markdown) <= InlineLimit {
+		content.Markdown = markdown
+		return content, nil
+	}
+	if len(markdown) > MaxSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTooLarge, len(markdown))
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(job.Name),
+			Namespace: job.Namespace,
+			Labels:    map[string]string{wikiv1alpha1.LabelJob: job.Name},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(job, wikiv1alpha1.GroupVersion.WithKind("TranslationJob")),
+			},
+		},
+		Data: map[string]string{markdownKey: markdown},
+	}
+	err := c.Create(ctx, cm)
+	if apierrors.IsAlreadyExists(err) {
+		// A retry replaces the earlier output; patching avoids reading the
+		// ConfigMap back, which would need a ConfigMap watch
+		err = c.Patch(ctx, cm, client.Merge)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store translated content in ConfigMap %s: %w", cm.Name, err)
+	}
+	content.ConfigMapName = cm.Name
+	return content, nil
+}
+
+// Load returns the translated title and markdown recorded for job. ok is false
+// when the job has no stored content. Pass an uncached reader so the caller
+// doesn't need a ConfigMap watch.
+func Load(ctx context.Context, r client.Reader, job *wikiv1alpha1.TranslationJob) (title, markdown string, ok bool, err error) {
+	content := job.Status.TranslatedContent
+	if content == nil {
+		return "", "", false, nil
+	}
+	if content.ConfigMapName == "" {
+		return content.Title, content.Markdown, true, nil
+	}
+
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: content.ConfigMapName}, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", "", false, nil
+		}
+		return "", "", false, fmt.Errorf("load translated content from ConfigMap %s: %w", content.ConfigMapName, err)
+	}
+	markdown, ok = cm.Data[markdownKey]
+	return content.Title, markdown, ok, nil
+}
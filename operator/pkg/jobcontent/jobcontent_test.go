@@ -0,0 +1,66 @@
+package jobcontent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+)
+
+func TestStoreAndLoad(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	job := &wikiv1alpha1.TranslationJob{ObjectMeta: metav1.ObjectMeta{Name: "translation-abc", Namespace: "glooscap-system", UID: "uid-1"}}
+
+	// Small content stays inline
+	content, err := Store(ctx, c, job, "Titre", "# Bonjour")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Markdown != "# Bonjour" || content.ConfigMapName != "" || content.Size != 9 {
+		t.Errorf("unexpected inline content: %+v", content)
+	}
+
+	// Large content spills to a ConfigMap owned by the job, and a retry replaces it
+	large := strings.Repeat("a", InlineLimit+1)
+	if _, err := Store(ctx, c, job, "Titre", large); err != nil {
+		t.Fatal(err)
+	}
+	large = strings.Repeat("b", InlineLimit+1)
+	content, err = Store(ctx, c, job, "Titre 2", large)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Markdown != "" || content.ConfigMapName != ConfigMapName(job.Name) {
+		t.Errorf("unexpected spilled content: %+v", content)
+	}
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKey{Namespace: job.Namespace, Name: content.ConfigMapName}, &cm); err != nil {
+		t.Fatal(err)
+	}
+	if len(cm.OwnerReferences) != 1 || cm.OwnerReferences[0].UID != job.UID {
+		t.Errorf("ConfigMap not owned by the job: %+v", cm.OwnerReferences)
+	}
+
+	job.Status.TranslatedContent = content
+	title, markdown, ok, err := Load(ctx, c, job)
+	if err != nil || !ok || title != "Titre 2" || markdown != large {
+		t.Errorf("Load = %q, %d bytes, %v, %v", title, len(markdown), ok, err)
+	}
+
+	if _, err := Store(ctx, c, job, "Titre", strings.Repeat("c", MaxSize+1)); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package langtag normalizes the language tags of translation requests to
+// canonical BCP 47, so "fr_ca", "FR-ca" and "fr-CA" all reach the translation
+// service, and get compared, as "fr-CA".
+package langtag
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Canonical parses tag, in any letter case and with "-" or "_" separators,
+// and returns its canonical BCP 47 form. Names such as "french", unknown
+// subtags and the undetermined language "und" are rejected.
+func Canonical(tag string) (string, error) {
+	trimmed := strings.TrimSpace(tag)
+	if trimmed == "" {
+		return "", fmt.Errorf("language tag is empty")
+	}
+	parsed, err := language.Parse(trimmed)
+	if err != nil || parsed.IsRoot() {
+		return "", fmt.Errorf("%q is not a BCP 47 language tag; use a tag such as \"fr\" or \"fr-CA\"", tag)
+	}
+	return parsed.String(), nil
+}
+
+// SameLanguage reports whether a and b name the same base language, e.g. "FR"
+// and "fr-CA", or "iw" and "he". Tags that don't parse are compared on their
+// first subtag.
+func SameLanguage(a, b string) bool {
+	baseA, baseB := base(a), base(b)
+	return baseA != "" && baseA == baseB
+}
+
+func base(tag string) string {
+	if parsed, err := language.Parse(strings.TrimSpace(tag)); err == nil && !parsed.IsRoot() {
+		b, _ := parsed.Base()
+		return b.String()
+	}
+	primary, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	return strings.ToLower(strings.TrimSpace(primary))
+}
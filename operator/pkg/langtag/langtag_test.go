@@ -0,0 +1,44 @@
+package langtag
+
+import "testing"
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "fr-CA", want: "fr-CA"},
+		{in: "fr_ca", want: "fr-CA"},
+		{in: " FR ", want: "fr"},
+		{in: "zh-hant-tw", want: "zh-Hant-TW"},
+		{in: "french", wantErr: true},
+		{in: "xx", wantErr: true},
+		{in: "und", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Canonical(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Canonical(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSameLanguage(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"FR", "fr-CA", true},
+		{"en_US", "en", true},
+		{"iw", "he", true},
+		{"en", "fr-CA", false},
+		{"", "fr-CA", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := SameLanguage(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameLanguage(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// footerMark opens every footer. It is an invisible separator (U+2063), so
+// StripFooter can find footers again after the page round-trips through
+// Outline, whatever their template says.
+const footerMark = "\u2063"
+
+// DefaultFooterTemplate is used when a footer is enabled without a template.
+const DefaultFooterTemplate = `*{{.Disclaimer}}* {{if .SourceURL}}[{{.SourceTitle}}]({{.SourceURL}}){{else}}{{.SourceTitle}}{{end}} ({{.SourceLanguage}}), {{.Date}}`
+
+// footerDisclaimers holds the machine translation notice by base language.
+var footerDisclaimers = map[string]string{
+	"en": "Machine translated from:",
+	"fr": "Traduit automatiquement de :",
+	"es": "Traducido automáticamente de:",
+	"de": "Maschinell übersetzt aus:",
+	"it": "Tradotto automaticamente da:",
+	"pt": "Traduzido automaticamente de:",
+	"nl": "Automatisch vertaald uit:",
+	"ja": "機械翻訳の原文:",
+	"zh": "机器翻译自：",
+}
+
+// FooterData fills a footer template.
+type FooterData struct {
+	SourceTitle    string
+	SourceURL      string
+	SourceLanguage string
+	// TargetLanguage picks the disclaimer's language.
+	TargetLanguage string
+	TranslatedAt   time.Time
+	// Engine and EngineVersion name what produced the translation, when the
+	// service reported it.
+	Engine        string
+	EngineVersion string
+}
+
+// footerFields are the template's fields: FooterData plus the derived ones.
+type footerFields struct {
+	FooterData
+	// Date is TranslatedAt as YYYY-MM-DD, which reads the same in every locale.
+	Date string
+	// Disclaimer is the machine translation notice in TargetLanguage,
+	// falling back to English.
+	Disclaimer string
+}
+
+// FooterDisclaimer returns the machine translation notice for lang, a BCP 47
+// tag, in English when there is no translation for it.
+func FooterDisclaimer(lang string) string {
+	base := strings.ToLower(lang)
+	if i := strings.IndexAny(base, "-_"); i >= 0 {
+		base = base[:i]
+	}
+	if d, ok := footerDisclaimers[base]; ok {
+		return d
+	}
+	return footerDisclaimers["en"]
+}
+
+// AppendFooter renders tmpl - DefaultFooterTemplate when empty - with data
+// and appends it to md below a horizontal rule, replacing any footer md
+// already has. The template sees data's fields plus .Date and .Disclaimer.
+func AppendFooter(md, tmpl string, data FooterData) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultFooterTemplate
+	}
+	t, err := template.New("footer").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parse footer template: %w", err)
+	}
+	var footer bytes.Buffer
+	fields := footerFields{
+		FooterData: data,
+		Date:       data.TranslatedAt.UTC().Format(time.DateOnly),
+		Disclaimer: FooterDisclaimer(data.TargetLanguage),
+	}
+	if err := t.Execute(&footer, fields); err != nil {
+		return "", fmt.Errorf("render footer template: %w", err)
+	}
+	body := strings.TrimSpace(footer.String())
+	if body == "" {
+		return md, nil
+	}
+	return strings.TrimRight(StripFooter(md), "\n") + "\n\n---\n\n" + footerMark + body + "\n", nil
+}
+
+// StripFooter removes the footer AppendFooter added to md, if any, so
+// content hashes and comparisons only see the page itself.
+func StripFooter(md string) string {
+	i := strings.LastIndex(md, footerMark)
+	if i < 0 {
+		return md
+	}
+	page := strings.TrimRight(md[:i], " \t\n")
+	page = strings.TrimSuffix(page, "---")
+	return strings.TrimRight(page, " \t\n")
+}
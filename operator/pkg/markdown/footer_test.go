@@ -0,0 +1,54 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendFooter(t *testing.T) {
+	data := FooterData{
+		SourceTitle:    "Setup Guide",
+		SourceURL:      "https://wiki.example.com/doc/setup-guide-Ab12Cd",
+		SourceLanguage: "en",
+		TargetLanguage: "fr-CA",
+		TranslatedAt:   time.Date(2025, 3, 14, 22, 0, 0, 0, time.UTC),
+	}
+	page := "# Guide\n\nBonjour.\n"
+
+	got, err := AppendFooter(page, "", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Traduit automatiquement", "[Setup Guide](https://wiki.example.com/doc/setup-guide-Ab12Cd)", "2025-03-14"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("footer missing %q:\n%s", want, got)
+		}
+	}
+	if stripped := StripFooter(got); stripped != "# Guide\n\nBonjour." {
+		t.Errorf("StripFooter = %q", stripped)
+	}
+
+	// Re-rendering replaces the footer instead of stacking another
+	data.TranslatedAt = data.TranslatedAt.AddDate(0, 1, 0)
+	again, err := AppendFooter(got, "Source: {{.SourceURL}}", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(again, footerMark) != 1 || strings.Contains(again, "Traduit") {
+		t.Errorf("footer not replaced:\n%s", again)
+	}
+
+	// Outline re-exports the rule and paragraphs with its own spacing
+	exported := strings.Replace(again, "\n\n---\n\n", "\n\n---\n", 1) + "\n\n"
+	if StripFooter(exported) != StripFooter(again) {
+		t.Errorf("StripFooter(%q) = %q", exported, StripFooter(exported))
+	}
+
+	if _, err := AppendFooter(page, "{{.Nope}}", data); err == nil {
+		t.Error("expected an error for an unknown template field")
+	}
+	if StripFooter(page) != page {
+		t.Error("StripFooter changed a page without a footer")
+	}
+}
@@ -0,0 +1,231 @@
+// Package markdown keeps the parts of an Outline page that must survive
+// translation unchanged - code, link targets, mentions, embeds and notice
+// markers - away from the translation model. Protect swaps them for numbered
+// placeholders before the page is sent, and Restore puts them back into the
+// translated text. AppendFooter and StripFooter manage the attribution footer
+// of published translations.
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// placeholderOpen and placeholderClose delimit placeholders. They aren't
+// markdown syntax, so models tend to copy them through untouched.
+const (
+	placeholderOpen  = "⟦"
+	placeholderClose = "⟧"
+)
+
+var (
+	// placeholderPattern also accepts the padding some models add
+	placeholderPattern = regexp.MustCompile(`⟦\s*(\d+)\s*⟧`)
+	// mentionPattern matches Outline mentions, e.g. @[Jane](mention://<id>/user/<id>)
+	mentionPattern = regexp.MustCompile(`^@\[[^\]\n]*\]\(mention://[^)\s]*\)`)
+	// autolinkPattern matches <https://...> autolinks
+	autolinkPattern = regexp.MustCompile(`^<(?:https?|mailto):[^>\s]*>`)
+	// urlPattern matches bare URLs, which Outline renders as embeds when they
+	// stand on their own line
+	urlPattern = regexp.MustCompile(`^https?://[^\s<>()\[\]]+`)
+)
+
+// Protected is markdown whose untranslatable parts were swapped for
+// placeholders.
+type Protected struct {
+	// Text is the markdown to translate.
+	Text   string
+	tokens []string
+}
+
+// Protect replaces the untranslatable parts of md with placeholders:
+//   - fenced code blocks and inline code spans
+//   - link and image destinations, such as /doc/... links and attachment
+//     URLs; link text stays translatable
+//   - mentions, including the mentioned name
+//   - bare URLs and autolinks, which Outline turns into embeds
+//   - the markers of ::: notice blocks
+func Protect(md string) *Protected {
+	p := &Protected{}
+	lines := strings.Split(md, "\n")
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := line[:len(line)-len(trimmed)]
+
+		if fence := fenceMarker(trimmed); fence != "" {
+			// An unclosed fence runs to the end of the document
+			end := i + 1
+			for end < len(lines) && !closesFence(lines[end], fence) {
+				end++
+			}
+			end = min(end, len(lines)-1)
+			block := strings.Join(append([]string{trimmed}, lines[i+1:end+1]...), "\n")
+			out = append(out, indent+p.add(block))
+			i = end
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, ":::") {
+			markerEnd := strings.IndexAny(trimmed, " \t")
+			if markerEnd < 0 {
+				markerEnd = len(trimmed)
+			}
+			out = append(out, indent+p.add(trimmed[:markerEnd])+p.protectInline(trimmed[markerEnd:]))
+			continue
+		}
+
+		out = append(out, p.protectInline(line))
+	}
+	p.Text = strings.Join(out, "\n")
+	return p
+}
+
+// Len returns the number of protected parts.
+func (p *Protected) Len() int {
+	return len(p.tokens)
+}
+
+// Restore puts the protected parts back into translated, the translation of
+// p.Text. missing holds the parts whose placeholders the translation dropped;
+// they are absent from restored.
+func (p *Protected) Restore(translated string) (restored string, missing []string) {
+	seen := make([]bool, len(p.tokens))
+	restored = placeholderPattern.ReplaceAllStringFunc(translated, func(match string) string {
+		i, err := strconv.Atoi(placeholderPattern.FindStringSubmatch(match)[1])
+		if err != nil || i >= len(p.tokens) {
+			return match
+		}
+		seen[i] = true
+		return p.tokens[i]
+	})
+	for i, ok := range seen {
+		if !ok {
+			missing = append(missing, p.tokens[i])
+		}
+	}
+	return restored, missing
+}
+
+// add records token and returns its placeholder.
+func (p *Protected) add(token string) string {
+	p.tokens = append(p.tokens, token)
+	return placeholderOpen + strconv.Itoa(len(p.tokens)-1) + placeholderClose
+}
+
+// protectInline protects the inline parts of one line.
+func (p *Protected) protectInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case rest[0] == '`':
+			n := len(rest) - len(strings.TrimLeft(rest, "`"))
+			if end := closingBackticks(rest, n); end > 0 {
+				b.WriteString(p.add(rest[:end]))
+				i += end
+			} else {
+				b.WriteString(rest[:n])
+				i += n
+			}
+			continue
+		case strings.HasPrefix(rest, "@["):
+			if m := mentionPattern.FindString(rest); m != "" {
+				b.WriteString(p.add(m))
+				i += len(m)
+				continue
+			}
+		case strings.HasPrefix(rest, "]("):
+			if end := closingParen(rest, 2); end > 0 {
+				b.WriteString("](")
+				if end > 2 {
+					b.WriteString(p.add(rest[2:end]))
+				}
+				b.WriteByte(')')
+				i += end + 1
+				continue
+			}
+		case rest[0] == '<':
+			if m := autolinkPattern.FindString(rest); m != "" {
+				b.WriteString(p.add(m))
+				i += len(m)
+				continue
+			}
+		case rest[0] == 'h' && (i == 0 || !isWordByte(s[i-1])):
+			if m := urlPattern.FindString(rest); m != "" {
+				// Sentence punctuation after a URL isn't part of it
+				m = strings.TrimRight(m, ".,;:!?'\"*_")
+				b.WriteString(p.add(m))
+				i += len(m)
+				continue
+			}
+		case strings.HasPrefix(rest, placeholderOpen):
+			// Literal delimiters in the page would be mistaken for placeholders
+			b.WriteString(p.add(placeholderOpen))
+			i += len(placeholderOpen)
+			continue
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
+
+// fenceMarker returns the opening ``` or ~~~ run of a fenced code block
+// line, or "" when line doesn't open one.
+func fenceMarker(line string) string {
+	if !strings.HasPrefix(line, "```") && !strings.HasPrefix(line, "~~~") {
+		return ""
+	}
+	n := len(line) - len(strings.TrimLeft(line, line[:1]))
+	return line[:n]
+}
+
+// closesFence reports whether line closes a block opened with fence.
+func closesFence(line, fence string) bool {
+	t := strings.TrimSpace(line)
+	return len(t) >= len(fence) && strings.Trim(t, fence[:1]) == ""
+}
+
+// closingBackticks returns the end of the code span opened by the n
+// backticks at the start of s, or -1 when it isn't closed.
+func closingBackticks(s string, n int) int {
+	for i := n; i < len(s); {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		run := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+		if run == n {
+			return i + run
+		}
+		i += run
+	}
+	return -1
+}
+
+// closingParen returns the index of the parenthesis closing the one before
+// start, or -1.
+func closingParen(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '/' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
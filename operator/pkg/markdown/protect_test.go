@@ -0,0 +1,106 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProtectRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		// hidden must not appear in the protected text
+		hidden []string
+		// visible must stay translatable
+		visible []string
+	}{
+		{
+			name:    "document link",
+			md:      "See [the setup guide](/doc/setup-guide-Ab12Cd) for details.",
+			hidden:  []string{"/doc/setup-guide-Ab12Cd"},
+			visible: []string{"the setup guide", "for details."},
+		},
+		{
+			name:    "nested image link",
+			md:      "[![Architecture diagram](/api/attachments.redirect?id=42)](/doc/architecture-x1)",
+			hidden:  []string{"attachments.redirect", "/doc/architecture-x1"},
+			visible: []string{"Architecture diagram"},
+		},
+		{
+			name:    "link destination with parentheses",
+			md:      "[Wiki](https://en.wikipedia.org/wiki/Go_(language)) page",
+			hidden:  []string{"wikipedia.org", "Go_(language)"},
+			visible: []string{"Wiki", "page"},
+		},
+		{
+			name:    "mention",
+			md:      "Ask @[Jane Doe](mention://9f2c/user/41aa) before merging.",
+			hidden:  []string{"Jane Doe", "mention://"},
+			visible: []string{"Ask", "before merging."},
+		},
+		{
+			name:    "embed and autolink",
+			md:      "https://www.youtube.com/watch?v=abc123\nOr mail <mailto:team@example.com>.",
+			hidden:  []string{"youtube.com", "mailto:"},
+			visible: []string{"Or mail"},
+		},
+		{
+			name:    "code fence",
+			md:      "Run this:\n\n```bash\n# see [docs](/doc/x)\nmake deploy\n```\n\nThen check `kubectl get pods`.",
+			hidden:  []string{"make deploy", "/doc/x", "kubectl get pods", "```"},
+			visible: []string{"Run this:", "Then check"},
+		},
+		{
+			name:    "unclosed code fence",
+			md:      "Intro\n  ~~~\n  code [a](b)",
+			hidden:  []string{"code [a](b)"},
+			visible: []string{"Intro"},
+		},
+		{
+			name:    "notice block",
+			md:      ":::info\nRead [this](/doc/notice-1) first.\n:::",
+			hidden:  []string{":::", "/doc/notice-1"},
+			visible: []string{"Read", "first."},
+		},
+		{
+			name:    "literal placeholder delimiters",
+			md:      "Brackets ⟦0⟧ in text",
+			visible: []string{"Brackets", "in text"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Protect(tt.md)
+			for _, s := range tt.hidden {
+				if strings.Contains(p.Text, s) {
+					t.Errorf("%q not protected: %q", s, p.Text)
+				}
+			}
+			for _, s := range tt.visible {
+				if !strings.Contains(p.Text, s) {
+					t.Errorf("%q not translatable: %q", s, p.Text)
+				}
+			}
+			restored, missing := p.Restore(p.Text)
+			if restored != tt.md || len(missing) != 0 {
+				t.Errorf("Restore = %q, missing %q; want %q", restored, missing, tt.md)
+			}
+		})
+	}
+}
+
+func TestRestoreTranslated(t *testing.T) {
+	p := Protect("See [the guide](/doc/guide-1) and `make test`.")
+	if p.Len() != 2 {
+		t.Fatalf("Len = %d, want 2: %q", p.Len(), p.Text)
+	}
+
+	// Models may pad placeholders or drop them
+	restored, missing := p.Restore("Voir [le guide](⟦ 0 ⟧) et ⟦7⟧.")
+	if restored != "Voir [le guide](/doc/guide-1) et ⟦7⟧." {
+		t.Errorf("restored = %q", restored)
+	}
+	if len(missing) != 1 || missing[0] != "`make test`" {
+		t.Errorf("missing = %q", missing)
+	}
+}
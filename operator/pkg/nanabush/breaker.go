@@ -0,0 +1,247 @@
+package nanabush
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/clock"
+
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+// Circuit breaker states.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitCooldown         = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned, without calling the service, while the circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("nanabush: circuit breaker open, translation service is failing")
+
+// CircuitConfig tunes the circuit breaker. Zero values use the defaults.
+type CircuitConfig struct {
+	// FailureThreshold is the number of consecutive failed calls that opens
+	// the breaker (default: 5).
+	FailureThreshold int
+	// Cooldown is how long the breaker stays open before letting one call
+	// through to test the service (default: 30s).
+	Cooldown time.Duration
+}
+
+// withDefaults fills in unset values.
+func (cfg CircuitConfig) withDefaults() CircuitConfig {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultCircuitFailureThreshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = defaultCircuitCooldown
+	}
+	return cfg
+}
+
+// CircuitConfigFromEnv reads the breaker settings from
+// NANABUSH_CIRCUIT_FAILURE_THRESHOLD and NANABUSH_CIRCUIT_COOLDOWN (a Go
+// duration). Unset or invalid values are left at zero so the defaults apply.
+func CircuitConfigFromEnv() CircuitConfig {
+	var cfg CircuitConfig
+	if v, err := strconv.Atoi(os.Getenv("NANABUSH_CIRCUIT_FAILURE_THRESHOLD")); err == nil {
+		cfg.FailureThreshold = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("NANABUSH_CIRCUIT_COOLDOWN")); err == nil {
+		cfg.Cooldown = v
+	}
+	return cfg
+}
+
+// CircuitStatus is the circuit breaker state reported by the API.
+type CircuitStatus struct {
+	State               string    `json:"state"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	FailureThreshold    int       `json:"failureThreshold"`
+	OpenedAt            time.Time `json:"openedAt,omitempty"`
+	// CooldownRemainingSeconds is how long an open breaker keeps failing
+	// calls fast before it tries the service again
+	CooldownRemainingSeconds float64 `json:"cooldownRemainingSeconds"`
+	LastError                string  `json:"lastError,omitempty"`
+}
+
+// circuitBreaker fails calls fast after repeated service failures, so jobs
+// don't each wait out a timeout against a service that is down. After the
+// cooldown one call is let through; its outcome closes or reopens the breaker.
+type circuitBreaker struct {
+	cfg   CircuitConfig
+	clock clock.PassiveClock
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	openedAt  time.Time
+	lastError string
+	// probing is set while the one half-open call is in flight
+	probing bool
+}
+
+func newCircuitBreaker(cfg CircuitConfig, clk clock.PassiveClock) *circuitBreaker {
+	return &circuitBreaker{cfg: cfg.withDefaults(), clock: clk, state: CircuitClosed}
+}
+
+// allow reports whether a call may go to the service, returning
+// ErrCircuitOpen when it may not. changed reports a state transition.
+func (b *circuitBreaker) allow() (changed bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitOpen:
+		if b.clock.Since(b.openedAt) < b.cfg.Cooldown {
+			return false, ErrCircuitOpen
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true, nil
+	case CircuitHalfOpen:
+		if b.probing {
+			return false, ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return false, nil
+}
+
+// record counts the outcome of a call allow let through and reports whether
+// the state changed. Errors that don't point at the service, such as the
+// caller giving up, count as neither success nor failure.
+func (b *circuitBreaker) record(err error) (changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		if b.state == CircuitClosed {
+			return false
+		}
+		verbosity.Printf("[nanabush] Circuit breaker closed, translation service recovered\n")
+		b.state = CircuitClosed
+		return true
+	}
+	if !isServiceFailure(err) {
+		return false
+	}
+	b.failures++
+	b.lastError = err.Error()
+	if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.cfg.FailureThreshold) {
+		verbosity.Printf("[nanabush] Circuit breaker opened after %d consecutive failures (cooldown %v): %v\n",
+			b.failures, b.cfg.Cooldown, err)
+		b.state = CircuitOpen
+		b.openedAt = b.clock.Now()
+		return true
+	}
+	return false
+}
+
+// reset force-closes the breaker and reports whether it was open.
+func (b *circuitBreaker) reset() (changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	changed = b.state != CircuitClosed
+	b.state = CircuitClosed
+	b.failures = 0
+	b.probing = false
+	return changed
+}
+
+func (b *circuitBreaker) status() CircuitStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := CircuitStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		FailureThreshold:    b.cfg.FailureThreshold,
+		LastError:           b.lastError,
+	}
+	if b.state != CircuitClosed {
+		s.OpenedAt = b.openedAt
+	}
+	if b.state == CircuitOpen {
+		if remaining := b.cfg.Cooldown - b.clock.Since(b.openedAt); remaining > 0 {
+			s.CooldownRemainingSeconds = remaining.Seconds()
+		}
+	}
+	return s
+}
+
+// isServiceFailure reports whether err says the service is down or failing,
+// rather than that the caller gave up or sent a bad request.
+func isServiceFailure(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch s.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// Circuit returns the state of the client's circuit breaker.
+func (c *Client) Circuit() CircuitStatus {
+	if c.breaker == nil {
+		return CircuitStatus{State: CircuitClosed}
+	}
+	return c.breaker.status()
+}
+
+// ResetCircuit force-closes the circuit breaker, for use once a known outage
+// is over, instead of waiting out the cooldown.
+func (c *Client) ResetCircuit() {
+	if c.breaker != nil && c.breaker.reset() {
+		verbosity.Printf("[nanabush] Circuit breaker reset\n")
+		c.notifyStatusChange()
+	}
+}
+
+// callService runs call through the circuit breaker.
+func (c *Client) callService(call func() error) error {
+	if c.breaker == nil {
+		return call()
+	}
+	changed, err := c.breaker.allow()
+	if changed {
+		c.notifyStatusChange()
+	}
+	if err != nil {
+		return fmt.Errorf("%w (retrying in %.0fs)", err, c.breaker.status().CooldownRemainingSeconds)
+	}
+	err = call()
+	if c.breaker.record(err) {
+		c.notifyStatusChange()
+	}
+	return err
+}
+
+// notifyStatusChange reports a status change to the OnStatusChange callback.
+func (c *Client) notifyStatusChange() {
+	if c.onStatusChange != nil {
+		c.onStatusChange(c.Status())
+	}
+}
@@ -0,0 +1,107 @@
+package nanabush
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	var notified int
+	c := &Client{
+		breaker:        newCircuitBreaker(CircuitConfig{FailureThreshold: 2, Cooldown: time.Minute}, fakeClock),
+		onStatusChange: func(Status) { notified++ },
+		clock:          fakeClock,
+		heartbeatCfg:   HeartbeatConfig{}.withDefaults(),
+	}
+	calls := 0
+	unavailable := func() error {
+		calls++
+		return status.Error(codes.Unavailable, "connection refused")
+	}
+
+	// Bad requests and cancelled callers say nothing about the service
+	_ = c.callService(func() error { return status.Error(codes.InvalidArgument, "bad language") })
+	_ = c.callService(func() error { return context.Canceled })
+	_ = c.callService(unavailable)
+	if s := c.Circuit(); s.State != CircuitClosed || s.ConsecutiveFailures != 1 {
+		t.Fatalf("after one failure: %+v", s)
+	}
+
+	_ = c.callService(unavailable)
+	if s := c.Circuit(); s.State != CircuitOpen || s.CooldownRemainingSeconds != 60 {
+		t.Fatalf("after two failures: %+v", s)
+	}
+	if err := c.callService(unavailable); !errors.Is(err, ErrCircuitOpen) || calls != 2 {
+		t.Fatalf("open breaker: err=%v calls=%d, want ErrCircuitOpen without a call", err, calls)
+	}
+
+	// After the cooldown one call tests the service; failing reopens it
+	fakeClock.Step(time.Minute)
+	_ = c.callService(unavailable)
+	if s := c.Circuit(); s.State != CircuitOpen || calls != 3 {
+		t.Fatalf("failed probe: %+v calls=%d", s, calls)
+	}
+
+	// A reset closes it at once
+	c.ResetCircuit()
+	if err := c.callService(func() error { return nil }); err != nil {
+		t.Fatalf("after reset: %v", err)
+	}
+	if s := c.Circuit(); s.State != CircuitClosed || s.ConsecutiveFailures != 0 {
+		t.Fatalf("after reset: %+v", s)
+	}
+	// Opened, half-open, reopened and reset
+	if notified != 4 {
+		t.Errorf("status changes notified = %d, want 4", notified)
+	}
+}
+
+func TestOpenCircuitFailsOver(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	healthy := func(addr string) *Client {
+		return &Client{
+			addr:              addr,
+			registered:        true,
+			lastHeartbeatTime: fakeClock.Now(),
+			heartbeatInterval: time.Hour,
+			heartbeatCfg:      HeartbeatConfig{}.withDefaults(),
+			breaker:           newCircuitBreaker(CircuitConfig{FailureThreshold: 1, Cooldown: time.Minute}, fakeClock),
+			clock:             fakeClock,
+		}
+	}
+	primary := healthy("primary:50051")
+	fallback := healthy("fallback:50051")
+	primary.fallbacks = []*Client{fallback}
+
+	if fb := primary.failoverTarget(); fb != nil {
+		t.Fatalf("healthy primary failed over to %s", fb.addr)
+	}
+
+	// The heartbeat is fine, but the breaker is failing translations fast
+	_ = primary.callService(func() error { return status.Error(codes.Unavailable, "overloaded") })
+	if fb := primary.failoverTarget(); fb != fallback {
+		t.Fatalf("open breaker: failover target %v, want the fallback", fb)
+	}
+	if got := primary.Status().ActiveEndpoint; got != "fallback:50051" {
+		t.Errorf("active endpoint %q, want the fallback", got)
+	}
+
+	// A fallback whose own breaker is open isn't healthy either
+	_ = fallback.callService(func() error { return status.Error(codes.Unavailable, "overloaded") })
+	if fb := primary.failoverTarget(); fb != nil {
+		t.Errorf("failed over to %s, whose breaker is open", fb.addr)
+	}
+
+	// After the cooldown the primary gets the call that can close its breaker
+	fakeClock.Step(time.Minute)
+	if fb := primary.failoverTarget(); fb != nil {
+		t.Errorf("after the cooldown: failed over to %s", fb.addr)
+	}
+}
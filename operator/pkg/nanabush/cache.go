@@ -0,0 +1,284 @@
+package nanabush
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+// DefaultCacheMaxEntries is used when CacheConfig.MaxEntries is unset.
+const DefaultCacheMaxEntries = 1000
+
+var (
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "glooscap_translation_cache_lookups_total",
+		Help: "Translation cache lookups by result (hit or miss).",
+	}, []string{"result"})
+
+	cacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "glooscap_translation_cache_entries",
+		Help: "Number of translations held in the translation cache.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(cacheLookups, cacheEntries)
+}
+
+// Translator performs translations; *Client implements it.
+type Translator interface {
+	Translate(ctx context.Context, req TranslateRequest) (*TranslateResponse, error)
+}
+
+// CacheConfig configures a TranslationCache.
+type CacheConfig struct {
+	// MaxEntries bounds the cache; the least recently used entry is evicted
+	// first. Defaults to DefaultCacheMaxEntries.
+	MaxEntries int
+	// TTL expires entries this long after they were stored. Zero keeps entries
+	// until they are evicted.
+	TTL time.Duration
+	// Path, when set, persists the cache as JSON so it survives restarts and
+	// can be shared by runners mounting the same volume.
+	Path string
+	// Check keeps translations CheckTranslation rejects out of the cache, so
+	// retrying a job asks the service again instead of getting them back.
+	Check TranslationCheckConfig
+}
+
+// cacheEntry is one cached translation. SourceTitle is kept so a hit for the
+// same body under a different title only needs the title translated.
+type cacheEntry struct {
+	Key                string    `json:"key"`
+	SourceTitle        string    `json:"sourceTitle"`
+	TranslatedTitle    string    `json:"translatedTitle"`
+	TranslatedMarkdown string    `json:"translatedMarkdown"`
+	Engine             string    `json:"engine,omitempty"`
+	EngineVersion      string    `json:"engineVersion,omitempty"`
+	StoredAt           time.Time `json:"storedAt"`
+}
+
+// TranslationCache stores document translations keyed by the hash of the
+// source markdown and the target language. A nil *TranslationCache is valid
+// and caches nothing.
+type TranslationCache struct {
+	cfg CacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*list.Element // key -> element holding *cacheEntry
+	order   *list.List               // most recently used at the front
+}
+
+// NewTranslationCache creates a cache, loading previously persisted entries
+// from cfg.Path when it exists.
+func NewTranslationCache(cfg CacheConfig) (*TranslationCache, error) {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = DefaultCacheMaxEntries
+	}
+	c := &TranslationCache{
+		cfg:     cfg,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+	if cfg.Path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(cfg.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read translation cache: %w", err)
+	}
+	var stored []cacheEntry
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("decode translation cache %s: %w", cfg.Path, err)
+	}
+	// Entries are persisted most recent first; insert oldest first to keep that order
+	for i := len(stored) - 1; i >= 0; i-- {
+		if c.expired(&stored[i], time.Now()) {
+			continue
+		}
+		c.insertLocked(&stored[i])
+	}
+	cacheEntries.Set(float64(c.order.Len()))
+	return c, nil
+}
+
+// TranslationCacheKey returns the cache key for markdown translated to targetLanguage.
+func TranslationCacheKey(markdown, targetLanguage string) string {
+	sum := sha256.Sum256([]byte(markdown))
+	return hex.EncodeToString(sum[:]) + ":" + strings.ToLower(targetLanguage)
+}
+
+// Len returns the number of cached translations.
+func (c *TranslationCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Translate serves a doc-translate request from the cache when possible and
+// otherwise calls t, caching successful results. hit reports whether the
+// backend's document translation was skipped. When only the source title
+// differs from the cached entry, just the title is sent to t.
+func (c *TranslationCache) Translate(ctx context.Context, t Translator, req TranslateRequest) (resp *TranslateResponse, hit bool, err error) {
+	if c == nil || req.Document == nil {
+		resp, err = t.Translate(ctx, req)
+		return resp, false, err
+	}
+
+	key := TranslationCacheKey(req.Document.Markdown, req.TargetLanguage)
+	if req.Context != "" {
+		// A context note can change the output, so it must not share entries
+		sum := sha256.Sum256([]byte(req.Context))
+		key += ":" + hex.EncodeToString(sum[:8])
+	}
+	if entry, ok := c.get(key); ok {
+		resp = &TranslateResponse{
+			JobID:              req.JobID,
+			Success:            true,
+			TranslatedTitle:    entry.TranslatedTitle,
+			TranslatedMarkdown: entry.TranslatedMarkdown,
+			CompletedAt:        time.Now(),
+			// The body, the bulk of the page, came from the cached engine
+			Engine:        entry.Engine,
+			EngineVersion: entry.EngineVersion,
+		}
+		if entry.SourceTitle == req.Document.Title {
+			return resp, true, nil
+		}
+
+		titleResp, err := t.Translate(ctx, TranslateRequest{
+			JobID:          req.JobID,
+			Namespace:      req.Namespace,
+			Primitive:      "title",
+			Title:          req.Document.Title,
+			SourceLanguage: req.SourceLanguage,
+			TargetLanguage: req.TargetLanguage,
+			SourceWikiURI:  req.SourceWikiURI,
+			PageID:         req.PageID,
+			PageSlug:       req.PageSlug,
+			Context:        req.Context,
+		})
+		if err == nil && titleResp.Success && titleResp.TranslatedTitle != "" {
+			resp.TranslatedTitle = titleResp.TranslatedTitle
+			resp.TokensUsed = titleResp.TokensUsed
+			resp.InferenceTimeSeconds = titleResp.InferenceTimeSeconds
+			return resp, true, nil
+		}
+		// Fall through to a full translation rather than publish a mismatched title
+	}
+
+	resp, err = t.Translate(ctx, req)
+	if err == nil && resp != nil && resp.Success && CheckTranslation(req.Document.Markdown, resp.TranslatedMarkdown, c.cfg.Check) == nil {
+		c.put(&cacheEntry{
+			Key:                key,
+			SourceTitle:        req.Document.Title,
+			TranslatedTitle:    resp.TranslatedTitle,
+			TranslatedMarkdown: resp.TranslatedMarkdown,
+			Engine:             resp.Engine,
+			EngineVersion:      resp.EngineVersion,
+			StoredAt:           time.Now(),
+		})
+	}
+	return resp, false, err
+}
+
+func (c *TranslationCache) get(key string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if ok {
+		entry := el.Value.(*cacheEntry)
+		if !c.expired(entry, time.Now()) {
+			c.order.MoveToFront(el)
+			cacheLookups.WithLabelValues("hit").Inc()
+			return entry, true
+		}
+		c.order.Remove(el)
+		delete(c.entries, key)
+		cacheEntries.Set(float64(c.order.Len()))
+	}
+	cacheLookups.WithLabelValues("miss").Inc()
+	return nil, false
+}
+
+func (c *TranslationCache) put(entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[entry.Key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, entry.Key)
+	}
+	c.insertLocked(entry)
+	cacheEntries.Set(float64(c.order.Len()))
+
+	// Saving under the lock keeps concurrent writers from persisting stale snapshots
+	if c.cfg.Path != "" {
+		if err := c.saveLocked(); err != nil {
+			verbosity.Printf("[nanabush] Failed to persist translation cache: %v\n", err)
+		}
+	}
+}
+
+// insertLocked adds entry as the most recently used, evicting the least
+// recently used entries beyond MaxEntries. Callers must hold c.mu.
+func (c *TranslationCache) insertLocked(entry *cacheEntry) {
+	c.entries[entry.Key] = c.order.PushFront(entry)
+	for c.order.Len() > c.cfg.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).Key)
+	}
+}
+
+func (c *TranslationCache) expired(entry *cacheEntry, now time.Time) bool {
+	return c.cfg.TTL > 0 && now.Sub(entry.StoredAt) > c.cfg.TTL
+}
+
+// saveLocked writes the cache to cfg.Path, most recently used first, via a
+// temporary file so readers never see a partial cache. Callers must hold c.mu.
+func (c *TranslationCache) saveLocked() error {
+	entries := make([]*cacheEntry, 0, c.order.Len())
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		entries = append(entries, el.Value.(*cacheEntry))
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.cfg.Path), ".translation-cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.cfg.Path)
+}
@@ -0,0 +1,138 @@
+package nanabush
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// countingTranslator returns canned translations and counts calls per primitive.
+type countingTranslator struct {
+	calls map[string]int
+}
+
+func (t *countingTranslator) Translate(_ context.Context, req TranslateRequest) (*TranslateResponse, error) {
+	if t.calls == nil {
+		t.calls = make(map[string]int)
+	}
+	t.calls[req.Primitive]++
+	if req.Primitive == "title" {
+		return &TranslateResponse{Success: true, TranslatedTitle: "fr " + req.Title, TokensUsed: 5}, nil
+	}
+	return &TranslateResponse{
+		Success:            true,
+		TranslatedTitle:    "fr " + req.Document.Title,
+		TranslatedMarkdown: "fr " + req.Document.Markdown,
+		TokensUsed:         100,
+		Engine:             "nanabush",
+		EngineVersion:      "model-v1",
+	}, nil
+}
+
+func docRequest(title, markdown, lang string) TranslateRequest {
+	return TranslateRequest{
+		Primitive:      "doc-translate",
+		Document:       &DocumentContent{Title: title, Markdown: markdown},
+		TargetLanguage: lang,
+	}
+}
+
+func TestTranslationCache(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewTranslationCache(CacheConfig{MaxEntries: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := &countingTranslator{}
+
+	if _, hit, _ := cache.Translate(ctx, tr, docRequest("Setup", "body", "fr-CA")); hit {
+		t.Fatal("first translation reported a cache hit")
+	}
+	resp, hit, err := cache.Translate(ctx, tr, docRequest("Setup", "body", "fr-CA"))
+	if err != nil || !hit {
+		t.Fatalf("expected cache hit, got hit=%v err=%v", hit, err)
+	}
+	if resp.TranslatedMarkdown != "fr body" || resp.TokensUsed != 0 || resp.EngineVersion != "model-v1" {
+		t.Errorf("unexpected cached response: %+v", resp)
+	}
+	if tr.calls["doc-translate"] != 1 {
+		t.Errorf("backend called %d times, want 1", tr.calls["doc-translate"])
+	}
+
+	// Same body under another title only needs the title translated
+	resp, hit, _ = cache.Translate(ctx, tr, docRequest("Install", "body", "fr-CA"))
+	if !hit || resp.TranslatedTitle != "fr Install" || tr.calls["title"] != 1 {
+		t.Errorf("expected title-only translation, got hit=%v resp=%+v calls=%v", hit, resp, tr.calls)
+	}
+
+	// Language is part of the key
+	if _, hit, _ := cache.Translate(ctx, tr, docRequest("Setup", "body", "es")); hit {
+		t.Error("different language served from cache")
+	}
+
+	// MaxEntries evicts the least recently used entry
+	cache.Translate(ctx, tr, docRequest("Other", "other body", "fr-CA"))
+	if cache.Len() != 2 {
+		t.Errorf("cache holds %d entries, want 2", cache.Len())
+	}
+	if _, hit, _ := cache.Translate(ctx, tr, docRequest("Setup", "body", "fr-CA")); hit {
+		t.Error("evicted entry served from cache")
+	}
+}
+
+func TestTranslationCacheContext(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewTranslationCache(CacheConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := &countingTranslator{}
+
+	cache.Translate(ctx, tr, docRequest("Setup", "body", "fr"))
+	req := docRequest("Setup", "body", "fr")
+	req.Context = "Audience: field technicians"
+	if _, hit, _ := cache.Translate(ctx, tr, req); hit {
+		t.Error("translation without context served for a request with context")
+	}
+	if _, hit, _ := cache.Translate(ctx, tr, req); !hit {
+		t.Error("same context not served from cache")
+	}
+
+	req.Document.Metadata = map[string]string{"collection": "docs"}
+	metadata := documentMetadata(req)
+	if metadata[ContextMetadataKey] != req.Context || metadata["collection"] != "docs" {
+		t.Errorf("unexpected metadata: %v", metadata)
+	}
+	if _, ok := req.Document.Metadata[ContextMetadataKey]; ok {
+		t.Error("documentMetadata modified the request's map")
+	}
+}
+
+func TestTranslationCacheTTLAndPersistence(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.json")
+	tr := &countingTranslator{}
+
+	cache, err := NewTranslationCache(CacheConfig{Path: path, TTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Translate(ctx, tr, docRequest("Setup", "body", "fr-CA"))
+
+	reloaded, err := NewTranslationCache(CacheConfig{Path: path, TTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, hit, _ := reloaded.Translate(ctx, tr, docRequest("Setup", "body", "fr-CA")); !hit {
+		t.Error("persisted entry not served after reload")
+	}
+
+	expired, err := NewTranslationCache(CacheConfig{Path: path, TTL: time.Nanosecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expired.Len() != 0 {
+		t.Errorf("expired entries loaded: %d", expired.Len())
+	}
+}
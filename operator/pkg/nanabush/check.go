@@ -0,0 +1,71 @@
+package nanabush
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const defaultMinTranslationRatio = 0.1
+
+// ErrSuspiciousTranslation is returned by CheckTranslation for a successful
+// response whose markdown is empty or far shorter than the source.
+var ErrSuspiciousTranslation = errors.New("nanabush: suspicious translation")
+
+// TranslationCheckConfig tunes CheckTranslation. Zero values use the defaults.
+type TranslationCheckConfig struct {
+	// MinLengthRatio is the shortest translated markdown accepted, as a
+	// fraction of the source's length in characters (default: 0.1). A
+	// negative value turns the length check off; empty output is still
+	// rejected.
+	MinLengthRatio float64
+}
+
+// withDefaults fills in unset values.
+func (cfg TranslationCheckConfig) withDefaults() TranslationCheckConfig {
+	if cfg.MinLengthRatio == 0 {
+		cfg.MinLengthRatio = defaultMinTranslationRatio
+	}
+	return cfg
+}
+
+// TranslationCheckConfigFromEnv reads NANABUSH_MIN_TRANSLATION_RATIO. Unset or
+// invalid values are left at zero so the default applies.
+func TranslationCheckConfigFromEnv() TranslationCheckConfig {
+	var cfg TranslationCheckConfig
+	if v, err := strconv.ParseFloat(os.Getenv("NANABUSH_MIN_TRANSLATION_RATIO"), 64); err == nil {
+		cfg.MinLengthRatio = v
+	}
+	return cfg
+}
+
+// CheckTranslation reports an error wrapping ErrSuspiciousTranslation when
+// translated, the markdown a service returned for source, is blank or
+// shorter than cfg allows. A backend can report success without having
+// translated anything, and publishing that would replace a page with nothing.
+func CheckTranslation(source, translated string, cfg TranslationCheckConfig) error {
+	cfg = cfg.withDefaults()
+	source = strings.TrimSpace(source)
+	translated = strings.TrimSpace(translated)
+	if source == "" {
+		return nil
+	}
+	if translated == "" {
+		return fmt.Errorf("%w: service reported success but returned no content", ErrSuspiciousTranslation)
+	}
+	if cfg.MinLengthRatio < 0 {
+		return nil
+	}
+	// Characters rather than bytes, so scripts that need more bytes per
+	// character aren't measured unfairly
+	sourceLen := utf8.RuneCountInString(source)
+	translatedLen := utf8.RuneCountInString(translated)
+	if float64(translatedLen) < cfg.MinLengthRatio*float64(sourceLen) {
+		return fmt.Errorf("%w: %d characters returned for %d of source, under the minimum ratio of %g",
+			ErrSuspiciousTranslation, translatedLen, sourceLen, cfg.MinLengthRatio)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package nanabush
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckTranslation(t *testing.T) {
+	source := strings.Repeat("word ", 100)
+	cases := []struct {
+		name       string
+		source     string
+		translated string
+		cfg        TranslationCheckConfig
+		suspicious bool
+	}{
+		{name: "full translation", source: source, translated: strings.Repeat("mot ", 100)},
+		{name: "empty", source: source, translated: "", suspicious: true},
+		{name: "whitespace only", source: source, translated: " \n\t", suspicious: true},
+		{name: "under the default ratio", source: source, translated: "truncated", suspicious: true},
+		{name: "just over the default ratio", source: source, translated: strings.Repeat("x", 50)},
+		{name: "under a configured ratio", source: source, translated: strings.Repeat("x", 200),
+			cfg: TranslationCheckConfig{MinLengthRatio: 0.5}, suspicious: true},
+		{name: "length check off", source: source, translated: "short", cfg: TranslationCheckConfig{MinLengthRatio: -1}},
+		{name: "length check off still rejects empty", source: source, translated: "",
+			cfg: TranslationCheckConfig{MinLengthRatio: -1}, suspicious: true},
+		{name: "empty source", source: "", translated: ""},
+		// 25 characters, though 75 bytes
+		{name: "counted in characters", source: strings.Repeat("a", 200), translated: strings.Repeat("日", 25)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckTranslation(tc.source, tc.translated, tc.cfg)
+			if got := errors.Is(err, ErrSuspiciousTranslation); got != tc.suspicious {
+				t.Errorf("CheckTranslation() = %v, want suspicious=%v", err, tc.suspicious)
+			}
+		})
+	}
+}
+
+func TestTranslationCheckConfigFromEnv(t *testing.T) {
+	t.Setenv("NANABUSH_MIN_TRANSLATION_RATIO", "0.25")
+	if cfg := TranslationCheckConfigFromEnv(); cfg.MinLengthRatio != 0.25 {
+		t.Errorf("MinLengthRatio = %v, want 0.25", cfg.MinLengthRatio)
+	}
+	t.Setenv("NANABUSH_MIN_TRANSLATION_RATIO", "lots")
+	if cfg := TranslationCheckConfigFromEnv(); cfg.MinLengthRatio != 0 {
+		t.Errorf("invalid value: MinLengthRatio = %v, want 0", cfg.MinLengthRatio)
+	}
+}
+
+// blankTranslator reports success without translating anything.
+type blankTranslator struct{}
+
+func (blankTranslator) Translate(_ context.Context, req TranslateRequest) (*TranslateResponse, error) {
+	return &TranslateResponse{Success: true, TranslatedTitle: req.Document.Title}, nil
+}
+
+func TestTranslationCacheSkipsSuspiciousTranslations(t *testing.T) {
+	cache, err := NewTranslationCache(CacheConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cache.Translate(context.Background(), blankTranslator{}, docRequest("Setup", "body", "fr-CA")); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("cache holds %d entries, want the blank translation left out", cache.Len())
+	}
+}
@@ -1,18 +1,35 @@
 package nanabush
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/utils/clock"
 
 	nanabushv1 "github.com/dasmlab/glooscap-operator/pkg/nanabush/proto/v1"
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+// Response metadata a translation service can send, as gRPC headers or
+// trailers of a Translate call, to say which engine and version produced the
+// translation. The proto has no fields for them, so older services keep
+// working unchanged.
+const (
+	MetadataEngine        = "x-translation-engine"
+	MetadataEngineVersion = "x-translation-engine-version"
 )
 
 // Client is a gRPC client for communicating with the Nanabush translation service.
@@ -20,8 +37,13 @@ type Client struct {
 	conn   *grpc.ClientConn
 	addr   string
 	secure bool
+	// creds are the transport credentials, reused when reconnecting
+	creds  credentials.TransportCredentials
 	client nanabushv1.TranslationServiceClient
 
+	// serviceType is Config.ServiceType
+	serviceType string
+
 	// Registration
 	clientID      string
 	clientName    string
@@ -47,22 +69,42 @@ type Client struct {
 	// Limit to 2 concurrent requests to prevent overwhelming the service
 	translateSemaphore chan struct{}
 	maxConcurrentTranslate int
+
+	// Heartbeat health thresholds
+	heartbeatCfg HeartbeatConfig
+
+	// Target languages accepted by the service (nil = unrestricted)
+	supportedLanguages []string
+
+	// Standby clients, in configured order (nil until connected)
+	fallbacks    []*Client
+	fallbackStop chan struct{}
+
+	// clock times heartbeats and judges their staleness
+	clock clock.WithTicker
+
+	// breaker fails Translate and CheckTitle fast while the service is down
+	breaker *circuitBreaker
 }
 
 // Config contains configuration for the Nanabush client.
 type Config struct {
 	// Address is the gRPC server address (e.g., "nanabush-service.nanabush.svc:50051")
 	Address string
-	// Secure enables TLS/mTLS (default: false for now)
+	// Secure enables TLS, or mTLS when TLSCertPath and TLSKeyPath are set
 	Secure bool
 	// TLSCertPath is the path to the client TLS certificate (for mTLS)
 	TLSCertPath string
 	// TLSKeyPath is the path to the client TLS private key
 	TLSKeyPath string
-	// TLSCAPath is the path to the CA certificate for server verification
+	// TLSCAPath is the path to the CA certificate for server verification;
+	// empty uses the system roots
 	TLSCAPath string
 	// Timeout is the connection timeout
 	Timeout time.Duration
+	// ServiceType is the kind of service (e.g., "iskoces", "nanabush"),
+	// reported as the engine of translations whose response names none
+	ServiceType string
 
 	// Client registration
 	ClientName    string            // Name of the client (e.g., "glooscap")
@@ -72,6 +114,74 @@ type Config struct {
 
 	// OnStatusChange is called when the client status changes (connect, disconnect, heartbeat, etc.)
 	OnStatusChange func(Status)
+
+	// Heartbeat tunes how missed heartbeats affect the reported status
+	Heartbeat HeartbeatConfig
+
+	// Circuit tunes the circuit breaker in front of Translate and CheckTitle
+	Circuit CircuitConfig
+
+	// SupportedLanguages lists the target languages the service accepts (e.g., "fr-CA", "es").
+	// Empty means any language is accepted.
+	SupportedLanguages []string
+
+	// Fallbacks are standby endpoints that Translate and CheckTitle route to
+	// while the primary is in an error state.
+	Fallbacks []Endpoint
+
+	// Clock drives heartbeat timing and staleness. Nil uses the real clock.
+	Clock clock.WithTicker
+}
+
+// HeartbeatConfig controls heartbeat health thresholds. Zero values use the defaults.
+type HeartbeatConfig struct {
+	// WarningThreshold is the number of missed heartbeats before status becomes "warning" (default: 1)
+	WarningThreshold int
+	// ErrorThreshold is the number of missed heartbeats before status becomes "error" (default: 3)
+	ErrorThreshold int
+	// WatchdogMultiplier is how many heartbeat intervals may pass without a heartbeat
+	// before the watchdog counts a miss (default: 2)
+	WatchdogMultiplier float64
+}
+
+const (
+	defaultHeartbeatWarningThreshold = 1
+	defaultHeartbeatErrorThreshold   = 3
+	defaultWatchdogMultiplier        = 2.0
+)
+
+// withDefaults fills in unset thresholds and keeps warning <= error.
+func (h HeartbeatConfig) withDefaults() HeartbeatConfig {
+	if h.WarningThreshold <= 0 {
+		h.WarningThreshold = defaultHeartbeatWarningThreshold
+	}
+	if h.ErrorThreshold <= 0 {
+		h.ErrorThreshold = defaultHeartbeatErrorThreshold
+	}
+	if h.WarningThreshold > h.ErrorThreshold {
+		h.WarningThreshold = h.ErrorThreshold
+	}
+	if h.WatchdogMultiplier <= 0 {
+		h.WatchdogMultiplier = defaultWatchdogMultiplier
+	}
+	return h
+}
+
+// HeartbeatConfigFromEnv reads heartbeat thresholds from NANABUSH_HEARTBEAT_WARNING_MISSES,
+// NANABUSH_HEARTBEAT_ERROR_MISSES and NANABUSH_WATCHDOG_MULTIPLIER. Unset or invalid values
+// are left at zero so the defaults apply.
+func HeartbeatConfigFromEnv() HeartbeatConfig {
+	var h HeartbeatConfig
+	if v, err := strconv.Atoi(os.Getenv("NANABUSH_HEARTBEAT_WARNING_MISSES")); err == nil {
+		h.WarningThreshold = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("NANABUSH_HEARTBEAT_ERROR_MISSES")); err == nil {
+		h.ErrorThreshold = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("NANABUSH_WATCHDOG_MULTIPLIER"), 64); err == nil {
+		h.WatchdogMultiplier = v
+	}
+	return h
 }
 
 // NewClient creates a new Nanabush gRPC client and automatically registers with the server.
@@ -93,16 +203,13 @@ func NewClient(cfg Config) (*Client, error) {
 	maxConcurrent := 2
 	translateSemaphore := make(chan struct{}, maxConcurrent)
 
-	var opts []grpc.DialOption
-
 	// Configure TLS/mTLS
-	if cfg.Secure {
-		// TODO: Load TLS credentials from cfg.TLSCertPath, cfg.TLSKeyPath, cfg.TLSCAPath
-		// For now, use insecure for development
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	} else {
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := transportCredentials(cfg)
+	if err != nil {
+		verbosity.Printf("[nanabush] Failed to load TLS credentials for %s: %v\n", cfg.Address, err)
+		return nil, err
 	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 
 	// Configure keepalive for connection health monitoring
 	opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
@@ -117,12 +224,12 @@ func NewClient(cfg Config) (*Client, error) {
 	defer cancel()
 
 	// Log connection attempt
-	fmt.Printf("[nanabush] Attempting gRPC connection to %s (secure=%v, timeout=%v)\n",
+	verbosity.Printf("[nanabush] Attempting gRPC connection to %s (secure=%v, timeout=%v)\n",
 		cfg.Address, cfg.Secure, timeout)
 
 	conn, err := grpc.DialContext(ctx, cfg.Address, opts...)
 	if err != nil {
-		fmt.Printf("[nanabush] Failed to dial %s: %v\n", cfg.Address, err)
+		verbosity.Printf("[nanabush] Failed to dial %s: %v\n", cfg.Address, err)
 		return nil, fmt.Errorf("nanabush: dial %s: %w", cfg.Address, err)
 	}
 
@@ -130,12 +237,12 @@ func NewClient(cfg Config) (*Client, error) {
 
 	// Log connection state
 	state := conn.GetState()
-	fmt.Printf("[nanabush] gRPC connection established to %s (state: %s)\n", cfg.Address, state.String())
+	verbosity.Printf("[nanabush] gRPC connection established to %s (state: %s)\n", cfg.Address, state.String())
 
 	// Wait for connection to be ready before proceeding
 	// This ensures the connection is fully established before we try to register
 	if state != connectivity.Ready {
-		fmt.Printf("[nanabush] Connection not ready (state: %s), waiting for Ready state...\n", state.String())
+		verbosity.Printf("[nanabush] Connection not ready (state: %s), waiting for Ready state...\n", state.String())
 		ctxReady, cancelReady := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancelReady()
 
@@ -144,22 +251,22 @@ func NewClient(cfg Config) (*Client, error) {
 			if !conn.WaitForStateChange(ctxReady, state) {
 				// Timeout or context cancelled
 				newState := conn.GetState()
-				fmt.Printf("[nanabush] Connection state wait timeout/cancelled, current state: %s\n", newState.String())
+				verbosity.Printf("[nanabush] Connection state wait timeout/cancelled, current state: %s\n", newState.String())
 				if newState == connectivity.Ready {
 					break
 				}
 				// If not ready, we'll try anyway but log a warning
-				fmt.Printf("[nanabush] Warning: Proceeding with registration despite connection not being Ready (state: %s)\n", newState.String())
+				verbosity.Printf("[nanabush] Warning: Proceeding with registration despite connection not being Ready (state: %s)\n", newState.String())
 				break
 			}
 			newState := conn.GetState()
-			fmt.Printf("[nanabush] Connection state changed: %s -> %s\n", state.String(), newState.String())
+			verbosity.Debugf("[nanabush] Connection state changed: %s -> %s\n", state.String(), newState.String())
 			if newState == connectivity.Ready {
-				fmt.Printf("[nanabush] Connection is now Ready!\n")
+				verbosity.Debugf("[nanabush] Connection is now Ready!\n")
 				break
 			}
 			if newState == connectivity.TransientFailure || newState == connectivity.Shutdown {
-				fmt.Printf("[nanabush] Connection failed or shutdown (state: %s), registration will likely fail\n", newState.String())
+				verbosity.Printf("[nanabush] Connection failed or shutdown (state: %s), registration will likely fail\n", newState.String())
 				break
 			}
 			// Update state for next iteration
@@ -174,6 +281,8 @@ func NewClient(cfg Config) (*Client, error) {
 		conn:                   conn,
 		addr:                   cfg.Address,
 		secure:                 cfg.Secure,
+		creds:                  creds,
+		serviceType:            cfg.ServiceType,
 		client:                 client,
 		clientName:             cfg.ClientName,
 		clientVersion:          cfg.ClientVersion,
@@ -184,45 +293,60 @@ func NewClient(cfg Config) (*Client, error) {
 		onStatusChange:         cfg.OnStatusChange,
 		translateSemaphore:     translateSemaphore,
 		maxConcurrentTranslate: maxConcurrent,
+		heartbeatCfg:           cfg.Heartbeat.withDefaults(),
+		supportedLanguages:     normalizeLanguages(cfg.SupportedLanguages),
+		fallbacks:              make([]*Client, len(cfg.Fallbacks)),
+		fallbackStop:           make(chan struct{}),
+		clock:                  cfg.Clock,
+	}
+	if c.clock == nil {
+		c.clock = clock.RealClock{}
 	}
+	c.breaker = newCircuitBreaker(cfg.Circuit, c.clock)
 
 	// Register with server
-	fmt.Printf("[nanabush] Registering client: name=%q, version=%q, namespace=%q\n",
+	verbosity.Debugf("[nanabush] Registering client: name=%q, version=%q, namespace=%q\n",
 		cfg.ClientName, cfg.ClientVersion, cfg.Namespace)
-	fmt.Printf("[nanabush] About to call c.register(ctx)\n")
+	verbosity.Debugf("[nanabush] About to call c.register(ctx)\n")
 	registerErr = c.register(ctx)
-	fmt.Printf("[nanabush] c.register(ctx) returned, err=%v\n", registerErr)
+	verbosity.Debugf("[nanabush] c.register(ctx) returned, err=%v\n", registerErr)
 	if registerErr != nil {
 		conn.Close()
-		fmt.Printf("[nanabush] Registration failed: %v\n", registerErr)
+		verbosity.Printf("[nanabush] Registration failed: %v\n", registerErr)
 		return nil, fmt.Errorf("nanabush: register: %w", registerErr)
 	}
 
 	// Call onStatusChange callback AFTER register() releases the lock
 	if c.onStatusChange != nil {
-		fmt.Printf("[nanabush] Calling onStatusChange callback after registration\n")
+		verbosity.Debugf("[nanabush] Calling onStatusChange callback after registration\n")
 		defer func() {
 			if r := recover(); r != nil {
-				fmt.Printf("[nanabush] PANIC in onStatusChange callback: %v\n", r)
+				verbosity.Printf("[nanabush] PANIC in onStatusChange callback: %v\n", r)
 			}
 		}()
 		c.onStatusChange(c.Status())
-		fmt.Printf("[nanabush] onStatusChange callback completed\n")
+		verbosity.Debugf("[nanabush] onStatusChange callback completed\n")
 	}
 
-	fmt.Printf("[nanabush] ✅ register() returned successfully, continuing in NewClient()\n")
-	fmt.Printf("[nanabush] Client registered successfully: client_id=%q, heartbeat_interval=%v\n",
+	verbosity.Debugf("[nanabush] ✅ register() returned successfully, continuing in NewClient()\n")
+	verbosity.Printf("[nanabush] Client registered successfully: client_id=%q, heartbeat_interval=%v\n",
 		c.clientID, c.heartbeatInterval)
 
 	// Start heartbeat goroutine (interval may have been updated during registration)
-	fmt.Printf("[nanabush] About to call startHeartbeat()\n")
+	verbosity.Debugf("[nanabush] About to call startHeartbeat()\n")
 	c.startHeartbeat()
-	fmt.Printf("[nanabush] Heartbeat goroutine started (interval: %v)\n", c.heartbeatInterval)
+	verbosity.Debugf("[nanabush] Heartbeat goroutine started (interval: %v)\n", c.heartbeatInterval)
 
 	// Start watchdog goroutine to monitor for missed heartbeats
-	fmt.Printf("[nanabush] About to call startHeartbeatWatchdog()\n")
+	verbosity.Debugf("[nanabush] About to call startHeartbeatWatchdog()\n")
 	c.startHeartbeatWatchdog()
-	fmt.Printf("[nanabush] Heartbeat watchdog started\n")
+	verbosity.Debugf("[nanabush] Heartbeat watchdog started\n")
+
+	// Connect standby endpoints in the background
+	if len(cfg.Fallbacks) > 0 {
+		c.heartbeatWg.Add(1)
+		go c.connectFallbacks(cfg)
+	}
 
 	// Notify initial status after successful registration
 	if c.onStatusChange != nil {
@@ -237,7 +361,7 @@ func (c *Client) register(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	fmt.Printf("[nanabush] Calling RegisterClient RPC: name=%q, version=%q, namespace=%q\n",
+	verbosity.Debugf("[nanabush] Calling RegisterClient RPC: name=%q, version=%q, namespace=%q\n",
 		c.clientName, c.clientVersion, c.namespace)
 
 	req := &nanabushv1.RegisterClientRequest{
@@ -250,29 +374,29 @@ func (c *Client) register(ctx context.Context) error {
 
 	// Check connection state before making RPC call
 	connState := c.conn.GetState()
-	fmt.Printf("[nanabush] Connection state before RegisterClient: %s\n", connState.String())
+	verbosity.Debugf("[nanabush] Connection state before RegisterClient: %s\n", connState.String())
 
 	if connState != connectivity.Ready && connState != connectivity.Idle {
-		fmt.Printf("[nanabush] Warning: Connection not in Ready/Idle state (state: %s), RPC may fail\n", connState.String())
+		verbosity.Printf("[nanabush] Warning: Connection not in Ready/Idle state (state: %s), RPC may fail\n", connState.String())
 	}
 
-	fmt.Printf("[nanabush] RegisterClient request sent, waiting for response...\n")
+	verbosity.Debugf("[nanabush] RegisterClient request sent, waiting for response...\n")
 	startTime := time.Now()
 	resp, err := c.client.RegisterClient(ctx, req)
 	duration := time.Since(startTime)
 
 	if err != nil {
-		fmt.Printf("[nanabush] RegisterClient RPC failed after %v: %v\n", duration, err)
-		fmt.Printf("[nanabush] Connection state after error: %s\n", c.conn.GetState().String())
+		verbosity.Printf("[nanabush] RegisterClient RPC failed after %v: %v\n", duration, err)
+		verbosity.Printf("[nanabush] Connection state after error: %s\n", c.conn.GetState().String())
 		return fmt.Errorf("register client: %w", err)
 	}
 
-	fmt.Printf("[nanabush] RegisterClient response received after %v: success=%v, client_id=%q, message=%q, heartbeat_interval=%ds\n",
+	verbosity.Debugf("[nanabush] RegisterClient response received after %v: success=%v, client_id=%q, message=%q, heartbeat_interval=%ds\n",
 		duration, resp.Success, resp.ClientId, resp.Message, resp.HeartbeatIntervalSeconds)
-	fmt.Printf("[nanabush] Connection state after successful response: %s\n", c.conn.GetState().String())
+	verbosity.Debugf("[nanabush] Connection state after successful response: %s\n", c.conn.GetState().String())
 
 	if !resp.Success {
-		fmt.Printf("[nanabush] Registration failed: %s\n", resp.Message)
+		verbosity.Printf("[nanabush] Registration failed: %s\n", resp.Message)
 		return fmt.Errorf("registration failed: %s", resp.Message)
 	}
 
@@ -283,22 +407,22 @@ func (c *Client) register(ctx context.Context) error {
 	if resp.HeartbeatIntervalSeconds > 0 {
 		oldInterval := c.heartbeatInterval
 		c.heartbeatInterval = time.Duration(resp.HeartbeatIntervalSeconds) * time.Second
-		fmt.Printf("[nanabush] Heartbeat interval updated: %v -> %v\n", oldInterval, c.heartbeatInterval)
+		verbosity.Printf("[nanabush] Heartbeat interval updated: %v -> %v\n", oldInterval, c.heartbeatInterval)
 	} else {
 		// Server didn't provide interval or returned 0, keep default but log warning
-		fmt.Printf("[nanabush] Warning: Server returned invalid heartbeat interval (%d), using default: %v\n",
+		verbosity.Printf("[nanabush] Warning: Server returned invalid heartbeat interval (%d), using default: %v\n",
 			resp.HeartbeatIntervalSeconds, c.heartbeatInterval)
 	}
 
 	// Ensure heartbeat interval is valid (at least 1 second)
 	if c.heartbeatInterval < 1*time.Second {
-		fmt.Printf("[nanabush] Warning: Heartbeat interval too small (%v), setting to minimum 1 second\n", c.heartbeatInterval)
+		verbosity.Printf("[nanabush] Warning: Heartbeat interval too small (%v), setting to minimum 1 second\n", c.heartbeatInterval)
 		c.heartbeatInterval = 1 * time.Second
 	}
 
-	fmt.Printf("[nanabush] Client registration complete: client_id=%q, registered=%v\n", c.clientID, c.registered)
+	verbosity.Debugf("[nanabush] Client registration complete: client_id=%q, registered=%v\n", c.clientID, c.registered)
 
-	fmt.Printf("[nanabush] register() about to return nil\n")
+	verbosity.Debugf("[nanabush] register() about to return nil\n")
 	return nil
 }
 
@@ -309,7 +433,7 @@ func (c *Client) startHeartbeat() {
 		defer func() {
 			// Recover from any panics in the heartbeat goroutine
 			if r := recover(); r != nil {
-				fmt.Printf("[nanabush] PANIC in heartbeat goroutine: %v\n", r)
+				verbosity.Printf("[nanabush] PANIC in heartbeat goroutine: %v\n", r)
 			}
 			c.heartbeatWg.Done()
 		}()
@@ -323,37 +447,37 @@ func (c *Client) startHeartbeat() {
 
 		// Validate interval before starting
 		if initialInterval < 1*time.Second {
-			fmt.Printf("[nanabush] ERROR: Cannot start heartbeat goroutine with invalid interval: %v\n", initialInterval)
+			verbosity.Printf("[nanabush] ERROR: Cannot start heartbeat goroutine with invalid interval: %v\n", initialInterval)
 			return
 		}
 
 		if !registered || clientID == "" {
-			fmt.Printf("[nanabush] ERROR: Cannot start heartbeat goroutine: not registered (registered=%v, client_id=%q)\n",
+			verbosity.Printf("[nanabush] ERROR: Cannot start heartbeat goroutine: not registered (registered=%v, client_id=%q)\n",
 				registered, clientID)
 			return
 		}
 
-		fmt.Printf("[nanabush] Starting heartbeat goroutine with interval: %v, client_id=%q\n", initialInterval, clientID)
+		verbosity.Debugf("[nanabush] Starting heartbeat goroutine with interval: %v, client_id=%q\n", initialInterval, clientID)
 
 		// Use a dynamic ticker that can be updated if interval changes
-		ticker := time.NewTicker(initialInterval)
+		ticker := c.clock.NewTicker(initialInterval)
 		defer ticker.Stop()
 
 		// Track last tick time and current ticker interval for debugging
-		lastTickTime := time.Now()
+		lastTickTime := c.clock.Now()
 		tickCount := 0
 		currentTickerInterval := initialInterval
 
 		// Log that we're ready to send heartbeats
-		fmt.Printf("[nanabush] Heartbeat goroutine ready, will send first heartbeat in %v\n", initialInterval)
+		verbosity.Debugf("[nanabush] Heartbeat goroutine ready, will send first heartbeat in %v\n", initialInterval)
 
 		for {
 			select {
-			case <-ticker.C:
+			case <-ticker.C():
 				tickCount++
-				now := time.Now()
+				now := c.clock.Now()
 				timeSinceLastTick := now.Sub(lastTickTime)
-				fmt.Printf("[nanabush] Heartbeat ticker fired (#%d): interval=%v, time_since_last_tick=%v\n",
+				verbosity.Debugf("[nanabush] Heartbeat ticker fired (#%d): interval=%v, time_since_last_tick=%v\n",
 					tickCount, currentTickerInterval, timeSinceLastTick.Round(time.Millisecond))
 				lastTickTime = now
 
@@ -365,18 +489,18 @@ func (c *Client) startHeartbeat() {
 				c.mu.RUnlock()
 				if currentTickerInterval != desiredInterval {
 					if desiredInterval < 1*time.Second {
-						fmt.Printf("[nanabush] ERROR: Cannot update ticker to invalid interval: %v, keeping current: %v\n",
+						verbosity.Printf("[nanabush] ERROR: Cannot update ticker to invalid interval: %v, keeping current: %v\n",
 							desiredInterval, currentTickerInterval)
 					} else {
-						fmt.Printf("[nanabush] Heartbeat interval changed, recreating ticker: %v -> %v\n", currentTickerInterval, desiredInterval)
+						verbosity.Printf("[nanabush] Heartbeat interval changed, recreating ticker: %v -> %v\n", currentTickerInterval, desiredInterval)
 						ticker.Stop()
-						ticker = time.NewTicker(desiredInterval)
+						ticker = c.clock.NewTicker(desiredInterval)
 						currentTickerInterval = desiredInterval
-						lastTickTime = time.Now() // Reset tick time
+						lastTickTime = c.clock.Now() // Reset tick time
 					}
 				}
 			case <-c.heartbeatStop:
-				fmt.Printf("[nanabush] Heartbeat goroutine stopping (sent %d heartbeats)\n", tickCount)
+				verbosity.Printf("[nanabush] Heartbeat goroutine stopping (sent %d heartbeats)\n", tickCount)
 				return
 			}
 		}
@@ -390,12 +514,12 @@ func (c *Client) startHeartbeatWatchdog() {
 		defer c.heartbeatWg.Done()
 
 		checkInterval := 5 * time.Second // Check every 5 seconds
-		ticker := time.NewTicker(checkInterval)
+		ticker := c.clock.NewTicker(checkInterval)
 		defer ticker.Stop()
 
 		for {
 			select {
-			case <-ticker.C:
+			case <-ticker.C():
 				c.mu.RLock()
 				lastHeartbeat := c.lastHeartbeatTime
 				interval := c.heartbeatInterval
@@ -407,12 +531,13 @@ func (c *Client) startHeartbeatWatchdog() {
 					continue // Not registered yet, skip check
 				}
 
-				now := time.Now()
+				now := c.clock.Now()
 				timeSinceLastHeartbeat := now.Sub(lastHeartbeat)
-				threshold := interval * 2 // Alert if no heartbeat in 2x the interval
+				// Alert if no heartbeat within WatchdogMultiplier x the interval
+				threshold := time.Duration(float64(interval) * c.heartbeatCfg.WatchdogMultiplier)
 
 				if !lastHeartbeat.IsZero() && timeSinceLastHeartbeat > threshold {
-					fmt.Printf("[nanabush] ⚠️  WARNING: No heartbeat received in %v (threshold: %v, last: %v)\n",
+					verbosity.Printf("[nanabush] ⚠️  WARNING: No heartbeat received in %v (threshold: %v, last: %v)\n",
 						timeSinceLastHeartbeat, threshold, lastHeartbeat.Format(time.RFC3339))
 					// Increment missed heartbeats
 					c.mu.Lock()
@@ -423,7 +548,7 @@ func (c *Client) startHeartbeatWatchdog() {
 						c.onStatusChange(c.Status())
 					}
 				} else if !lastHeartbeat.IsZero() {
-					fmt.Printf("[nanabush] ✓ Heartbeat OK: last received %v ago (threshold: %v)\n",
+					verbosity.Debugf("[nanabush] ✓ Heartbeat OK: last received %v ago (threshold: %v)\n",
 						timeSinceLastHeartbeat.Round(time.Second), threshold.Round(time.Second))
 				}
 			case <-c.heartbeatStop:
@@ -442,11 +567,11 @@ func (c *Client) sendHeartbeat() {
 	c.mu.RUnlock()
 
 	if !registered || clientID == "" {
-		fmt.Printf("[nanabush] Skipping heartbeat: registered=%v, client_id=%q\n", registered, clientID)
+		verbosity.Debugf("[nanabush] Skipping heartbeat: registered=%v, client_id=%q\n", registered, clientID)
 		return
 	}
 
-	fmt.Printf("[nanabush] 📤 Sending heartbeat: client_id=%q, client_name=%q, time=%v\n",
+	verbosity.Debugf("[nanabush] 📤 Sending heartbeat: client_id=%q, client_name=%q, time=%v\n",
 		clientID, clientName, time.Now().Format(time.RFC3339))
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -463,10 +588,10 @@ func (c *Client) sendHeartbeat() {
 		// (Iskoces will wait 60 seconds before dropping the client, giving time for translations to complete)
 		ongoingTranslations := c.maxConcurrentTranslate - len(c.translateSemaphore)
 		if ongoingTranslations > 0 {
-			fmt.Printf("[nanabush] ⚠️  Heartbeat failed (out of band): client_id=%q, error=%v, but %d translation(s) in progress - connection remains open, will retry next heartbeat\n", clientID, err, ongoingTranslations)
+			verbosity.Printf("[nanabush] ⚠️  Heartbeat failed (out of band): client_id=%q, error=%v, but %d translation(s) in progress - connection remains open, will retry next heartbeat\n", clientID, err, ongoingTranslations)
 			c.mu.Lock()
 			c.missedHeartbeats++ // Increment missed heartbeats on error
-			fmt.Printf("[nanabush] Missed heartbeats: %d\n", c.missedHeartbeats)
+			verbosity.Printf("[nanabush] Missed heartbeats: %d\n", c.missedHeartbeats)
 			c.mu.Unlock()
 			// Notify status change on error
 			if c.onStatusChange != nil {
@@ -478,11 +603,11 @@ func (c *Client) sendHeartbeat() {
 		}
 		
 		// No active translations - safe to re-register
-		fmt.Printf("[nanabush] Heartbeat failed: client_id=%q, error=%v (no active translations, re-registering)\n", clientID, err)
+		verbosity.Printf("[nanabush] Heartbeat failed: client_id=%q, error=%v (no active translations, re-registering)\n", clientID, err)
 		c.mu.Lock()
 		c.registered = false
 		c.missedHeartbeats++ // Increment missed heartbeats on error
-		fmt.Printf("[nanabush] Missed heartbeats: %d\n", c.missedHeartbeats)
+		verbosity.Printf("[nanabush] Missed heartbeats: %d\n", c.missedHeartbeats)
 		c.mu.Unlock()
 
 		// Notify status change on error
@@ -491,12 +616,12 @@ func (c *Client) sendHeartbeat() {
 		}
 
 		// Try to reconnect and re-register
-		fmt.Printf("[nanabush] Attempting to reconnect and re-register...\n")
+		verbosity.Printf("[nanabush] Attempting to reconnect and re-register...\n")
 		go c.reconnectAndRegister()
 		return
 	}
 
-	fmt.Printf("[nanabush] Heartbeat acknowledged: client_id=%q, success=%v, message=%q\n",
+	verbosity.Debugf("[nanabush] Heartbeat acknowledged: client_id=%q, success=%v, message=%q\n",
 		clientID, resp.Success, resp.Message)
 
 	// Check if heartbeat was successful - if not, we need to re-register
@@ -505,7 +630,7 @@ func (c *Client) sendHeartbeat() {
 		// BUT: Don't re-register if there are ongoing translations (would break them by closing connection)
 		ongoingTranslations := c.maxConcurrentTranslate - len(c.translateSemaphore)
 		if ongoingTranslations > 0 {
-			fmt.Printf("[nanabush] ⚠️  Heartbeat failed (success=false, out of band): %s, but %d translation(s) in progress - connection remains open, will retry next heartbeat\n", resp.Message, ongoingTranslations)
+			verbosity.Printf("[nanabush] ⚠️  Heartbeat failed (success=false, out of band): %s, but %d translation(s) in progress - connection remains open, will retry next heartbeat\n", resp.Message, ongoingTranslations)
 			// Don't mark as unregistered or close connection - let translation complete
 			// Next heartbeat will retry, and if still failing after translations complete, we'll re-register then
 			c.mu.Lock()
@@ -518,7 +643,7 @@ func (c *Client) sendHeartbeat() {
 		}
 		
 		// No active translations - safe to re-register
-		fmt.Printf("[nanabush] Heartbeat failed (success=false): %s, triggering re-registration\n", resp.Message)
+		verbosity.Printf("[nanabush] Heartbeat failed (success=false): %s, triggering re-registration\n", resp.Message)
 		c.mu.Lock()
 		c.registered = false
 		c.mu.Unlock()
@@ -526,10 +651,10 @@ func (c *Client) sendHeartbeat() {
 		// Re-register
 		if err := c.register(context.Background()); err != nil {
 			// If registration fails, try to reconnect
-			fmt.Printf("[nanabush] Re-registration failed, attempting reconnect: %v\n", err)
+			verbosity.Printf("[nanabush] Re-registration failed, attempting reconnect: %v\n", err)
 			go c.reconnectAndRegister()
 		} else {
-			fmt.Printf("[nanabush] Re-registration successful after failed heartbeat\n")
+			verbosity.Printf("[nanabush] Re-registration successful after failed heartbeat\n")
 		}
 		return
 	}
@@ -539,7 +664,7 @@ func (c *Client) sendHeartbeat() {
 		// BUT: Don't re-register if there are ongoing translations (would break them by closing connection)
 		ongoingTranslations := c.maxConcurrentTranslate - len(c.translateSemaphore)
 		if ongoingTranslations > 0 {
-			fmt.Printf("[nanabush] ⚠️  Server requested re-registration, but %d translation(s) in progress - deferring (connection remains open)\n", ongoingTranslations)
+			verbosity.Printf("[nanabush] ⚠️  Server requested re-registration, but %d translation(s) in progress - deferring (connection remains open)\n", ongoingTranslations)
 			// Don't mark as unregistered or close connection - let translation complete
 			// Next heartbeat will retry, and if still failing after translations complete, we'll re-register then
 			c.mu.Lock()
@@ -577,22 +702,22 @@ func (c *Client) sendHeartbeat() {
 	c.mu.Lock()
 	previousMissed := c.missedHeartbeats
 	previousLastHeartbeat := c.lastHeartbeatTime
-	c.lastHeartbeatTime = time.Now()
+	c.lastHeartbeatTime = c.clock.Now()
 	c.missedHeartbeats = 0 // Reset missed heartbeats on success
 	c.mu.Unlock()
 
 	// Log heartbeat received
 	if previousLastHeartbeat.IsZero() {
-		fmt.Printf("[nanabush] ✓ First heartbeat received: client_id=%q, acknowledged at %v\n",
-			clientID, time.Now().Format(time.RFC3339))
+		verbosity.Printf("[nanabush] ✓ First heartbeat received: client_id=%q, acknowledged at %v\n",
+			clientID, c.clock.Now().Format(time.RFC3339))
 	} else {
-		timeSinceLast := time.Since(previousLastHeartbeat)
-		fmt.Printf("[nanabush] ✓ Heartbeat received: client_id=%q, time_since_last=%v, acknowledged at %v\n",
-			clientID, timeSinceLast.Round(time.Millisecond), time.Now().Format(time.RFC3339))
+		timeSinceLast := c.clock.Since(previousLastHeartbeat)
+		verbosity.Debugf("[nanabush] ✓ Heartbeat received: client_id=%q, time_since_last=%v, acknowledged at %v\n",
+			clientID, timeSinceLast.Round(time.Millisecond), c.clock.Now().Format(time.RFC3339))
 	}
 
 	if previousMissed > 0 {
-		fmt.Printf("[nanabush] Heartbeat recovered: client_id=%q, missed_heartbeats_reset=0\n", clientID)
+		verbosity.Printf("[nanabush] Heartbeat recovered: client_id=%q, missed_heartbeats_reset=0\n", clientID)
 	}
 
 	// Notify status change on successful heartbeat
@@ -625,7 +750,7 @@ func (c *Client) reconnectAndRegister() {
 		c.mu.Lock()
 		oldConn := c.conn
 		addr := c.addr
-		secure := c.secure
+		creds := c.creds
 		c.mu.Unlock()
 
 		if oldConn != nil {
@@ -642,13 +767,8 @@ func (c *Client) reconnectAndRegister() {
 		}
 
 		// Re-dial the server
-		var opts []grpc.DialOption
-		if secure {
-			// TODO: Load TLS credentials
-			opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		} else {
-			opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		}
+		// Same credentials as the first dial; a secure client never reconnects in plaintext
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 
 		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                30 * time.Second,
@@ -726,11 +846,20 @@ func (c *Client) Close() error {
 	if c.heartbeatStop != nil {
 		close(c.heartbeatStop)
 	}
+	if c.fallbackStop != nil {
+		select {
+		case <-c.fallbackStop:
+		default:
+			close(c.fallbackStop)
+		}
+	}
 	c.mu.Unlock()
 
 	// Wait for heartbeat goroutines to finish (don't copy WaitGroup)
 	c.heartbeatWg.Wait()
 
+	c.closeFallbacks()
+
 	// Close connection
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -763,6 +892,8 @@ type Status struct {
 	MissedHeartbeats  int       `json:"missedHeartbeats"`
 	HeartbeatInterval int64     `json:"heartbeatIntervalSeconds"`
 	Status            string    `json:"status"` // "healthy", "warning", "error"
+	// ActiveEndpoint is the address Translate/CheckTitle currently use
+	ActiveEndpoint string `json:"activeEndpoint,omitempty"`
 }
 
 // Status returns the current connection status.
@@ -770,7 +901,7 @@ func (c *Client) Status() Status {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	now := time.Now()
+	now := c.clock.Now()
 	// Check connection state - consider connected if:
 	// 1. Connection exists and is Ready, OR
 	// 2. Client is registered and has recent heartbeat (connection might be in transient state)
@@ -789,9 +920,9 @@ func (c *Client) Status() Status {
 	status := "error"
 	if !c.registered {
 		status = "error"
-	} else if c.missedHeartbeats >= 3 {
+	} else if c.missedHeartbeats >= c.heartbeatCfg.ErrorThreshold {
 		status = "error"
-	} else if c.missedHeartbeats >= 1 {
+	} else if c.missedHeartbeats >= c.heartbeatCfg.WarningThreshold {
 		status = "warning"
 	} else if hasRecentHeartbeat {
 		// Has recent heartbeat - healthy
@@ -804,7 +935,15 @@ func (c *Client) Status() Status {
 		status = "error"
 	}
 
+	activeEndpoint := c.addr
+	if status == "error" || c.circuitTripped() {
+		if fb := c.healthyFallbackLocked(); fb != nil {
+			activeEndpoint = fb.addr
+		}
+	}
+
 	return Status{
+		ActiveEndpoint:    activeEndpoint,
 		Connected:         effectivelyConnected, // Use effective connection state
 		Registered:        c.registered,
 		ClientID:          c.clientID,
@@ -815,6 +954,61 @@ func (c *Client) Status() Status {
 	}
 }
 
+// SupportedLanguages returns the target languages the service accepts. A nil
+// result means the list is unrestricted. The translation service has no
+// capabilities RPC, so this is the statically configured list cached on the client.
+func (c *Client) SupportedLanguages(ctx context.Context) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.supportedLanguages == nil {
+		return nil, nil
+	}
+	return append([]string(nil), c.supportedLanguages...), nil
+}
+
+// SetSupportedLanguages replaces the cached list of supported target languages.
+func (c *Client) SetSupportedLanguages(languages []string) {
+	normalized := normalizeLanguages(languages)
+	c.mu.Lock()
+	c.supportedLanguages = normalized
+	c.mu.Unlock()
+}
+
+// SupportsLanguage reports whether tag is an accepted target language. Tags
+// match case-insensitively, and a bare language ("fr") accepts any of its
+// regional variants ("fr-CA"). An unrestricted client accepts every tag.
+func (c *Client) SupportsLanguage(tag string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.supportedLanguages) == 0 {
+		return true
+	}
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	base, _, _ := strings.Cut(tag, "-")
+	for _, lang := range c.supportedLanguages {
+		if strings.EqualFold(lang, tag) || strings.EqualFold(lang, base) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeLanguages trims and de-duplicates language tags, returning nil for an empty list.
+func normalizeLanguages(languages []string) []string {
+	var out []string
+	seen := make(map[string]bool, len(languages))
+	for _, lang := range languages {
+		lang = strings.ReplaceAll(strings.TrimSpace(lang), "_", "-")
+		key := strings.ToLower(lang)
+		if lang == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, lang)
+	}
+	return out
+}
+
 // CheckTitleRequest represents a title-only pre-flight check.
 type CheckTitleRequest struct {
 	Title          string
@@ -832,14 +1026,21 @@ type CheckTitleResponse struct {
 // CheckTitle performs a lightweight pre-flight check with title only.
 // This validates that Nanabush is ready and can handle the request.
 func (c *Client) CheckTitle(ctx context.Context, req CheckTitleRequest) (*CheckTitleResponse, error) {
+	if fb := c.failoverTarget(); fb != nil {
+		return fb.CheckTitle(ctx, req)
+	}
 	if c.client == nil {
 		return nil, fmt.Errorf("nanabush: client not initialized")
 	}
 
-	resp, err := c.client.CheckTitle(ctx, &nanabushv1.TitleCheckRequest{
-		Title:          req.Title,
-		LanguageTag:    req.LanguageTag,
-		SourceLanguage: req.SourceLanguage,
+	var resp *nanabushv1.TitleCheckResponse
+	err := c.callService(func() (err error) {
+		resp, err = c.client.CheckTitle(ctx, &nanabushv1.TitleCheckRequest{
+			Title:          req.Title,
+			LanguageTag:    req.LanguageTag,
+			SourceLanguage: req.SourceLanguage,
+		})
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("nanabush: CheckTitle: %w", err)
@@ -852,6 +1053,18 @@ func (c *Client) CheckTitle(ctx context.Context, req CheckTitleRequest) (*CheckT
 	}, nil
 }
 
+// documentMetadata returns the document's metadata with the request context
+// added under ContextMetadataKey. The caller's map is not modified.
+func documentMetadata(req TranslateRequest) map[string]string {
+	if req.Context == "" {
+		return req.Document.Metadata
+	}
+	metadata := make(map[string]string, len(req.Document.Metadata)+1)
+	maps.Copy(metadata, req.Document.Metadata)
+	metadata[ContextMetadataKey] = req.Context
+	return metadata
+}
+
 // DocumentContent represents document content and metadata.
 type DocumentContent struct {
 	Title    string
@@ -873,8 +1086,15 @@ type TranslateRequest struct {
 	SourceWikiURI  string
 	PageID         string
 	PageSlug       string
+	// Context is a free-form note for the model (audience, register, glossary
+	// hints). It is sent in the document metadata under ContextMetadataKey.
+	Context string
 }
 
+// ContextMetadataKey is the reserved document metadata key carrying
+// TranslateRequest.Context.
+const ContextMetadataKey = "glooscap.context"
+
 // TranslateResponse contains the translation result.
 type TranslateResponse struct {
 	JobID                string
@@ -885,10 +1105,22 @@ type TranslateResponse struct {
 	TokensUsed           int32
 	InferenceTimeSeconds float64
 	CompletedAt          time.Time
+	// Engine and EngineVersion identify what produced the translation; see
+	// MetadataEngine
+	Engine        string
+	EngineVersion string
+}
+
+// InferenceTime returns InferenceTimeSeconds as a Duration.
+func (r *TranslateResponse) InferenceTime() time.Duration {
+	return time.Duration(r.InferenceTimeSeconds * float64(time.Second))
 }
 
 // Translate performs full document translation.
 func (c *Client) Translate(ctx context.Context, req TranslateRequest) (*TranslateResponse, error) {
+	if fb := c.failoverTarget(); fb != nil {
+		return fb.Translate(ctx, req)
+	}
 	if c.client == nil {
 		return nil, fmt.Errorf("nanabush: client not initialized")
 	}
@@ -933,7 +1165,7 @@ func (c *Client) Translate(ctx context.Context, req TranslateRequest) (*Translat
 				Title:    req.Document.Title,
 				Markdown: req.Document.Markdown,
 				Slug:     req.Document.Slug,
-				Metadata: req.Document.Metadata,
+				Metadata: documentMetadata(req),
 			},
 		}
 	default:
@@ -951,7 +1183,12 @@ func (c *Client) Translate(ctx context.Context, req TranslateRequest) (*Translat
 	}
 
 	// Call the gRPC service
-	resp, err := c.client.Translate(ctx, grpcReq)
+	var resp *nanabushv1.TranslateResponse
+	var header, trailer metadata.MD
+	err := c.callService(func() (err error) {
+		resp, err = c.client.Translate(ctx, grpcReq, grpc.Header(&header), grpc.Trailer(&trailer))
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("nanabush: Translate: %w", err)
 	}
@@ -971,9 +1208,21 @@ func (c *Client) Translate(ctx context.Context, req TranslateRequest) (*Translat
 		TokensUsed:           resp.TokensUsed,
 		InferenceTimeSeconds: resp.InferenceTimeSeconds,
 		CompletedAt:          completedAt,
+		Engine:               cmp.Or(metadataValue(MetadataEngine, header, trailer), c.serviceType),
+		EngineVersion:        metadataValue(MetadataEngineVersion, header, trailer),
 	}, nil
 }
 
+// metadataValue returns the first value of key in the first of mds that has it.
+func metadataValue(key string, mds ...metadata.MD) string {
+	for _, md := range mds {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return ""
+}
+
 // Helper function to convert DocumentContent to proto
 func documentContentToProto(doc *DocumentContent) *nanabushv1.DocumentContent {
 	if doc == nil {
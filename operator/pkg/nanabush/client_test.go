@@ -0,0 +1,51 @@
+package nanabush
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestStatusHeartbeatStaleness(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	c := &Client{
+		addr:              "nanabush:50051",
+		registered:        true,
+		clientID:          "client-1",
+		heartbeatInterval: 10 * time.Second,
+		heartbeatCfg:      HeartbeatConfig{}.withDefaults(),
+		clock:             fakeClock,
+	}
+
+	if got := c.Status().Status; got != "warning" {
+		t.Errorf("before the first heartbeat: status %q, want warning", got)
+	}
+
+	c.lastHeartbeatTime = fakeClock.Now()
+	fakeClock.Step(25 * time.Second)
+	if s := c.Status(); s.Status != "healthy" || !s.Connected {
+		t.Errorf("heartbeat 25s ago: status %q connected=%v, want healthy and connected", s.Status, s.Connected)
+	}
+
+	// Three missed intervals without a heartbeat make the client stale
+	fakeClock.Step(10 * time.Second)
+	if s := c.Status(); s.Status != "error" || s.Connected {
+		t.Errorf("heartbeat 35s ago: status %q connected=%v, want error and disconnected", s.Status, s.Connected)
+	}
+}
+
+func TestMetadataValue(t *testing.T) {
+	header := metadata.Pairs(MetadataEngine, "vllm")
+	trailer := metadata.Pairs(MetadataEngine, "ignored", MetadataEngineVersion, "llama-3.1-8b")
+	if got := metadataValue(MetadataEngine, header, trailer); got != "vllm" {
+		t.Errorf("engine = %q, want the header's", got)
+	}
+	if got := metadataValue(MetadataEngineVersion, header, trailer); got != "llama-3.1-8b" {
+		t.Errorf("version = %q, want the trailer's", got)
+	}
+	if got := metadataValue(MetadataEngineVersion, nil, nil); got != "" {
+		t.Errorf("missing version = %q", got)
+	}
+}
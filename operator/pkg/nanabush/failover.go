@@ -0,0 +1,163 @@
+package nanabush
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+// fallbackRetryInterval is how often unreachable fallback endpoints are redialled.
+const fallbackRetryInterval = 30 * time.Second
+
+// Endpoint is an alternative translation service address used when the primary is unhealthy.
+type Endpoint struct {
+	Address string
+	Secure  bool
+}
+
+// NewClientWithFailover creates a client for cfg.Address with cfg.Fallbacks as
+// standby endpoints. If the primary can't be reached, each fallback is tried in
+// order as the primary, with the remaining endpoints (including the original
+// primary) kept as fallbacks.
+func NewClientWithFailover(cfg Config) (*Client, error) {
+	endpoints := append([]Endpoint{{Address: cfg.Address, Secure: cfg.Secure}}, cfg.Fallbacks...)
+
+	var errs []error
+	for i, ep := range endpoints {
+		attempt := cfg
+		attempt.Address = ep.Address
+		attempt.Secure = ep.Secure
+		attempt.Fallbacks = nil
+		for j, other := range endpoints {
+			if j != i {
+				attempt.Fallbacks = append(attempt.Fallbacks, other)
+			}
+		}
+
+		c, err := NewClient(attempt)
+		if err == nil {
+			if i > 0 {
+				verbosity.Printf("[nanabush] Primary %s unavailable, using fallback %s\n", cfg.Address, ep.Address)
+			}
+			return c, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 1 {
+		return nil, errs[0]
+	}
+	return nil, fmt.Errorf("nanabush: no reachable endpoint (%d tried): %v", len(errs), errs)
+}
+
+// connectFallbacks dials the configured fallback endpoints in the background,
+// redialling unreachable ones until all are connected or the client is closed.
+func (c *Client) connectFallbacks(cfg Config) {
+	defer c.heartbeatWg.Done()
+
+	ticker := c.clock.NewTicker(fallbackRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		pending := 0
+		for i, ep := range cfg.Fallbacks {
+			c.mu.RLock()
+			connected := c.fallbacks[i] != nil
+			c.mu.RUnlock()
+			if connected {
+				continue
+			}
+
+			fbCfg := cfg
+			fbCfg.Address = ep.Address
+			fbCfg.Secure = ep.Secure
+			fbCfg.Fallbacks = nil
+			// Fallback status changes affect which endpoint is active, so surface them
+			// through the primary's callback.
+			fbCfg.OnStatusChange = func(Status) {
+				if c.onStatusChange != nil {
+					c.onStatusChange(c.Status())
+				}
+			}
+
+			fb, err := NewClient(fbCfg)
+			if err != nil {
+				verbosity.Printf("[nanabush] Fallback %s unavailable: %v\n", ep.Address, err)
+				pending++
+				continue
+			}
+
+			select {
+			case <-c.fallbackStop:
+				_ = fb.Close()
+				return
+			default:
+			}
+			c.mu.Lock()
+			c.fallbacks[i] = fb
+			c.mu.Unlock()
+			verbosity.Printf("[nanabush] Fallback %s connected\n", ep.Address)
+		}
+
+		if pending == 0 {
+			return
+		}
+		select {
+		case <-c.fallbackStop:
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// failoverTarget returns the first healthy fallback when the primary is in an
+// error state or its circuit breaker is failing calls fast, or nil when
+// requests should go to the primary.
+func (c *Client) failoverTarget() *Client {
+	c.mu.RLock()
+	hasFallbacks := len(c.fallbacks) > 0
+	c.mu.RUnlock()
+	if !hasFallbacks || c.Status().Status != "error" && !c.circuitTripped() {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.healthyFallbackLocked()
+}
+
+// healthyFallbackLocked returns the first registered, non-error fallback.
+// Callers must hold c.mu.
+func (c *Client) healthyFallbackLocked() *Client {
+	for _, fb := range c.fallbacks {
+		if fb == nil {
+			continue
+		}
+		if s := fb.Status(); s.Registered && s.Status != "error" && !fb.circuitTripped() {
+			return fb
+		}
+	}
+	return nil
+}
+
+// circuitTripped reports whether the client's circuit breaker is open and
+// still in its cooldown. Once the cooldown ends the primary gets calls again,
+// so the breaker's probe can close it.
+func (c *Client) circuitTripped() bool {
+	s := c.Circuit()
+	return s.State == CircuitOpen && s.CooldownRemainingSeconds > 0
+}
+
+// closeFallbacks closes every connected fallback client.
+func (c *Client) closeFallbacks() {
+	c.mu.Lock()
+	fallbacks := c.fallbacks
+	c.fallbacks = nil
+	c.mu.Unlock()
+
+	for _, fb := range fallbacks {
+		if fb != nil {
+			_ = fb.Close()
+		}
+	}
+}
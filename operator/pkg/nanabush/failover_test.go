@@ -0,0 +1,157 @@
+package nanabush
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	nanabushv1 "github.com/dasmlab/glooscap-operator/pkg/nanabush/proto/v1"
+)
+
+// fakeTranslationService accepts every registration and heartbeat.
+type fakeTranslationService struct {
+	nanabushv1.UnimplementedTranslationServiceServer
+}
+
+func (fakeTranslationService) RegisterClient(context.Context, *nanabushv1.RegisterClientRequest) (*nanabushv1.RegisterClientResponse, error) {
+	return &nanabushv1.RegisterClientResponse{Success: true, ClientId: "client-1"}, nil
+}
+
+func (fakeTranslationService) Heartbeat(context.Context, *nanabushv1.HeartbeatRequest) (*nanabushv1.HeartbeatResponse, error) {
+	return &nanabushv1.HeartbeatResponse{Success: true}, nil
+}
+
+// serveFakeTranslationService serves fakeTranslationService on lis until the
+// test ends.
+func serveFakeTranslationService(t *testing.T, lis net.Listener) {
+	t.Helper()
+	srv := grpc.NewServer()
+	nanabushv1.RegisterTranslationServiceServer(srv, fakeTranslationService{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+}
+
+func TestFailoverTarget(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	fakeClient := func(addr string, registered bool, lastHeartbeat time.Time) *Client {
+		return &Client{
+			addr:              addr,
+			registered:        registered,
+			lastHeartbeatTime: lastHeartbeat,
+			heartbeatInterval: time.Minute,
+			heartbeatCfg:      HeartbeatConfig{}.withDefaults(),
+			breaker:           newCircuitBreaker(CircuitConfig{}, fakeClock),
+			clock:             fakeClock,
+		}
+	}
+	now := fakeClock.Now()
+	stale := now.Add(-time.Hour)
+
+	primary := fakeClient("primary:50051", true, now)
+	unregistered := fakeClient("unregistered:50051", false, now)
+	silent := fakeClient("silent:50051", true, stale)
+	healthy := fakeClient("healthy:50051", true, now)
+	spare := fakeClient("spare:50051", true, now)
+	// A fallback that hasn't connected yet leaves its slot empty
+	primary.fallbacks = []*Client{nil, unregistered, silent, healthy, spare}
+
+	if fb := primary.failoverTarget(); fb != nil {
+		t.Fatalf("healthy primary failed over to %s", fb.addr)
+	}
+
+	// A primary whose heartbeats stopped goes to the first healthy fallback
+	primary.lastHeartbeatTime = stale
+	if fb := primary.failoverTarget(); fb != healthy {
+		t.Fatalf("failover target %v, want %s", fb, healthy.addr)
+	}
+	primary.mu.RLock()
+	fb := primary.healthyFallbackLocked()
+	primary.mu.RUnlock()
+	if fb != healthy {
+		t.Errorf("healthyFallbackLocked() = %v, want %s", fb, healthy.addr)
+	}
+
+	// Without a healthy fallback the primary keeps the calls
+	primary.fallbacks = []*Client{nil, unregistered, silent}
+	if fb := primary.failoverTarget(); fb != nil {
+		t.Errorf("failed over to unhealthy %s", fb.addr)
+	}
+	primary.fallbacks = nil
+	if fb := primary.failoverTarget(); fb != nil {
+		t.Errorf("failed over to %s without fallbacks", fb.addr)
+	}
+}
+
+func TestConnectFallbacks(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	up, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serveFakeTranslationService(t, up)
+	// Nothing listens on down's address until it is restarted below
+	down, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	downAddr := down.Addr().String()
+	_ = down.Close()
+
+	cfg := Config{
+		Fallbacks: []Endpoint{{Address: downAddr}, {Address: up.Addr().String()}},
+		Timeout:   time.Second,
+		Clock:     fakeClock,
+	}
+	c := &Client{
+		fallbacks:    make([]*Client, len(cfg.Fallbacks)),
+		fallbackStop: make(chan struct{}),
+		clock:        fakeClock,
+	}
+	done := make(chan struct{})
+	c.heartbeatWg.Add(1)
+	go func() {
+		c.connectFallbacks(cfg)
+		close(done)
+	}()
+	defer c.closeFallbacks()
+
+	connected := func(i int) bool {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		return c.fallbacks[i] != nil
+	}
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// The reachable fallback connects while the other is still pending
+	waitFor("the reachable fallback", func() bool { return connected(1) })
+	if connected(0) {
+		t.Fatal("the unreachable fallback connected")
+	}
+
+	// Once it comes up, the next retry connects it and the loop ends
+	relisten, err := net.Listen("tcp", downAddr)
+	if err != nil {
+		t.Skipf("can't listen on %s again: %v", downAddr, err)
+	}
+	serveFakeTranslationService(t, relisten)
+	fakeClock.Step(fallbackRetryInterval)
+	waitFor("the retried fallback", func() bool { return connected(0) })
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("connectFallbacks kept running with every fallback connected")
+	}
+}
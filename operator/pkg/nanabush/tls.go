@@ -0,0 +1,50 @@
+package nanabush
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// transportCredentials returns the credentials cfg asks for: plaintext unless
+// cfg.Secure is set, otherwise TLS verified against cfg.TLSCAPath (or the
+// system roots when it is empty), presenting the client certificate in
+// cfg.TLSCertPath and cfg.TLSKeyPath for mTLS when they are set. A secure
+// config whose files can't be loaded is an error, never a plaintext fallback.
+func transportCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	if !cfg.Secure {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	switch {
+	case cfg.TLSCertPath != "" && cfg.TLSKeyPath != "":
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertPath, cfg.TLSKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("nanabush: load client certificate %s: %w", cfg.TLSCertPath, err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	case cfg.TLSCertPath != "" || cfg.TLSKeyPath != "":
+		return nil, fmt.Errorf("nanabush: mTLS needs both a client certificate and key (cert=%q, key=%q)",
+			cfg.TLSCertPath, cfg.TLSKeyPath)
+	}
+
+	if cfg.TLSCAPath != "" {
+		pem, err := os.ReadFile(cfg.TLSCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("nanabush: read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("nanabush: no PEM certificates in CA file %s", cfg.TLSCAPath)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	// A nil RootCAs verifies the server against the system roots
+
+	return credentials.NewTLS(tlsCfg), nil
+}
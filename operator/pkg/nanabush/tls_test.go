@@ -0,0 +1,101 @@
+package nanabush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestKeyPair writes a self-signed certificate and its key to dir.
+func writeTestKeyPair(t *testing.T, dir string) (certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "glooscap-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPath = filepath.Join(dir, "tls.crt")
+	keyPath = filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyPath
+}
+
+func TestTransportCredentials(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestKeyPair(t, dir)
+	notPEM := filepath.Join(dir, "not-pem.crt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		cfg      Config
+		protocol string
+		wantErr  bool
+	}{
+		{name: "plaintext", cfg: Config{}, protocol: "insecure"},
+		// Paths are ignored unless Secure is set
+		{name: "plaintext ignores paths", cfg: Config{TLSCAPath: "/missing/ca.crt"}, protocol: "insecure"},
+		{name: "system roots", cfg: Config{Secure: true}, protocol: "tls"},
+		{name: "custom CA", cfg: Config{Secure: true, TLSCAPath: certPath}, protocol: "tls"},
+		{name: "mTLS", cfg: Config{Secure: true, TLSCAPath: certPath, TLSCertPath: certPath, TLSKeyPath: keyPath}, protocol: "tls"},
+		{name: "certificate without key", cfg: Config{Secure: true, TLSCertPath: certPath}, wantErr: true},
+		{name: "missing certificate", cfg: Config{Secure: true, TLSCertPath: filepath.Join(dir, "missing.crt"), TLSKeyPath: keyPath}, wantErr: true},
+		{name: "missing CA", cfg: Config{Secure: true, TLSCAPath: filepath.Join(dir, "missing-ca.crt")}, wantErr: true},
+		{name: "CA without certificates", cfg: Config{Secure: true, TLSCAPath: notPEM}, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			creds, err := transportCredentials(tc.cfg)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s credentials", creds.Info().SecurityProtocol)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := creds.Info().SecurityProtocol; got != tc.protocol {
+				t.Errorf("security protocol = %q, want %q", got, tc.protocol)
+			}
+		})
+	}
+}
+
+func TestNewClientRejectsUnloadableTLS(t *testing.T) {
+	_, err := NewClient(Config{Address: "localhost:1", Secure: true, TLSCAPath: filepath.Join(t.TempDir(), "missing-ca.crt")})
+	if err == nil {
+		t.Fatal("NewClient connected without its CA certificate")
+	}
+}
@@ -0,0 +1,57 @@
+package outline
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultFetchWorkers is the number of pages GetPagesContent fetches at once
+// when the caller doesn't say.
+const DefaultFetchWorkers = 4
+
+// PageResult is the outcome of fetching one page with GetPagesContent.
+type PageResult struct {
+	PageID string
+	// Content is set when the fetch succeeded, including for empty pages,
+	// which also report ErrEmptyContent in Err.
+	Content *PageContent
+	Err     error
+}
+
+// GetPagesContent fetches the content of pageIDs with at most workers
+// (DefaultFetchWorkers when <= 0) requests in flight, so a large batch doesn't
+// flood Outline. Results are in pageIDs order. A failed page doesn't stop the
+// others: its error is kept in its result, after the client's usual retries
+// of transient failures. Pages not started before ctx ends fail with ctx's
+// error.
+func (c *Client) GetPagesContent(ctx context.Context, pageIDs []string, workers int) []PageResult {
+	if workers <= 0 {
+		workers = DefaultFetchWorkers
+	}
+	workers = min(workers, len(pageIDs))
+
+	results := make([]PageResult, len(pageIDs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				content, err := c.GetPageContent(ctx, pageIDs[i])
+				results[i] = PageResult{PageID: pageIDs[i], Content: content, Err: err}
+			}
+		}()
+	}
+
+	for i := range pageIDs {
+		if ctx.Err() != nil {
+			results[i] = PageResult{PageID: pageIDs[i], Err: ctx.Err()}
+			continue
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,84 @@
+package outline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetPagesContent(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var payload struct {
+			ID string `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		attempts[payload.ID]++
+		attempt := attempts[payload.ID]
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case payload.ID == "missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"not_found"}`))
+		case payload.ID == "flaky" && attempt == 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"rate_limited"}`))
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]string{"data": "# " + payload.ID})
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{"a", "b", "missing", "c", "flaky", "d", "e"}
+	results := c.GetPagesContent(context.Background(), ids, 2)
+
+	if len(results) != len(ids) {
+		t.Fatalf("got %d results, want %d", len(results), len(ids))
+	}
+	for i, r := range results {
+		if r.PageID != ids[i] {
+			t.Errorf("result %d is page %q, want %q", i, r.PageID, ids[i])
+		}
+		if r.PageID == "missing" {
+			var statusErr *StatusError
+			if !errors.As(r.Err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+				t.Errorf("missing page: err = %v, want a 404", r.Err)
+			}
+			continue
+		}
+		if r.Err != nil || r.Content.Markdown != "# "+r.PageID {
+			t.Errorf("page %s: content %+v, err %v", r.PageID, r.Content, r.Err)
+		}
+	}
+	if attempts["flaky"] != 2 {
+		t.Errorf("flaky page fetched %d times, want 2", attempts["flaky"])
+	}
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("%d requests in flight, want at most 2", got)
+	}
+}
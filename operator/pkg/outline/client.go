@@ -1,43 +1,111 @@
 package outline
 
 import (
-	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
 )
 
 const (
-	defaultTimeout        = 15 * time.Second
-	documentsListPath     = "/api/documents.list"
-	documentsExportPath   = "/api/documents.export"
-	documentsCreatePath   = "/api/documents.create"
-	documentsUpdatePath   = "/api/documents.update"
-	documentsDeletePath   = "/api/documents.delete"
-	collectionsListPath   = "/api/collections.list"
-	collectionsCreatePath = "/api/collections.create"
+	defaultTimeout            = 15 * time.Second
+	defaultMaxBodyBytes       = 32 << 20 // 32 MiB
+	defaultMaxRetries         = 3
+	defaultRetryBackoff       = time.Second
+	maxListLimit              = 100 // Outline API maximum per documents.list request
+	defaultSearchLimit        = 25
+	documentsListPath         = "/api/documents.list"
+	documentsSearchPath       = "/api/documents.search"
+	documentsSearchTitlesPath = "/api/documents.search_titles"
+	documentsExportPath       = "/api/documents.export"
+	documentsCreatePath       = "/api/documents.create"
+	documentsUpdatePath       = "/api/documents.update"
+	documentsDeletePath       = "/api/documents.delete"
+	documentsArchivePath      = "/api/documents.archive"
+	documentsRestorePath      = "/api/documents.restore"
+	commentsCreatePath        = "/api/comments.create"
+	collectionsListPath       = "/api/collections.list"
+	collectionsInfoPath       = "/api/collections.info"
+	collectionsCreatePath     = "/api/collections.create"
+	collectionsDocsPath       = "/api/collections.documents"
 )
 
 // Client interacts with an Outline instance.
 type Client struct {
-	baseURL    *url.URL
-	httpClient *http.Client
-	token      string
+	baseURL      *url.URL
+	httpClient   *http.Client
+	token        string
+	maxBodyBytes int64
+	maxRetries   int
+	retryBackoff time.Duration
+	readOnly     bool
+
+	serverMu sync.RWMutex
+	server   ServerInfo
 }
 
+// ErrCommentsDisabled is returned by CreateComment when the Outline instance (or
+// the document's workspace) doesn't allow comments.
+var ErrCommentsDisabled = errors.New("outline: comments are not available on this instance")
+
+// ErrPageNotRestorable is returned by RestorePage when Outline no longer has the
+// page, typically because it was permanently deleted or its trash retention expired.
+var ErrPageNotRestorable = errors.New("outline: page was permanently deleted and cannot be restored")
+
+// ErrEmptyContent is returned by GetPageContent when the page exported
+// successfully but has no content.
+var ErrEmptyContent = errors.New("outline: page has no content")
+
+// ErrAmbiguousCollection is returned by GetOrCreateCollection when more than
+// one collection has the requested name.
+var ErrAmbiguousCollection = errors.New("outline: several collections share this name")
+
+// ErrReadOnly is returned by every method that would change the wiki when the
+// client was created with Config.ReadOnly.
+var ErrReadOnly = errors.New("outline: client is read-only")
+
+// ErrResponseTooLarge is returned when an Outline response body exceeds Config.MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("outline: response body too large")
+
 // Config contains Outline client settings.
 type Config struct {
 	BaseURL              string
 	Token                string
 	Timeout              time.Duration
 	InsecureSkipTLSVerify bool
+	// CABundle holds PEM certificates trusted in addition to the system roots.
+	// When it or CABundleFile is set, InsecureSkipTLSVerify is ignored.
+	CABundle []byte
+	// CABundleFile is a path to a PEM bundle, read when the client is created.
+	CABundleFile string
+	// SlowCallThreshold logs a warning for calls slower than this (default 5s, negative disables).
+	SlowCallThreshold time.Duration
+	// MaxResponseBytes caps how much of a response body is read (default 32 MiB).
+	MaxResponseBytes int64
+	// MaxRetries is the number of attempts every API call gets when Outline
+	// rate limits it (429), fails with a 5xx or the connection drops, counting
+	// the first one (default 3; 1 turns retries off). Creates are only
+	// retried after a 429, a 503 or a refused connection, since any other
+	// failure may come after the page or comment was made.
+	MaxRetries int
+	// RetryBackoff is the base of the exponential backoff between retries,
+	// before jitter (default 1s). A 429's Retry-After takes over when longer.
+	RetryBackoff time.Duration
+	// ReadOnly makes every write - creating, updating, publishing, archiving,
+	// deleting or restoring pages, creating collections or comments - fail with
+	// ErrReadOnly before anything is sent.
+	ReadOnly bool
 }
 
 // NewClient creates a new Outline client using the provided config.
@@ -57,28 +125,111 @@ func NewClient(cfg Config) (*Client, error) {
 		timeout = defaultTimeout
 	}
 
-	// Configure HTTP client with TLS settings
+	// Configure HTTP client with TLS settings. A CA bundle is the verified
+	// alternative to skipping verification, so it wins when both are set.
+	rootCAs, err := loadRootCAs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	insecure := cfg.InsecureSkipTLSVerify && rootCAs == nil
 	transport := &http.Transport{
 		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: cfg.InsecureSkipTLSVerify,
+			InsecureSkipVerify: insecure,
+			RootCAs:            rootCAs,
 		},
 	}
 
+	maxBodyBytes := cfg.MaxResponseBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = defaultMaxBodyBytes
+	}
+	maxRetries := cfg.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	retryBackoff := cfg.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+	slowThreshold := cfg.SlowCallThreshold
+	if slowThreshold == 0 {
+		slowThreshold = defaultSlowCallThreshold
+	}
+
 	// Log TLS configuration for debugging
-	if cfg.InsecureSkipTLSVerify {
-		fmt.Printf("[outline] Creating client with InsecureSkipTLSVerify=true for %s\n", cfg.BaseURL)
+	if insecure {
+		verbosity.Printf("[outline] Creating client with InsecureSkipTLSVerify=true for %s\n", cfg.BaseURL)
+	} else if rootCAs != nil {
+		verbosity.Debugf("[outline] Creating client with custom CA bundle for %s\n", cfg.BaseURL)
 	}
 
 	return &Client{
 		baseURL:    u,
 		httpClient: &http.Client{
-			Timeout:   timeout,
-			Transport: transport,
+			Timeout: timeout,
+			Transport: &instrumentedTransport{
+				base:          transport,
+				slowThreshold: slowThreshold,
+			},
 		},
-		token: cfg.Token,
+		token:        cfg.Token,
+		maxBodyBytes: maxBodyBytes,
+		maxRetries:   maxRetries,
+		retryBackoff: retryBackoff,
+		readOnly:     cfg.ReadOnly,
 	}, nil
 }
 
+// ReadOnly reports whether the client refuses writes.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkWritable returns ErrReadOnly, naming op, when the client refuses writes.
+func (c *Client) checkWritable(op string) error {
+	if c.readOnly {
+		return fmt.Errorf("%w: refusing to %s on %s", ErrReadOnly, op, c.baseURL.Host)
+	}
+	return nil
+}
+
+// loadRootCAs returns the system roots extended with cfg's CA bundle, or nil
+// when no bundle is configured.
+func loadRootCAs(cfg Config) (*x509.CertPool, error) {
+	bundle := cfg.CABundle
+	if len(bundle) == 0 && cfg.CABundleFile != "" {
+		data, err := os.ReadFile(cfg.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("outline: read CA bundle: %w", err)
+		}
+		bundle = data
+	}
+	if len(bundle) == 0 {
+		return nil, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("outline: CA bundle contains no PEM certificates")
+	}
+	return pool, nil
+}
+
+// readBody reads a response body, refusing to buffer more than maxBodyBytes so a
+// misbehaving server or proxy can't make us allocate unbounded memory.
+func (c *Client) readBody(body io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, c.maxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > c.maxBodyBytes {
+		return nil, fmt.Errorf("%w: exceeded %d bytes", ErrResponseTooLarge, c.maxBodyBytes)
+	}
+	return data, nil
+}
+
 // PageSummary represents minimal metadata for a wiki page.
 type PageSummary struct {
 	ID         string    `json:"id"`
@@ -93,16 +244,24 @@ type PageSummary struct {
 	IsDraft    bool      `json:"isDraft,omitempty"`    // True if this page is a draft
 }
 
+// listedDocument is a document as documents.list and documents.search return it.
+type listedDocument struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Slug         string    `json:"urlId"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	IsDraft      bool      `json:"isDraft"`
+	CollectionID string    `json:"collectionId,omitempty"`
+	TemplateID   string    `json:"templateId,omitempty"`
+	Template     bool      `json:"template"`
+}
+
 type documentsListResponse struct {
-	Data []struct {
-		ID           string    `json:"id"`
-		Title        string    `json:"title"`
-		Slug         string    `json:"urlId"`
-		UpdatedAt    time.Time `json:"updatedAt"`
-		IsDraft      bool      `json:"isDraft"`
-		CollectionID string    `json:"collectionId,omitempty"`
-		TemplateID   string    `json:"templateId,omitempty"`
-	} `json:"data"`
+	Data       []listedDocument `json:"data"`
+	Pagination struct {
+		// Total is missing from older Outline releases
+		Total *int `json:"total"`
+	} `json:"pagination"`
 }
 
 type collectionResponse struct {
@@ -110,149 +269,300 @@ type collectionResponse struct {
 	Name string `json:"name"`
 }
 
+// ListPagesOptions controls which pages ListPagesWithOptions returns.
+type ListPagesOptions struct {
+	// CollectionID restricts the listing to a single collection when set.
+	CollectionID string
+	// IncludeDrafts keeps draft pages in the result. Diagnostics rely on this to
+	// find their own draft pages; normal catalogue discovery leaves it off.
+	IncludeDrafts bool
+}
+
+// ListPagesPageOptions selects one page of a listing for ListPagesPage.
+type ListPagesPageOptions struct {
+	ListPagesOptions
+	// Offset is the number of documents to skip, usually the NextOffset of
+	// the previous page.
+	Offset int
+	// Limit is the number of documents to ask for (default and maximum 100).
+	Limit int
+}
+
+// ListPagesResult is one page of a documents.list listing.
+type ListPagesResult struct {
+	Pages []PageSummary
+	// Total is the number of documents Outline reports for the whole listing,
+	// drafts included, or -1 when the server doesn't say.
+	Total int
+	// Truncated is true when Outline has more documents after this page;
+	// NextOffset is where they start.
+	Truncated  bool
+	NextOffset int
+}
+
 // ListPages fetches page summaries from Outline with pagination support.
 // If collectionID is provided, only fetches pages from that collection.
+// Drafts are included; use ListPagesWithOptions to exclude them.
 func (c *Client) ListPages(ctx context.Context, collectionID ...string) ([]PageSummary, error) {
+	opts := ListPagesOptions{IncludeDrafts: true}
+	if len(collectionID) > 0 {
+		opts.CollectionID = collectionID[0]
+	}
+	return c.ListPagesWithOptions(ctx, opts)
+}
+
+// ListPagesWithOptions fetches page summaries from Outline, walking every
+// page of the listing with ListPagesPage.
+func (c *Client) ListPagesWithOptions(ctx context.Context, opts ListPagesOptions) ([]PageSummary, error) {
 	var allPages []PageSummary
-	offset := 0
-	limit := 100 // Outline API maximum is 100 per request
-	
-	// If collectionID is provided, use it
-	var targetCollectionID string
-	if len(collectionID) > 0 && collectionID[0] != "" {
-		targetCollectionID = collectionID[0]
-		fmt.Printf("[outline] ListPages: filtering by collection ID: %s\n", targetCollectionID)
+	pageOpts := ListPagesPageOptions{ListPagesOptions: opts}
+	if opts.CollectionID != "" {
+		verbosity.Debugf("[outline] ListPages: filtering by collection ID: %s\n", opts.CollectionID)
 	}
 
+	total := -1
 	for {
-		reqURL := c.baseURL.ResolveReference(&url.URL{Path: documentsListPath})
-
-		payload := map[string]any{
-			"direction": "DESC",
-			"sort":      "updatedAt",
-			"limit":     limit,
-			"offset":    offset,
-		}
-		
-		// Add collection filter if specified
-		if targetCollectionID != "" {
-			payload["collectionId"] = targetCollectionID
-		}
-		
-		body, err := json.Marshal(payload)
+		result, err := c.ListPagesPage(ctx, pageOpts)
 		if err != nil {
-			return nil, fmt.Errorf("outline: marshal request body: %w", err)
+			return nil, err
 		}
-
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader(body))
-		if err != nil {
-			return nil, fmt.Errorf("outline: new request: %w", err)
+		allPages = append(allPages, result.Pages...)
+		total = result.Total
+		if !result.Truncated {
+			break
 		}
+		pageOpts.Offset = result.NextOffset
+		verbosity.Debugf("[outline] ListPages: fetched %d pages so far (offset: %d)\n", len(allPages), pageOpts.Offset)
+	}
 
-		// Ensure token is trimmed of any whitespace
-		token := strings.TrimSpace(c.token)
+	if total >= 0 {
+		verbosity.Printf("[outline] ListPages: total pages fetched: %d (of %d documents)\n", len(allPages), total)
+	} else {
+		verbosity.Printf("[outline] ListPages: total pages fetched: %d\n", len(allPages))
+	}
+	return allPages, nil
+}
 
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Set("Content-Type", "application/json")
+// ListPagesPage fetches one page of page summaries, newest first, so callers
+// can page through a large wiki themselves, e.g. to report progress against
+// Total. Drafts left out by opts don't count against Limit, so a page can hold
+// fewer summaries than asked for even when it is Truncated.
+func (c *Client) ListPagesPage(ctx context.Context, opts ListPagesPageOptions) (*ListPagesResult, error) {
+	limit := opts.Limit
+	if limit <= 0 || limit > maxListLimit {
+		limit = maxListLimit
+	}
+	offset := max(opts.Offset, 0)
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("outline: request failed: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			// Read response body for error details
-			bodyBytes, readErr := io.ReadAll(resp.Body)
-			bodyStr := ""
-			if readErr == nil {
-				bodyStr = string(bodyBytes)
-			}
-			return nil, fmt.Errorf("outline: unexpected status code %d: %s", resp.StatusCode, bodyStr)
-		}
+	payload := map[string]any{
+		"direction": "DESC",
+		"sort":      "updatedAt",
+		"limit":     limit,
+		"offset":    offset,
+	}
+	// Add collection filter if specified
+	if opts.CollectionID != "" {
+		payload["collectionId"] = opts.CollectionID
+	}
 
-		var list documentsListResponse
-		if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
-			return nil, fmt.Errorf("outline: decode response: %w", err)
-		}
+	resp, listBody, err := c.doRequest(ctx, documentsListPath, payload)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(listBody)}
+	}
 
-		// If no data returned, we've reached the end
-		if len(list.Data) == 0 {
-			break
-		}
+	var list documentsListResponse
+	if err := expectJSON(resp, listBody); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(listBody, &list); err != nil {
+		return nil, fmt.Errorf("outline: decode response: %w", err)
+	}
 
-		pages := make([]PageSummary, 0, len(list.Data))
-
-		// Fetch collections map to get collection names
-		collectionsMap := make(map[string]string)
-		if len(list.Data) > 0 {
-			// Fetch all collections to map IDs to names
-			collections, collErr := c.ListCollections(ctx)
-			if collErr == nil {
-				for _, coll := range collections {
-					collectionsMap[coll.ID] = coll.Name
-				}
-			}
-			// Fallback: use collection ID as name if we couldn't fetch collections
-			for _, item := range list.Data {
-				if item.CollectionID != "" && collectionsMap[item.CollectionID] == "" {
-					collectionsMap[item.CollectionID] = item.CollectionID
-				}
-			}
+	result := &ListPagesResult{Total: -1}
+	if list.Pagination.Total != nil {
+		result.Total = *list.Pagination.Total
+	}
+	// If no data returned, we've reached the end
+	if len(list.Data) == 0 {
+		return result, nil
+	}
+	// A full page may be followed by more; the total, when reported, settles it
+	result.NextOffset = offset + len(list.Data)
+	result.Truncated = len(list.Data) >= limit
+	if result.Total >= 0 {
+		result.Truncated = result.NextOffset < result.Total
+	}
+	if !result.Truncated {
+		result.NextOffset = 0
+	}
+
+	var skippedDrafts int
+	result.Pages, skippedDrafts = c.pageSummaries(ctx, list.Data, opts.IncludeDrafts)
+	if skippedDrafts > 0 {
+		verbosity.Debugf("[outline] ListPages: skipped %d drafts at offset %d\n", skippedDrafts, offset)
+	}
+	return result, nil
+}
+
+// pageSummaries turns listed documents into PageSummaries, naming their
+// collections, and leaves drafts out unless includeDrafts is set. skipped is
+// the number of drafts left out.
+func (c *Client) pageSummaries(ctx context.Context, docs []listedDocument, includeDrafts bool) (pages []PageSummary, skipped int) {
+	templateFlag := c.Server().TemplateFlag
+
+	// Fetch all collections to map IDs to names
+	collectionsMap := make(map[string]string)
+	collections, collErr := c.ListCollections(ctx)
+	if collErr == nil {
+		for _, coll := range collections {
+			collectionsMap[coll.ID] = coll.Name
+		}
+	}
+	// Fallback: use collection ID as name if we couldn't fetch collections
+	for _, item := range docs {
+		if item.CollectionID != "" && collectionsMap[item.CollectionID] == "" {
+			collectionsMap[item.CollectionID] = item.CollectionID
 		}
+	}
 
-		for _, item := range list.Data {
-		// Include both drafts and published pages (removed draft filter)
-		// This allows diagnostic jobs to find and update existing draft pages
+	pages = make([]PageSummary, 0, len(docs))
+	for _, item := range docs {
+		// Drafts are only kept when requested (diagnostic jobs need to find their draft pages)
+		if item.IsDraft && !includeDrafts {
+			skipped++
+			continue
+		}
 
 		collectionName := ""
 		if item.CollectionID != "" {
 			collectionName = collectionsMap[item.CollectionID]
 		}
 
-		// Try to detect template from title (e.g., "Feature Completion Template (EN)")
+		// Servers that report the template flag are trusted; older ones get
+		// the title heuristic (e.g., "Feature Completion Template (EN)")
 		template := ""
-		isTemplate := false
-		if strings.Contains(item.Title, "Template") {
+		isTemplate := strings.Contains(item.Title, "Template")
+		if templateFlag {
+			isTemplate = item.Template
+		}
+		if isTemplate {
 			// Extract template name (e.g., "Feature Completion Template" from "Feature Completion Template (EN)")
 			parts := strings.Split(item.Title, "(")
 			if len(parts) > 0 {
 				template = strings.TrimSpace(parts[0])
 			}
-			// Mark as template if title contains "Template" - this is a heuristic
-			// TODO: Check Outline API for actual template metadata if available
-			isTemplate = true
 		}
 
-			pages = append(pages, PageSummary{
-				ID:        item.ID,
-				Title:     item.Title,
-				Slug:      item.Slug,
-				UpdatedAt: item.UpdatedAt,
-				// Outline does not expose language directly; try to extract from title
-				Language:   extractLanguageFromTitle(item.Title),
-				HasAssets:  false,
-				Collection: collectionName,
-				Template:   template,
-				IsTemplate: isTemplate,
-				IsDraft:    item.IsDraft,
-			})
-		}
+		pages = append(pages, PageSummary{
+			ID:        item.ID,
+			Title:     item.Title,
+			Slug:      item.Slug,
+			UpdatedAt: item.UpdatedAt,
+			// Outline does not expose language directly; try to extract from title
+			Language:   extractLanguageFromTitle(item.Title),
+			HasAssets:  false,
+			Collection: collectionName,
+			Template:   template,
+			IsTemplate: isTemplate,
+			IsDraft:    item.IsDraft,
+		})
+	}
+	return pages, skipped
+}
 
-		allPages = append(allPages, pages...)
-		
-		// If we got fewer than the limit, we've reached the end
-		if len(list.Data) < limit {
-			break
-		}
-		
-		// Increment offset for next page
-		offset += limit
-		fmt.Printf("[outline] ListPages: fetched %d pages so far (offset: %d)\n", len(allPages), offset)
+// SearchOptions narrows SearchPages.
+type SearchOptions struct {
+	// CollectionID restricts the search to a single collection when set.
+	CollectionID string
+	// Limit is the most results returned (default 25, at most 100).
+	Limit int
+	// IncludeDrafts includes the token owner's draft pages in the results.
+	IncludeDrafts bool
+}
+
+type documentsSearchResponse struct {
+	Data []struct {
+		Document listedDocument `json:"document"`
+	} `json:"data"`
+}
+
+// SearchPages runs a full-text search with POST /api/documents.search and
+// returns the matching pages, best match first. Matches are by words in the
+// title or text, so callers looking for an exact title still compare it.
+// Instances with search disabled or unavailable answer with a *StatusError.
+func (c *Client) SearchPages(ctx context.Context, query string, opts SearchOptions) ([]PageSummary, error) {
+	bodyBytes, err := c.search(ctx, documentsSearchPath, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	var searchResp documentsSearchResponse
+	if err := json.Unmarshal(bodyBytes, &searchResp); err != nil {
+		return nil, fmt.Errorf("outline: decode response: %w", err)
 	}
 
-	fmt.Printf("[outline] ListPages: total pages fetched: %d\n", len(allPages))
-	return allPages, nil
+	docs := make([]listedDocument, 0, len(searchResp.Data))
+	for _, hit := range searchResp.Data {
+		docs = append(docs, hit.Document)
+	}
+	// Older releases ignore includeDrafts and search drafts anyway
+	pages, _ := c.pageSummaries(ctx, docs, opts.IncludeDrafts)
+	verbosity.Debugf("[outline] SearchPages: %d results for %q\n", len(pages), query)
+	return pages, nil
+}
+
+// SearchTitles finds the pages whose title contains query, ignoring case, with
+// POST /api/documents.search_titles. Unlike SearchPages it doesn't go through
+// the search index, so a page is found as soon as it is saved. Instances
+// without the endpoint answer with a *StatusError.
+func (c *Client) SearchTitles(ctx context.Context, query string, opts SearchOptions) ([]PageSummary, error) {
+	bodyBytes, err := c.search(ctx, documentsSearchTitlesPath, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	var list documentsListResponse
+	if err := json.Unmarshal(bodyBytes, &list); err != nil {
+		return nil, fmt.Errorf("outline: decode response: %w", err)
+	}
+	pages, _ := c.pageSummaries(ctx, list.Data, opts.IncludeDrafts)
+	verbosity.Debugf("[outline] SearchTitles: %d results for %q\n", len(pages), query)
+	return pages, nil
+}
+
+// search posts query and opts to one of the search endpoints and returns the
+// response body once it is known to be a successful JSON answer.
+func (c *Client) search(ctx context.Context, path, query string, opts SearchOptions) ([]byte, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("outline: search query is required")
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxListLimit)
+
+	payload := map[string]any{
+		"query":         query,
+		"limit":         limit,
+		"includeDrafts": opts.IncludeDrafts,
+	}
+	if opts.CollectionID != "" {
+		payload["collectionId"] = opts.CollectionID
+	}
+
+	resp, bodyBytes, err := c.doRequest(ctx, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+	}
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
+	}
+	return bodyBytes, nil
 }
 
 // extractLanguageFromTitle tries to extract language code from page title
@@ -288,50 +598,25 @@ type PageContent struct {
 }
 
 type documentsExportResponse struct {
-	Data string `json:"data"` // Markdown content
+	Data *string `json:"data"` // Markdown content; nil when the export returned none
 }
 
 // GetPageContent fetches the full content of a page as Markdown.
 // Uses POST /api/documents.export endpoint.
+//
+// A page with no content returns ErrEmptyContent together with a valid
+// PageContent, so callers that can work with empty pages may ignore it. A
+// response without any markdown is reported as an export failure instead.
 func (c *Client) GetPageContent(ctx context.Context, pageID string) (*PageContent, error) {
-	reqURL := c.baseURL.ResolveReference(&url.URL{Path: documentsExportPath})
-
 	payload := map[string]string{
 		"id": pageID,
 	}
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("outline: marshal request body: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader(body))
+	resp, bodyBytes, err := c.doRequest(ctx, documentsExportPath, payload)
 	if err != nil {
-		return nil, fmt.Errorf("outline: new request: %w", err)
+		return nil, err
 	}
-
-	token := strings.TrimSpace(c.token)
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("outline: request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, readErr := io.ReadAll(resp.Body)
-		bodyStr := ""
-		if readErr == nil {
-			bodyStr = string(bodyBytes)
-		}
-		return nil, fmt.Errorf("outline: unexpected status code %d: %s", resp.StatusCode, bodyStr)
-	}
-
-	// Read the full response body first to debug
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("outline: read response body: %w", err)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	// Log raw response for debugging (first 1000 chars)
@@ -339,29 +624,41 @@ func (c *Client) GetPageContent(ctx context.Context, pageID string) (*PageConten
 	if len(bodyPreview) > 1000 {
 		bodyPreview = bodyPreview[:1000] + "..."
 	}
-	fmt.Printf("[outline] GetPageContent raw response for pageID=%s (status=%d): %q\n",
+	verbosity.Debugf("[outline] GetPageContent raw response for pageID=%s (status=%d): %q\n",
 		pageID, resp.StatusCode, bodyPreview)
 
 	var exportResp documentsExportResponse
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
+	}
 	if err := json.Unmarshal(bodyBytes, &exportResp); err != nil {
 		return nil, fmt.Errorf("outline: decode response: %w (body: %s)", err, bodyPreview)
 	}
 
+	if exportResp.Data == nil {
+		return nil, fmt.Errorf("outline: export of page %s returned no data (body: %s)", pageID, bodyPreview)
+	}
+	markdown := *exportResp.Data
+
 	// Log the response for debugging (first 500 chars to avoid huge logs)
-	markdownPreview := exportResp.Data
+	markdownPreview := markdown
 	if len(markdownPreview) > 500 {
 		markdownPreview = markdownPreview[:500] + "..."
 	}
-	fmt.Printf("[outline] GetPageContent response for pageID=%s: markdown length=%d, preview=%q\n",
-		pageID, len(exportResp.Data), markdownPreview)
+	verbosity.Debugf("[outline] GetPageContent response for pageID=%s: markdown length=%d, preview=%q\n",
+		pageID, len(markdown), markdownPreview)
 
 	// We need to get page metadata separately to get title and slug
 	// For now, we'll return what we have and the caller can enrich it
-	return &PageContent{
+	content := &PageContent{
 		ID:       pageID,
-		Markdown: exportResp.Data,
+		Markdown: markdown,
 		// Title and Slug will need to be populated from PageSummary if available
-	}, nil
+	}
+	if strings.TrimSpace(markdown) == "" {
+		return content, fmt.Errorf("%w: page %s", ErrEmptyContent, pageID)
+	}
+	return content, nil
 }
 
 // CreatePageRequest represents the request to create a new page.
@@ -370,6 +667,8 @@ type CreatePageRequest struct {
 	Text             string `json:"text"`                       // Markdown content
 	CollectionID     string `json:"collectionId,omitempty"`     // Optional collection ID
 	ParentDocumentID string `json:"parentDocumentId,omitempty"` // Optional parent document ID
+	// Publish creates the page published rather than as a draft.
+	Publish bool `json:"publish,omitempty"`
 }
 
 // CreatePageResponse represents the response from creating a page.
@@ -385,8 +684,9 @@ type CreatePageResponse struct {
 // Returns the created page ID, title, and slug.
 // SAFETY: This method only creates new pages - it never modifies existing pages.
 func (c *Client) CreatePage(ctx context.Context, req CreatePageRequest) (*CreatePageResponse, error) {
-	reqURL := c.baseURL.ResolveReference(&url.URL{Path: documentsCreatePath})
-
+	if err := c.checkWritable("create a page"); err != nil {
+		return nil, err
+	}
 	payload := map[string]any{
 		"title": req.Title,
 		"text":  req.Text,
@@ -397,31 +697,14 @@ func (c *Client) CreatePage(ctx context.Context, req CreatePageRequest) (*Create
 	if req.ParentDocumentID != "" {
 		payload["parentDocumentId"] = req.ParentDocumentID
 	}
-
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("outline: marshal request body: %w", err)
+	publishOnCreate := c.Server().PublishOnCreate
+	if req.Publish && publishOnCreate {
+		payload["publish"] = true
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader(body))
+	resp, bodyBytes, err := c.doRequest(ctx, documentsCreatePath, payload)
 	if err != nil {
-		return nil, fmt.Errorf("outline: new request: %w", err)
-	}
-
-	token := strings.TrimSpace(c.token)
-	httpReq.Header.Set("Authorization", "Bearer "+token)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("outline: request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body for debugging
-	bodyBytes, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		return nil, fmt.Errorf("outline: read response body: %w", readErr)
+		return nil, err
 	}
 
 	bodyStr := string(bodyBytes)
@@ -431,8 +714,8 @@ func (c *Client) CreatePage(ctx context.Context, req CreatePageRequest) (*Create
 		if len(errorPreview) > 500 {
 			errorPreview = errorPreview[:500] + "..."
 		}
-		fmt.Printf("[outline] CreatePage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
-		return nil, fmt.Errorf("outline: unexpected status code %d: %s", resp.StatusCode, errorPreview)
+		verbosity.Printf("[outline] CreatePage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
 	}
 
 	// Log successful response for debugging
@@ -440,16 +723,24 @@ func (c *Client) CreatePage(ctx context.Context, req CreatePageRequest) (*Create
 	if len(responsePreview) > 500 {
 		responsePreview = responsePreview[:500] + "..."
 	}
-	fmt.Printf("[outline] CreatePage raw response (status=%d): %q\n", resp.StatusCode, responsePreview)
+	verbosity.Debugf("[outline] CreatePage raw response (status=%d): %q\n", resp.StatusCode, responsePreview)
 
 	var createResp CreatePageResponse
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
+	}
 	if err := json.Unmarshal(bodyBytes, &createResp); err != nil {
 		return nil, fmt.Errorf("outline: decode response: %w (body: %s)", err, responsePreview)
 	}
 
-	fmt.Printf("[outline] CreatePage parsed response: id=%s, title=%s, slug=%s\n",
+	verbosity.Debugf("[outline] CreatePage parsed response: id=%s, title=%s, slug=%s\n",
 		createResp.Data.ID, createResp.Data.Title, createResp.Data.Slug)
 
+	if req.Publish && !publishOnCreate {
+		if _, err := c.PublishPage(ctx, PublishPageRequest{ID: createResp.Data.ID}); err != nil {
+			return &createResp, fmt.Errorf("outline: publish created page %s: %w", createResp.Data.ID, err)
+		}
+	}
 	return &createResp, nil
 }
 
@@ -476,36 +767,109 @@ type PublishPageResponse struct {
 // PublishPage publishes a draft page in Outline.
 // This converts a draft document to a published document.
 func (c *Client) PublishPage(ctx context.Context, req PublishPageRequest) (*PublishPageResponse, error) {
-	reqURL := c.baseURL.ResolveReference(&url.URL{Path: documentsUpdatePath})
-
+	if err := c.checkWritable("publish a page"); err != nil {
+		return nil, err
+	}
 	payload := map[string]any{
 		"id":      req.ID,
 		"publish": true, // Publish the document
 	}
 
-	body, err := json.Marshal(payload)
+	resp, bodyBytes, err := c.doRequest(ctx, documentsUpdatePath, payload)
 	if err != nil {
-		return nil, fmt.Errorf("outline: marshal request body: %w", err)
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("outline: new request: %w", err)
+	bodyStr := string(bodyBytes)
+	if resp.StatusCode != http.StatusOK {
+		errorPreview := bodyStr
+		if len(errorPreview) > 500 {
+			errorPreview = errorPreview[:500] + "..."
+		}
+		verbosity.Printf("[outline] PublishPage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
+	}
+
+	var publishResp PublishPageResponse
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bodyBytes, &publishResp); err != nil {
+		return nil, fmt.Errorf("outline: decode response: %w (body: %s)", err, bodyStr)
 	}
 
-	token := strings.TrimSpace(c.token)
-	httpReq.Header.Set("Authorization", "Bearer "+token)
-	httpReq.Header.Set("Content-Type", "application/json")
+	verbosity.Debugf("[outline] PublishPage success: id=%s, title=%s, slug=%s\n",
+		publishResp.Data.ID, publishResp.Data.Title, publishResp.Data.Slug)
+
+	return &publishResp, nil
+}
 
-	resp, err := c.httpClient.Do(httpReq)
+// UnpublishPage reverts a published page in Outline back to a draft.
+// The page and its revision history are kept; it simply stops being visible to readers.
+func (c *Client) UnpublishPage(ctx context.Context, pageID string) (*PublishPageResponse, error) {
+	if err := c.checkWritable("unpublish a page"); err != nil {
+		return nil, err
+	}
+	payload := map[string]any{
+		"id":      pageID,
+		"publish": false, // Revert the document to draft
+	}
+
+	resp, bodyBytes, err := c.doRequest(ctx, documentsUpdatePath, payload)
 	if err != nil {
-		return nil, fmt.Errorf("outline: request failed: %w", err)
+		return nil, err
+	}
+
+	bodyStr := string(bodyBytes)
+	if resp.StatusCode != http.StatusOK {
+		errorPreview := bodyStr
+		if len(errorPreview) > 500 {
+			errorPreview = errorPreview[:500] + "..."
+		}
+		verbosity.Printf("[outline] UnpublishPage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
+	}
+
+	var unpublishResp PublishPageResponse
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bodyBytes, &unpublishResp); err != nil {
+		return nil, fmt.Errorf("outline: decode response: %w (body: %s)", err, bodyStr)
+	}
+
+	verbosity.Debugf("[outline] UnpublishPage success: id=%s, title=%s, slug=%s\n",
+		unpublishResp.Data.ID, unpublishResp.Data.Title, unpublishResp.Data.Slug)
+
+	return &unpublishResp, nil
+}
+
+// Comment is a comment on an Outline document.
+type Comment struct {
+	ID         string    `json:"id"`
+	DocumentID string    `json:"documentId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type commentResponse struct {
+	Data Comment `json:"data"`
+}
+
+// CreateComment posts a Markdown comment on a page. It returns ErrCommentsDisabled
+// when Outline says commenting is disabled, and a *StatusError for anything
+// else, including a 404 for a page that no longer exists (see IsNotFound).
+func (c *Client) CreateComment(ctx context.Context, pageID, text string) (*Comment, error) {
+	if err := c.checkWritable("comment on a page"); err != nil {
+		return nil, err
+	}
+	payload := map[string]any{
+		"documentId": pageID,
+		"text":       text,
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		return nil, fmt.Errorf("outline: read response body: %w", readErr)
+	resp, bodyBytes, err := c.doRequest(ctx, commentsCreatePath, payload)
+	if err != nil {
+		return nil, err
 	}
 
 	bodyStr := string(bodyBytes)
@@ -514,19 +878,32 @@ func (c *Client) PublishPage(ctx context.Context, req PublishPageRequest) (*Publ
 		if len(errorPreview) > 500 {
 			errorPreview = errorPreview[:500] + "..."
 		}
-		fmt.Printf("[outline] PublishPage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
-		return nil, fmt.Errorf("outline: unexpected status code %d: %s", resp.StatusCode, errorPreview)
+		verbosity.Printf("[outline] CreateComment error response (status=%d): %q\n", resp.StatusCode, errorPreview)
+		statusErr := &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
+		if commentsDisabled(bodyStr) {
+			return nil, fmt.Errorf("%w: %w", ErrCommentsDisabled, statusErr)
+		}
+		return nil, statusErr
 	}
 
-	var publishResp PublishPageResponse
-	if err := json.Unmarshal(bodyBytes, &publishResp); err != nil {
+	var commentResp commentResponse
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bodyBytes, &commentResp); err != nil {
 		return nil, fmt.Errorf("outline: decode response: %w (body: %s)", err, bodyStr)
 	}
 
-	fmt.Printf("[outline] PublishPage success: id=%s, title=%s, slug=%s\n",
-		publishResp.Data.ID, publishResp.Data.Title, publishResp.Data.Slug)
+	return &commentResp.Data, nil
+}
 
-	return &publishResp, nil
+// commentsDisabled reports whether an error body from comments.create says
+// commenting is turned off, as opposed to the page or the token being the
+// problem.
+func commentsDisabled(body string) bool {
+	body = strings.ToLower(body)
+	return strings.Contains(body, "comment") &&
+		(strings.Contains(body, "disabled") || strings.Contains(body, "not enabled"))
 }
 
 // Collection represents a collection in Outline.
@@ -542,38 +919,106 @@ type ListCollectionsResponse struct {
 
 // ListCollections fetches all collections from Outline.
 func (c *Client) ListCollections(ctx context.Context) ([]Collection, error) {
-	reqURL := c.baseURL.ResolveReference(&url.URL{Path: collectionsListPath})
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader([]byte("{}")))
+	resp, listBody, err := c.doRequest(ctx, collectionsListPath, struct{}{})
 	if err != nil {
-		return nil, fmt.Errorf("outline: new request: %w", err)
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(listBody)}
 	}
 
-	token := strings.TrimSpace(c.token)
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
+	var listResp ListCollectionsResponse
+	if err := expectJSON(resp, listBody); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(listBody, &listResp); err != nil {
+		return nil, fmt.Errorf("outline: decode response: %w", err)
+	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("outline: request failed: %w", err)
+	return listResp.Data, nil
+}
+
+// CollectionInfo describes a collection and what the client's token may do in it.
+type CollectionInfo struct {
+	ID   string
+	Name string
+	// Permission is the collection's default for workspace members: "read",
+	// "read_write", or empty when access is granted per member.
+	Permission string
+	// CanCreateDocuments is the token's own createDocument ability from the
+	// response policies; nil when Outline didn't report one.
+	CanCreateDocuments *bool
+}
+
+// Writable reports whether the token can create documents in the collection,
+// falling back to the default permission when no policy was returned.
+func (i *CollectionInfo) Writable() bool {
+	if i.CanCreateDocuments != nil {
+		return *i.CanCreateDocuments
 	}
-	defer resp.Body.Close()
+	return i.Permission != "read"
+}
+
+type collectionInfoResponse struct {
+	Data struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		Permission string `json:"permission"`
+	} `json:"data"`
+	Policies []struct {
+		ID        string                     `json:"id"`
+		Abilities map[string]json.RawMessage `json:"abilities"`
+	} `json:"policies"`
+}
 
+// CollectionInfo fetches a collection and the token's permissions on it. A
+// token without access gets a *StatusError with status 403.
+func (c *Client) CollectionInfo(ctx context.Context, collectionID string) (*CollectionInfo, error) {
+	resp, bodyBytes, err := c.doRequest(ctx, collectionsInfoPath, map[string]any{"id": collectionID})
+	if err != nil {
+		return nil, err
+	}
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, readErr := io.ReadAll(resp.Body)
-		bodyStr := ""
-		if readErr == nil {
-			bodyStr = string(bodyBytes)
-		}
-		return nil, fmt.Errorf("outline: unexpected status code %d: %s", resp.StatusCode, bodyStr)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+	}
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
 	}
 
-	var listResp ListCollectionsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&listResp); err != nil {
+	var infoResp collectionInfoResponse
+	if err := json.Unmarshal(bodyBytes, &infoResp); err != nil {
 		return nil, fmt.Errorf("outline: decode response: %w", err)
 	}
 
-	return listResp.Data, nil
+	info := &CollectionInfo{
+		ID:         infoResp.Data.ID,
+		Name:       infoResp.Data.Name,
+		Permission: infoResp.Data.Permission,
+	}
+	for _, policy := range infoResp.Policies {
+		if policy.ID != info.ID {
+			continue
+		}
+		if raw, ok := policy.Abilities["createDocument"]; ok {
+			allowed := abilityGranted(raw)
+			info.CanCreateDocuments = &allowed
+		}
+	}
+	return info, nil
+}
+
+// abilityGranted decodes an Outline policy ability, which is a boolean or, in
+// newer versions, the list of memberships granting it.
+func abilityGranted(raw json.RawMessage) bool {
+	var allowed bool
+	if err := json.Unmarshal(raw, &allowed); err == nil {
+		return allowed
+	}
+	var grants []json.RawMessage
+	if err := json.Unmarshal(raw, &grants); err == nil {
+		return len(grants) > 0
+	}
+	return false
 }
 
 // CreateCollectionRequest represents the request to create a collection.
@@ -588,35 +1033,16 @@ type CreateCollectionResponse struct {
 
 // CreateCollection creates a new collection in Outline.
 func (c *Client) CreateCollection(ctx context.Context, req CreateCollectionRequest) (*CreateCollectionResponse, error) {
-	reqURL := c.baseURL.ResolveReference(&url.URL{Path: collectionsCreatePath})
-
+	if err := c.checkWritable("create a collection"); err != nil {
+		return nil, err
+	}
 	payload := map[string]any{
 		"name": req.Name,
 	}
 
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("outline: marshal request body: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader(body))
+	resp, bodyBytes, err := c.doRequest(ctx, collectionsCreatePath, payload)
 	if err != nil {
-		return nil, fmt.Errorf("outline: new request: %w", err)
-	}
-
-	token := strings.TrimSpace(c.token)
-	httpReq.Header.Set("Authorization", "Bearer "+token)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("outline: request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		return nil, fmt.Errorf("outline: read response body: %w", readErr)
+		return nil, err
 	}
 
 	bodyStr := string(bodyBytes)
@@ -625,84 +1051,71 @@ func (c *Client) CreateCollection(ctx context.Context, req CreateCollectionReque
 		if len(errorPreview) > 500 {
 			errorPreview = errorPreview[:500] + "..."
 		}
-		fmt.Printf("[outline] CreateCollection error response (status=%d): %q\n", resp.StatusCode, errorPreview)
-		return nil, fmt.Errorf("outline: unexpected status code %d: %s", resp.StatusCode, errorPreview)
+		verbosity.Printf("[outline] CreateCollection error response (status=%d): %q\n", resp.StatusCode, errorPreview)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
 	}
 
 	var createResp CreateCollectionResponse
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
+	}
 	if err := json.Unmarshal(bodyBytes, &createResp); err != nil {
 		return nil, fmt.Errorf("outline: decode response: %w (body: %s)", err, bodyStr)
 	}
 
-	fmt.Printf("[outline] CreateCollection success: id=%s, name=%s\n", createResp.Data.ID, createResp.Data.Name)
+	verbosity.Printf("[outline] CreateCollection success: id=%s, name=%s\n", createResp.Data.ID, createResp.Data.Name)
 
 	return &createResp, nil
 }
 
 // GetOrCreateCollection gets a collection by name, or creates it if it doesn't exist.
-// Retries on network errors with exponential backoff.
+// It returns ErrAmbiguousCollection rather than guess when the name is taken
+// by more than one collection.
 func (c *Client) GetOrCreateCollection(ctx context.Context, name string) (string, error) {
-	maxRetries := 3
-	var lastErr error
-
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			// Exponential backoff: 1s, 2s, 4s
-			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
-			fmt.Printf("[outline] Retrying GetOrCreateCollection (attempt %d/%d) after %v...\n", attempt+1, maxRetries, backoff)
-			select {
-			case <-ctx.Done():
-				return "", ctx.Err()
-			case <-time.After(backoff):
-			}
-		}
-
-		// List all collections
-		collections, err := c.ListCollections(ctx)
-		if err != nil {
-			lastErr = fmt.Errorf("outline: list collections: %w", err)
-			// Check if it's a network error that we should retry
-			if strings.Contains(err.Error(), "timeout") ||
-				strings.Contains(err.Error(), "EOF") ||
-				strings.Contains(err.Error(), "connection") {
-				continue // Retry
-			}
-			return "", lastErr
-		}
-
-		// Check if collection exists
-		for _, coll := range collections {
-			if coll.Name == name {
-				fmt.Printf("[outline] Collection '%s' already exists with ID: %s\n", name, coll.ID)
-				return coll.ID, nil
-			}
-		}
+	// List all collections
+	collections, err := c.ListCollections(ctx)
+	if err != nil {
+		return "", fmt.Errorf("outline: list collections: %w", err)
+	}
 
-		// Create collection if it doesn't exist
-		fmt.Printf("[outline] Collection '%s' not found, creating...\n", name)
-		createResp, err := c.CreateCollection(ctx, CreateCollectionRequest{Name: name})
-		if err != nil {
-			lastErr = fmt.Errorf("outline: create collection: %w", err)
-			// Check if it's a network error that we should retry
-			if strings.Contains(err.Error(), "timeout") ||
-				strings.Contains(err.Error(), "EOF") ||
-				strings.Contains(err.Error(), "connection") {
-				continue // Retry
-			}
-			return "", lastErr
+	// Check if collection exists. Outline doesn't enforce unique names, and
+	// picking one of several would be arbitrary
+	var ids []string
+	for _, coll := range collections {
+		if coll.Name == name {
+			ids = append(ids, coll.ID)
 		}
-
-		return createResp.Data.ID, nil
+	}
+	switch len(ids) {
+	case 0:
+	case 1:
+		verbosity.Printf("[outline] Collection '%s' already exists with ID: %s\n", name, ids[0])
+		return ids[0], nil
+	default:
+		verbosity.Printf("[outline] Collection name '%s' is ambiguous, matching IDs: %s\n", name, strings.Join(ids, ", "))
+		return "", fmt.Errorf("%w: %q matches collections %s; rename all but one", ErrAmbiguousCollection, name, strings.Join(ids, ", "))
 	}
 
-	return "", fmt.Errorf("outline: failed after %d attempts: %w", maxRetries, lastErr)
+	// Create collection if it doesn't exist
+	verbosity.Printf("[outline] Collection '%s' not found, creating...\n", name)
+	createResp, err := c.CreateCollection(ctx, CreateCollectionRequest{Name: name})
+	if err != nil {
+		return "", fmt.Errorf("outline: create collection: %w", err)
+	}
+	return createResp.Data.ID, nil
 }
 
 // UpdatePageRequest represents the request to update an existing page.
+// Without Append, a non-empty Text replaces the entire page body.
 type UpdatePageRequest struct {
 	ID    string `json:"id"`
 	Title string `json:"title,omitempty"`
 	Text  string `json:"text,omitempty"`
+	// Append adds Text to the end of the current page content instead of
+	// replacing it. Outline does the appending, so edits made in between
+	// aren't lost; the update is then not retried when it may have gone
+	// through, since a retry would append Text twice.
+	Append bool `json:"-"`
 }
 
 // UpdatePageResponse represents the response from updating a page.
@@ -716,41 +1129,31 @@ type UpdatePageResponse struct {
 
 // UpdatePage updates an existing page in Outline.
 func (c *Client) UpdatePage(ctx context.Context, req UpdatePageRequest) (*UpdatePageResponse, error) {
-	reqURL := c.baseURL.ResolveReference(&url.URL{Path: documentsUpdatePath})
-
+	if err := c.checkWritable("update a page"); err != nil {
+		return nil, err
+	}
 	payload := map[string]any{
 		"id": req.ID,
 	}
 	if req.Title != "" {
 		payload["title"] = req.Title
 	}
+	appending := req.Text != "" && req.Append
 	if req.Text != "" {
-		payload["text"] = req.Text
-	}
-
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("outline: marshal request body: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("outline: new request: %w", err)
+		text := req.Text
+		if appending {
+			// Outline adds text directly after the current content
+			if !strings.HasPrefix(text, "\n") {
+				text = "\n" + text
+			}
+			payload["append"] = true
+		}
+		payload["text"] = text
 	}
 
-	token := strings.TrimSpace(c.token)
-	httpReq.Header.Set("Authorization", "Bearer "+token)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(httpReq)
+	resp, bodyBytes, err := c.post(ctx, documentsUpdatePath, payload, !appending)
 	if err != nil {
-		return nil, fmt.Errorf("outline: request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		return nil, fmt.Errorf("outline: read response body: %w", readErr)
+		return nil, err
 	}
 
 	bodyStr := string(bodyBytes)
@@ -759,16 +1162,19 @@ func (c *Client) UpdatePage(ctx context.Context, req UpdatePageRequest) (*Update
 		if len(errorPreview) > 500 {
 			errorPreview = errorPreview[:500] + "..."
 		}
-		fmt.Printf("[outline] UpdatePage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
-		return nil, fmt.Errorf("outline: unexpected status code %d: %s", resp.StatusCode, errorPreview)
+		verbosity.Printf("[outline] UpdatePage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
 	}
 
 	var updateResp UpdatePageResponse
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
+	}
 	if err := json.Unmarshal(bodyBytes, &updateResp); err != nil {
 		return nil, fmt.Errorf("outline: decode response: %w (body: %s)", err, bodyStr)
 	}
 
-	fmt.Printf("[outline] UpdatePage success: id=%s, title=%s, slug=%s\n",
+	verbosity.Debugf("[outline] UpdatePage success: id=%s, title=%s, slug=%s\n",
 		updateResp.Data.ID, updateResp.Data.Title, updateResp.Data.Slug)
 
 	return &updateResp, nil
@@ -776,47 +1182,151 @@ func (c *Client) UpdatePage(ctx context.Context, req UpdatePageRequest) (*Update
 
 // DeletePage deletes a page in Outline.
 func (c *Client) DeletePage(ctx context.Context, pageID string) error {
-	reqURL := c.baseURL.ResolveReference(&url.URL{Path: documentsDeletePath})
-
+	if err := c.checkWritable("delete a page"); err != nil {
+		return err
+	}
 	payload := map[string]any{
 		"id": pageID,
 	}
 
-	body, err := json.Marshal(payload)
+	resp, bodyBytes, err := c.doRequest(ctx, documentsDeletePath, payload)
 	if err != nil {
-		return fmt.Errorf("outline: marshal request body: %w", err)
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		bodyStr := string(bodyBytes)
+		errorPreview := bodyStr
+		if len(errorPreview) > 500 {
+			errorPreview = errorPreview[:500] + "..."
+		}
+		verbosity.Printf("[outline] DeletePage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
+		return &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewReader(body))
+	// Outline API returns success even if the page doesn't exist, but a 200
+	// HTML page means a proxy answered instead
+	return expectJSON(resp, bodyBytes)
+}
+
+// ArchivePage archives a page in Outline. Archived pages are hidden from
+// collections but can be restored, unlike DeletePage.
+func (c *Client) ArchivePage(ctx context.Context, pageID string) error {
+	if err := c.checkWritable("archive a page"); err != nil {
+		return err
+	}
+	payload := map[string]any{
+		"id": pageID,
+	}
+
+	resp, bodyBytes, err := c.doRequest(ctx, documentsArchivePath, payload)
 	if err != nil {
-		return fmt.Errorf("outline: new request: %w", err)
+		return err
 	}
 
-	token := strings.TrimSpace(c.token)
-	httpReq.Header.Set("Authorization", "Bearer "+token)
-	httpReq.Header.Set("Content-Type", "application/json")
+	if resp.StatusCode != http.StatusOK {
+		errorPreview := string(bodyBytes)
+		if len(errorPreview) > 500 {
+			errorPreview = errorPreview[:500] + "..."
+		}
+		verbosity.Printf("[outline] ArchivePage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
+		return &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
+	}
+
+	// A 200 HTML page means a proxy answered, not Outline - don't report the page as archived
+	return expectJSON(resp, bodyBytes)
+}
+
+// RestorePage restores a deleted or archived page in Outline. Deleted pages can
+// only be restored while they are still in the trash; after that Outline
+// reports them as missing and ErrPageNotRestorable is returned.
+func (c *Client) RestorePage(ctx context.Context, pageID string) error {
+	if err := c.checkWritable("restore a page"); err != nil {
+		return err
+	}
+	payload := map[string]any{
+		"id": pageID,
+	}
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, bodyBytes, err := c.doRequest(ctx, documentsRestorePath, payload)
 	if err != nil {
-		return fmt.Errorf("outline: request failed: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		return fmt.Errorf("outline: read response body: %w", readErr)
+	if resp.StatusCode != http.StatusOK {
+		errorPreview := string(bodyBytes)
+		if len(errorPreview) > 500 {
+			errorPreview = errorPreview[:500] + "..."
+		}
+		verbosity.Printf("[outline] RestorePage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
+		statusErr := &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %w", ErrPageNotRestorable, statusErr)
+		}
+		return statusErr
+	}
+
+	return expectJSON(resp, bodyBytes)
+}
+
+// DocumentNode is a node in a collection's document tree as returned by
+// collections.documents. Children are nested documents in display order.
+type DocumentNode struct {
+	ID       string         `json:"id"`
+	Title    string         `json:"title"`
+	URL      string         `json:"url"`
+	Children []DocumentNode `json:"children,omitempty"`
+}
+
+type collectionDocumentsResponse struct {
+	Data []DocumentNode `json:"data"`
+}
+
+// GetCollectionStructure fetches the nested document tree of a collection.
+// Uses POST /api/collections.documents endpoint.
+func (c *Client) GetCollectionStructure(ctx context.Context, collectionID string) ([]DocumentNode, error) {
+	if collectionID == "" {
+		return nil, errors.New("outline: collection ID is required")
+	}
+	payload := map[string]any{
+		"id": collectionID,
+	}
+
+	resp, bodyBytes, err := c.doRequest(ctx, collectionsDocsPath, payload)
+	if err != nil {
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		bodyStr := string(bodyBytes)
-		errorPreview := bodyStr
+		errorPreview := string(bodyBytes)
 		if len(errorPreview) > 500 {
 			errorPreview = errorPreview[:500] + "..."
 		}
-		fmt.Printf("[outline] DeletePage error response (status=%d): %q\n", resp.StatusCode, errorPreview)
-		return fmt.Errorf("outline: unexpected status code %d: %s", resp.StatusCode, errorPreview)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: errorPreview}
 	}
 
-	// Outline API returns success even if the page doesn't exist
-	return nil
+	var structResp collectionDocumentsResponse
+	if err := expectJSON(resp, bodyBytes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bodyBytes, &structResp); err != nil {
+		return nil, fmt.Errorf("outline: decode response: %w", err)
+	}
+
+	return structResp.Data, nil
+}
+
+// ParentIndex flattens a document tree into a map of document ID to parent
+// document ID. Top-level documents map to an empty string.
+func ParentIndex(nodes []DocumentNode) map[string]string {
+	index := make(map[string]string)
+	var walk func(parentID string, children []DocumentNode)
+	walk = func(parentID string, children []DocumentNode) {
+		for _, node := range children {
+			index[node.ID] = parentID
+			walk(node.ID, node.Children)
+		}
+	}
+	walk("", nodes)
+	return index
 }
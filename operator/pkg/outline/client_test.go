@@ -0,0 +1,532 @@
+package outline
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newFakeOutline serves documents.export and documents.update for a single
+// page, recording the page's text after each update. Updates append like
+// Outline does, and exporting the page fails the test.
+func newFakeOutline(t *testing.T, markdown string) (*Client, *string) {
+	t.Helper()
+	updatedText := markdown
+	mux := http.NewServeMux()
+	mux.HandleFunc(documentsExportPath, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the page was exported")
+		_ = json.NewEncoder(w).Encode(map[string]string{"data": markdown})
+	})
+	mux.HandleFunc(documentsUpdatePath, func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ID     string `json:"id"`
+			Text   string `json:"text"`
+			Append bool   `json:"append"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode update payload: %v", err)
+		}
+		if payload.Append {
+			updatedText += payload.Text
+		} else {
+			updatedText = payload.Text
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]string{"id": payload.ID, "title": "Page", "urlId": "abc123"},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c, &updatedText
+}
+
+func TestUpdatePage(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		text     string
+		append   bool
+		want     string
+	}{
+		{
+			name:     "replace",
+			existing: "# Page\n\nOld body\n",
+			text:     "New body",
+			want:     "New body",
+		},
+		{
+			name:     "append starts a new line",
+			existing: "# Page",
+			text:     "More",
+			append:   true,
+			want:     "# Page\nMore",
+		},
+		{
+			name:     "append keeps a leading newline",
+			existing: "# Page\n",
+			text:     "\nMore",
+			append:   true,
+			want:     "# Page\n\nMore",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, updated := newFakeOutline(t, tt.existing)
+			resp, err := c.UpdatePage(context.Background(), UpdatePageRequest{ID: "doc-1", Text: tt.text, Append: tt.append})
+			if err != nil {
+				t.Fatalf("UpdatePage: %v", err)
+			}
+			if resp.Data.ID != "doc-1" {
+				t.Errorf("response ID = %q, want %q", resp.Data.ID, "doc-1")
+			}
+			if *updated != tt.want {
+				t.Errorf("page text = %q, want %q", *updated, tt.want)
+			}
+		})
+	}
+}
+
+func TestCABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": []Collection{{ID: "c1", Name: "Docs"}}})
+	}))
+	t.Cleanup(srv.Close)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	untrusted, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := untrusted.ListCollections(context.Background()); err == nil {
+		t.Fatal("expected certificate verification to fail without a CA bundle")
+	}
+
+	trusted, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", CABundle: caPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := trusted.ListCollections(context.Background()); err != nil {
+		t.Fatalf("ListCollections with CA bundle: %v", err)
+	}
+
+	if _, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", CABundle: []byte("not a certificate")}); err == nil {
+		t.Error("expected an error for a bundle without certificates")
+	}
+}
+
+func TestNonJSONResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<!DOCTYPE html>\n<html><body>Sign in to continue</body></html>"))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.ListCollections(context.Background())
+	var ctErr *ContentTypeError
+	if !errors.As(err, &ctErr) {
+		t.Fatalf("expected ContentTypeError, got %v", err)
+	}
+	if ctErr.StatusCode != http.StatusOK || !strings.Contains(ctErr.Snippet, "Sign in") {
+		t.Errorf("unexpected error details: %+v", ctErr)
+	}
+	if err := c.DeletePage(context.Background(), "doc-1"); !errors.As(err, &ctErr) {
+		t.Errorf("DeletePage: expected ContentTypeError, got %v", err)
+	}
+}
+
+func TestRestorePage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ID string `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != documentsRestorePath || payload.ID != "doc-1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"not_found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":"doc-1"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RestorePage(context.Background(), "doc-1"); err != nil {
+		t.Fatalf("RestorePage: %v", err)
+	}
+	if err := c.RestorePage(context.Background(), "purged"); !errors.Is(err, ErrPageNotRestorable) {
+		t.Errorf("expected ErrPageNotRestorable, got %v", err)
+	}
+}
+
+func TestCollectionInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ID string `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		switch payload.ID {
+		case "readonly":
+			// Writable by default, but this token's policy denies it
+			_, _ = w.Write([]byte(`{"data":{"id":"readonly","name":"Docs","permission":"read_write"},"policies":[{"id":"readonly","abilities":{"createDocument":false}}]}`))
+		case "member":
+			_, _ = w.Write([]byte(`{"data":{"id":"member","name":"Team","permission":null},"policies":[{"id":"member","abilities":{"createDocument":["membership-1"]}}]}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"authorization_error"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := c.CollectionInfo(context.Background(), "readonly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Name != "Docs" || info.Writable() {
+		t.Errorf("unexpected info for read-only collection: %+v", info)
+	}
+	info, err = c.CollectionInfo(context.Background(), "member")
+	if err != nil || !info.Writable() {
+		t.Errorf("member collection should be writable: %+v, %v", info, err)
+	}
+	var statusErr *StatusError
+	if _, err := c.CollectionInfo(context.Background(), "private"); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 StatusError, got %v", err)
+	}
+}
+
+func TestGetOrCreateCollectionAmbiguous(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != collectionsListPath {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"c1","name":"Diagnostics"},{"id":"c2","name":"Diagnostics"},{"id":"c3","name":"Docs"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := c.GetOrCreateCollection(context.Background(), "Docs"); err != nil || id != "c3" {
+		t.Errorf("GetOrCreateCollection(Docs) = %q, %v", id, err)
+	}
+	if _, err := c.GetOrCreateCollection(context.Background(), "Diagnostics"); !errors.Is(err, ErrAmbiguousCollection) {
+		t.Errorf("expected ErrAmbiguousCollection, got %v", err)
+	}
+}
+
+func TestGetPageContentEmpty(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			ID string `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		switch payload.ID {
+		case "blank":
+			_, _ = w.Write([]byte(`{"data":"\n"}`))
+		case "broken":
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":"# Hello"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content, err := c.GetPageContent(context.Background(), "doc"); err != nil || content.Markdown != "# Hello" {
+		t.Errorf("GetPageContent(doc) = %+v, %v", content, err)
+	}
+	content, err := c.GetPageContent(context.Background(), "blank")
+	if !errors.Is(err, ErrEmptyContent) || content == nil {
+		t.Errorf("expected ErrEmptyContent with content, got %+v, %v", content, err)
+	}
+	if _, err := c.GetPageContent(context.Background(), "broken"); err == nil || errors.Is(err, ErrEmptyContent) {
+		t.Errorf("missing data should be an export failure, got %v", err)
+	}
+}
+
+func TestReadOnlyClientRefusesWrites(t *testing.T) {
+	var writes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != documentsListPath && r.URL.Path != collectionsListPath {
+			writes = append(writes, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[],"pagination":{}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := c.ListPages(ctx); err != nil {
+		t.Fatalf("ListPages: %v", err)
+	}
+
+	calls := map[string]func() error{
+		"CreatePage": func() error { _, err := c.CreatePage(ctx, CreatePageRequest{Title: "t"}); return err },
+		"UpdatePage": func() error { _, err := c.UpdatePage(ctx, UpdatePageRequest{ID: "doc-1"}); return err },
+		"PublishPage": func() error {
+			_, err := c.PublishPage(ctx, PublishPageRequest{ID: "doc-1"})
+			return err
+		},
+		"DeletePage":            func() error { return c.DeletePage(ctx, "doc-1") },
+		"ArchivePage":           func() error { return c.ArchivePage(ctx, "doc-1") },
+		"GetOrCreateCollection": func() error { _, err := c.GetOrCreateCollection(ctx, "Docs"); return err },
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s: expected ErrReadOnly, got %v", name, err)
+		}
+	}
+	if len(writes) > 0 {
+		t.Errorf("read-only client sent writes: %v", writes)
+	}
+}
+
+// newPagedOutline serves a documents.list of n documents, reporting the total
+// when withTotal is set, and records the offsets asked for.
+func newPagedOutline(t *testing.T, n int, withTotal bool) (*Client, *[]int) {
+	t.Helper()
+	var offsets []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != documentsListPath {
+			_, _ = w.Write([]byte(`{"data":[]}`))
+			return
+		}
+		var payload struct {
+			Offset int `json:"offset"`
+			Limit  int `json:"limit"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		offsets = append(offsets, payload.Offset)
+		data := []map[string]any{}
+		for i := payload.Offset; i < min(payload.Offset+payload.Limit, n); i++ {
+			data = append(data, map[string]any{"id": fmt.Sprintf("p%d", i), "title": "Page", "isDraft": i%2 == 1})
+		}
+		resp := map[string]any{"data": data}
+		if withTotal {
+			resp["pagination"] = map[string]int{"limit": payload.Limit, "offset": payload.Offset, "total": n}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, &offsets
+}
+
+func TestListPagesPage(t *testing.T) {
+	c, _ := newPagedOutline(t, 250, true)
+	ctx := context.Background()
+
+	first, err := c.ListPagesPage(ctx, ListPagesPageOptions{Limit: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Pages) != 50 || first.Total != 250 || !first.Truncated || first.NextOffset != 100 {
+		t.Errorf("first page: %d pages, total %d, truncated %v, next %d",
+			len(first.Pages), first.Total, first.Truncated, first.NextOffset)
+	}
+	last, err := c.ListPagesPage(ctx, ListPagesPageOptions{Offset: 200, Limit: 100,
+		ListPagesOptions: ListPagesOptions{IncludeDrafts: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(last.Pages) != 50 || last.Truncated || last.NextOffset != 0 {
+		t.Errorf("last page: %d pages, truncated %v, next %d", len(last.Pages), last.Truncated, last.NextOffset)
+	}
+
+	// Without a total, a full page is taken to be followed by more
+	c, offsets := newPagedOutline(t, 200, false)
+	pages, err := c.ListPages(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 200 || len(*offsets) != 3 {
+		t.Errorf("ListPages: %d pages in %d requests (offsets %v)", len(pages), len(*offsets), *offsets)
+	}
+	if result, err := c.ListPagesPage(ctx, ListPagesPageOptions{}); err != nil || result.Total != -1 {
+		t.Errorf("unreported total: %+v, %v", result, err)
+	}
+
+	// With one, the request for the empty page after it is saved
+	c, offsets = newPagedOutline(t, 200, true)
+	if pages, err := c.ListPages(ctx); err != nil || len(pages) != 200 || len(*offsets) != 2 {
+		t.Errorf("ListPages with total: %d pages, %v, offsets %v", len(pages), err, *offsets)
+	}
+}
+
+func TestSearchPages(t *testing.T) {
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case documentsSearchPath:
+			payload = nil
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			_, _ = w.Write([]byte(`{"data":[
+				{"ranking":0.9,"context":"...","document":{"id":"p1","title":"AUTOTRANSLATED--> Setup","collectionId":"c1"}},
+				{"ranking":0.5,"context":"...","document":{"id":"p2","title":"Setup notes","isDraft":true}}]}`))
+		case collectionsListPath:
+			_, _ = w.Write([]byte(`{"data":[{"id":"c1","name":"Docs"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	pages, err := c.SearchPages(ctx, "Setup", SearchOptions{CollectionID: "c1", Limit: 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 1 || pages[0].ID != "p1" || pages[0].Collection != "Docs" {
+		t.Errorf("pages = %+v, want p1 in Docs with the draft left out", pages)
+	}
+	if payload["query"] != "Setup" || payload["collectionId"] != "c1" || payload["limit"] != float64(100) {
+		t.Errorf("payload = %v", payload)
+	}
+
+	pages, err = c.SearchPages(ctx, "Setup", SearchOptions{IncludeDrafts: true})
+	if err != nil || len(pages) != 2 {
+		t.Errorf("with drafts: %+v, %v", pages, err)
+	}
+	if _, ok := payload["collectionId"]; ok || payload["limit"] != float64(defaultSearchLimit) {
+		t.Errorf("unscoped payload = %v", payload)
+	}
+	if _, err := c.SearchPages(ctx, " ", SearchOptions{}); err == nil {
+		t.Error("searched for a blank query")
+	}
+}
+
+func TestSearchTitles(t *testing.T) {
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case documentsSearchTitlesPath:
+			payload = nil
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"p1","title":"AUTOTRANSLATED--> Setup","collectionId":"c1"},
+				{"id":"p2","title":"Setup notes","isDraft":true}]}`))
+		case collectionsListPath:
+			_, _ = w.Write([]byte(`{"data":[{"id":"c1","name":"Docs"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	pages, err := c.SearchTitles(ctx, "Setup", SearchOptions{CollectionID: "c1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 1 || pages[0].ID != "p1" || pages[0].Collection != "Docs" {
+		t.Errorf("pages = %+v, want p1 in Docs with the draft left out", pages)
+	}
+	if payload["query"] != "Setup" || payload["collectionId"] != "c1" {
+		t.Errorf("payload = %v", payload)
+	}
+
+	pages, err = c.SearchTitles(ctx, "Setup", SearchOptions{IncludeDrafts: true})
+	if err != nil || len(pages) != 2 {
+		t.Errorf("with drafts: %+v, %v", pages, err)
+	}
+	if _, err := c.SearchTitles(ctx, " ", SearchOptions{}); err == nil {
+		t.Error("searched for a blank query")
+	}
+}
+
+func TestCreateComment(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantDisabled bool
+		wantNotFound bool
+	}{
+		{name: "created", status: http.StatusOK, body: `{"data":{"id":"comment-1","documentId":"doc-1"}}`},
+		{name: "commenting disabled", status: http.StatusForbidden,
+			body: `{"ok":false,"error":"authorization_error","message":"Commenting is disabled"}`, wantDisabled: true},
+		{name: "no permission", status: http.StatusForbidden,
+			body: `{"ok":false,"error":"authorization_error","message":"Authorization error"}`},
+		{name: "page gone", status: http.StatusNotFound,
+			body: `{"ok":false,"error":"not_found","message":"Resource not found"}`, wantNotFound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(srv.Close)
+			c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			comment, err := c.CreateComment(context.Background(), "doc-1", "Looks good")
+			if tt.status == http.StatusOK {
+				if err != nil || comment.ID != "comment-1" {
+					t.Fatalf("CreateComment() = %+v, %v", comment, err)
+				}
+				return
+			}
+			if got := errors.Is(err, ErrCommentsDisabled); got != tt.wantDisabled {
+				t.Errorf("ErrCommentsDisabled = %v, want %v (err %v)", got, tt.wantDisabled, err)
+			}
+			if got := IsNotFound(err); got != tt.wantNotFound {
+				t.Errorf("IsNotFound = %v, want %v (err %v)", got, tt.wantNotFound, err)
+			}
+		})
+	}
+}
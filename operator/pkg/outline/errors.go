@@ -0,0 +1,112 @@
+package outline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// contentSnippetBytes bounds how much of an unexpected body is quoted in a ContentTypeError.
+const contentSnippetBytes = 200
+
+// StatusError is returned when Outline answers with a non-200 status code.
+type StatusError struct {
+	StatusCode int
+	// Body is the (possibly truncated) response body
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("outline: unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// ContentTypeError is returned when a call succeeds at the HTTP level but the
+// body isn't JSON, typically an HTML login or error page served by an auth
+// proxy or gateway in front of Outline, or a base URL pointing elsewhere.
+type ContentTypeError struct {
+	StatusCode  int
+	ContentType string
+	// RedirectedTo is the final URL when the request was redirected
+	RedirectedTo string
+	// Snippet is the start of the response body
+	Snippet string
+}
+
+func (e *ContentTypeError) Error() string {
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "no content type"
+	}
+	msg := fmt.Sprintf("outline: expected JSON, got %s (status %d)", contentType, e.StatusCode)
+	if e.RedirectedTo != "" {
+		msg += " after redirect to " + e.RedirectedTo
+	}
+	return msg + " - check base URL and auth proxy: " + e.Snippet
+}
+
+// expectJSON returns a ContentTypeError unless resp's body looks like JSON.
+// Bodies starting with an object or array are accepted whatever their content
+// type, since some proxies rewrite or drop the header on valid responses.
+func expectJSON(resp *http.Response, body []byte) error {
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		return nil
+	}
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return nil
+	}
+	if len(body) == 0 && mediaType == "" {
+		return nil
+	}
+
+	snippet := strings.Join(strings.Fields(trimmed), " ")
+	if len(snippet) > contentSnippetBytes {
+		snippet = snippet[:contentSnippetBytes] + "..."
+	}
+	ctErr := &ContentTypeError{StatusCode: resp.StatusCode, ContentType: contentType, Snippet: snippet}
+	if resp.Request != nil && resp.Request.Response != nil {
+		ctErr.RedirectedTo = resp.Request.URL.String()
+	}
+	return ctErr
+}
+
+// IsNotFound reports whether err is Outline answering 404, e.g. for a
+// document that was deleted.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// IsRetryable reports whether err is a transient failure worth retrying:
+// network timeouts, dropped or refused connections, rate limiting (429) and
+// server-side errors (5xx). Context cancellation and client errors are not.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
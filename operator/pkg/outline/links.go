@@ -0,0 +1,24 @@
+package outline
+
+import "regexp"
+
+// docLinkPattern matches Outline document links, relative (/doc/...) or
+// absolute, as they appear in link destinations and autolinks.
+var docLinkPattern = regexp.MustCompile(`/doc/[A-Za-z0-9_~-]+`)
+
+// LinkedDocuments returns the urlIds of the documents md links to, in order
+// of first appearance and without repeats. Links to other Outline instances
+// are included; callers resolve the urlIds against the pages they know.
+func LinkedDocuments(md string) []string {
+	var urlIDs []string
+	seen := make(map[string]bool)
+	for _, link := range docLinkPattern.FindAllString(md, -1) {
+		urlID := documentURLID(link)
+		if urlID == "" || seen[urlID] {
+			continue
+		}
+		seen[urlID] = true
+		urlIDs = append(urlIDs, urlID)
+	}
+	return urlIDs
+}
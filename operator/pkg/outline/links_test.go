@@ -0,0 +1,20 @@
+package outline
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLinkedDocuments(t *testing.T) {
+	md := "See the [guide](/doc/guides-Ab3dE5gH9k) and [notes](https://wiki.example.com/doc/release-notes-Zx8yW7vU6t#fixes).\n" +
+		"The [guide again](/doc/guides-Ab3dE5gH9k?edit=1), an <https://wiki.example.com/doc/faq-Mm4Nn5Bb6v> autolink,\n" +
+		"a [collection](/collection/docs-Qq1Ww2Ee3r) and an [external page](https://example.com/docs/page).\n"
+	got := LinkedDocuments(md)
+	want := []string{"Ab3dE5gH9k", "Zx8yW7vU6t", "Mm4Nn5Bb6v"}
+	if !slices.Equal(got, want) {
+		t.Errorf("LinkedDocuments() = %v, want %v", got, want)
+	}
+	if got := LinkedDocuments("No links here."); len(got) != 0 {
+		t.Errorf("LinkedDocuments() = %v, want none", got)
+	}
+}
@@ -0,0 +1,105 @@
+package outline
+
+import (
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+// defaultSlowCallThreshold is used when Config.SlowCallThreshold is unset.
+const defaultSlowCallThreshold = 5 * time.Second
+
+var (
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "glooscap_outline_request_duration_seconds",
+		Help:    "Latency of Outline API calls, including reading the response body.",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"endpoint", "code"})
+
+	responseSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "glooscap_outline_response_size_bytes",
+		Help:    "Size of Outline API response bodies.",
+		Buckets: prometheus.ExponentialBuckets(256, 4, 8), // 256B .. 4MiB
+	}, []string{"endpoint"})
+
+	slowCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "glooscap_outline_slow_calls_total",
+		Help: "Number of Outline API calls that exceeded the slow-call threshold.",
+	}, []string{"endpoint"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestDuration, responseSize, slowCalls)
+}
+
+// instrumentedTransport records per-endpoint latency and response size for
+// every Outline call and warns when a call exceeds slowThreshold.
+type instrumentedTransport struct {
+	base          http.RoundTripper
+	slowThreshold time.Duration
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Outline endpoints are RPC-style (/api/documents.list), so the last path
+	// segment identifies the call without exploding label cardinality.
+	endpoint := path.Base(req.URL.Path)
+	start := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.observe(endpoint, "error", start, 0)
+		return nil, err
+	}
+
+	resp.Body = &countingBody{
+		ReadCloser: resp.Body,
+		onClose: func(n int64) {
+			t.observe(endpoint, strconv.Itoa(resp.StatusCode), start, n)
+		},
+	}
+	return resp, nil
+}
+
+func (t *instrumentedTransport) observe(endpoint, code string, start time.Time, size int64) {
+	elapsed := time.Since(start)
+	requestDuration.WithLabelValues(endpoint, code).Observe(elapsed.Seconds())
+	if code != "error" {
+		responseSize.WithLabelValues(endpoint).Observe(float64(size))
+	}
+	if t.slowThreshold > 0 && elapsed > t.slowThreshold {
+		slowCalls.WithLabelValues(endpoint).Inc()
+		verbosity.Printf("[outline] WARNING: slow call to %s took %v (threshold %v, status=%s, %d bytes)\n",
+			endpoint, elapsed.Round(time.Millisecond), t.slowThreshold, code, size)
+	}
+}
+
+// countingBody counts bytes read from a response body and reports the total
+// once when the body is closed.
+type countingBody struct {
+	io.ReadCloser
+	n       int64
+	onClose func(n int64)
+	closed  bool
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	if !b.closed {
+		b.closed = true
+		b.onClose(b.n)
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package outline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDocumentNotFound is returned by ResolveDocument when no document matches
+// the reference.
+var ErrDocumentNotFound = errors.New("outline: no document matches")
+
+// ErrAmbiguousDocument is returned by ResolveDocument when a title matches more
+// than one document.
+var ErrAmbiguousDocument = errors.New("outline: several documents match")
+
+// ResolveDocument finds the published document ref refers to, so callers can
+// name a parent document the way users see it rather than by its internal ID.
+// ref may be the document's ID, its urlId, its URL or /doc/ slug, or its
+// title. collectionID, when set, restricts the search to that collection.
+func (c *Client) ResolveDocument(ctx context.Context, ref, collectionID string) (*PageSummary, error) {
+	pages, err := c.ListPagesWithOptions(ctx, ListPagesOptions{CollectionID: collectionID})
+	if err != nil {
+		return nil, fmt.Errorf("outline: list documents: %w", err)
+	}
+	return MatchDocument(pages, ref)
+}
+
+// MatchDocument picks the document ref refers to from pages; see
+// ResolveDocument. IDs and slugs are unique and win over titles. Titles are
+// compared exactly, then ignoring case, and must match a single document.
+func MatchDocument(pages []PageSummary, ref string) (*PageSummary, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("%w: empty document reference", ErrDocumentNotFound)
+	}
+	urlID := documentURLID(ref)
+
+	var exact, folded []*PageSummary
+	for i := range pages {
+		page := &pages[i]
+		if page.IsTemplate {
+			continue
+		}
+		if page.ID == ref || (page.Slug != "" && page.Slug == urlID) {
+			return page, nil
+		}
+		switch {
+		case page.Title == ref:
+			exact = append(exact, page)
+		case strings.EqualFold(page.Title, ref):
+			folded = append(folded, page)
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = folded
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w %q", ErrDocumentNotFound, ref)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, len(matches))
+	for i, page := range matches {
+		ids[i] = page.ID
+	}
+	return nil, fmt.Errorf("%w %q: %s; use the document's URL or ID instead", ErrAmbiguousDocument, ref, strings.Join(ids, ", "))
+}
+
+// documentURLID returns the urlId a document reference carries: the trailing
+// dash-separated segment of a /doc/{title-slug}-{urlId} URL or slug, or ref
+// itself.
+func documentURLID(ref string) string {
+	if i := strings.Index(ref, "/doc/"); i >= 0 {
+		ref = ref[i+len("/doc/"):]
+	}
+	ref = strings.TrimSuffix(ref, "/")
+	if i := strings.IndexAny(ref, "?#"); i >= 0 {
+		ref = ref[:i]
+	}
+	if i := strings.LastIndex(ref, "-"); i >= 0 {
+		return ref[i+1:]
+	}
+	return ref
+}
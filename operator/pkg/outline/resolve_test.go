@@ -0,0 +1,48 @@
+package outline
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatchDocument(t *testing.T) {
+	pages := []PageSummary{
+		{ID: "doc-1", Title: "Guides", Slug: "Ab3dE5gH9k"},
+		{ID: "doc-2", Title: "Release Notes", Slug: "Zx8yW7vU6t"},
+		{ID: "doc-3", Title: "release notes", Slug: "Qq1Ww2Ee3r"},
+		{ID: "doc-4", Title: "FAQ", Slug: "Mm4Nn5Bb6v"},
+		{ID: "doc-5", Title: "FAQ", Slug: "Lk9Jh8Gf7d"},
+		{ID: "tpl-1", Title: "Runbook", Slug: "Tt0Yy9Uu8i", IsTemplate: true},
+	}
+	tests := []struct {
+		ref    string
+		wantID string
+		err    error
+	}{
+		{ref: "doc-4", wantID: "doc-4"},
+		{ref: "Ab3dE5gH9k", wantID: "doc-1"},
+		{ref: "guides-Ab3dE5gH9k", wantID: "doc-1"},
+		{ref: "https://wiki.example.com/doc/guides-Ab3dE5gH9k?edit=1", wantID: "doc-1"},
+		{ref: " Guides ", wantID: "doc-1"},
+		{ref: "GUIDES", wantID: "doc-1"},
+		// An exact title wins over case-insensitive ones
+		{ref: "Release Notes", wantID: "doc-2"},
+		{ref: "RELEASE NOTES", err: ErrAmbiguousDocument},
+		{ref: "FAQ", err: ErrAmbiguousDocument},
+		{ref: "Runbook", err: ErrDocumentNotFound},
+		{ref: "Missing", err: ErrDocumentNotFound},
+		{ref: "", err: ErrDocumentNotFound},
+	}
+	for _, tt := range tests {
+		got, err := MatchDocument(pages, tt.ref)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("MatchDocument(%q) error = %v, want %v", tt.ref, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got.ID != tt.wantID {
+			t.Errorf("MatchDocument(%q) = %+v, %v; want %s", tt.ref, got, err, tt.wantID)
+		}
+	}
+}
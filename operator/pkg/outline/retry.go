@@ -0,0 +1,160 @@
+package outline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+// maxRetryWait caps the wait before a retry. A 429 asking for longer than this
+// isn't retried, since waiting less would only be rate limited again.
+const maxRetryWait = time.Minute
+
+// createPaths are the calls that create something each time they succeed. A
+// timeout or a 5xx may come after the server has done the work, so retrying
+// them could create a duplicate; see retryableCreate. Calls that are only
+// sometimes like this, such as an update appending to a page, go through post.
+var createPaths = map[string]bool{
+	documentsCreatePath:   true,
+	commentsCreatePath:    true,
+	collectionsCreatePath: true,
+}
+
+// doRequest posts payload as JSON to an Outline API path and returns the
+// response together with its body, already read and closed. Rate limiting
+// (429), server errors (5xx) and transient network failures (see IsRetryable)
+// are retried, up to the client's MaxRetries attempts in all, with exponential
+// backoff and jitter; a 429's Retry-After is waited out when it is longer.
+// Creates are only retried when the server can't have acted on them. No wait
+// runs past ctx's deadline. Once the attempts run out the last response is
+// returned, so callers still turn its status into their usual errors.
+func (c *Client) doRequest(ctx context.Context, path string, payload any) (*http.Response, []byte, error) {
+	return c.post(ctx, path, payload, !createPaths[path])
+}
+
+// post is doRequest for a call whose repeatability depends on its payload.
+// Unless idempotent, it is retried only like a create.
+func (c *Client) post(ctx context.Context, path string, payload any, idempotent bool) (*http.Response, []byte, error) {
+	reqURL := c.baseURL.ResolveReference(&url.URL{Path: path})
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("outline: marshal request body: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, respBody, err := c.send(ctx, reqURL.String(), body)
+		var reason string
+		switch {
+		case err != nil && IsRetryable(err):
+			reason = err.Error()
+		case err == nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500):
+			reason = fmt.Sprintf("status %d", resp.StatusCode)
+		default:
+			return resp, respBody, err
+		}
+		if !idempotent && !retryableCreate(resp, err) {
+			verbosity.Debugf("[outline] Not retrying %s after %s: it may have gone through\n", path, reason)
+			return resp, respBody, err
+		}
+		if attempt >= c.maxRetries {
+			return resp, respBody, err
+		}
+
+		wait, ok := c.retryWait(attempt, resp)
+		if deadline, hasDeadline := ctx.Deadline(); !ok || hasDeadline && time.Until(deadline) < wait {
+			verbosity.Debugf("[outline] Not retrying %s after %s: a %v wait is too long\n", path, reason, wait)
+			return resp, respBody, err
+		}
+		verbosity.Debugf("[outline] Retrying %s (attempt %d/%d) after %v: %s\n", path, attempt+1, c.maxRetries, wait, reason)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryableCreate reports whether a create, or another call that isn't
+// idempotent, that failed with resp or err certainly wasn't carried out: it was rate limited (429), refused as
+// unavailable (503), or the connection was refused before it was sent.
+func retryableCreate(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNREFUSED)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
+
+// send makes a single authenticated POST and reads the whole response body.
+func (c *Client) send(ctx context.Context, reqURL string, body []byte) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("outline: new request: %w", err)
+	}
+
+	// Ensure token is trimmed of any whitespace
+	token := strings.TrimSpace(c.token)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("outline: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := c.readBody(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("outline: read response body: %w", err)
+	}
+	return resp, respBody, nil
+}
+
+// retryWait is how long to wait before retrying after attempt: the backoff
+// base doubled for each earlier attempt, plus up to half again of jitter so
+// concurrent callers don't retry in lockstep, or a 429's Retry-After when that
+// is longer. ok is false when Retry-After asks for more than maxRetryWait.
+func (c *Client) retryWait(attempt int, resp *http.Response) (time.Duration, bool) {
+	wait := min(c.retryBackoff<<(attempt-1), maxRetryWait)
+	if wait <= 0 {
+		// The shift overflowed
+		wait = maxRetryWait
+	}
+	if wait >= 2 {
+		wait += rand.N(wait / 2)
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok && after > wait {
+			return after, after <= maxRetryWait
+		}
+	}
+	return wait, true
+}
+
+// parseRetryAfter reads a Retry-After header, given either in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,186 @@
+package outline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newFlakyOutline answers the first failures calls with status (and the
+// given Retry-After, when set), then succeeds.
+func newFlakyOutline(t *testing.T, failures int32, status int, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"ok":false}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"c1","name":"Docs"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestRequestsRetryTransientFailures(t *testing.T) {
+	cases := []struct {
+		name       string
+		failures   int32
+		status     int
+		retryAfter string
+		maxRetries int
+		wantCalls  int32
+		wantStatus int
+	}{
+		{name: "server error", failures: 2, status: http.StatusBadGateway, wantCalls: 3},
+		{name: "rate limited", failures: 1, status: http.StatusTooManyRequests, retryAfter: "0", wantCalls: 2},
+		{name: "attempts run out", failures: 5, status: http.StatusServiceUnavailable, maxRetries: 2,
+			wantCalls: 2, wantStatus: http.StatusServiceUnavailable},
+		{name: "client error is final", failures: 5, status: http.StatusForbidden,
+			wantCalls: 1, wantStatus: http.StatusForbidden},
+		{name: "retry-after too long", failures: 5, status: http.StatusTooManyRequests, retryAfter: "3600",
+			wantCalls: 1, wantStatus: http.StatusTooManyRequests},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, calls := newFlakyOutline(t, tc.failures, tc.status, tc.retryAfter)
+			c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", MaxRetries: tc.maxRetries, RetryBackoff: time.Millisecond})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			collections, err := c.ListCollections(context.Background())
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Errorf("%d calls, want %d", got, tc.wantCalls)
+			}
+			if tc.wantStatus == 0 {
+				if err != nil || len(collections) != 1 {
+					t.Errorf("ListCollections() = %v, %v", collections, err)
+				}
+				return
+			}
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tc.wantStatus {
+				t.Errorf("err = %v, want status %d", err, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCreatesOnlyRetryWhenNotCarriedOut(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    int
+		wantCalls int32
+	}{
+		{name: "gateway timeout", status: http.StatusGatewayTimeout, wantCalls: 1},
+		{name: "server error", status: http.StatusInternalServerError, wantCalls: 1},
+		{name: "unavailable", status: http.StatusServiceUnavailable, wantCalls: 2},
+		{name: "rate limited", status: http.StatusTooManyRequests, wantCalls: 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if calls.Add(1) == 1 {
+					w.WriteHeader(tc.status)
+					_, _ = w.Write([]byte(`{"ok":false}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":{"id":"doc-1","title":"Page"}}`))
+			}))
+			defer srv.Close()
+			c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", RetryBackoff: time.Millisecond})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, _ = c.CreatePage(context.Background(), CreatePageRequest{Title: "Page", Text: "text"})
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Errorf("%d calls, want %d", got, tc.wantCalls)
+			}
+		})
+	}
+
+	// Appending to a page isn't repeatable either, unlike other updates
+	for _, appending := range []bool{true, false} {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusGatewayTimeout)
+				_, _ = w.Write([]byte(`{"ok":false}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"id":"doc-1","title":"Page"}}`))
+		}))
+		c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", RetryBackoff: time.Millisecond})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = c.UpdatePage(context.Background(), UpdatePageRequest{ID: "doc-1", Text: "More", Append: appending})
+		srv.Close()
+		if want := map[bool]int32{true: 1, false: 2}[appending]; calls.Load() != want {
+			t.Errorf("append=%v: %d calls, want %d", appending, calls.Load(), want)
+		}
+	}
+
+	// A refused connection never reached the server
+	resp := &http.Response{StatusCode: http.StatusBadGateway}
+	if retryableCreate(resp, nil) {
+		t.Error("a 502 create would be retried")
+	}
+	if !retryableCreate(nil, fmt.Errorf("outline: request failed: %w", syscall.ECONNREFUSED)) {
+		t.Error("a refused create wouldn't be retried")
+	}
+}
+
+func TestRequestRetryStopsWithContext(t *testing.T) {
+	srv, calls := newFlakyOutline(t, 5, http.StatusServiceUnavailable, "")
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", RetryBackoff: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The deadline is too close for an hour's backoff, so no wait starts
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := c.ListCollections(ctx); !IsRetryable(err) {
+		t.Errorf("err = %v, want the 503", err)
+	}
+
+	// Without a deadline, cancelling ends the wait
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := c.ListCollections(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("%d calls, want 2", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("120"); !ok || d != 2*time.Minute {
+		t.Errorf("seconds: %v, %v", d, ok)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d < 59*time.Minute || d > time.Hour {
+		t.Errorf("HTTP date: %v, %v", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("accepted an invalid value")
+	}
+}
@@ -0,0 +1,68 @@
+package outline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+const revisionsInfoPath = "/api/revisions.info"
+
+// ErrRevisionNotFound is returned by GetPageRevisionContent when the revision
+// doesn't exist (any more) or belongs to another page.
+var ErrRevisionNotFound = errors.New("outline: revision not found")
+
+type revisionInfoResponse struct {
+	Data struct {
+		ID         string `json:"id"`
+		DocumentID string `json:"documentId"`
+		Title      string `json:"title"`
+		Text       string `json:"text"`
+	} `json:"data"`
+}
+
+// GetPageRevisionContent fetches the markdown of revisionID of a page through
+// revisions.info, or the page's current content when revisionID is empty. Like
+// GetPageContent, an empty revision returns ErrEmptyContent with its content.
+func (c *Client) GetPageRevisionContent(ctx context.Context, pageID, revisionID string) (*PageContent, error) {
+	if revisionID == "" {
+		return c.GetPageContent(ctx, pageID)
+	}
+
+	body, err := c.postJSON(ctx, revisionsInfoPath, map[string]string{"id": revisionID})
+	if err != nil {
+		var statusErr *StatusError
+		// Outline answers 400 for IDs that aren't UUIDs and 404 for unknown ones
+		if errors.As(err, &statusErr) &&
+			(statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusBadRequest) {
+			return nil, fmt.Errorf("%w: %s of page %s", ErrRevisionNotFound, revisionID, pageID)
+		}
+		return nil, err
+	}
+
+	var infoResp revisionInfoResponse
+	if err := json.Unmarshal(body, &infoResp); err != nil {
+		return nil, fmt.Errorf("outline: decode response: %w", err)
+	}
+	if infoResp.Data.DocumentID != pageID {
+		return nil, fmt.Errorf("%w: %s belongs to page %s, not %s",
+			ErrRevisionNotFound, revisionID, infoResp.Data.DocumentID, pageID)
+	}
+	verbosity.Debugf("[outline] GetPageRevisionContent: page=%s revision=%s markdown length=%d\n",
+		pageID, revisionID, len(infoResp.Data.Text))
+
+	content := &PageContent{
+		ID:       pageID,
+		Title:    infoResp.Data.Title,
+		Markdown: infoResp.Data.Text,
+	}
+	if strings.TrimSpace(content.Markdown) == "" {
+		return content, fmt.Errorf("%w: page %s revision %s", ErrEmptyContent, pageID, revisionID)
+	}
+	return content, nil
+}
@@ -0,0 +1,53 @@
+package outline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetPageRevisionContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var payload struct {
+			ID string `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		switch {
+		case r.URL.Path == documentsExportPath:
+			_, _ = w.Write([]byte(`{"data":"# Current"}`))
+		case r.URL.Path == revisionsInfoPath && payload.ID == "rev-approved":
+			_, _ = w.Write([]byte(`{"data":{"id":"rev-approved","documentId":"page-1","title":"Approved","text":"# Approved"}}`))
+		case r.URL.Path == revisionsInfoPath && payload.ID == "rev-elsewhere":
+			_, _ = w.Write([]byte(`{"data":{"id":"rev-elsewhere","documentId":"page-2","title":"Other","text":"# Other"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"not_found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	content, err := c.GetPageRevisionContent(ctx, "page-1", "")
+	if err != nil || content.Markdown != "# Current" {
+		t.Errorf("head: content %+v, err %v", content, err)
+	}
+	content, err = c.GetPageRevisionContent(ctx, "page-1", "rev-approved")
+	if err != nil || content.Markdown != "# Approved" || content.Title != "Approved" {
+		t.Errorf("pinned revision: content %+v, err %v", content, err)
+	}
+	for _, revisionID := range []string{"rev-elsewhere", "rev-deleted"} {
+		if _, err := c.GetPageRevisionContent(ctx, "page-1", revisionID); !errors.Is(err, ErrRevisionNotFound) {
+			t.Errorf("%s: err = %v, want ErrRevisionNotFound", revisionID, err)
+		}
+	}
+}
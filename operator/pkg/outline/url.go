@@ -0,0 +1,59 @@
+package outline
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DocumentURL builds the browser URL of an Outline document.
+//
+// Outline serves documents at /doc/{title-slug}-{urlId}. Only the urlId is used
+// to resolve the document, but it must be the trailing dash-separated segment,
+// and the router only accepts ASCII letters, digits, "-", "_" and "~" before it.
+// Returns "" when baseURI or urlID is empty.
+func DocumentURL(baseURI, title, urlID string) string {
+	if baseURI == "" || urlID == "" {
+		return ""
+	}
+	return strings.TrimSuffix(baseURI, "/") + "/doc/" + DocumentSlug(title, urlID)
+}
+
+// DocumentSlug returns the {title-slug}-{urlId} path segment for a document,
+// mirroring Outline's slugify (lower-cased, accents folded, punctuation dropped).
+func DocumentSlug(title, urlID string) string {
+	slug := slugify(title)
+	if slug == "" {
+		slug = "untitled"
+	}
+	return slug + "-" + urlID
+}
+
+// stripMarks folds accented characters to their base letter (é -> e).
+var stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+func slugify(title string) string {
+	folded, _, err := transform.String(stripMarks, title)
+	if err != nil {
+		folded = title
+	}
+
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(folded) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			dash = false
+		case unicode.IsSpace(r) || r == '-' || r == '.':
+			if !dash && b.Len() > 0 {
+				b.WriteByte('-')
+				dash = true
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,62 @@
+package outline
+
+import "testing"
+
+func TestDocumentURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURI string
+		title   string
+		urlID   string
+		want    string
+	}{
+		{
+			name:    "simple title",
+			baseURI: "https://wiki.example.com",
+			title:   "Getting Started",
+			urlID:   "Ab3dE5gH9k",
+			want:    "https://wiki.example.com/doc/getting-started-Ab3dE5gH9k",
+		},
+		{
+			name:    "trailing slash and prefixed title",
+			baseURI: "https://wiki.example.com/",
+			title:   "AUTOTRANSLATED--> Release Notes v1.2",
+			urlID:   "Ab3dE5gH9k",
+			want:    "https://wiki.example.com/doc/autotranslated-release-notes-v1-2-Ab3dE5gH9k",
+		},
+		{
+			name:    "accents and punctuation",
+			baseURI: "https://wiki.example.com",
+			title:   "Résumé: l'équipe & «projets»",
+			urlID:   "Ab3dE5gH9k",
+			want:    "https://wiki.example.com/doc/resume-lequipe-projets-Ab3dE5gH9k",
+		},
+		{
+			name:    "empty title",
+			baseURI: "https://wiki.example.com",
+			title:   "",
+			urlID:   "Ab3dE5gH9k",
+			want:    "https://wiki.example.com/doc/untitled-Ab3dE5gH9k",
+		},
+		{
+			name:    "missing urlId",
+			baseURI: "https://wiki.example.com",
+			title:   "Getting Started",
+			want:    "",
+		},
+		{
+			name:  "missing base URI",
+			title: "Getting Started",
+			urlID: "Ab3dE5gH9k",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DocumentURL(tt.baseURI, tt.title, tt.urlID); got != tt.want {
+				t.Errorf("DocumentURL(%q, %q, %q) = %q, want %q", tt.baseURI, tt.title, tt.urlID, got, tt.want)
+			}
+		})
+	}
+}
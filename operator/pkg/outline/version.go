@@ -0,0 +1,115 @@
+package outline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dasmlab/glooscap-operator/pkg/verbosity"
+)
+
+const (
+	authInfoPath         = "/api/auth.info"
+	installationInfoPath = "/api/installation.info"
+
+	// minFeatureVersion is the oldest Outline release whose documents.list
+	// reports the template flag and whose documents.create accepts publish.
+	// Older or unidentified servers get the title heuristic and a separate
+	// documents.update call instead.
+	minFeatureVersion = "0.72.0"
+)
+
+// ServerInfo describes the Outline instance a client talks to, as found by
+// DetectServer.
+type ServerInfo struct {
+	// Version is the Outline release, empty when the server doesn't report it.
+	Version string
+	// TemplateFlag is true when documents.list says which documents are
+	// templates. Without it templates are guessed from their title.
+	TemplateFlag bool
+	// PublishOnCreate is true when documents.create can publish the page it
+	// creates. Without it CreatePage publishes with a second call.
+	PublishOnCreate bool
+}
+
+// DetectServer checks the token against auth.info and asks installation.info
+// for the Outline version, then records which features the client may use.
+// Servers that don't expose their version are treated as old ones, so the
+// error is only for a rejected token or an unreachable server.
+func (c *Client) DetectServer(ctx context.Context) (ServerInfo, error) {
+	if _, err := c.postJSON(ctx, authInfoPath, struct{}{}); err != nil {
+		return ServerInfo{}, err
+	}
+
+	info := ServerInfo{}
+	body, err := c.postJSON(ctx, installationInfoPath, struct{}{})
+	if err == nil {
+		var resp struct {
+			Data struct {
+				Version string `json:"version"`
+			} `json:"data"`
+		}
+		if json.Unmarshal(body, &resp) == nil {
+			info.Version = strings.TrimPrefix(strings.TrimSpace(resp.Data.Version), "v")
+		}
+	}
+	if info.Version != "" && versionAtLeast(info.Version, minFeatureVersion) {
+		info.TemplateFlag = true
+		info.PublishOnCreate = true
+	}
+	verbosity.Debugf("[outline] DetectServer: version=%q templateFlag=%v publishOnCreate=%v\n",
+		info.Version, info.TemplateFlag, info.PublishOnCreate)
+
+	c.serverMu.Lock()
+	c.server = info
+	c.serverMu.Unlock()
+	return info, nil
+}
+
+// Server returns what the last DetectServer call found. Before any detection
+// every feature is off and the client sticks to calls all versions support.
+func (c *Client) Server() ServerInfo {
+	c.serverMu.RLock()
+	defer c.serverMu.RUnlock()
+	return c.server
+}
+
+// postJSON posts payload to an Outline API path and returns the body of a 200
+// JSON response. Other statuses come back as a *StatusError.
+func (c *Client) postJSON(ctx context.Context, path string, payload any) ([]byte, error) {
+	resp, body, err := c.doRequest(ctx, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	if err := expectJSON(resp, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// versionAtLeast compares dotted release numbers such as "0.78.1". Anything
+// after a '-' or '+' is ignored, and parts that aren't numbers count as 0.
+func versionAtLeast(version, minimum string) bool {
+	parse := func(v string) [3]int {
+		var parts [3]int
+		if i := strings.IndexAny(v, "-+"); i >= 0 {
+			v = v[:i]
+		}
+		for i, field := range strings.SplitN(v, ".", 3) {
+			parts[i], _ = strconv.Atoi(field)
+		}
+		return parts
+	}
+	have, want := parse(version), parse(minimum)
+	for i := range have {
+		if have[i] != want[i] {
+			return have[i] > want[i]
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package outline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeOutline serves auth.info, an installation.info reporting version (or a
+// 404 when version is empty) and a documents.list with one flagged template.
+func fakeOutline(t *testing.T, version string, created *map[string]any, published *int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case authInfoPath:
+			_, _ = w.Write([]byte(`{"data":{"user":{"id":"u1"}}}`))
+		case installationInfoPath:
+			if version == "" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"ok":false,"error":"not_found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{"version": version}})
+		case documentsListPath:
+			var payload map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if payload["offset"].(float64) > 0 {
+				_, _ = w.Write([]byte(`{"data":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"p1","title":"Release Template (EN)","template":false},
+				{"id":"p2","title":"Onboarding (EN)","template":true}]}`))
+		case collectionsListPath:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case documentsCreatePath:
+			_ = json.NewDecoder(r.Body).Decode(created)
+			_, _ = w.Write([]byte(`{"data":{"id":"new","title":"t","urlId":"t"}}`))
+		case documentsUpdatePath:
+			*published++
+			_, _ = w.Write([]byte(`{"data":{"id":"new","title":"t","urlId":"t"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDetectServer(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       string
+		wantTemplates []string
+		wantFeatures  bool
+	}{
+		{name: "current release", version: "0.80.2", wantTemplates: []string{"p2"}, wantFeatures: true},
+		{name: "old release", version: "0.65.0", wantTemplates: []string{"p1"}},
+		{name: "unknown version", version: "", wantTemplates: []string{"p1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created map[string]any
+			var published int
+			srv := fakeOutline(t, tt.version, &created, &published)
+			c, err := NewClient(Config{BaseURL: srv.URL, Token: "test-token", RetryBackoff: time.Millisecond})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			info, err := c.DetectServer(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Version != tt.version || info.TemplateFlag != tt.wantFeatures || info.PublishOnCreate != tt.wantFeatures {
+				t.Errorf("DetectServer() = %+v", info)
+			}
+			if c.Server() != info {
+				t.Errorf("Server() = %+v, want %+v", c.Server(), info)
+			}
+
+			pages, err := c.ListPages(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var templates []string
+			for _, p := range pages {
+				if p.IsTemplate {
+					templates = append(templates, p.ID)
+				}
+			}
+			if len(templates) != len(tt.wantTemplates) || templates[0] != tt.wantTemplates[0] {
+				t.Errorf("templates = %v, want %v", templates, tt.wantTemplates)
+			}
+
+			if _, err := c.CreatePage(context.Background(), CreatePageRequest{Title: "t", Text: "x", Publish: true}); err != nil {
+				t.Fatal(err)
+			}
+			if _, inline := created["publish"]; inline != tt.wantFeatures {
+				t.Errorf("publish sent with create = %v, want %v", inline, tt.wantFeatures)
+			}
+			if wantPublishCalls := map[bool]int{true: 0, false: 1}[tt.wantFeatures]; published != wantPublishCalls {
+				t.Errorf("%d separate publish calls, want %d", published, wantPublishCalls)
+			}
+		})
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	for _, tt := range []struct {
+		version string
+		want    bool
+	}{
+		{"0.72.0", true},
+		{"0.72.1", true},
+		{"1.0.0-beta", true},
+		{"0.71.9", false},
+		{"0.9.0", false},
+	} {
+		if got := versionAtLeast(tt.version, minFeatureVersion); got != tt.want {
+			t.Errorf("versionAtLeast(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
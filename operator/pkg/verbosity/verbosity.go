@@ -0,0 +1,92 @@
+// Package verbosity gates the diagnostic lines glooscap prints directly to
+// stdout, outside the structured controller-runtime logger.
+//
+// The level is read from GLOOSCAP_LOG_LEVEL ("quiet", "info" or "debug",
+// default "info"); GLOOSCAP_QUIET=true is shorthand for "quiet". Per-page and
+// per-heartbeat output is only printed at "debug".
+package verbosity
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Level controls which stdout diagnostics are printed.
+type Level int32
+
+const (
+	// LevelQuiet suppresses all direct stdout diagnostics.
+	LevelQuiet Level = iota
+	// LevelInfo prints lifecycle events, warnings and errors.
+	LevelInfo
+	// LevelDebug additionally prints per-page, per-request and per-heartbeat detail.
+	LevelDebug
+)
+
+var level atomic.Int32
+
+func init() {
+	level.Store(int32(FromEnv()))
+}
+
+// String returns the level name as accepted by ParseLevel.
+func (l Level) String() string {
+	switch l {
+	case LevelQuiet:
+		return "quiet"
+	case LevelDebug:
+		return "debug"
+	default:
+		return "info"
+	}
+}
+
+// ParseLevel parses a level name ("quiet", "info" or "debug").
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "quiet", "off", "none":
+		return LevelQuiet, nil
+	case "", "info":
+		return LevelInfo, nil
+	case "debug", "verbose":
+		return LevelDebug, nil
+	}
+	return LevelInfo, fmt.Errorf("verbosity: unknown level %q (want quiet, info or debug)", s)
+}
+
+// FromEnv resolves the level from GLOOSCAP_QUIET and GLOOSCAP_LOG_LEVEL.
+// Unknown values fall back to LevelInfo.
+func FromEnv() Level {
+	if quiet, err := strconv.ParseBool(os.Getenv("GLOOSCAP_QUIET")); err == nil && quiet {
+		return LevelQuiet
+	}
+	l, _ := ParseLevel(os.Getenv("GLOOSCAP_LOG_LEVEL"))
+	return l
+}
+
+// SetLevel changes the level for the whole process.
+func SetLevel(l Level) {
+	level.Store(int32(l))
+}
+
+// CurrentLevel returns the active level.
+func CurrentLevel() Level {
+	return Level(level.Load())
+}
+
+// Printf prints to stdout unless the level is LevelQuiet.
+func Printf(format string, args ...any) {
+	if CurrentLevel() >= LevelInfo {
+		fmt.Printf(format, args...)
+	}
+}
+
+// Debugf prints to stdout only at LevelDebug.
+func Debugf(format string, args ...any) {
+	if CurrentLevel() >= LevelDebug {
+		fmt.Printf(format, args...)
+	}
+}
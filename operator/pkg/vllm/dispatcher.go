@@ -9,6 +9,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/credentials"
 )
 
 // Mode represents the backend execution strategy.
@@ -32,6 +35,21 @@ type Request struct {
 	LanguageTag  string
 	SourceTarget string
 	Mode         Mode
+	// Tokens are handed to the runner in a Secret; see RunnerToken.
+	Tokens []RunnerToken
+}
+
+// RunnerToken is an Outline token the runner reads from an environment
+// variable or a file in the token directory, as its WikiTarget's token
+// provider says, but which only the operator's pod has. Set one of Env and
+// Path.
+type RunnerToken struct {
+	// Env is the environment variable the runner reads the token from.
+	Env string
+	// Path is the file the runner reads the token from, relative to the
+	// token directory.
+	Path  string
+	Value string
 }
 
 // TektonJobDispatcher submits Kubernetes Jobs that in turn invoke the vLLM API.
@@ -40,6 +58,10 @@ type TektonJobDispatcher struct {
 	Namespace    string
 	Image        string
 	APIServerURL string
+	// Env is added to the runner container's environment after
+	// TRANSLATION_SERVICE_ADDR and TRANSLATION_SERVICE_SECURE, so runners apply the same translation settings
+	// as the operator.
+	Env []corev1.EnvVar
 }
 
 // Dispatch creates or patches a Job that runs the translation-runner container.
@@ -64,7 +86,7 @@ func (d *TektonJobDispatcher) Dispatch(ctx context.Context, req Request) error {
 			Namespace: ns,
 			Labels: map[string]string{
 				"app.kubernetes.io/managed-by": "glooscap-operator",
-				"glooscap.dasmlab.org/job":     req.JobName,
+				wikiv1alpha1.LabelJob:          req.JobName,
 			},
 		},
 		Spec: batchv1.JobSpec{
@@ -75,7 +97,7 @@ func (d *TektonJobDispatcher) Dispatch(ctx context.Context, req Request) error {
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
 						"app.kubernetes.io/managed-by": "glooscap-operator",
-						"glooscap.dasmlab.org/job":     req.JobName,
+						wikiv1alpha1.LabelJob:          req.JobName,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -94,7 +116,7 @@ func (d *TektonJobDispatcher) Dispatch(ctx context.Context, req Request) error {
 							Args: []string{
 								"--translation-job", fmt.Sprintf("%s/%s", ns, req.JobName),
 							},
-							Env: []corev1.EnvVar{
+							Env: append([]corev1.EnvVar{
 								{
 									Name: "TRANSLATION_SERVICE_ADDR",
 									ValueFrom: &corev1.EnvVarSource{
@@ -107,7 +129,19 @@ func (d *TektonJobDispatcher) Dispatch(ctx context.Context, req Request) error {
 										},
 									},
 								},
-							},
+								{
+									Name: "TRANSLATION_SERVICE_SECURE",
+									ValueFrom: &corev1.EnvVarSource{
+										ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{
+												Name: "glooscap-config",
+											},
+											Key:      "translation-service-secure",
+											Optional: ptr.To(true),
+										},
+									},
+								},
+							}, d.Env...),
 						},
 					},
 					ServiceAccountName: "operator-controller-manager", // Use operator's service account which has RBAC
@@ -116,10 +150,97 @@ func (d *TektonJobDispatcher) Dispatch(ctx context.Context, req Request) error {
 		},
 	}
 
-	return d.Client.Patch(ctx, job, client.Apply, &client.PatchOptions{
+	var secret *corev1.Secret
+	if len(req.Tokens) > 0 {
+		secret = runnerTokenSecret(job, req.Tokens)
+	}
+
+	if err := d.Client.Patch(ctx, job, client.Apply, &client.PatchOptions{
+		Force:        ptr.To(true),
+		FieldManager: "glooscap-operator",
+	}); err != nil {
+		return err
+	}
+	if secret == nil {
+		return nil
+	}
+	// Owned by the Job, so it goes when the Job is cleaned up. The runner pod
+	// waits for it if it starts first.
+	secret.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "batch/v1",
+		Kind:       "Job",
+		Name:       job.Name,
+		UID:        job.UID,
+	}}
+	if err := d.Client.Patch(ctx, secret, client.Apply, &client.PatchOptions{
 		Force:        ptr.To(true),
 		FieldManager: "glooscap-operator",
-	})
+	}); err != nil {
+		return fmt.Errorf("store runner tokens: %w", err)
+	}
+	return nil
+}
+
+// runnerTokenSecret returns the Secret holding tokens for job's runner, and
+// has the runner read them from it: env tokens through variables referring to
+// the Secret, and file tokens from the Secret mounted as the token directory.
+func runnerTokenSecret(job *batchv1.Job, tokens []RunnerToken) *corev1.Secret {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name + "-tokens",
+			Namespace: job.Namespace,
+			Labels:    job.Labels,
+		},
+		Data: map[string][]byte{},
+	}
+	pod := &job.Spec.Template.Spec
+	container := &pod.Containers[0]
+	var files []corev1.KeyToPath
+	for _, token := range tokens {
+		switch {
+		case token.Env != "":
+			if _, ok := secret.Data[token.Env]; ok {
+				continue
+			}
+			secret.Data[token.Env] = []byte(token.Value)
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name: token.Env,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+						Key:                  token.Env,
+					},
+				},
+			})
+		case token.Path != "":
+			key := fmt.Sprintf("file-%d", len(files))
+			secret.Data[key] = []byte(token.Value)
+			files = append(files, corev1.KeyToPath{Key: key, Path: token.Path})
+		}
+	}
+	if len(files) > 0 {
+		dir := credentials.TokenDir()
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: "tokens",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+					Items:      files,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "tokens",
+			MountPath: dir,
+			ReadOnly:  true,
+		})
+		container.Env = append(container.Env, corev1.EnvVar{Name: "GLOOSCAP_TOKEN_DIR", Value: dir})
+	}
+	return secret
 }
 
 // InlineDispatcher is a placeholder that will call the vLLM API directly in-process.
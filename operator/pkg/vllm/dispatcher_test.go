@@ -0,0 +1,122 @@
+package vllm
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/dasmlab/glooscap-operator/pkg/credentials"
+)
+
+// applied records the objects Dispatch applies; the fake client can't apply.
+func applied(t *testing.T) (client.Client, map[string]client.Object) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	objects := map[string]client.Object{}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			obj.SetUID(types.UID("uid-" + obj.GetName()))
+			objects[obj.GetObjectKind().GroupVersionKind().Kind] = obj
+			return nil
+		},
+	}).Build()
+	return c, objects
+}
+
+func TestDispatchRunnerTokens(t *testing.T) {
+	t.Setenv("GLOOSCAP_TOKEN_DIR", "/etc/glooscap/tokens")
+	c, objects := applied(t)
+	d := &TektonJobDispatcher{Client: c, Image: "runner:latest"}
+
+	err := d.Dispatch(context.Background(), Request{
+		JobName:   "guide-fr",
+		Namespace: "docs",
+		Tokens: []RunnerToken{
+			{Env: "GLOOSCAP_TOKEN_WIKI", Value: "env-token"},
+			{Path: "outline/token", Value: "file-token"},
+			// Two targets sharing a variable need it only once
+			{Env: "GLOOSCAP_TOKEN_WIKI", Value: "env-token"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+
+	job := objects["Job"].(*batchv1.Job)
+	secret := objects["Secret"].(*corev1.Secret)
+	if secret.Name != "translation-guide-fr-tokens" || secret.Namespace != "docs" {
+		t.Errorf("secret %s/%s, want docs/translation-guide-fr-tokens", secret.Namespace, secret.Name)
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != job.UID {
+		t.Errorf("secret owners %+v, want the Job", secret.OwnerReferences)
+	}
+	if string(secret.Data["GLOOSCAP_TOKEN_WIKI"]) != "env-token" || string(secret.Data["file-0"]) != "file-token" || len(secret.Data) != 2 {
+		t.Errorf("secret data %q", secret.Data)
+	}
+
+	pod := job.Spec.Template.Spec
+	container := pod.Containers[0]
+	env := map[string]corev1.EnvVar{}
+	for _, v := range container.Env {
+		if _, ok := env[v.Name]; ok {
+			t.Errorf("%s set twice", v.Name)
+		}
+		env[v.Name] = v
+	}
+	wiki := env["GLOOSCAP_TOKEN_WIKI"]
+	if wiki.Value != "" || wiki.ValueFrom == nil || wiki.ValueFrom.SecretKeyRef == nil ||
+		wiki.ValueFrom.SecretKeyRef.Name != secret.Name || wiki.ValueFrom.SecretKeyRef.Key != "GLOOSCAP_TOKEN_WIKI" {
+		t.Errorf("GLOOSCAP_TOKEN_WIKI = %+v, want a reference to the secret", wiki)
+	}
+	if secure := env["TRANSLATION_SERVICE_SECURE"].ValueFrom; secure == nil || secure.ConfigMapKeyRef == nil ||
+		secure.ConfigMapKeyRef.Key != "translation-service-secure" {
+		t.Errorf("TRANSLATION_SERVICE_SECURE = %+v, want the glooscap-config setting", env["TRANSLATION_SERVICE_SECURE"])
+	}
+	if dir := env["GLOOSCAP_TOKEN_DIR"].Value; dir != credentials.TokenDir() {
+		t.Errorf("GLOOSCAP_TOKEN_DIR = %q, want %q", dir, credentials.TokenDir())
+	}
+
+	if len(pod.Volumes) != 1 || pod.Volumes[0].Secret == nil || pod.Volumes[0].Secret.SecretName != secret.Name {
+		t.Fatalf("volumes %+v, want the token secret", pod.Volumes)
+	}
+	items := pod.Volumes[0].Secret.Items
+	if len(items) != 1 || items[0].Key != "file-0" || items[0].Path != "outline/token" {
+		t.Errorf("volume items %+v", items)
+	}
+	if len(container.VolumeMounts) != 1 || container.VolumeMounts[0].MountPath != "/etc/glooscap/tokens" || !container.VolumeMounts[0].ReadOnly {
+		t.Errorf("volume mounts %+v, want the token directory", container.VolumeMounts)
+	}
+}
+
+func TestDispatchWithoutRunnerTokens(t *testing.T) {
+	c, objects := applied(t)
+	d := &TektonJobDispatcher{Client: c, Image: "runner:latest"}
+	if err := d.Dispatch(context.Background(), Request{JobName: "guide-fr", Namespace: "docs"}); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if _, ok := objects["Secret"]; ok {
+		t.Error("a token secret was created for a job without file or env tokens")
+	}
+	pod := objects["Job"].(*batchv1.Job).Spec.Template.Spec
+	if len(pod.Volumes) != 0 || len(pod.Containers[0].VolumeMounts) != 0 {
+		t.Errorf("volumes %+v mounted without tokens", pod.Volumes)
+	}
+	for _, v := range pod.Containers[0].Env {
+		if v.Name == "GLOOSCAP_TOKEN_DIR" {
+			t.Error("GLOOSCAP_TOKEN_DIR set without file tokens")
+		}
+	}
+}
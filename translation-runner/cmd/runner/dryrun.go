@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dasmlab/glooscap-operator/pkg/markdown"
+	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
+)
+
+// dryRun skips every Kubernetes write and Outline publish; the translation is
+// printed to resultOut as JSON instead. Set by --dry-run and --source-file.
+var dryRun bool
+
+// resultOut receives the dry-run JSON. In dry-run mode the progress output
+// that normally goes to stdout is sent to stderr so stdout stays parseable.
+var resultOut io.Writer = os.Stdout
+
+// dryRunResult is the JSON document printed by a dry run.
+type dryRunResult struct {
+	Job                  string  `json:"job,omitempty"`
+	SourceFile           string  `json:"sourceFile,omitempty"`
+	SourcePageID         string  `json:"sourcePageId,omitempty"`
+	SourceTitle          string  `json:"sourceTitle"`
+	SourceLanguage       string  `json:"sourceLanguage"`
+	TargetLanguage       string  `json:"targetLanguage"`
+	TranslatedTitle      string  `json:"translatedTitle"`
+	TranslatedMarkdown   string  `json:"translatedMarkdown"`
+	TokensUsed           int32   `json:"tokensUsed"`
+	InferenceTimeSeconds float64 `json:"inferenceTimeSeconds"`
+	Engine               string  `json:"engine,omitempty"`
+	EngineVersion        string  `json:"engineVersion,omitempty"`
+	CacheHit             bool    `json:"cacheHit"`
+}
+
+// enableDryRun switches the runner to dry-run mode.
+func enableDryRun() {
+	dryRun = true
+	resultOut = os.Stdout
+	os.Stdout = os.Stderr
+}
+
+func printDryRunResult(result dryRunResult) error {
+	enc := json.NewEncoder(resultOut)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// translateSourceFile translates a local markdown file without a cluster or
+// wiki, printing the result as JSON. The title defaults to the file's first
+// heading, or its name.
+func translateSourceFile(path, title, sourceLang, targetLang, translationServiceAddr string, cache *nanabush.TranslationCache, check nanabush.TranslationCheckConfig) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read source file: %w", err)
+	}
+	source := string(data)
+	if title == "" {
+		title = markdownTitle(source, path)
+	}
+	protected := markdown.Protect(source)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	fmt.Printf("Translating %s (source: %s -> target: %s) via %s\n", path, sourceLang, targetLang, translationServiceAddr)
+	serviceConfig, err := translationServiceConfig(translationServiceAddr, "")
+	if err != nil {
+		return err
+	}
+	nanabushClient, err := nanabush.NewClient(serviceConfig)
+	if err != nil {
+		return fmt.Errorf("connect to translation service: %w", err)
+	}
+	defer nanabushClient.Close()
+
+	resp, hit, err := cache.Translate(ctx, nanabushClient, nanabush.TranslateRequest{
+		JobID:     "source-file-" + filepath.Base(path),
+		Primitive: "doc-translate",
+		Document: &nanabush.DocumentContent{
+			Title:    title,
+			Markdown: protected.Text,
+		},
+		SourceLanguage: sourceLang,
+		TargetLanguage: targetLang,
+	})
+	if err != nil {
+		return fmt.Errorf("translation failed: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("translation service returned error: %s", resp.ErrorMessage)
+	}
+	if err := nanabush.CheckTranslation(protected.Text, resp.TranslatedMarkdown, check); err != nil {
+		return err
+	}
+	translated, missing := protected.Restore(resp.TranslatedMarkdown)
+	if len(missing) > 0 {
+		fmt.Printf("warning: translation dropped %d of %d protected markdown part(s)\n", len(missing), protected.Len())
+	}
+
+	return printDryRunResult(dryRunResult{
+		SourceFile:           path,
+		SourceTitle:          title,
+		SourceLanguage:       sourceLang,
+		TargetLanguage:       targetLang,
+		TranslatedTitle:      resp.TranslatedTitle,
+		TranslatedMarkdown:   translated,
+		TokensUsed:           resp.TokensUsed,
+		InferenceTimeSeconds: resp.InferenceTimeSeconds,
+		Engine:               resp.Engine,
+		EngineVersion:        resp.EngineVersion,
+		CacheHit:             hit,
+	})
+}
+
+// markdownTitle returns the text of the first level-one heading in markdown,
+// falling back to path's base name without its extension.
+func markdownTitle(markdown, path string) string {
+	for _, line := range strings.Split(markdown, "\n") {
+		if heading, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok && strings.TrimSpace(heading) != "" {
+			return strings.TrimSpace(heading)
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
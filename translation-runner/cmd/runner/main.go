@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,21 +17,78 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/credentials"
+	"github.com/dasmlab/glooscap-operator/pkg/jobcontent"
+	"github.com/dasmlab/glooscap-operator/pkg/markdown"
 	"github.com/dasmlab/glooscap-operator/pkg/nanabush"
 	"github.com/dasmlab/glooscap-operator/pkg/outline"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 )
 
+// Diagnostic modes control how repeated diagnostic runs write to the destination wiki.
+const (
+	// diagnosticModeUpdate replaces the existing diag page content with this run's output.
+	diagnosticModeUpdate = "update"
+	// diagnosticModeAppend keeps the existing diag page content and appends this run's marker.
+	diagnosticModeAppend = "append"
+	// diagnosticModeNew creates a distinct, timestamped page for every run.
+	diagnosticModeNew = "new"
+)
+
+// defaultDiagnosticCollection is the collection diagnostic pages are written to
+// on the diagnostic target when the job doesn't name one.
+const defaultDiagnosticCollection = "GLOOSCAP-DIAG"
+
 func main() {
 	var translationJobRef string
 	var translationServiceAddr string
+	var translationCachePath string
+	var translationCacheTTL time.Duration
 	flag.StringVar(&translationJobRef, "translation-job", "", "TranslationJob reference in format namespace/name")
 	flag.StringVar(&translationServiceAddr, "translation-service-addr", "", "Translation service gRPC address (or use TRANSLATION_SERVICE_ADDR env)")
+	flag.StringVar(&translationCachePath, "translation-cache-path", os.Getenv("TRANSLATION_CACHE_PATH"),
+		"Shared translation cache file (or use TRANSLATION_CACHE_PATH env). Empty disables the cache.")
+	flag.DurationVar(&translationCacheTTL, "translation-cache-ttl", 24*time.Hour, "How long cached translations are reused")
+	var dryRunFlag bool
+	var sourceFile, sourceFileTitle, sourceFileLang, sourceFileTargetLang string
+	flag.BoolVar(&dryRunFlag, "dry-run", false,
+		"Fetch and translate the job's page, then print the result as JSON to stdout without updating the job or publishing")
+	flag.StringVar(&sourceFile, "source-file", "", "Translate a local markdown file instead of a TranslationJob (implies --dry-run)")
+	flag.StringVar(&sourceFileTitle, "title", "", "Title for --source-file (default: first heading or file name)")
+	flag.StringVar(&sourceFileLang, "source-language", "en", "Source language for --source-file")
+	flag.StringVar(&sourceFileTargetLang, "target-language", "fr-CA", "Target language for --source-file")
 	flag.Parse()
 
+	if dryRunFlag || sourceFile != "" {
+		enableDryRun()
+	}
+
+	// Get translation service address from env if not provided
+	if translationServiceAddr == "" {
+		translationServiceAddr = os.Getenv("TRANSLATION_SERVICE_ADDR")
+	}
+	if translationServiceAddr == "" {
+		translationServiceAddr = "iskoces-service.iskoces.svc.cluster.local:50051" // Default
+	}
+	translationCheck := nanabush.TranslationCheckConfigFromEnv()
+
+	if sourceFile != "" {
+		var cache *nanabush.TranslationCache
+		if translationCachePath != "" {
+			var err error
+			cache, err = nanabush.NewTranslationCache(nanabush.CacheConfig{TTL: translationCacheTTL, Path: translationCachePath, Check: translationCheck})
+			if err != nil {
+				fmt.Printf("warning: translation cache unavailable, translating without it: %v\n", err)
+			}
+		}
+		if err := translateSourceFile(sourceFile, sourceFileTitle, sourceFileLang, sourceFileTargetLang, translationServiceAddr, cache, translationCheck); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if translationJobRef == "" {
-		fmt.Fprintf(os.Stderr, "error: --translation-job is required\n")
+		fmt.Fprintf(os.Stderr, "error: --translation-job or --source-file is required\n")
 		os.Exit(1)
 	}
 
@@ -39,13 +98,8 @@ func main() {
 	fmt.Println("========================================")
 	fmt.Printf("Step 1: Job scheduled, data received\n")
 	fmt.Printf("  TranslationJob: %s\n", translationJobRef)
-
-	// Get translation service address from env if not provided
-	if translationServiceAddr == "" {
-		translationServiceAddr = os.Getenv("TRANSLATION_SERVICE_ADDR")
-	}
-	if translationServiceAddr == "" {
-		translationServiceAddr = "iskoces-service.iskoces.svc.cluster.local:50051" // Default
+	if dryRun {
+		fmt.Printf("  Dry run: the job will not be updated and nothing will be published\n")
 	}
 	fmt.Printf("  Translation Service: %s\n", translationServiceAddr)
 
@@ -99,20 +153,46 @@ func main() {
 	}
 	
 	// Check if this is a publish job
-	isPublishJob := job.Spec.Parameters["publish"] == "true"
+	isPublishJob := job.IsPublishJob()
 	if isPublishJob {
 		fmt.Printf("  This is a PUBLISH job (publishing draft page)\n")
 		fmt.Printf("  Original Job: %s\n", job.Spec.Parameters["originalJob"])
 		fmt.Printf("  Page ID to publish: %s\n", job.Spec.Parameters["pageId"])
+		if dryRun {
+			fmt.Fprintf(os.Stderr, "error: publish jobs only publish, so there is nothing to dry-run\n")
+			os.Exit(1)
+		}
 	}
 
 	// Check if this is a diagnostic job
-	isDiagnostic := job.Labels["glooscap.dasmlab.org/diagnostic"] == "true" ||
+	isDiagnostic := job.Labels[wikiv1alpha1.LabelDiagnostic] == "true" ||
 		job.Spec.Parameters["diagnostic"] == "true"
 	prefix := "AUTOTRANSLATED"
+	diagnosticMode := diagnosticModeUpdate
 	if isDiagnostic {
 		prefix = "AUTODIAG"
 		fmt.Printf("  Diagnostic job detected - will use %s prefix\n", prefix)
+		if mode := job.Spec.Parameters["diagnosticMode"]; mode != "" {
+			switch mode {
+			case diagnosticModeUpdate, diagnosticModeAppend, diagnosticModeNew:
+				diagnosticMode = mode
+			default:
+				fmt.Printf("warning: unknown diagnosticMode %q, falling back to %q\n", mode, diagnosticModeUpdate)
+			}
+		}
+		fmt.Printf("  Diagnostic mode: %s\n", diagnosticMode)
+	}
+	// Diagnostic jobs only publish when a dedicated diagnostic target is configured
+	diagnosticTarget := ""
+	diagnosticCollection := defaultDiagnosticCollection
+	if isDiagnostic {
+		diagnosticTarget = job.Spec.Parameters["diagnosticTarget"]
+		if c := job.Spec.Parameters["diagnosticCollection"]; c != "" {
+			diagnosticCollection = c
+		}
+		if diagnosticTarget != "" {
+			fmt.Printf("  Diagnostic target: %s (collection %s)\n", diagnosticTarget, diagnosticCollection)
+		}
 	}
 
 	// Update job status to Running
@@ -122,8 +202,10 @@ func main() {
 	if job.Status.StartedAt == nil {
 		job.Status.StartedAt = &now
 	}
-	if err := k8sClient.Status().Update(ctx, &job); err != nil {
-		fmt.Printf("warning: failed to update job status: %v\n", err)
+	if !dryRun {
+		if err := k8sClient.Status().Update(ctx, &job); err != nil {
+			fmt.Printf("warning: failed to update job status: %v\n", err)
+		}
 	}
 
 	// Step 2: Source page is pulled down and handled locally
@@ -157,30 +239,14 @@ func main() {
 
 	// Create Outline client helper function
 	createOutlineClient := func(target *wikiv1alpha1.WikiTarget) (*outline.Client, error) {
-		if target.Spec.ServiceAccountSecretRef.Name == "" {
-			return nil, fmt.Errorf("service account secret ref is empty")
-		}
-
-		var secret corev1.Secret
-		key := types.NamespacedName{
-			Namespace: target.Namespace,
-			Name:      target.Spec.ServiceAccountSecretRef.Name,
-		}
-		if err := k8sClient.Get(ctx, key, &secret); err != nil {
-			return nil, fmt.Errorf("get secret %s: %w", key, err)
-		}
-
-		keyName := target.Spec.ServiceAccountSecretRef.Key
-		if keyName == "" {
-			keyName = "token"
+		token, err := credentials.OutlineToken(ctx, k8sClient, target)
+		if err != nil {
+			return nil, err
 		}
-
-		tokenBytes, ok := secret.Data[keyName]
-		if !ok {
-			return nil, fmt.Errorf("key %q not found in secret %s", keyName, key)
+		caBundle, err := credentials.CABundle(ctx, k8sClient, target)
+		if err != nil {
+			return nil, err
 		}
-
-		token := strings.TrimSpace(string(tokenBytes))
 		// Default to skipping TLS verification (like operator does) to handle self-signed certs
 		// Network is transient, so we accept certs to verify connection is working
 		skipTLS := target.Spec.InsecureSkipTLSVerify
@@ -188,11 +254,23 @@ func main() {
 			// Default to true if not explicitly set (matches operator behavior)
 			skipTLS = true
 		}
-		return outline.NewClient(outline.Config{
+		outlineClient, err := outline.NewClient(outline.Config{
 			BaseURL:              target.Spec.URI,
 			Token:                token,
 			InsecureSkipTLSVerify: skipTLS,
+			CABundle:              caBundle,
+			ReadOnly:              target.Spec.Mode == wikiv1alpha1.WikiTargetModeReadOnly,
 		})
+		if err != nil {
+			return nil, err
+		}
+		// Unknown versions fall back to the calls every Outline release supports
+		if server, err := outlineClient.DetectServer(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not detect Outline version of %s: %v\n", target.Spec.URI, err)
+		} else {
+			fmt.Printf("Outline version at %s: %q\n", target.Spec.URI, server.Version)
+		}
+		return outlineClient, nil
 	}
 
 	// Handle publish job (publish draft page)
@@ -262,7 +340,7 @@ func main() {
 		// Build page URL
 		pageURL := ""
 		if destTarget.Spec.URI != "" {
-			pageURL = fmt.Sprintf("%s/doc/%s", strings.TrimSuffix(destTarget.Spec.URI, "/"), publishResp.Data.Slug)
+			pageURL = outline.DocumentURL(destTarget.Spec.URI, publishResp.Data.Title, publishResp.Data.Slug)
 			fmt.Printf("  URL: %s\n", pageURL)
 		}
 		
@@ -273,13 +351,12 @@ func main() {
 		job.Status.Message = fmt.Sprintf("Page published successfully (page: %s)", publishResp.Data.Slug)
 		
 		// Store published page info in annotations
-		if job.Annotations == nil {
-			job.Annotations = make(map[string]string)
-		}
-		job.Annotations["glooscap.dasmlab.org/published-page-id"] = publishResp.Data.ID
-		job.Annotations["glooscap.dasmlab.org/published-page-slug"] = publishResp.Data.Slug
-		job.Annotations["glooscap.dasmlab.org/published-page-url"] = pageURL
-		job.Annotations["glooscap.dasmlab.org/is-draft"] = "false"
+		wikiv1alpha1.SetPublishedPage(&job, wikiv1alpha1.PublishedPage{
+			ID:    publishResp.Data.ID,
+			Slug:  publishResp.Data.Slug,
+			URL:   pageURL,
+			Title: publishResp.Data.Title,
+		})
 		
 		if err := k8sClient.Update(ctx, &job); err != nil {
 			fmt.Printf("warning: failed to update job annotations: %v\n", err)
@@ -312,6 +389,7 @@ func main() {
 	var sourcePageTitle string
 	var sourcePageSlug string
 	var sourceCollectionID string
+	var sourcePageLanguage string
 	
 	if isDiagnostic && job.Spec.Parameters["testContent"] != "" {
 		// Use embedded test content for diagnostic jobs
@@ -334,8 +412,22 @@ func main() {
 	} else {
 		// Fetch from source wiki
 		fmt.Printf("Fetching page content for pageID: %s\n", job.Spec.Source.PageID)
+		revisionID := job.Spec.Source.PinnedRevision()
+		if revisionID != "" {
+			fmt.Printf("Using pinned revision: %s\n", revisionID)
+		}
 		var err error
-		pageContent, err = sourceClient.GetPageContent(ctx, job.Spec.Source.PageID)
+		pageContent, err = sourceClient.GetPageRevisionContent(ctx, job.Spec.Source.PageID, revisionID)
+		if errors.Is(err, outline.ErrRevisionNotFound) {
+			fmt.Fprintf(os.Stderr, "error: pinned revision %s is gone: %v\n", revisionID, err)
+			updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Pinned source revision %s no longer exists: %v", revisionID, err))
+			os.Exit(1)
+		}
+		if errors.Is(err, outline.ErrEmptyContent) {
+			fmt.Fprintf(os.Stderr, "error: source page %s is empty\n", job.Spec.Source.PageID)
+			updateJobStatusFailed(ctx, k8sClient, &job, "Source page has no content to translate")
+			os.Exit(1)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "error: failed to fetch page content: %v\n", err)
 			updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Failed to fetch page content: %v", err))
@@ -351,11 +443,14 @@ func main() {
 			if p.ID == job.Spec.Source.PageID {
 				sourcePageTitle = p.Title
 				sourcePageSlug = p.Slug
-				sourceCollectionID = p.Collection
+				// Summaries carry the collection's name; find its ID the way the operator does
+				sourceCollectionID = sourceTarget.Status.CollectionIDFor(p.Collection)
+				sourcePageLanguage = p.Language
 				break
 			}
 		}
-		if sourcePageTitle == "" && pageContent.Title != "" {
+		// A pinned revision is translated with the title it had then
+		if (sourcePageTitle == "" || revisionID != "") && pageContent.Title != "" {
 			sourcePageTitle = pageContent.Title
 		}
 		if sourcePageSlug == "" && pageContent.Slug != "" {
@@ -379,19 +474,18 @@ func main() {
 		targetLang = job.Spec.Destination.LanguageTag
 	}
 
-	// Determine source language (default to en)
-	sourceLang := "en"
+	// Determine source language: the page's, then the source target's default, then en
+	sourceLang := sourceTarget.Spec.SourceLanguage(sourcePageLanguage)
 
 	// Create translation service client (portable gRPC client)
 	fmt.Printf("Connecting to translation service: %s\n", translationServiceAddr)
-	nanabushClient, err := nanabush.NewClient(nanabush.Config{
-		Address:       translationServiceAddr,
-		Secure:        false, // TODO: make configurable
-		ClientName:    "glooscap-translation-runner",
-		ClientVersion: "1.0.0",
-		Namespace:     namespace,
-		Timeout:       30 * time.Second,
-	})
+	serviceConfig, err := translationServiceConfig(translationServiceAddr, namespace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Invalid translation service configuration: %v", err))
+		os.Exit(1)
+	}
+	nanabushClient, err := nanabush.NewClient(serviceConfig)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: failed to create translation service client: %v\n", err)
 		updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Failed to connect to translation service: %v", err))
@@ -410,13 +504,19 @@ func main() {
 	fmt.Printf("Translating page (source: %s -> target: %s)...\n", sourceLang, targetLang)
 	fmt.Printf("Source content preview (first 200 chars):\n%s\n", truncateString(pageContent.Markdown, 200))
 	
+	// Keep links, mentions, embeds and code away from the model
+	protected := markdown.Protect(pageContent.Markdown)
+	if protected.Len() > 0 {
+		fmt.Printf("Protected %d link(s), mention(s), embed(s) and code span(s) from translation\n", protected.Len())
+	}
+
 	translateReq := nanabush.TranslateRequest{
 		JobID:     job.Name,
 		Namespace: namespace,
 		Primitive: "doc-translate",
 		Document: &nanabush.DocumentContent{
 			Title:    sourcePageTitle,
-			Markdown: pageContent.Markdown,
+			Markdown: protected.Text,
 			Slug:     sourcePageSlug,
 		},
 		SourceLanguage: sourceLang,
@@ -424,6 +524,7 @@ func main() {
 		SourceWikiURI:  sourceTarget.Spec.URI,
 		PageID:         job.Spec.Source.PageID,
 		PageSlug:       sourcePageSlug,
+		Context:        job.Spec.Context,
 	}
 
 	fmt.Printf("Calling translation service with:\n")
@@ -434,7 +535,23 @@ func main() {
 	fmt.Printf("  Document Title: %s\n", translateReq.Document.Title)
 	fmt.Printf("  Document Length: %d chars\n", len(translateReq.Document.Markdown))
 
-	translateResp, err := nanabushClient.Translate(ctx, translateReq)
+	// Diagnostic jobs exercise the translation service, so never serve them from the cache
+	var translationCache *nanabush.TranslationCache
+	if translationCachePath != "" && !isDiagnostic {
+		translationCache, err = nanabush.NewTranslationCache(nanabush.CacheConfig{
+			TTL:   translationCacheTTL,
+			Path:  translationCachePath,
+			Check: translationCheck,
+		})
+		if err != nil {
+			fmt.Printf("warning: translation cache unavailable, translating without it: %v\n", err)
+		}
+	}
+
+	translateResp, cacheHit, err := translationCache.Translate(ctx, nanabushClient, translateReq)
+	if cacheHit {
+		fmt.Printf("✓ Translation served from cache (%s)\n", translationCachePath)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: translation failed: %v\n", err)
 		updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Translation failed: %v", err))
@@ -446,23 +563,79 @@ func main() {
 	fmt.Printf("  JobID: %s\n", translateResp.JobID)
 	fmt.Printf("  Tokens used: %d\n", translateResp.TokensUsed)
 	fmt.Printf("  Inference time: %.2fs\n", translateResp.InferenceTimeSeconds)
+	if translateResp.Engine != "" || translateResp.EngineVersion != "" {
+		fmt.Printf("  Engine: %s %s\n", translateResp.Engine, translateResp.EngineVersion)
+	}
 	if translateResp.ErrorMessage != "" {
 		fmt.Printf("  Error Message: %s\n", translateResp.ErrorMessage)
 	}
 
+	// Every status update from here on carries the tokens spent, for namespace budgets
+	job.Status.TokensUsed = int64(translateResp.TokensUsed)
+	job.Status.InferenceTimeMilliseconds = translateResp.InferenceTime().Milliseconds()
+	if !translateResp.CompletedAt.IsZero() {
+		completedAt := metav1.NewTime(translateResp.CompletedAt)
+		job.Status.TranslationCompletedAt = &completedAt
+	}
+	job.Status.Engine = nil
+	if translateResp.Engine != "" || translateResp.EngineVersion != "" {
+		job.Status.Engine = &wikiv1alpha1.TranslationEngine{Name: translateResp.Engine, Version: translateResp.EngineVersion}
+	}
+
 	if !translateResp.Success {
 		fmt.Fprintf(os.Stderr, "error: translation service returned error: %s\n", translateResp.ErrorMessage)
 		updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Translation failed: %s", translateResp.ErrorMessage))
 		os.Exit(1)
 	}
 
+	if job.Spec.MaxTokens > 0 && job.Status.TokensUsed > job.Spec.MaxTokens {
+		fmt.Fprintf(os.Stderr, "error: translation used %d tokens, over the job's limit of %d\n", job.Status.TokensUsed, job.Spec.MaxTokens)
+		updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Token limit exceeded (%d/%d tokens)", job.Status.TokensUsed, job.Spec.MaxTokens))
+		os.Exit(1)
+	}
+
+	// A service can report success without translating anything
+	if err := nanabush.CheckTranslation(protected.Text, translateResp.TranslatedMarkdown, translationCheck); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Suspicious translation, not published: %v", err))
+		os.Exit(1)
+	}
+
+	restored, missing := protected.Restore(translateResp.TranslatedMarkdown)
+	translateResp.TranslatedMarkdown = restored
+	if len(missing) > 0 {
+		fmt.Printf("warning: translation dropped %d of %d protected markdown part(s)\n", len(missing), protected.Len())
+	}
+
 	fmt.Printf("✓ Translation completed successfully\n")
 	fmt.Printf("  Translated Title: %s\n", translateResp.TranslatedTitle)
 	fmt.Printf("  Translated content length: %d characters\n", len(translateResp.TranslatedMarkdown))
 	fmt.Printf("  Translated content preview (first 500 chars):\n%s\n", truncateString(translateResp.TranslatedMarkdown, 500))
 
-	// Step 4: Create target destination page with PREFIX (skip for diagnostic jobs)
-	if isDiagnostic {
+	if dryRun {
+		if err := printDryRunResult(dryRunResult{
+			Job:                  namespace + "/" + name,
+			SourcePageID:         job.Spec.Source.PageID,
+			SourceTitle:          sourcePageTitle,
+			SourceLanguage:       sourceLang,
+			TargetLanguage:       targetLang,
+			TranslatedTitle:      translateResp.TranslatedTitle,
+			TranslatedMarkdown:   translateResp.TranslatedMarkdown,
+			TokensUsed:           translateResp.TokensUsed,
+			InferenceTimeSeconds: translateResp.InferenceTimeSeconds,
+			Engine:               translateResp.Engine,
+			EngineVersion:        translateResp.EngineVersion,
+			CacheHit:             cacheHit,
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "error: failed to write dry-run result: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Step 4: Create target destination page with PREFIX (skip for diagnostic jobs
+	// without a dedicated diagnostic target)
+	if isDiagnostic && diagnosticTarget == "" {
 		// Diagnostic jobs just test the translation service - don't publish
 		fmt.Println("\nStep 4: Diagnostic job - skipping wiki publish (translation service test complete)")
 		fmt.Println("----------------------------------------")
@@ -487,15 +660,27 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Keep the output on the job for preview and republishing; it's saved with
+	// the final status update
+	if content, err := jobcontent.Store(ctx, k8sClient, &job, translateResp.TranslatedTitle, translateResp.TranslatedMarkdown); err != nil {
+		fmt.Printf("warning: failed to store translated content: %v\n", err)
+	} else {
+		job.Status.TranslatedContent = content
+	}
+
 	// Step 4: Create target destination page with PREFIX
 	fmt.Println("\nStep 4: Creating destination page with prefix")
 	fmt.Println("----------------------------------------")
 
-	// Get destination WikiTarget (skip for diagnostic jobs - they don't publish)
+	// Get destination WikiTarget (the configured diagnostic target for diagnostic
+	// jobs, never the production source/destination)
 	var destTarget wikiv1alpha1.WikiTarget
 	if isDiagnostic {
-		// Diagnostic jobs don't publish to wiki - just test translation service
-		fmt.Printf("Diagnostic job - skipping destination WikiTarget fetch (results will be logged only)\n")
+		if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: diagnosticTarget}, &destTarget); err != nil {
+			fmt.Fprintf(os.Stderr, "error: failed to get diagnostic WikiTarget %s: %v\n", diagnosticTarget, err)
+			updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Failed to get diagnostic target: %v", err))
+			os.Exit(1)
+		}
 	} else {
 		// Regular job - need destination WikiTarget
 		destTargetRef := job.Spec.Source.TargetRef
@@ -525,19 +710,20 @@ func main() {
 	}
 
 	var translatedTitle string
+	var updateTitle string // Title for a page the publish strategy rewrites, without the unique suffix
 	var collectionID string
 	var finalContent string
 	var createResp *outline.CreatePageResponse // Declare here for use in both branches
 
 	if isDiagnostic {
-		// Diagnostic jobs: AUTODIAG prefix, GLOOSCAP-DIAG collection
+		// Diagnostic jobs: AUTODIAG prefix, diagnostic collection on the diagnostic target
 		translatedTitle = fmt.Sprintf("%s--> %s", prefix, baseTitle)
 		
-		// Get or create GLOOSCAP-DIAG collection
-		fmt.Printf("Ensuring GLOOSCAP-DIAG collection exists...\n")
-		diagCollectionID, err := destClient.GetOrCreateCollection(ctx, "GLOOSCAP-DIAG")
+		// Get or create the diagnostic collection
+		fmt.Printf("Ensuring %s collection exists...\n", diagnosticCollection)
+		diagCollectionID, err := destClient.GetOrCreateCollection(ctx, diagnosticCollection)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "error: failed to get/create GLOOSCAP-DIAG collection: %v\n", err)
+			fmt.Fprintf(os.Stderr, "error: failed to get/create %s collection: %v\n", diagnosticCollection, err)
 			updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Failed to get/create collection: %v", err))
 			os.Exit(1)
 		}
@@ -552,12 +738,21 @@ func main() {
 		// Base content without marker (for comparison)
 		baseContent := translateResp.TranslatedMarkdown
 		finalContent = baseContent + marker
+
+		// In "new" mode every run gets its own timestamped page, so skip the lookup
+		if diagnosticMode == diagnosticModeNew {
+			translatedTitle = fmt.Sprintf("%s (%s)", translatedTitle, time.Now().UTC().Format("2006-01-02 15:04:05"))
+		}
 		
-		// Check if page with same title exists (for diagnostic jobs, always update existing)
+		// Check if page with same title exists (update/append modes reuse it)
 		// Check both drafts and published pages
-		fmt.Printf("Checking for existing page with title: %s (including drafts)\n", translatedTitle)
-		destPages, err := destClient.ListPages(ctx)
 		var existingPageID string
+		var destPages []outline.PageSummary
+		err = nil
+		if diagnosticMode != diagnosticModeNew {
+			fmt.Printf("Checking for existing page with title: %s (including drafts)\n", translatedTitle)
+			destPages, err = destClient.ListPages(ctx)
+		}
 		if err == nil {
 			for _, dp := range destPages {
 				// Match by exact title (drafts and published pages both have titles)
@@ -571,9 +766,19 @@ func main() {
 			fmt.Printf("warning: failed to list pages to check for existing: %v\n", err)
 		}
 		
+		if existingPageID != "" && diagnosticMode == diagnosticModeAppend {
+			// Keep previous runs' history: append this run's marker to the current text
+			existing, err := destClient.GetPageContent(ctx, existingPageID)
+			if err != nil && !errors.Is(err, outline.ErrEmptyContent) {
+				fmt.Printf("warning: failed to fetch existing page content, replacing instead: %v\n", err)
+			} else {
+				finalContent = strings.TrimRight(existing.Markdown, "\n") + marker
+			}
+		}
+
 		if existingPageID != "" {
-			// For diagnostic jobs, always update existing page (add new UUID marker)
-			fmt.Printf("Updating existing diagnostic page with new UUID marker...\n")
+			// Update existing page (with the new UUID marker, or the appended history)
+			fmt.Printf("Updating existing diagnostic page (%s mode)...\n", diagnosticMode)
 			updateReq := outline.UpdatePageRequest{
 				ID:   existingPageID,
 				Text: finalContent, // This includes the new UUID marker
@@ -610,6 +815,24 @@ func main() {
 		translatedTitle = fmt.Sprintf("%s--> %s", prefix, baseTitle)
 		collectionID = sourceCollectionID
 		finalContent = translateResp.TranslatedMarkdown
+		if footer := job.TranslationFooter(&destTarget); footer != nil {
+			withFooter, err := markdown.AppendFooter(finalContent, footer.Template, markdown.FooterData{
+				SourceTitle:    baseTitle,
+				SourceURL:      outline.DocumentURL(sourceTarget.Spec.URI, sourcePageTitle, sourcePageSlug),
+				SourceLanguage: sourceLang,
+				TargetLanguage: targetLang,
+				TranslatedAt:   time.Now(),
+				Engine:         translateResp.Engine,
+				EngineVersion:  translateResp.EngineVersion,
+			})
+			if err != nil {
+				fmt.Printf("warning: failed to render translation footer, publishing without it: %v\n", err)
+			} else {
+				finalContent = withFooter
+			}
+		}
+
+		updateTitle = translatedTitle
 
 		// Check for existing pages with same title (for regular jobs, don't overwrite)
 		destPages, err := destClient.ListPages(ctx)
@@ -637,6 +860,19 @@ func main() {
 		}
 	}
 
+	// Regular jobs may rewrite the page an earlier job published instead
+	if createResp == nil && !isDiagnostic {
+		createResp, err = updatePreviousTranslation(ctx, k8sClient, destClient, &job, updateTitle, finalContent)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: failed to update the earlier translation: %v\n", err)
+			updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Failed to publish page: %v", err))
+			os.Exit(1)
+		}
+		if createResp != nil {
+			fmt.Printf("✓ Earlier translation updated in place\n")
+		}
+	}
+
 	// Create the translated page (if not already updated above)
 	if createResp == nil {
 		fmt.Printf("Creating page with:\n")
@@ -650,6 +886,16 @@ func main() {
 			Text:         finalContent,
 			CollectionID: collectionID,
 		}
+		if job.Spec.Destination != nil && job.Spec.Destination.ParentDocument != "" {
+			parent, err := destClient.ResolveDocument(ctx, job.Spec.Destination.ParentDocument, collectionID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: failed to resolve parent document %q: %v\n", job.Spec.Destination.ParentDocument, err)
+				updateJobStatusFailed(ctx, k8sClient, &job, fmt.Sprintf("Failed to resolve parent document: %v", err))
+				os.Exit(1)
+			}
+			fmt.Printf("  Parent document: %s (%s)\n", parent.Title, parent.ID)
+			createReq.ParentDocumentID = parent.ID
+		}
 
 		var err error
 		createResp, err = destClient.CreatePage(ctx, createReq)
@@ -668,7 +914,7 @@ func main() {
 	// Build page URL
 	pageURL := ""
 	if destTarget.Spec.URI != "" {
-		pageURL = fmt.Sprintf("%s/doc/%s", strings.TrimSuffix(destTarget.Spec.URI, "/"), createResp.Data.Slug)
+		pageURL = outline.DocumentURL(destTarget.Spec.URI, createResp.Data.Title, createResp.Data.Slug)
 		fmt.Printf("  URL: %s\n", pageURL)
 	}
 
@@ -681,17 +927,24 @@ func main() {
 	job.Status.Message = fmt.Sprintf("Translation completed and created as draft (page: %s). Awaiting approval to publish.", createResp.Data.Slug)
 	
 	// Store published page info in annotations for UI to access
-	if job.Annotations == nil {
-		job.Annotations = make(map[string]string)
+	wikiv1alpha1.SetPublishedPage(&job, wikiv1alpha1.PublishedPage{
+		ID:      createResp.Data.ID,
+		Slug:    createResp.Data.Slug,
+		URL:     pageURL,
+		Title:   createResp.Data.Title,
+		IsDraft: true,
+	})
+	if job.Status.Engine != nil {
+		wikiv1alpha1.SetTranslationEngine(&job, *job.Status.Engine)
 	}
-	job.Annotations["glooscap.dasmlab.org/published-page-id"] = createResp.Data.ID
-	job.Annotations["glooscap.dasmlab.org/published-page-slug"] = createResp.Data.Slug
-	job.Annotations["glooscap.dasmlab.org/published-page-url"] = pageURL
-	job.Annotations["glooscap.dasmlab.org/is-draft"] = "true"
 	
+	// Update refreshes job from the API server, which would drop the status
+	// (state and tokens used) set above
+	status := job.Status
 	if err := k8sClient.Update(ctx, &job); err != nil {
 		fmt.Printf("warning: failed to update job annotations: %v\n", err)
 	}
+	job.Status = status
 	
 	if err := k8sClient.Status().Update(ctx, &job); err != nil {
 		fmt.Printf("warning: failed to update job status to completed: %v\n", err)
@@ -713,12 +966,36 @@ func main() {
 	os.Exit(0)
 }
 
+// translationServiceConfig returns the client configuration for the
+// translation service at addr, read from TRANSLATION_SERVICE_SECURE and
+// TRANSLATION_SERVICE_TYPE as the dispatcher sets them.
+func translationServiceConfig(addr, namespace string) (nanabush.Config, error) {
+	cfg := nanabush.Config{
+		Address:       addr,
+		ServiceType:   os.Getenv("TRANSLATION_SERVICE_TYPE"),
+		ClientName:    "glooscap-translation-runner",
+		ClientVersion: "1.0.0",
+		Namespace:     namespace,
+		Timeout:       30 * time.Second,
+	}
+	if value := os.Getenv("TRANSLATION_SERVICE_SECURE"); value != "" {
+		secure, err := strconv.ParseBool(value)
+		if err != nil {
+			return cfg, fmt.Errorf("TRANSLATION_SERVICE_SECURE: %w", err)
+		}
+		cfg.Secure = secure
+	}
+	return cfg, nil
+}
+
 func updateJobStatusFailed(ctx context.Context, k8sClient client.Client, job *wikiv1alpha1.TranslationJob, message string) {
 	now := metav1.Now()
 	job.Status.State = wikiv1alpha1.TranslationJobStateFailed
 	job.Status.FinishedAt = &now
 	job.Status.Message = message
-	_ = k8sClient.Status().Update(ctx, job)
+	if !dryRun {
+		_ = k8sClient.Status().Update(ctx, job)
+	}
 	fmt.Printf("\n✗ Job failed: %s\n", message)
 }
 
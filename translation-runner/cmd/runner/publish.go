@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wikiv1alpha1 "github.com/dasmlab/glooscap-operator/api/v1alpha1"
+	"github.com/dasmlab/glooscap-operator/pkg/outline"
+)
+
+// updatePreviousTranslation applies the update and createOrUpdate publish
+// strategies: it rewrites the page an earlier job published for the same
+// source page, destination and language with title and text. It returns nil
+// and no error when the job should create a page instead.
+func updatePreviousTranslation(ctx context.Context, k8sClient client.Client, destClient *outline.Client,
+	job *wikiv1alpha1.TranslationJob, title, text string) (*outline.CreatePageResponse, error) {
+	strategy := job.Spec.PublishStrategy
+	if strategy != wikiv1alpha1.PublishStrategyUpdate && strategy != wikiv1alpha1.PublishStrategyCreateOrUpdate {
+		return nil, nil
+	}
+
+	var jobs wikiv1alpha1.TranslationJobList
+	if err := k8sClient.List(ctx, &jobs, client.InNamespace(job.Namespace)); err != nil {
+		return nil, fmt.Errorf("list jobs to find the earlier translation: %w", err)
+	}
+	previous := job.PreviousTranslation(jobs.Items)
+	if previous == nil {
+		if strategy == wikiv1alpha1.PublishStrategyUpdate {
+			return nil, fmt.Errorf("no earlier translation of this page to update")
+		}
+		fmt.Printf("No earlier translation to update, creating a new page\n")
+		return nil, nil
+	}
+
+	pageID := wikiv1alpha1.GetPublishedPage(previous).ID
+	fmt.Printf("Updating page %s published by TranslationJob %s (%s strategy)\n", pageID, previous.Name, strategy)
+	updateResp, err := destClient.UpdatePage(ctx, outline.UpdatePageRequest{ID: pageID, Title: title, Text: text})
+	if err != nil {
+		if outline.IsNotFound(err) && strategy == wikiv1alpha1.PublishStrategyCreateOrUpdate {
+			fmt.Printf("Page %s no longer exists, creating a new page\n", pageID)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("update page %s published by TranslationJob %s: %w", pageID, previous.Name, err)
+	}
+	return &outline.CreatePageResponse{Data: updateResp.Data}, nil
+}
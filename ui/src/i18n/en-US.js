@@ -32,6 +32,7 @@ export default {
     wikitargetStatus: 'WikiTarget Status',
     lastSync: 'Last Sync',
     catalogRevision: 'Catalog Revision',
+    lastSyncDelta: 'Last sync: {added} added, {updated} updated, {deleted} deleted',
   },
   jobs: {
     title: 'Translation Queue',
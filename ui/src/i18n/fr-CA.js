@@ -32,6 +32,7 @@ export default {
     wikitargetStatus: 'Statut WikiTarget',
     lastSync: 'Dernière synchronisation',
     catalogRevision: 'Révision du catalogue',
+    lastSyncDelta: 'Dernière synchronisation : {added} ajoutées, {updated} modifiées, {deleted} supprimées',
   },
   jobs: {
     title: 'File de traduction',
@@ -113,6 +113,35 @@ export const useCatalogueStore = defineStore('catalogue', () => {
     }
   }
 
+  // loadTargetPages fetches a target's pages when the SSE state only reports
+  // its page count, and merges them into the store
+  const loadingTargets = new Set()
+  async function loadTargetPages(targetId) {
+    if (loadingTargets.has(targetId)) {
+      return
+    }
+    loadingTargets.add(targetId)
+    try {
+      const { data } = await api.get('/catalogue', { params: { target: targetId } })
+      if (!Array.isArray(data)) {
+        return
+      }
+      pages.value = [
+        ...pages.value.filter((page) => page.targetId !== targetId),
+        ...data.map((page) => ({
+          ...page,
+          targetId,
+          status: page.state || 'Discovered',
+        })),
+      ]
+      logCallback?.('INFO', `Loaded ${data.length} pages for ${targetId}`)
+    } catch (err) {
+      console.error('[CatalogueStore] Error loading target pages:', err)
+    } finally {
+      loadingTargets.delete(targetId)
+    }
+  }
+
   async function ensureTargets() {
     if (targets.value.length > 0) {
       return
@@ -235,6 +264,7 @@ export const useCatalogueStore = defineStore('catalogue', () => {
           // Update targets
           const newTargets = []
           const newPages = []
+          const truncatedTargets = []
           
           data.wikitargets.forEach((wt) => {
             const targetId = wt.targetId || `${wt.namespace}/${wt.name}`
@@ -247,8 +277,16 @@ export const useCatalogueStore = defineStore('catalogue', () => {
               resourceName: wt.name,
             })
             
-            // Add pages
-            if (wt.pages && Array.isArray(wt.pages)) {
+            // Large catalogues only carry a page count; keep the pages already
+            // loaded for them and fetch the rest from /catalogue
+            if (wt.pagesTruncated) {
+              const loaded = pages.value.filter((page) => page.targetId === targetId)
+              if (loaded.length > 0) {
+                newPages.push(...loaded)
+              } else {
+                truncatedTargets.push(targetId)
+              }
+            } else if (wt.pages && Array.isArray(wt.pages)) {
               wt.pages.forEach((page) => {
                 newPages.push({
                   ...page,
@@ -291,6 +329,7 @@ export const useCatalogueStore = defineStore('catalogue', () => {
             
             logCallback?.('INFO', `Updated ${newPages.length} pages from SSE`)
           }
+          truncatedTargets.forEach((targetId) => loadTargetPages(targetId))
         }
       } catch (err) {
         logCallback?.('ERROR', 'Failed to parse SSE event', err.message)